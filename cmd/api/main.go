@@ -7,11 +7,15 @@ import (
 	"time"
 
 	"finanvilla/internal/domain/entities"
+	domainRepositories "finanvilla/internal/domain/repositories"
 	"finanvilla/internal/domain/services"
 	"finanvilla/internal/infrastructure/repositories"
 	"finanvilla/internal/interfaces/http/handlers"
 	"finanvilla/internal/interfaces/http/routes"
 	"finanvilla/pkg/config"
+	"finanvilla/pkg/mailer"
+	"finanvilla/pkg/storage"
+	"finanvilla/pkg/validator"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/driver/postgres"
@@ -32,6 +36,8 @@ func main() {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
+	validator.Init()
+
 	db, err := setupDatabase(cfg)
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
@@ -39,29 +45,163 @@ func main() {
 
 	userRepo := repositories.NewPostgresUserRepository(db)
 	refreshTokenRepo := repositories.NewPostgresRefreshTokenRepository(db)
+	userTokenRepo := repositories.NewPostgresUserTokenRepository(db)
+	notificationPrefRepo := repositories.NewPostgresNotificationPreferenceRepository(db)
+	securityEventRepo := repositories.NewPostgresSecurityEventRepository(db)
+	accountRepo := repositories.NewPostgresAccountRepository(db)
+	categoryRepo := repositories.NewPostgresCategoryRepository(db)
+	transactionRepo := repositories.NewPostgresTransactionRepository(db)
+	budgetRepo := repositories.NewPostgresBudgetRepository(db)
+	recurringRepo := repositories.NewPostgresRecurringTransactionRepository(db)
+	csvProfileRepo := repositories.NewPostgresCSVImportProfileRepository(db)
+	creditCardRepo := repositories.NewPostgresCreditCardRepository(db)
+	householdRepo := repositories.NewPostgresHouseholdRepository(db)
+	sharedExpenseRepo := repositories.NewPostgresSharedExpenseRepository(db)
+	tagRepo := repositories.NewPostgresTagRepository(db)
+	savedSearchRepo := repositories.NewPostgresSavedSearchRepository(db)
+	attachmentRepo := repositories.NewPostgresAttachmentRepository(db)
+	ruleRepo := repositories.NewPostgresCategorizationRuleRepository(db)
+	txManager := repositories.NewTransactionManager(db)
+
+	mail := mailer.New(mailer.SMTPConfig{
+		Host:     cfg.SMTP.Host,
+		Port:     cfg.SMTP.Port,
+		User:     cfg.SMTP.User,
+		Password: cfg.SMTP.Password,
+		From:     cfg.SMTP.From,
+	})
+
+	fileStorage, err := storage.New(storage.Config{
+		Driver:        cfg.Storage.Driver,
+		LocalPath:     cfg.Storage.LocalPath,
+		PublicURL:     cfg.Storage.PublicURL,
+		SigningSecret: cfg.Storage.SigningSecret,
+		S3: storage.S3Config{
+			Endpoint:  cfg.Storage.S3Endpoint,
+			Region:    cfg.Storage.S3Region,
+			Bucket:    cfg.Storage.S3Bucket,
+			AccessKey: cfg.Storage.S3AccessKey,
+			SecretKey: cfg.Storage.S3SecretKey,
+		},
+	})
+	if err != nil {
+		log.Fatal("Failed to set up file storage:", err)
+	}
 
-	userService := services.NewUserService(userRepo)
+	securityEventService := services.NewSecurityEventService(
+		securityEventRepo,
+		time.Duration(cfg.Security.EventRetentionDays)*24*time.Hour,
+	)
+	userService := services.NewUserService(userRepo, categoryRepo, txManager, securityEventService)
 	authService := services.NewAuthService(
 		userService,
 		refreshTokenRepo,
+		securityEventService,
 		cfg.JWT.Secret,
 		cfg.JWT.RefreshSecret,
 	)
+	invitationService := services.NewInvitationService(userService, userTokenRepo, txManager, mail, cfg.App.BaseURL)
+	userImportService := services.NewUserImportService(userService, invitationService, txManager)
+	avatarService := services.NewAvatarService(userService, fileStorage)
+	notificationPrefService := services.NewNotificationPreferenceService(userService, notificationPrefRepo, txManager)
+	accountService := services.NewAccountService(accountRepo, userService)
+	transactionService := services.NewTransactionService(transactionRepo, accountRepo, categoryRepo, tagRepo, ruleRepo, txManager)
+	categoryService := services.NewCategoryService(categoryRepo, budgetRepo, recurringRepo, txManager)
+	budgetService := services.NewBudgetService(budgetRepo, categoryRepo, savedSearchRepo, transactionRepo, userService, txManager)
+	recurringService := services.NewRecurringTransactionService(recurringRepo, transactionRepo, transactionService, txManager)
+	statementImportService := services.NewStatementImportService(transactionRepo, accountRepo, categoryRepo, csvProfileRepo, ruleRepo, tagRepo, userService, txManager)
+	statementExportService := services.NewStatementExportService(transactionRepo, accountRepo, categoryRepo, savedSearchRepo, userService)
+	pixService := services.NewPixService(userService)
+	boletoService := services.NewBoletoService(transactionService, transactionRepo, userService, txManager)
+	creditCardService := services.NewCreditCardService(creditCardRepo, accountRepo, transactionRepo, transactionService, userService, txManager)
+	householdService := services.NewHouseholdService(householdRepo, sharedExpenseRepo, userService)
+	attachmentService := services.NewAttachmentService(attachmentRepo, transactionRepo, accountRepo, fileStorage, txManager)
+	dataExportService := services.NewDataExportService(userService, accountRepo, categoryRepo, transactionRepo, attachmentRepo, attachmentService)
+	tagService := services.NewTagService(tagRepo)
+	savedSearchService := services.NewSavedSearchService(savedSearchRepo, transactionRepo, categoryRepo, userService)
+	csvProfileService := services.NewCSVImportProfileService(csvProfileRepo)
+	ruleService := services.NewCategorizationRuleService(ruleRepo, transactionRepo, accountRepo, categoryRepo, tagRepo, txManager)
+	emailChangeService := services.NewEmailChangeService(
+		userService,
+		userTokenRepo,
+		refreshTokenRepo,
+		txManager,
+		mail,
+		cfg.App.BaseURL,
+	)
 
-	userHandler := handlers.NewUserHandler(userService)
+	userHandler := handlers.NewUserHandler(userService, avatarService)
 	healthHandler := handlers.NewHealthHandler(cfg.Environment, AppVersion)
 	authHandler := handlers.NewAuthHandler(authService)
+	userImportHandler := handlers.NewUserImportHandler(userImportService)
+	invitationHandler := handlers.NewInvitationHandler(invitationService)
+	emailChangeHandler := handlers.NewEmailChangeHandler(emailChangeService)
+	avatarHandler := handlers.NewAvatarHandler(avatarService)
+	notificationPrefHandler := handlers.NewNotificationPreferenceHandler(notificationPrefService)
+	securityEventHandler := handlers.NewSecurityEventHandler(securityEventService)
+	accountHandler := handlers.NewAccountHandler(accountService)
+	transactionHandler := handlers.NewTransactionHandler(transactionService)
+	categoryHandler := handlers.NewCategoryHandler(categoryService)
+	budgetHandler := handlers.NewBudgetHandler(budgetService)
+	recurringHandler := handlers.NewRecurringTransactionHandler(recurringService)
+	statementImportHandler := handlers.NewStatementImportHandler(statementImportService)
+	csvProfileHandler := handlers.NewCSVImportProfileHandler(csvProfileService)
+	statementExportHandler := handlers.NewStatementExportHandler(statementExportService)
+	pixHandler := handlers.NewPixHandler(pixService)
+	boletoHandler := handlers.NewBoletoHandler(boletoService)
+	creditCardHandler := handlers.NewCreditCardHandler(creditCardService)
+	householdHandler := handlers.NewHouseholdHandler(householdService)
+	tagHandler := handlers.NewTagHandler(tagService)
+	savedSearchHandler := handlers.NewSavedSearchHandler(savedSearchService)
+	attachmentHandler := handlers.NewAttachmentHandler(attachmentService)
+	dataExportHandler := handlers.NewDataExportHandler(dataExportService)
+	ruleHandler := handlers.NewCategorizationRuleHandler(ruleService)
+
+	var fileHandler *handlers.FileHandler
+	if local, ok := fileStorage.(*storage.LocalStorage); ok {
+		fileHandler = handlers.NewFileHandler(local)
+	}
 
 	routerConfig := routes.RouterConfig{
-		UserHandler:   userHandler,
-		HealthHandler: healthHandler,
-		AuthHandler:   authHandler,
-		JWTSecret:     cfg.JWT.Secret,
+		UserHandler:        userHandler,
+		HealthHandler:      healthHandler,
+		AuthHandler:        authHandler,
+		UserImportHandler:  userImportHandler,
+		InvitationHandler:  invitationHandler,
+		EmailChangeHandler: emailChangeHandler,
+		AvatarHandler:      avatarHandler,
+		FileHandler:        fileHandler,
+
+		NotificationPreferenceHandler: notificationPrefHandler,
+		SecurityEventHandler:          securityEventHandler,
+		AccountHandler:                accountHandler,
+		TransactionHandler:            transactionHandler,
+		CategoryHandler:               categoryHandler,
+		BudgetHandler:                 budgetHandler,
+		RecurringTransactionHandler:   recurringHandler,
+		StatementImportHandler:        statementImportHandler,
+		CSVImportProfileHandler:       csvProfileHandler,
+		StatementExportHandler:        statementExportHandler,
+		PixHandler:                    pixHandler,
+		BoletoHandler:                 boletoHandler,
+		CreditCardHandler:             creditCardHandler,
+		HouseholdHandler:              householdHandler,
+		TagHandler:                    tagHandler,
+		SavedSearchHandler:            savedSearchHandler,
+		AttachmentHandler:             attachmentHandler,
+		DataExportHandler:             dataExportHandler,
+		CategorizationRuleHandler:     ruleHandler,
+		UserService:                   userService,
+		JWTSecret:                     cfg.JWT.Secret,
 	}
 
 	router := routes.SetupRouter(routerConfig)
 
 	go startRefreshTokenCleanup(refreshTokenRepo)
+	go startUserTokenCleanup(userTokenRepo)
+	go startSecurityEventCleanup(securityEventService)
+	go startRecurringMaterializer(recurringService)
+	go startAttachmentCleanup(attachmentService)
 
 	log.Printf("Server starting on port %s in %s mode", cfg.Server.Port, cfg.Environment)
 	if err := router.Run(":" + cfg.Server.Port); err != nil {
@@ -110,3 +250,56 @@ func startRefreshTokenCleanup(repo *repositories.PostgresRefreshTokenRepository)
 		}
 	}
 }
+
+func startUserTokenCleanup(repo domainRepositories.UserTokenRepository) {
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := repo.DeleteExpired(context.Background()); err != nil {
+			log.Printf("Error cleaning up expired user tokens: %v", err)
+		}
+	}
+}
+
+func startSecurityEventCleanup(service *services.SecurityEventService) {
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := service.Cleanup(context.Background()); err != nil {
+			log.Printf("Error cleaning up old security events: %v", err)
+		}
+	}
+}
+
+// startAttachmentCleanup remove do armazenamento os arquivos que ficaram sem
+// anexos, como os de lançamentos e contas excluídos
+func startAttachmentCleanup(service *services.AttachmentService) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if removed, err := service.Cleanup(context.Background()); err != nil {
+			log.Printf("Error cleaning up attachment files: %v", err)
+		} else if removed > 0 {
+			log.Printf("Removed %d unused attachment files", removed)
+		}
+	}
+}
+
+// startRecurringMaterializer lança as ocorrências vencidas na subida e depois a
+// cada hora; a operação é idempotente, então várias instâncias podem rodá-la
+func startRecurringMaterializer(service *services.RecurringTransactionService) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		if created, err := service.MaterializeDue(context.Background()); err != nil {
+			log.Printf("Error materializing recurring transactions: %v", err)
+		} else if created > 0 {
+			log.Printf("Materialized %d recurring transactions", created)
+		}
+		<-ticker.C
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/services"
+	"finanvilla/internal/infrastructure/database/postgres"
+	"finanvilla/internal/infrastructure/repositories"
+	"finanvilla/pkg/config"
+	"finanvilla/pkg/mailer"
+)
+
+// Uso:
+//
+//	go run ./cmd/import-users -file users.csv [-dry-run] [-mode atomic|per_row] [-invite] [-json]
+func main() {
+	file := flag.String("file", "", "CSV file with name,email,user_type[,roles][,password] columns")
+	dryRun := flag.Bool("dry-run", false, "only validate the file and report errors per row")
+	mode := flag.String("mode", string(dtos.ImportPerRow), "atomic (all or nothing) or per_row")
+	invite := flag.Bool("invite", false, "send invitations instead of using the password column")
+	asJSON := flag.Bool("json", false, "print the report as JSON")
+	flag.Parse()
+
+	if *file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("Failed to load config:", err)
+	}
+
+	db, err := postgres.NewConnection(cfg)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		log.Fatal("Failed to open file:", err)
+	}
+	defer f.Close()
+
+	txManager := repositories.NewTransactionManager(db)
+	securityEventService := services.NewSecurityEventService(
+		repositories.NewPostgresSecurityEventRepository(db),
+		time.Duration(cfg.Security.EventRetentionDays)*24*time.Hour,
+	)
+	userService := services.NewUserService(
+		repositories.NewPostgresUserRepository(db),
+		repositories.NewPostgresCategoryRepository(db),
+		txManager,
+		securityEventService,
+	)
+	invitationService := services.NewInvitationService(
+		userService,
+		repositories.NewPostgresUserTokenRepository(db),
+		txManager,
+		mailer.New(mailer.SMTPConfig{
+			Host:     cfg.SMTP.Host,
+			Port:     cfg.SMTP.Port,
+			User:     cfg.SMTP.User,
+			Password: cfg.SMTP.Password,
+			From:     cfg.SMTP.From,
+		}),
+		cfg.App.BaseURL,
+	)
+	importService := services.NewUserImportService(userService, invitationService, txManager)
+
+	report, err := importService.Import(context.Background(), f, dtos.UserImportOptions{
+		DryRun:          *dryRun,
+		Mode:            dtos.UserImportMode(*mode),
+		SendInvitations: *invite,
+	})
+	if err != nil {
+		log.Fatal("Import failed:", err)
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(report)
+	} else {
+		printReport(report)
+	}
+
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func printReport(report *dtos.UserImportReport) {
+	for _, row := range report.Rows {
+		line := fmt.Sprintf("row %d\t%-10s\t%s", row.Row, row.Status, row.Email)
+		if len(row.Errors) > 0 {
+			line += "\t" + strings.Join(row.Errors, "; ")
+		}
+		fmt.Println(line)
+	}
+
+	fmt.Printf("\ntotal: %d, succeeded: %d, failed: %d (mode=%s, dry-run=%t)\n",
+		report.Total, report.Succeeded, report.Failed, report.Mode, report.DryRun)
+}
@@ -0,0 +1,40 @@
+package dtos
+
+import (
+	"finanvilla/internal/domain/enums"
+	"finanvilla/pkg/money"
+)
+
+// CreateAccountRequest recebe valores como string decimal ("1234.56"). Sem
+// moeda informada, a conta usa UserSettings.Currency.
+type CreateAccountRequest struct {
+	Name           string            `json:"name" validate:"required,max=100"`
+	Type           enums.AccountType `json:"type" validate:"required"`
+	Institution    string            `json:"institution" validate:"max=100"`
+	Currency       string            `json:"currency" validate:"omitempty,len=3"`
+	OpeningBalance money.Money       `json:"openingBalance"`
+	DisplayOrder   *int              `json:"displayOrder" validate:"omitempty,min=0"`
+	// ClosingDay, DueDay e CreditLimit só valem para cartões de crédito
+	ClosingDay  *int         `json:"closingDay" validate:"omitempty,min=1,max=31"`
+	DueDay      *int         `json:"dueDay" validate:"omitempty,min=1,max=31"`
+	CreditLimit *money.Money `json:"creditLimit"`
+}
+
+// UpdateAccountRequest altera apenas os campos enviados. A moeda não pode ser
+// trocada depois da criação.
+type UpdateAccountRequest struct {
+	Name           *string            `json:"name" validate:"omitempty,min=1,max=100"`
+	Type           *enums.AccountType `json:"type"`
+	Institution    *string            `json:"institution" validate:"omitempty,max=100"`
+	OpeningBalance *money.Money       `json:"openingBalance"`
+	Archived       *bool              `json:"archived"`
+	DisplayOrder   *int               `json:"displayOrder" validate:"omitempty,min=0"`
+	ClosingDay     *int               `json:"closingDay" validate:"omitempty,min=1,max=31"`
+	DueDay         *int               `json:"dueDay" validate:"omitempty,min=1,max=31"`
+	CreditLimit    *money.Money       `json:"creditLimit"`
+}
+
+// ReorderAccountsRequest define a ordem de exibição pela posição de cada ID
+type ReorderAccountsRequest struct {
+	AccountIDs []string `json:"accountIds" validate:"required,min=1,dive,uuid"`
+}
@@ -0,0 +1,41 @@
+package dtos
+
+import (
+	"finanvilla/pkg/money"
+	"time"
+)
+
+type BoletoParseRequest struct {
+	// Line é a linha digitável ou o código de barras, com ou sem pontuação
+	Line string `json:"line" validate:"required,max=80"`
+}
+
+// BoletoScheduleRequest agenda o pagamento do boleto como lançamento pendente
+// na data de vencimento. Amount e DueDate só são exigidos quando o boleto não
+// os traz (valor em aberto, guias de arrecadação); quando informados,
+// prevalecem sobre os do código.
+type BoletoScheduleRequest struct {
+	Line        string      `json:"line" validate:"required,max=80"`
+	AccountID   string      `json:"accountId" validate:"required,uuid"`
+	CategoryID  *string     `json:"categoryId" validate:"omitempty,uuid"`
+	Description string      `json:"description" validate:"max=255"`
+	Payee       string      `json:"payee" validate:"max=255"`
+	Amount      money.Money `json:"amount"`
+	DueDate     string      `json:"dueDate" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type BoletoResponse struct {
+	Kind               string       `json:"kind"`
+	Barcode            string       `json:"barcode"`
+	TypedLine          string       `json:"typedLine"`
+	FormattedTypedLine string       `json:"formattedTypedLine"`
+	BankCode           string       `json:"bankCode,omitempty"`
+	BankName           string       `json:"bankName,omitempty"`
+	DueDate            *time.Time   `json:"dueDate,omitempty"`
+	Amount             *money.Money `json:"amount,omitempty"`
+	Segment            string       `json:"segment,omitempty"`
+	SegmentName        string       `json:"segmentName,omitempty"`
+	CompanyID          string       `json:"companyId,omitempty"`
+	// Overdue indica vencimento anterior a hoje, no fuso do usuário
+	Overdue bool `json:"overdue"`
+}
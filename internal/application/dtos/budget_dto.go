@@ -0,0 +1,46 @@
+package dtos
+
+import (
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/pkg/money"
+)
+
+// SetBudgetRequest define o valor orçado da categoria no mês, na moeda do usuário
+type SetBudgetRequest struct {
+	Amount   money.Money `json:"amount"`
+	Rollover bool        `json:"rollover"`
+}
+
+// CopyBudgetRequest copia os orçamentos de from (padrão: mês anterior) para o
+// mês da rota; overwrite substitui os valores já definidos no destino.
+type CopyBudgetRequest struct {
+	From      string `json:"from" validate:"omitempty,datetime=2006-01"`
+	Overwrite bool   `json:"overwrite"`
+}
+
+// BudgetReport compara orçado, realizado e saldo por categoria em um mês.
+// ToBeAssigned só é preenchido no modo envelope.
+type BudgetReport struct {
+	Month        string                `json:"month"`
+	Currency     string                `json:"currency"`
+	Mode         enums.BudgetMode      `json:"mode"`
+	Categories   []entities.BudgetLine `json:"categories"`
+	Budgeted     money.Money           `json:"budgeted"`
+	Actual       money.Money           `json:"actual"`
+	Remaining    money.Money           `json:"remaining"`
+	Income       money.Money           `json:"income"`
+	ToBeAssigned *money.Money          `json:"toBeAssigned,omitempty"`
+	// Searches traz as pesquisas salvas com limite mensal na moeda
+	Searches []SearchBudgetLine `json:"searches"`
+}
+
+// SearchBudgetLine compara o limite de uma pesquisa salva com o gasto líquido
+// dos lançamentos dela no mês
+type SearchBudgetLine struct {
+	SavedSearchID string      `json:"savedSearchId"`
+	Name          string      `json:"name"`
+	Budgeted      money.Money `json:"budgeted"`
+	Actual        money.Money `json:"actual"`
+	Remaining     money.Money `json:"remaining"`
+}
@@ -0,0 +1,83 @@
+package dtos
+
+import (
+	"finanvilla/internal/domain/enums"
+	"finanvilla/pkg/money"
+	"time"
+)
+
+// CategorizationRuleRequest cria ou substitui uma regra. Todas as condições
+// precisam bater; as ações precisam fazer ao menos uma mudança.
+type CategorizationRuleRequest struct {
+	Name       string                 `json:"name" validate:"required,max=100"`
+	Active     *bool                  `json:"active"`
+	Conditions []RuleConditionRequest `json:"conditions" validate:"required,min=1,max=10,dive"`
+	Actions    RuleActionsRequest     `json:"actions"`
+}
+
+// RuleConditionRequest compara um campo: DESCRIPTION e PAYEE aceitam
+// CONTAINS, EQUALS, STARTS_WITH e REGEX em Value; AMOUNT aceita BETWEEN com
+// Min e/ou Max (valor sem sinal); ACCOUNT aceita EQUALS com o ID da conta.
+type RuleConditionRequest struct {
+	Field    enums.RuleField    `json:"field" validate:"required"`
+	Operator enums.RuleOperator `json:"operator" validate:"required"`
+	Value    string             `json:"value" validate:"max=200"`
+	Min      *money.Money       `json:"min"`
+	Max      *money.Money       `json:"max"`
+}
+
+// RuleActionsRequest: categoryId e transferAccountId se excluem; tags são
+// nomes, criados se ainda não existirem
+type RuleActionsRequest struct {
+	CategoryID        *string  `json:"categoryId" validate:"omitempty,uuid"`
+	Payee             string   `json:"payee" validate:"max=255"`
+	Tags              []string `json:"tags" validate:"omitempty,max=20,dive,max=50"`
+	TransferAccountID *string  `json:"transferAccountId" validate:"omitempty,uuid"`
+}
+
+// ReorderRulesRequest define a ordem em que as regras são testadas
+type ReorderRulesRequest struct {
+	RuleIDs []string `json:"ruleIds" validate:"required,min=1,dive,uuid"`
+}
+
+// ApplyRulesRequest roda as regras sobre lançamentos já gravados que ainda
+// têm contrapartida sem categoria. Sem ruleIds, valem todas as ativas.
+type ApplyRulesRequest struct {
+	RuleIDs   []string `json:"ruleIds" validate:"omitempty,dive,uuid"`
+	AccountID string   `json:"accountId" validate:"omitempty,uuid"`
+	From      string   `json:"from" validate:"omitempty,datetime=2006-01-02"`
+	To        string   `json:"to" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type RuleApplyReport struct {
+	// Scanned é quantos lançamentos sem categoria foram examinados
+	Scanned int              `json:"scanned"`
+	Updated int              `json:"updated"`
+	Rules   []RuleApplyCount `json:"rules"`
+}
+
+type RuleApplyCount struct {
+	RuleID       string `json:"ruleId"`
+	Name         string `json:"name"`
+	Transactions int    `json:"transactions"`
+}
+
+// RulePreview mostra em quais lançamentos do histórico a regra bate. Changes
+// conta os que ela mudaria ao ser aplicada: sem categoria e não conciliados.
+type RulePreview struct {
+	Matches      int                `json:"matches"`
+	Changes      int                `json:"changes"`
+	Transactions []RulePreviewMatch `json:"transactions"`
+	// Truncated indica que nem todos os lançamentos encontrados foram listados
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+type RulePreviewMatch struct {
+	TransactionID string      `json:"transactionId"`
+	Date          time.Time   `json:"date"`
+	Description   string      `json:"description"`
+	Payee         string      `json:"payee"`
+	Amount        money.Money `json:"amount"`
+	CategoryID    *string     `json:"categoryId"`
+	WouldChange   bool        `json:"wouldChange"`
+}
@@ -0,0 +1,36 @@
+package dtos
+
+import "finanvilla/internal/domain/enums"
+
+// CreateCategoryRequest cria uma categoria raiz (type obrigatório) ou uma
+// subcategoria, que herda o tipo do pai.
+type CreateCategoryRequest struct {
+	Name     string             `json:"name" validate:"required,max=100"`
+	Type     enums.CategoryType `json:"type"`
+	ParentID *string            `json:"parentId" validate:"omitempty,uuid"`
+	Color    string             `json:"color" validate:"omitempty,hexcolor"`
+	Icon     string             `json:"icon" validate:"max=50"`
+}
+
+// UpdateCategoryRequest altera apenas os campos enviados. "parentId": "" move a
+// categoria para a raiz; arquivar uma categoria arquiva também as subcategorias.
+type UpdateCategoryRequest struct {
+	Name     *string `json:"name" validate:"omitempty,min=1,max=100"`
+	ParentID *string `json:"parentId" validate:"omitempty,len=0|uuid"`
+	Color    *string `json:"color" validate:"omitempty,len=0|hexcolor"`
+	Icon     *string `json:"icon" validate:"omitempty,max=50"`
+	Archived *bool   `json:"archived"`
+}
+
+// MergeCategoryRequest move lançamentos e subcategorias para targetId e exclui a origem
+type MergeCategoryRequest struct {
+	TargetID string `json:"targetId" validate:"required,uuid"`
+}
+
+// MoveCategoryTransactionsRequest troca a categoria dos lançamentos, opcionalmente
+// apenas no período informado, mantendo a categoria de origem
+type MoveCategoryTransactionsRequest struct {
+	TargetID string `json:"targetId" validate:"required,uuid"`
+	From     string `json:"from" validate:"omitempty,datetime=2006-01-02"`
+	To       string `json:"to" validate:"omitempty,datetime=2006-01-02"`
+}
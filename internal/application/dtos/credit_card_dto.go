@@ -0,0 +1,48 @@
+package dtos
+
+import (
+	"finanvilla/internal/domain/entities"
+	"finanvilla/pkg/money"
+)
+
+// CardStatementsResponse resume o cartão. Used é o saldo devedor, incluindo
+// as parcelas de faturas futuras; AvailableLimit é o limite menos esse saldo.
+type CardStatementsResponse struct {
+	AccountID      string                   `json:"accountId"`
+	Currency       string                   `json:"currency"`
+	ClosingDay     int                      `json:"closingDay"`
+	DueDay         int                      `json:"dueDay"`
+	CreditLimit    *money.Money             `json:"creditLimit,omitempty"`
+	Used           money.Money              `json:"used"`
+	AvailableLimit *money.Money             `json:"availableLimit,omitempty"`
+	Statements     []entities.CardStatement `json:"statements"`
+}
+
+type CardStatementResponse struct {
+	entities.CardStatement
+	Entries []entities.CardStatementEntry `json:"entries"`
+}
+
+// PayCardStatementRequest transfere da conta de origem para o cartão. Sem
+// valor, paga o saldo devido da fatura; sem data, o pagamento é de hoje.
+type PayCardStatementRequest struct {
+	FromAccountID string      `json:"fromAccountId" validate:"required,uuid"`
+	Amount        money.Money `json:"amount"`
+	Date          string      `json:"date" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// InstallmentPurchaseRequest cria ou substitui uma compra parcelada. Por
+// padrão a primeira parcela cai na fatura da data da compra; FirstStatement
+// (data de fechamento) a antecipa ou adia, como quando a loja lança a compra
+// dias depois.
+type InstallmentPurchaseRequest struct {
+	AccountID      string      `json:"accountId" validate:"required,uuid"`
+	CategoryID     *string     `json:"categoryId" validate:"omitempty,uuid"`
+	Description    string      `json:"description" validate:"required,max=255"`
+	Payee          string      `json:"payee" validate:"max=255"`
+	Notes          string      `json:"notes"`
+	Date           string      `json:"date" validate:"required,datetime=2006-01-02"`
+	TotalAmount    money.Money `json:"totalAmount"`
+	Installments   int         `json:"installments" validate:"required,min=2,max=60"`
+	FirstStatement string      `json:"firstStatement" validate:"omitempty,datetime=2006-01-02"`
+}
@@ -0,0 +1,45 @@
+package dtos
+
+// CSVMapping descreve o layout de um CSV, salvo em um perfil ou enviado na
+// query string da importação. Colunas são o nome no cabeçalho ou o número (a
+// partir de 1). Delimiter aceita ";", ",", "|" ou "tab".
+type CSVMapping struct {
+	Delimiter         string `json:"delimiter" form:"delimiter"`
+	Encoding          string `json:"encoding" form:"encoding"`
+	HasHeader         *bool  `json:"hasHeader" form:"hasHeader"`
+	SkipRows          int    `json:"skipRows" form:"skipRows" validate:"min=0,max=100"`
+	DateColumn        string `json:"dateColumn" form:"dateColumn" validate:"max=100"`
+	DescriptionColumn string `json:"descriptionColumn" form:"descriptionColumn" validate:"max=100"`
+	AmountColumn      string `json:"amountColumn" form:"amountColumn" validate:"max=100"`
+	DebitColumn       string `json:"debitColumn" form:"debitColumn" validate:"max=100"`
+	CreditColumn      string `json:"creditColumn" form:"creditColumn" validate:"max=100"`
+	PayeeColumn       string `json:"payeeColumn" form:"payeeColumn" validate:"max=100"`
+	MemoColumn        string `json:"memoColumn" form:"memoColumn" validate:"max=100"`
+	ExternalIDColumn  string `json:"externalIdColumn" form:"externalIdColumn" validate:"max=100"`
+	DateFormat        string `json:"dateFormat" form:"dateFormat"`
+	NumberFormat      string `json:"numberFormat" form:"numberFormat"`
+	InvertSign        bool   `json:"invertSign" form:"invertSign"`
+}
+
+type CSVImportProfileRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Institution string `json:"institution" validate:"max=100"`
+	CSVMapping
+}
+
+// CSVImportOptions usa o perfil informado ou, sem ele, o mapeamento da query string
+type CSVImportOptions struct {
+	DryRun    bool   `form:"dryRun"`
+	ProfileID string `form:"profileId"`
+	CSVMapping
+}
+
+// CSVInspection é a prévia do arquivo antes do mapeamento: o que foi detectado,
+// as primeiras linhas e um mapeamento sugerido pelos nomes do cabeçalho
+type CSVInspection struct {
+	Encoding  string     `json:"encoding"`
+	Delimiter string     `json:"delimiter"`
+	Header    []string   `json:"header"`
+	Rows      [][]string `json:"rows"`
+	Suggested CSVMapping `json:"suggested"`
+}
@@ -0,0 +1,10 @@
+package dtos
+
+type ChangeEmailRequest struct {
+	NewEmail        string `json:"newEmail" validate:"required,email"`
+	CurrentPassword string `json:"currentPassword" validate:"required"`
+}
+
+type EmailTokenRequest struct {
+	Token string `json:"token" validate:"required"`
+}
@@ -0,0 +1,88 @@
+package dtos
+
+import (
+	"finanvilla/internal/domain/enums"
+	"finanvilla/pkg/money"
+)
+
+// CreateHouseholdRequest cria a casa com o usuário como primeiro membro. Sem
+// moeda informada, vale UserSettings.Currency.
+type CreateHouseholdRequest struct {
+	Name     string `json:"name" validate:"required,max=100"`
+	Currency string `json:"currency" validate:"omitempty,len=3"`
+}
+
+type UpdateHouseholdRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+// AddHouseholdMemberRequest convida um usuário para a casa pelo e-mail
+type AddHouseholdMemberRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// UpdateHouseholdMemberRequest grava a chave Pix em que o membro recebe os
+// acertos. Chave vazia desliga a geração do Pix.
+type UpdateHouseholdMemberRequest struct {
+	PixKey  string `json:"pixKey" validate:"max=77"`
+	PixCity string `json:"pixCity" validate:"required_with=PixKey,max=100"`
+}
+
+// SharedExpenseRequest cria ou substitui uma despesa compartilhada. Sem
+// PaidBy, quem pagou é o próprio usuário. Em EQUAL, Shares só escolhe os
+// participantes e, vazio, divide entre todos os membros; nos demais modos,
+// cada participante informa Percent, Amount ou Shares conforme o modo.
+type SharedExpenseRequest struct {
+	PaidBy      string                `json:"paidBy" validate:"omitempty,uuid"`
+	Description string                `json:"description" validate:"required,max=255"`
+	Notes       string                `json:"notes"`
+	Date        string                `json:"date" validate:"required,datetime=2006-01-02"`
+	Amount      money.Money           `json:"amount"`
+	SplitMode   enums.SplitMode       `json:"splitMode" validate:"required"`
+	Shares      []ExpenseShareRequest `json:"shares" validate:"omitempty,dive"`
+}
+
+type ExpenseShareRequest struct {
+	UserID string `json:"userId" validate:"required,uuid"`
+	// Percent é decimal com até duas casas ("33.33"), em PERCENTAGE
+	Percent string `json:"percent" validate:"omitempty,numeric"`
+	// Amount é a parte exata, em EXACT
+	Amount *money.Money `json:"amount"`
+	// Shares é o número de cotas, em SHARES
+	Shares int64 `json:"shares" validate:"min=0"`
+}
+
+// SettlementRequest registra um acerto. Sem FromUserID, quem paga é o próprio
+// usuário, que precisa ser uma das pontas. Sem valor, acerta a dívida sugerida
+// entre os dois ou, na falta dela, a dívida direta. Com Pix, gera o "copia e
+// cola" para a chave do recebedor.
+type SettlementRequest struct {
+	FromUserID string      `json:"fromUserId" validate:"omitempty,uuid"`
+	ToUserID   string      `json:"toUserId" validate:"required,uuid"`
+	Amount     money.Money `json:"amount"`
+	Date       string      `json:"date" validate:"omitempty,datetime=2006-01-02"`
+	Note       string      `json:"note" validate:"max=255"`
+	Pix        bool        `json:"pix"`
+}
+
+type MemberBalance struct {
+	UserID string `json:"userId"`
+	Name   string `json:"name"`
+	// Balance positivo é a receber; negativo, a pagar
+	Balance money.Money `json:"balance"`
+}
+
+type DebtResponse struct {
+	FromUserID string      `json:"fromUserId"`
+	ToUserID   string      `json:"toUserId"`
+	Amount     money.Money `json:"amount"`
+}
+
+// HouseholdBalancesResponse traz o saldo de cada membro, as dívidas diretas
+// entre cada par e as transferências sugeridas para zerar tudo
+type HouseholdBalancesResponse struct {
+	Currency    string          `json:"currency"`
+	Members     []MemberBalance `json:"members"`
+	Pairwise    []DebtResponse  `json:"pairwise"`
+	Suggestions []DebtResponse  `json:"suggestions"`
+}
@@ -0,0 +1,25 @@
+package dtos
+
+import "finanvilla/internal/domain/enums"
+
+type QuietHours struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+type NotificationPreferencesResponse struct {
+	Enabled         bool                                                           `json:"enabled"`
+	Timezone        string                                                         `json:"timezone"`
+	QuietHours      *QuietHours                                                    `json:"quietHours"`
+	DigestFrequency enums.DigestFrequency                                          `json:"digestFrequency"`
+	Preferences     map[enums.NotificationEvent]map[enums.NotificationChannel]bool `json:"preferences"`
+}
+
+// UpdateNotificationPreferencesRequest altera apenas o que for enviado. Para
+// desativar o horário de silêncio envie "quietHours": {"start": "", "end": ""}.
+type UpdateNotificationPreferencesRequest struct {
+	Enabled         *bool                                                          `json:"enabled"`
+	QuietHours      *QuietHours                                                    `json:"quietHours"`
+	DigestFrequency *enums.DigestFrequency                                         `json:"digestFrequency"`
+	Preferences     map[enums.NotificationEvent]map[enums.NotificationChannel]bool `json:"preferences"`
+}
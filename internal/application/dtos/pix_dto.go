@@ -0,0 +1,52 @@
+package dtos
+
+import "finanvilla/pkg/money"
+
+// PixChargeRequest monta um BR Code: com Key, o estático; com URL (endereço
+// da cobrança criada no PSP), o dinâmico. Sem MerchantName, vale o nome do
+// usuário; sem Amount, o pagador informa o valor.
+type PixChargeRequest struct {
+	Key          string      `json:"key" validate:"required_without=URL,excluded_with=URL,max=77"`
+	URL          string      `json:"url" validate:"omitempty,max=77"`
+	Amount       money.Money `json:"amount"`
+	TxID         string      `json:"txid" validate:"omitempty,max=25,alphanum"`
+	Description  string      `json:"description" validate:"max=72"`
+	MerchantName string      `json:"merchantName" validate:"max=100"`
+	MerchantCity string      `json:"merchantCity" validate:"required,max=100"`
+	PostalCode   string      `json:"postalCode" validate:"max=9"`
+	SingleUse    bool        `json:"singleUse"`
+}
+
+type PixParseRequest struct {
+	Payload string `json:"payload" validate:"required,max=512"`
+	// AccountID, opcional, é a conta de onde sai o pagamento no lançamento sugerido
+	AccountID *string `json:"accountId" validate:"omitempty,uuid"`
+}
+
+type PixQRCodeRequest struct {
+	Payload string `json:"payload" validate:"required,max=512"`
+	// Size é o lado da imagem em pixels
+	Size int `json:"size" validate:"omitempty,min=100,max=2000"`
+}
+
+// PixPayload descreve um BR Code gerado ou lido
+type PixPayload struct {
+	Payload      string       `json:"payload"`
+	Dynamic      bool         `json:"dynamic"`
+	Key          string       `json:"key,omitempty"`
+	KeyType      string       `json:"keyType,omitempty"`
+	URL          string       `json:"url,omitempty"`
+	Description  string       `json:"description,omitempty"`
+	MerchantName string       `json:"merchantName"`
+	MerchantCity string       `json:"merchantCity"`
+	Amount       *money.Money `json:"amount,omitempty"`
+	TxID         string       `json:"txid,omitempty"`
+	SingleUse    bool         `json:"singleUse"`
+}
+
+// PixParseResponse traz o BR Code lido e um lançamento pré-preenchido para o
+// pagamento, pronto para o POST /transactions depois de revisado
+type PixParseResponse struct {
+	PixPayload
+	Transaction TransactionRequest `json:"transaction"`
+}
@@ -0,0 +1,30 @@
+package dtos
+
+import "finanvilla/pkg/money"
+
+// RecurringTransactionRequest descreve o modelo com as mesmas pernas (ou formas
+// simplificadas) de TransactionRequest. Rule segue o formato RRULE de
+// pkg/recurrence, ex.: "FREQ=MONTHLY;BYMONTHDAY=5;X-ADJUST=FOLLOWING".
+type RecurringTransactionRequest struct {
+	Description string           `json:"description" validate:"required,max=255"`
+	Payee       string           `json:"payee" validate:"max=255"`
+	Notes       string           `json:"notes"`
+	Rule        string           `json:"rule" validate:"required,max=255"`
+	StartDate   string           `json:"startDate" validate:"required,datetime=2006-01-02"`
+	Postings    []PostingRequest `json:"postings" validate:"omitempty,dive"`
+	AccountID   *string          `json:"accountId" validate:"omitempty,uuid"`
+	ToAccountID *string          `json:"toAccountId" validate:"omitempty,uuid"`
+	CategoryID  *string          `json:"categoryId" validate:"omitempty,uuid"`
+	Amount      money.Money      `json:"amount"`
+}
+
+type SetRecurringActiveRequest struct {
+	Active *bool `json:"active" validate:"required"`
+}
+
+// RecurrencePreviewRequest calcula as próximas datas de uma regra ainda não salva
+type RecurrencePreviewRequest struct {
+	Rule      string `json:"rule" validate:"required,max=255"`
+	StartDate string `json:"startDate" validate:"required,datetime=2006-01-02"`
+	Count     int    `json:"count" validate:"omitempty,min=1,max=100"`
+}
@@ -0,0 +1,62 @@
+package dtos
+
+import (
+	"finanvilla/internal/domain/enums"
+	"finanvilla/pkg/money"
+)
+
+// SavedSearchRequest cria ou substitui uma pesquisa salva. Budget, opcional, é
+// o limite mensal de gastos na moeda do usuário; com ele a pesquisa aparece
+// no relatório de orçamento.
+type SavedSearchRequest struct {
+	Name   string              `json:"name" validate:"required,max=100"`
+	Filter SearchFilterRequest `json:"filter"`
+	Budget *money.Money        `json:"budget"`
+}
+
+// SearchFilterRequest combina os mesmos filtros da listagem de lançamentos.
+// Várias contas ou categorias aceitam qualquer uma; várias etiquetas exigem
+// todas.
+type SearchFilterRequest struct {
+	AccountIDs  []string                `json:"accountIds" validate:"omitempty,dive,uuid"`
+	CategoryIDs []string                `json:"categoryIds" validate:"omitempty,dive,uuid"`
+	TagIDs      []string                `json:"tagIds" validate:"omitempty,dive,uuid"`
+	Status      enums.TransactionStatus `json:"status"`
+	From        string                  `json:"from" validate:"omitempty,datetime=2006-01-02"`
+	To          string                  `json:"to" validate:"omitempty,datetime=2006-01-02"`
+	MinAmount   *money.Money            `json:"minAmount"`
+	MaxAmount   *money.Money            `json:"maxAmount"`
+	Text        string                  `json:"text" validate:"max=100"`
+}
+
+// SearchReport resume os lançamentos da pesquisa pelas pernas de categoria:
+// Inflow é o que entrou nas contas, Outflow o que saiu e Net a diferença.
+// Transferências entre contas não entram nos valores.
+type SearchReport struct {
+	SavedSearchID string                 `json:"savedSearchId"`
+	Name          string                 `json:"name"`
+	Currency      string                 `json:"currency"`
+	From          string                 `json:"from,omitempty"`
+	To            string                 `json:"to,omitempty"`
+	Transactions  int                    `json:"transactions"`
+	Inflow        money.Money            `json:"inflow"`
+	Outflow       money.Money            `json:"outflow"`
+	Net           money.Money            `json:"net"`
+	Categories    []SearchReportCategory `json:"categories"`
+	Months        []SearchReportMonth    `json:"months"`
+}
+
+type SearchReportCategory struct {
+	CategoryID *string     `json:"categoryId"`
+	Name       string      `json:"name"`
+	Inflow     money.Money `json:"inflow"`
+	Outflow    money.Money `json:"outflow"`
+	Net        money.Money `json:"net"`
+}
+
+type SearchReportMonth struct {
+	Month   string      `json:"month"`
+	Inflow  money.Money `json:"inflow"`
+	Outflow money.Money `json:"outflow"`
+	Net     money.Money `json:"net"`
+}
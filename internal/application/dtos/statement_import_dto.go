@@ -0,0 +1,107 @@
+package dtos
+
+import (
+	"finanvilla/pkg/money"
+	"time"
+)
+
+// StatementImportOptions vem da query string. Statement escolhe, pelo número
+// da conta no banco, qual extrato importar quando o arquivo traz mais de um.
+type StatementImportOptions struct {
+	DryRun    bool   `json:"dryRun" form:"dryRun"`
+	Statement string `json:"statement" form:"statement"`
+}
+
+// QIFImportOptions vem da query string. Account escolhe, pelo nome no arquivo,
+// a conta a importar; DateOrder (MDY ou DMY) só desempata arquivos cujas datas
+// não revelam a ordem e, vazio, segue o formato de data do usuário.
+type QIFImportOptions struct {
+	DryRun    bool   `json:"dryRun" form:"dryRun"`
+	Account   string `json:"account" form:"account"`
+	DateOrder string `json:"dateOrder" form:"dateOrder" validate:"omitempty,oneof=MDY DMY"`
+}
+
+// CNABImportOptions vem da query string
+type CNABImportOptions struct {
+	DryRun bool `json:"dryRun" form:"dryRun"`
+}
+
+type StatementEntryStatus string
+
+const (
+	// StatementEntryNew ainda não existe na conta e será (ou seria, na prévia) importada
+	StatementEntryNew       StatementEntryStatus = "new"
+	StatementEntryDuplicate StatementEntryStatus = "duplicate"
+	StatementEntryImported  StatementEntryStatus = "imported"
+	StatementEntryFailed    StatementEntryStatus = "failed"
+)
+
+type StatementEntryResult struct {
+	Row           int                  `json:"row"`
+	ExternalID    string               `json:"externalId"`
+	Date          time.Time            `json:"date"`
+	Description   string               `json:"description"`
+	Amount        money.Money          `json:"amount"`
+	Status        StatementEntryStatus `json:"status"`
+	TransactionID string               `json:"transactionId,omitempty"`
+	// RuleID é a regra de categorização aplicada à movimentação nova
+	RuleID string   `json:"ruleId,omitempty"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+// StatementBalanceCheck compara o saldo informado pelo banco com o saldo da
+// conta na mesma data, já contando as movimentações novas do extrato
+type StatementBalanceCheck struct {
+	AsOf       time.Time   `json:"asOf"`
+	Statement  money.Money `json:"statement"`
+	Computed   money.Money `json:"computed"`
+	Difference money.Money `json:"difference"`
+	Matches    bool        `json:"matches"`
+}
+
+type StatementImportReport struct {
+	DryRun     bool   `json:"dryRun"`
+	Format     string `json:"format"`
+	AccountID  string `json:"accountId"`
+	Total      int    `json:"total"`
+	New        int    `json:"new"`
+	Duplicates int    `json:"duplicates"`
+	Imported   int    `json:"imported"`
+	Failed     int    `json:"failed"`
+	// Categorized é quantas movimentações novas foram classificadas por regras
+	Categorized int                    `json:"categorized"`
+	Entries     []StatementEntryResult `json:"entries"`
+	// Truncated indica que nem todas as linhas novas e duplicadas foram listadas
+	Truncated bool                   `json:"truncated,omitempty"`
+	Balance   *StatementBalanceCheck `json:"balance,omitempty"`
+	// NewCategories são as categorias do arquivo criadas (ou, na prévia, a criar)
+	NewCategories []string `json:"newCategories,omitempty"`
+	// OpeningBalance é o saldo inicial do arquivo aplicado à conta
+	OpeningBalance *money.Money `json:"openingBalance,omitempty"`
+	Warnings       []string     `json:"warnings,omitempty"`
+	// Unprocessed lista os registros do retorno bancário que não viraram
+	// lançamento: recusados pelo banco ou com ocorrência desconhecida
+	Unprocessed []StatementUnprocessedRecord `json:"unprocessed,omitempty"`
+}
+
+type StatementUnprocessedStatus string
+
+const (
+	StatementRecordRejected  StatementUnprocessedStatus = "rejected"
+	StatementRecordUnmatched StatementUnprocessedStatus = "unmatched"
+)
+
+// StatementUnprocessedRecord é um título ou pagamento do retorno que ficou de
+// fora da importação, com os códigos de motivo informados pelo banco
+type StatementUnprocessedRecord struct {
+	Row            int                        `json:"row"`
+	Kind           string                     `json:"kind"`
+	OurNumber      string                     `json:"ourNumber,omitempty"`
+	DocumentNumber string                     `json:"documentNumber,omitempty"`
+	Counterparty   string                     `json:"counterparty,omitempty"`
+	Amount         money.Money                `json:"amount"`
+	Occurrence     string                     `json:"occurrence"`
+	Description    string                     `json:"description"`
+	Status         StatementUnprocessedStatus `json:"status"`
+	Reasons        []string                   `json:"reasons,omitempty"`
+}
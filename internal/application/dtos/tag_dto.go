@@ -0,0 +1,11 @@
+package dtos
+
+type RenameTagRequest struct {
+	Name string `json:"name" validate:"required,max=50"`
+}
+
+// MergeTagRequest passa os lançamentos da etiqueta da rota para TargetID e
+// apaga a etiqueta da rota
+type MergeTagRequest struct {
+	TargetID string `json:"targetId" validate:"required,uuid"`
+}
@@ -0,0 +1,54 @@
+package dtos
+
+import (
+	"finanvilla/internal/domain/enums"
+	"finanvilla/pkg/money"
+)
+
+// TransactionRequest aceita as pernas explícitas em Postings ou uma das formas
+// simplificadas, com valores como string decimal ("-150.90"):
+//   - despesa/receita: accountId + amount (negativo para saída) + categoryId opcional;
+//   - transferência: accountId (origem) + toAccountId + amount positivo;
+//   - dividida: accountId + amount + splits, linhas de categoria no sinal do
+//     amount que precisam somar exatamente o total.
+//
+// Tags são nomes de etiquetas, criadas se ainda não existirem. Na edição,
+// omitir tags mantém as atuais e uma lista vazia as remove.
+type TransactionRequest struct {
+	Date        string                  `json:"date" validate:"required,datetime=2006-01-02"`
+	Description string                  `json:"description" validate:"required,max=255"`
+	Payee       string                  `json:"payee" validate:"max=255"`
+	Notes       string                  `json:"notes"`
+	Status      enums.TransactionStatus `json:"status"`
+	Postings    []PostingRequest        `json:"postings" validate:"omitempty,dive"`
+	AccountID   *string                 `json:"accountId" validate:"omitempty,uuid"`
+	ToAccountID *string                 `json:"toAccountId" validate:"omitempty,uuid"`
+	CategoryID  *string                 `json:"categoryId" validate:"omitempty,uuid"`
+	Amount      money.Money             `json:"amount"`
+	Splits      []SplitRequest          `json:"splits" validate:"omitempty,dive"`
+	Tags        []string                `json:"tags,omitempty" validate:"omitempty,max=20,dive,max=50"`
+}
+
+type PostingRequest struct {
+	AccountID  *string     `json:"accountId" validate:"omitempty,uuid"`
+	CategoryID *string     `json:"categoryId" validate:"omitempty,uuid"`
+	Amount     money.Money `json:"amount"`
+	Memo       string      `json:"memo" validate:"max=255"`
+}
+
+// SplitRequest é uma linha de um lançamento dividido entre categorias
+type SplitRequest struct {
+	CategoryID *string     `json:"categoryId" validate:"omitempty,uuid"`
+	Amount     money.Money `json:"amount"`
+	Memo       string      `json:"memo" validate:"max=255"`
+}
+
+// SplitTransactionRequest troca as linhas de categoria de um lançamento
+// existente; as contas e o total não mudam
+type SplitTransactionRequest struct {
+	Splits []SplitRequest `json:"splits" validate:"required,min=1,dive"`
+}
+
+type UpdateTransactionStatusRequest struct {
+	Status enums.TransactionStatus `json:"status" validate:"required"`
+}
@@ -0,0 +1,8 @@
+package dtos
+
+// UpdateProfileRequest altera apenas os campos enviados; uma string vazia remove o valor
+type UpdateProfileRequest struct {
+	TaxID     *string `json:"taxId" validate:"omitempty,len=0|taxid"`
+	Phone     *string `json:"phone" validate:"omitempty,len=0|phone"`
+	BirthDate *string `json:"birthDate" validate:"omitempty,len=0|datetime=2006-01-02"`
+}
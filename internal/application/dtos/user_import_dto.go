@@ -0,0 +1,48 @@
+package dtos
+
+type UserImportMode string
+
+const (
+	// ImportAtomic grava todas as linhas em uma única transação: qualquer erro desfaz a importação inteira
+	ImportAtomic UserImportMode = "atomic"
+	// ImportPerRow grava cada linha em sua própria transação, mantendo as linhas válidas
+	ImportPerRow UserImportMode = "per_row"
+)
+
+type UserImportOptions struct {
+	DryRun          bool           `json:"dryRun" form:"dryRun"`
+	Mode            UserImportMode `json:"mode" form:"mode" validate:"omitempty,oneof=atomic per_row"`
+	SendInvitations bool           `json:"sendInvitations" form:"sendInvitations"`
+}
+
+type UserImportRowStatus string
+
+const (
+	ImportRowValid      UserImportRowStatus = "valid"
+	ImportRowCreated    UserImportRowStatus = "created"
+	ImportRowFailed     UserImportRowStatus = "failed"
+	ImportRowRolledBack UserImportRowStatus = "rolled_back"
+)
+
+type UserImportRowResult struct {
+	Row    int                 `json:"row"`
+	Email  string              `json:"email"`
+	Status UserImportRowStatus `json:"status"`
+	UserID string              `json:"userId,omitempty"`
+	Errors []string            `json:"errors,omitempty"`
+}
+
+type UserImportReport struct {
+	DryRun          bool                  `json:"dryRun"`
+	Mode            UserImportMode        `json:"mode"`
+	SendInvitations bool                  `json:"sendInvitations"`
+	Total           int                   `json:"total"`
+	Succeeded       int                   `json:"succeeded"`
+	Failed          int                   `json:"failed"`
+	Rows            []UserImportRowResult `json:"rows"`
+}
+
+type AcceptInvitationRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,min=8"`
+}
@@ -0,0 +1,29 @@
+package entities
+
+import (
+	"finanvilla/internal/domain/enums"
+	"finanvilla/pkg/money"
+	"time"
+)
+
+// Account é uma conta financeira do usuário; os valores estão na moeda da conta.
+type Account struct {
+	ID             string            `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	UserID         string            `json:"-" gorm:"type:uuid;not null;index"`
+	Name           string            `json:"name" gorm:"not null"`
+	Type           enums.AccountType `json:"type" gorm:"type:varchar(20);not null"`
+	Institution    string            `json:"institution"`
+	Currency       string            `json:"currency" gorm:"type:char(3);not null"`
+	OpeningBalance money.Money       `json:"openingBalance" gorm:"type:numeric(19,4);not null"`
+	Archived       bool              `json:"archived"`
+	DisplayOrder   int               `json:"displayOrder"`
+	// ClosingDay, DueDay e CreditLimit só se aplicam a cartões de crédito
+	ClosingDay  *int         `json:"closingDay,omitempty"`
+	DueDay      *int         `json:"dueDay,omitempty"`
+	CreditLimit *money.Money `json:"creditLimit,omitempty" gorm:"type:numeric(19,4)"`
+	CreatedAt   time.Time    `json:"createdAt"`
+	UpdatedAt   time.Time    `json:"updatedAt"`
+
+	// Calculado pelo repositório a partir do saldo inicial e dos lançamentos
+	CurrentBalance money.Money `json:"currentBalance" gorm:"->;-:migration"`
+}
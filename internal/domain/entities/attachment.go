@@ -0,0 +1,36 @@
+package entities
+
+import "time"
+
+// Attachment é um comprovante, nota ou contrato anexado a um lançamento ou a
+// uma conta. O conteúdo fica em AttachmentFile, compartilhado entre anexos
+// iguais do mesmo usuário.
+type Attachment struct {
+	ID            string    `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	UserID        string    `json:"-" gorm:"type:uuid;not null;index"`
+	FileID        string    `json:"-" gorm:"type:uuid;not null"`
+	TransactionID *string   `json:"transactionId,omitempty" gorm:"type:uuid"`
+	AccountID     *string   `json:"accountId,omitempty" gorm:"type:uuid"`
+	Filename      string    `json:"filename" gorm:"not null"`
+	CreatedAt     time.Time `json:"createdAt"`
+
+	// Campos do arquivo, preenchidos nas consultas
+	ContentType  string  `json:"contentType" gorm:"->;-:migration"`
+	Size         int64   `json:"size" gorm:"->;-:migration"`
+	SHA256       string  `json:"sha256" gorm:"column:sha256;->;-:migration"`
+	StorageKey   string  `json:"-" gorm:"->;-:migration"`
+	ThumbnailKey *string `json:"-" gorm:"->;-:migration"`
+	HasThumbnail bool    `json:"hasThumbnail" gorm:"->;-:migration"`
+}
+
+// AttachmentFile é o conteúdo de um anexo no armazenamento
+type AttachmentFile struct {
+	ID           string `gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	UserID       string `gorm:"type:uuid"`
+	SHA256       string `gorm:"column:sha256;type:char(64);not null"`
+	Size         int64  `gorm:"not null"`
+	ContentType  string `gorm:"not null"`
+	StorageKey   string `gorm:"not null"`
+	ThumbnailKey *string
+	CreatedAt    time.Time
+}
@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"finanvilla/pkg/money"
+	"time"
+)
+
+// Budget é o valor orçado para uma categoria de despesa em um mês. Month é
+// sempre o primeiro dia do mês.
+type Budget struct {
+	ID         string      `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	UserID     string      `json:"-" gorm:"type:uuid;not null"`
+	CategoryID string      `json:"categoryId" gorm:"type:uuid;not null"`
+	Month      time.Time   `json:"month" gorm:"type:date;not null"`
+	Amount     money.Money `json:"amount" gorm:"type:numeric(19,4);not null"`
+	Currency   string      `json:"currency" gorm:"type:char(3);not null"`
+	// Rollover leva a sobra (ou o estouro) deste mês para o seguinte
+	Rollover  bool      `json:"rollover" gorm:"not null;default:false"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// BudgetLine é a linha de uma categoria no relatório mensal: Remaining =
+// CarriedOver + Budgeted - Actual.
+type BudgetLine struct {
+	CategoryID  string      `json:"categoryId"`
+	ParentID    *string     `json:"parentId,omitempty"`
+	Name        string      `json:"name"`
+	Archived    bool        `json:"archived"`
+	HasBudget   bool        `json:"hasBudget"`
+	Rollover    bool        `json:"rollover"`
+	Budgeted    money.Money `json:"budgeted"`
+	CarriedOver money.Money `json:"carriedOver"`
+	Actual      money.Money `json:"actual"`
+	Remaining   money.Money `json:"remaining"`
+}
+
+// BudgetMonthTotals resume a receita e a distribuição de um mês. ToBeAssigned
+// é o acumulado de saldos iniciais e receitas até o fim do mês menos tudo o
+// que já foi orçado até ele.
+type BudgetMonthTotals struct {
+	Month        time.Time   `json:"month"`
+	Income       money.Money `json:"income"`
+	Assigned     money.Money `json:"assigned"`
+	ToBeAssigned money.Money `json:"toBeAssigned"`
+}
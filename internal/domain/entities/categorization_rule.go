@@ -0,0 +1,97 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/pkg/money"
+	"fmt"
+	"time"
+)
+
+// CategorizationRule classifica lançamentos importados ou já existentes.
+// As regras ativas são testadas pela ordem de Position e a primeira cujas
+// condições batem todas aplica as suas ações.
+type CategorizationRule struct {
+	ID         string         `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	UserID     string         `json:"-" gorm:"type:uuid;not null;index"`
+	Name       string         `json:"name" gorm:"not null"`
+	Position   int            `json:"position" gorm:"not null;default:0"`
+	Active     bool           `json:"active" gorm:"not null;default:true"`
+	Conditions RuleConditions `json:"conditions" gorm:"type:jsonb;not null"`
+	Actions    RuleActions    `json:"actions" gorm:"type:jsonb;not null"`
+	CreatedAt  time.Time      `json:"createdAt"`
+	UpdatedAt  time.Time      `json:"updatedAt"`
+}
+
+// RuleCondition compara um campo do lançamento. Value é o texto, a expressão
+// regular ou o ID da conta; Min e Max limitam o valor em AMOUNT.
+type RuleCondition struct {
+	Field    enums.RuleField    `json:"field"`
+	Operator enums.RuleOperator `json:"operator"`
+	Value    string             `json:"value,omitempty"`
+	Min      *money.Money       `json:"min,omitempty"`
+	Max      *money.Money       `json:"max,omitempty"`
+}
+
+// RuleActions é o que a regra muda no lançamento. CategoryID e
+// TransferAccountID se excluem; Tags são somadas às que o lançamento já tem.
+type RuleActions struct {
+	CategoryID *string  `json:"categoryId,omitempty"`
+	Payee      string   `json:"payee,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+	// TransferAccountID transforma a contrapartida numa transferência
+	TransferAccountID *string `json:"transferAccountId,omitempty"`
+}
+
+type RuleConditions []RuleCondition
+
+func (c RuleConditions) Value() (driver.Value, error) {
+	return jsonValue(c)
+}
+
+func (c *RuleConditions) Scan(src interface{}) error {
+	return scanJSON(src, c)
+}
+
+func (a RuleActions) Value() (driver.Value, error) {
+	return jsonValue(a)
+}
+
+func (a *RuleActions) Scan(src interface{}) error {
+	return scanJSON(src, a)
+}
+
+// RuleSuggestion junta as recategorizações manuais de lançamentos com a
+// mesma palavra-chave na descrição para a mesma categoria
+type RuleSuggestion struct {
+	ID          string    `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	UserID      string    `json:"-" gorm:"type:uuid;not null;index"`
+	Keyword     string    `json:"keyword" gorm:"not null"`
+	CategoryID  string    `json:"categoryId" gorm:"type:uuid;not null"`
+	Occurrences int       `json:"occurrences" gorm:"not null;default:1"`
+	Dismissed   bool      `json:"-" gorm:"not null;default:false"`
+	LastSeenAt  time.Time `json:"lastSeenAt"`
+	CreatedAt   time.Time `json:"createdAt"`
+	// CategoryName vem do cadastro da categoria
+	CategoryName string `json:"categoryName" gorm:"->;-:migration"`
+}
+
+func jsonValue(v interface{}) (driver.Value, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func scanJSON(src interface{}, dst interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, dst)
+	case string:
+		return json.Unmarshal([]byte(v), dst)
+	default:
+		return fmt.Errorf("cannot scan %T into %T", src, dst)
+	}
+}
@@ -0,0 +1,24 @@
+package entities
+
+import (
+	"finanvilla/internal/domain/enums"
+	"time"
+)
+
+// Category classifica o lado de receita/despesa dos lançamentos. Subcategorias
+// apontam para a categoria pai e herdam dela o tipo. Categorias arquivadas
+// continuam nos lançamentos antigos, mas não podem ser usadas em novos.
+type Category struct {
+	ID        string             `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	UserID    string             `json:"-" gorm:"type:uuid;not null;index"`
+	ParentID  *string            `json:"parentId,omitempty" gorm:"type:uuid;index"`
+	Name      string             `json:"name" gorm:"not null"`
+	Type      enums.CategoryType `json:"type" gorm:"type:varchar(10);not null"`
+	Color     string             `json:"color"`
+	Icon      string             `json:"icon"`
+	Archived  bool               `json:"archived"`
+	CreatedAt time.Time          `json:"createdAt"`
+	UpdatedAt time.Time          `json:"updatedAt"`
+
+	Children []Category `json:"children,omitempty" gorm:"-"`
+}
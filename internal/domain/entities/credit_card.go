@@ -0,0 +1,56 @@
+package entities
+
+import (
+	"finanvilla/internal/domain/enums"
+	"finanvilla/pkg/money"
+	"time"
+)
+
+// InstallmentPurchase é uma compra parcelada no cartão. Cada parcela é um
+// lançamento próprio cuja perna do cartão fica presa à fatura em que é cobrada.
+type InstallmentPurchase struct {
+	ID           string      `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	UserID       string      `json:"-" gorm:"type:uuid;not null;index"`
+	AccountID    string      `json:"accountId" gorm:"type:uuid;not null"`
+	Description  string      `json:"description" gorm:"not null"`
+	Payee        string      `json:"payee"`
+	Notes        string      `json:"notes"`
+	PurchaseDate time.Time   `json:"purchaseDate" gorm:"type:date;not null"`
+	TotalAmount  money.Money `json:"totalAmount" gorm:"type:numeric(19,4);not null"`
+	Installments int         `json:"installments" gorm:"not null"`
+	// FirstStatement é a data de fechamento da fatura da primeira parcela
+	FirstStatement time.Time `json:"firstStatement" gorm:"type:date;not null"`
+	Currency       string    `json:"currency" gorm:"type:char(3);not null"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+
+	// Parcelas já lançadas, pela ordem
+	Transactions []Transaction `json:"transactions" gorm:"foreignKey:InstallmentPurchaseID;constraint:OnDelete:CASCADE"`
+}
+
+// CardStatement é a fatura do cartão, identificada pela data de fechamento.
+// Charges soma as saídas do cartão e Credits os pagamentos e estornos.
+type CardStatement struct {
+	ClosingDate time.Time                 `json:"closingDate"`
+	DueDate     time.Time                 `json:"dueDate"`
+	Status      enums.CardStatementStatus `json:"status"`
+	// Overdue indica fatura fechada, não paga e com vencimento já passado
+	Overdue   bool        `json:"overdue"`
+	Entries   int         `json:"entries"`
+	Charges   money.Money `json:"charges"`
+	Credits   money.Money `json:"credits"`
+	AmountDue money.Money `json:"amountDue"`
+}
+
+// CardStatementEntry é uma linha da fatura
+type CardStatementEntry struct {
+	TransactionID     string                  `json:"transactionId"`
+	Date              time.Time               `json:"date"`
+	Description       string                  `json:"description"`
+	Payee             string                  `json:"payee"`
+	Status            enums.TransactionStatus `json:"status"`
+	Currency          string                  `json:"currency"`
+	Amount            money.Money             `json:"amount"`
+	InstallmentNumber *int                    `json:"installmentNumber,omitempty"`
+	Installments      *int                    `json:"installments,omitempty"`
+}
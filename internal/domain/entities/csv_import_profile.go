@@ -0,0 +1,31 @@
+package entities
+
+import "time"
+
+// CSVImportProfile guarda o mapeamento de colunas do CSV de um banco para ser
+// reaproveitado. Colunas são o nome no cabeçalho ou o número (a partir de 1).
+// Delimiter e Encoding vazios são detectados; DateFormat e NumberFormat vazios
+// usam as preferências do usuário.
+type CSVImportProfile struct {
+	ID                string    `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	UserID            string    `json:"-" gorm:"type:uuid;not null;index"`
+	Name              string    `json:"name" gorm:"not null"`
+	Institution       string    `json:"institution"`
+	Delimiter         string    `json:"delimiter" gorm:"type:varchar(1)"`
+	Encoding          string    `json:"encoding" gorm:"type:varchar(20)"`
+	HasHeader         bool      `json:"hasHeader"`
+	SkipRows          int       `json:"skipRows"`
+	DateColumn        string    `json:"dateColumn" gorm:"not null"`
+	DescriptionColumn string    `json:"descriptionColumn" gorm:"not null"`
+	AmountColumn      string    `json:"amountColumn"`
+	DebitColumn       string    `json:"debitColumn"`
+	CreditColumn      string    `json:"creditColumn"`
+	PayeeColumn       string    `json:"payeeColumn"`
+	MemoColumn        string    `json:"memoColumn"`
+	ExternalIDColumn  string    `json:"externalIdColumn"`
+	DateFormat        string    `json:"dateFormat" gorm:"type:varchar(20)"`
+	NumberFormat      string    `json:"numberFormat" gorm:"type:varchar(20)"`
+	InvertSign        bool      `json:"invertSign"`
+	CreatedAt         time.Time `json:"createdAt"`
+	UpdatedAt         time.Time `json:"updatedAt"`
+}
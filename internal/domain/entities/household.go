@@ -0,0 +1,79 @@
+package entities
+
+import (
+	"finanvilla/internal/domain/enums"
+	"finanvilla/pkg/money"
+	"time"
+)
+
+// Household é um grupo de usuários que divide despesas. Todos os valores da
+// casa estão na moeda dela.
+type Household struct {
+	ID        string    `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	Name      string    `json:"name" gorm:"not null"`
+	Currency  string    `json:"currency" gorm:"type:char(3);not null"`
+	CreatedBy *string   `json:"createdBy" gorm:"type:uuid"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	Members []HouseholdMember `json:"members" gorm:"foreignKey:HouseholdID;constraint:OnDelete:CASCADE"`
+}
+
+type HouseholdMember struct {
+	HouseholdID string `json:"-" gorm:"primaryKey;type:uuid"`
+	UserID      string `json:"userId" gorm:"primaryKey;type:uuid"`
+	// Name e Email vêm do cadastro do usuário
+	Name     string    `json:"name" gorm:"->;-:migration"`
+	Email    string    `json:"email" gorm:"->;-:migration"`
+	PixKey   string    `json:"pixKey"`
+	PixCity  string    `json:"pixCity"`
+	JoinedAt time.Time `json:"joinedAt" gorm:"autoCreateTime"`
+	// Status é PENDING enquanto o convite não for aceito; Members só traz os ativos
+	Status    enums.HouseholdMemberStatus `json:"-" gorm:"type:varchar(10);not null;default:ACTIVE"`
+	InvitedBy *string                     `json:"-" gorm:"type:uuid"`
+}
+
+// SharedExpense é uma despesa paga por PaidBy e dividida entre os
+// participantes conforme SplitMode
+type SharedExpense struct {
+	ID          string          `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	HouseholdID string          `json:"householdId" gorm:"type:uuid;not null;index"`
+	PaidBy      string          `json:"paidBy" gorm:"type:uuid;not null"`
+	CreatedBy   *string         `json:"createdBy" gorm:"type:uuid"`
+	Description string          `json:"description" gorm:"not null"`
+	Notes       string          `json:"notes"`
+	Date        time.Time       `json:"date" gorm:"type:date;not null"`
+	Amount      money.Money     `json:"amount" gorm:"type:numeric(19,4);not null"`
+	Currency    string          `json:"currency" gorm:"type:char(3);not null"`
+	SplitMode   enums.SplitMode `json:"splitMode" gorm:"type:varchar(20);not null"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	UpdatedAt   time.Time       `json:"updatedAt"`
+
+	Shares []SharedExpenseShare `json:"shares" gorm:"foreignKey:ExpenseID;constraint:OnDelete:CASCADE"`
+}
+
+// SharedExpenseShare é a parte de um participante. Weight guarda o que foi
+// informado na divisão: centésimos de ponto percentual (PERCENTAGE) ou
+// número de cotas (SHARES).
+type SharedExpenseShare struct {
+	ExpenseID string      `json:"-" gorm:"primaryKey;type:uuid"`
+	UserID    string      `json:"userId" gorm:"primaryKey;type:uuid"`
+	Amount    money.Money `json:"amount" gorm:"type:numeric(19,4);not null"`
+	Weight    *int64      `json:"weight,omitempty"`
+}
+
+// Settlement registra que FromUserID pagou Amount a ToUserID
+type Settlement struct {
+	ID          string      `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	HouseholdID string      `json:"householdId" gorm:"type:uuid;not null;index"`
+	FromUserID  string      `json:"fromUserId" gorm:"type:uuid;not null"`
+	ToUserID    string      `json:"toUserId" gorm:"type:uuid;not null"`
+	Amount      money.Money `json:"amount" gorm:"type:numeric(19,4);not null"`
+	Currency    string      `json:"currency" gorm:"type:char(3);not null"`
+	Date        time.Time   `json:"date" gorm:"type:date;not null"`
+	Note        string      `json:"note"`
+	// PixPayload é o "Pix copia e cola" gerado para o pagamento, se pedido
+	PixPayload string    `json:"pixPayload,omitempty"`
+	CreatedBy  *string   `json:"createdBy" gorm:"type:uuid"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
@@ -0,0 +1,17 @@
+package entities
+
+import (
+	"finanvilla/internal/domain/enums"
+	"time"
+)
+
+// NotificationPreference guarda apenas as escolhas explícitas do usuário; os
+// pares evento/canal ausentes seguem os padrões definidos em ShouldNotify.
+type NotificationPreference struct {
+	UserID    string                    `json:"-" gorm:"primaryKey;type:uuid"`
+	Event     enums.NotificationEvent   `json:"event" gorm:"primaryKey;type:varchar(30)"`
+	Channel   enums.NotificationChannel `json:"channel" gorm:"primaryKey;type:varchar(20)"`
+	Enabled   bool                      `json:"enabled"`
+	CreatedAt time.Time                 `json:"createdAt"`
+	UpdatedAt time.Time                 `json:"updatedAt"`
+}
@@ -0,0 +1,36 @@
+package entities
+
+import (
+	"finanvilla/pkg/money"
+	"time"
+)
+
+// RecurringTransaction é o modelo de um lançamento que se repete (aluguel,
+// salário, assinaturas). Rule segue o formato de pkg/recurrence; cada
+// ocorrência vira um lançamento pendente quando a data chega.
+type RecurringTransaction struct {
+	ID          string             `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	UserID      string             `json:"-" gorm:"type:uuid;not null;index"`
+	Description string             `json:"description" gorm:"not null"`
+	Payee       string             `json:"payee"`
+	Notes       string             `json:"notes"`
+	Currency    string             `json:"currency" gorm:"type:char(3);not null"`
+	Rule        string             `json:"rule" gorm:"not null"`
+	StartDate   time.Time          `json:"startDate" gorm:"type:date;not null"`
+	NextDate    *time.Time         `json:"nextDate" gorm:"type:date"` // nil quando a série terminou
+	Active      bool               `json:"active" gorm:"not null;default:true"`
+	Postings    []RecurringPosting `json:"postings" gorm:"foreignKey:RecurringID;constraint:OnDelete:CASCADE"`
+	CreatedAt   time.Time          `json:"createdAt"`
+	UpdatedAt   time.Time          `json:"updatedAt"`
+}
+
+// RecurringPosting é uma perna do modelo, copiada para cada ocorrência
+type RecurringPosting struct {
+	ID          string      `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	RecurringID string      `json:"-" gorm:"type:uuid;not null;index"`
+	AccountID   *string     `json:"accountId,omitempty" gorm:"type:uuid;index"`
+	CategoryID  *string     `json:"categoryId,omitempty" gorm:"type:uuid;index"`
+	Amount      money.Money `json:"amount" gorm:"type:numeric(19,4);not null"`
+	Memo        string      `json:"memo"`
+	CreatedAt   time.Time   `json:"createdAt"`
+}
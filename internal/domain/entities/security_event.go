@@ -0,0 +1,19 @@
+package entities
+
+import (
+	"finanvilla/internal/domain/enums"
+	"time"
+)
+
+type SecurityEvent struct {
+	ID        string                     `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	UserID    *string                    `json:"userId,omitempty" gorm:"type:uuid;index"` // nulo em tentativas com e-mail inexistente
+	Email     string                     `json:"email,omitempty"`
+	Type      enums.SecurityEventType    `json:"type" gorm:"type:varchar(30);not null"`
+	Outcome   enums.SecurityEventOutcome `json:"outcome" gorm:"type:varchar(10);not null"`
+	Reason    string                     `json:"reason,omitempty"`
+	Details   string                     `json:"details,omitempty"`
+	IP        string                     `json:"ip" gorm:"column:ip"`
+	UserAgent string                     `json:"userAgent"`
+	CreatedAt time.Time                  `json:"createdAt" gorm:"index"`
+}
@@ -0,0 +1,72 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/pkg/money"
+	"fmt"
+	"time"
+)
+
+// Tag é uma etiqueta livre do usuário, aplicada a lançamentos de quaisquer
+// contas e categorias. O nome não diferencia maiúsculas de minúsculas.
+type Tag struct {
+	ID        string    `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	UserID    string    `json:"-" gorm:"type:uuid;not null;index"`
+	Name      string    `json:"name" gorm:"not null"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	// Transactions é quantos lançamentos usam a etiqueta, só na listagem
+	Transactions *int64 `json:"transactions,omitempty" gorm:"->;-:migration"`
+}
+
+// SavedSearch guarda uma combinação de filtros de lançamentos para reuso na
+// listagem, em relatórios, na exportação e, com Budget, como limite mensal no
+// relatório de orçamento
+type SavedSearch struct {
+	ID     string       `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	UserID string       `json:"-" gorm:"type:uuid;not null;index"`
+	Name   string       `json:"name" gorm:"not null"`
+	Filter SearchFilter `json:"filter" gorm:"type:jsonb;not null"`
+	// Budget é o limite de gastos por mês, na moeda Currency
+	Budget    *money.Money `json:"budget" gorm:"type:numeric(19,4)"`
+	Currency  string       `json:"currency" gorm:"type:char(3);not null"`
+	CreatedAt time.Time    `json:"createdAt"`
+	UpdatedAt time.Time    `json:"updatedAt"`
+}
+
+// SearchFilter são os critérios de uma pesquisa salva; campos vazios são
+// ignorados. Datas são YYYY-MM-DD e valores comparam o que foi movimentado.
+type SearchFilter struct {
+	AccountIDs  []string `json:"accountIds,omitempty"`
+	CategoryIDs []string `json:"categoryIds,omitempty"`
+	// TagIDs exige todas as etiquetas no lançamento
+	TagIDs    []string                `json:"tagIds,omitempty"`
+	Status    enums.TransactionStatus `json:"status,omitempty"`
+	From      string                  `json:"from,omitempty"`
+	To        string                  `json:"to,omitempty"`
+	MinAmount *money.Money            `json:"minAmount,omitempty"`
+	MaxAmount *money.Money            `json:"maxAmount,omitempty"`
+	Text      string                  `json:"text,omitempty"`
+}
+
+// Value grava o filtro como JSONB
+func (f SearchFilter) Value() (driver.Value, error) {
+	data, err := json.Marshal(f)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (f *SearchFilter) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, f)
+	case string:
+		return json.Unmarshal([]byte(v), f)
+	default:
+		return fmt.Errorf("search filter: cannot scan %T", src)
+	}
+}
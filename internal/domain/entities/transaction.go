@@ -0,0 +1,62 @@
+package entities
+
+import (
+	"finanvilla/internal/domain/enums"
+	"finanvilla/pkg/money"
+	"time"
+)
+
+// Transaction é um lançamento do livro-razão. Os Postings movimentam contas ou
+// categorias e sempre somam zero: uma despesa simples tem duas pernas (conta e
+// categoria) e uma transferência liga duas contas.
+type Transaction struct {
+	ID          string                  `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	UserID      string                  `json:"-" gorm:"type:uuid;not null;index"`
+	Date        time.Time               `json:"date" gorm:"type:date;not null"`
+	Description string                  `json:"description" gorm:"not null"`
+	Payee       string                  `json:"payee"`
+	Notes       string                  `json:"notes"`
+	Status      enums.TransactionStatus `json:"status" gorm:"type:varchar(20);not null"`
+	Currency    string                  `json:"currency" gorm:"type:char(3);not null"`
+	Postings    []Posting               `json:"postings" gorm:"foreignKey:TransactionID;constraint:OnDelete:CASCADE"`
+	Tags        []Tag                   `json:"tags" gorm:"many2many:transaction_tags"`
+	// RecurringID e OccurrenceDate identificam lançamentos gerados por um RecurringTransaction
+	RecurringID    *string    `json:"recurringId,omitempty" gorm:"type:uuid"`
+	OccurrenceDate *time.Time `json:"occurrenceDate,omitempty" gorm:"type:date"`
+	// InstallmentPurchaseID e InstallmentNumber identificam a parcela de uma compra parcelada
+	InstallmentPurchaseID *string   `json:"installmentPurchaseId,omitempty" gorm:"type:uuid"`
+	InstallmentNumber     *int      `json:"installmentNumber,omitempty"`
+	CreatedAt             time.Time `json:"createdAt"`
+	UpdatedAt             time.Time `json:"updatedAt"`
+}
+
+// Posting é uma perna do lançamento, na moeda do lançamento. Valores
+// positivos entram na conta; negativos saem. Uma perna sem conta representa o
+// lado de receita/despesa, classificado pela categoria (ou sem categoria).
+type Posting struct {
+	ID            string      `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	TransactionID string      `json:"-" gorm:"type:uuid;not null;index"`
+	AccountID     *string     `json:"accountId,omitempty" gorm:"type:uuid;index"`
+	CategoryID    *string     `json:"categoryId,omitempty" gorm:"type:uuid;index"`
+	Amount        money.Money `json:"amount" gorm:"type:numeric(19,4);not null"`
+	Memo          string      `json:"memo"`
+	// ExternalID identifica a movimentação no extrato importado do banco
+	ExternalID *string `json:"externalId,omitempty" gorm:"size:255"`
+	// StatementDate fixa a fatura do cartão (data de fechamento) a que a perna
+	// pertence; sem ela, a fatura sai da data do lançamento
+	StatementDate *time.Time `json:"statementDate,omitempty" gorm:"type:date"`
+	CreatedAt     time.Time  `json:"createdAt"`
+}
+
+// RegisterEntry é uma linha do extrato de uma conta, com o saldo acumulado
+// após o lançamento.
+type RegisterEntry struct {
+	TransactionID  string                  `json:"transactionId"`
+	Date           time.Time               `json:"date"`
+	Description    string                  `json:"description"`
+	Payee          string                  `json:"payee"`
+	Status         enums.TransactionStatus `json:"status"`
+	Currency       string                  `json:"currency"`
+	Amount         money.Money             `json:"amount"`
+	RunningBalance money.Money             `json:"runningBalance"`
+}
@@ -2,6 +2,7 @@ package entities
 
 import (
 	"finanvilla/internal/domain/enums"
+	"finanvilla/pkg/brdoc"
 	"time"
 )
 
@@ -12,9 +13,19 @@ type User struct {
 	Password    string         `json:"-" gorm:"not null"` // O "-" oculta o campo nas respostas JSON
 	UserType    enums.UserType `json:"userType" gorm:"type:varchar(20);not null"`
 	Active      bool           `json:"active" gorm:"default:true"`
+	AvatarKey   string         `json:"-"`
+	TaxID       *brdoc.TaxID   `json:"taxId,omitempty" gorm:"column:tax_id"` // CPF ou CNPJ, mascarado no JSON
+	Phone       *brdoc.Phone   `json:"phone,omitempty"`                      // E.164, mascarado no JSON
+	BirthDate   *time.Time     `json:"birthDate,omitempty" gorm:"type:date"`
 	CreatedAt   time.Time      `json:"createdAt"`
 	UpdatedAt   time.Time      `json:"updatedAt"`
 	DeletedAt   *time.Time     `json:"deletedAt,omitempty" gorm:"index"`
 	Settings    *UserSettings  `json:"settings" gorm:"foreignKey:UserID"`
 	Permissions []Permission   `json:"permissions" gorm:"many2many:user_permissions;"`
+
+	NotificationPreferences []NotificationPreference `json:"-" gorm:"foreignKey:UserID"`
+
+	// URLs assinadas e temporárias, preenchidas pelo AvatarService a cada resposta
+	AvatarURL        string            `json:"avatarUrl,omitempty" gorm:"-"`
+	AvatarThumbnails map[string]string `json:"avatarThumbnails,omitempty" gorm:"-"`
 }
@@ -1,15 +1,25 @@
 package entities
 
-import "time"
+import (
+	"finanvilla/internal/domain/enums"
+	"time"
+)
 
 type UserSettings struct {
-	ID                   string    `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
-	UserID               string    `json:"userId" gorm:"not null"`
-	Theme                string    `json:"theme" gorm:"default:'light'"`
-	Language             string    `json:"language" gorm:"default:'pt-BR'"`
-	NotificationsEnabled bool      `json:"notificationsEnabled" gorm:"default:true"`
-	Currency             string    `json:"currency" gorm:"default:'BRL'"`
-	DateFormat           string    `json:"dateFormat" gorm:"default:'DD/MM/YYYY'"`
-	CreatedAt            time.Time `json:"createdAt"`
-	UpdatedAt            time.Time `json:"updatedAt"`
+	ID                   string                `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	UserID               string                `json:"userId" gorm:"not null"`
+	Theme                string                `json:"theme" gorm:"default:'light'"`
+	Language             string                `json:"language" gorm:"default:'pt-BR'"`
+	NotificationsEnabled bool                  `json:"notificationsEnabled" gorm:"default:true"`
+	Currency             string                `json:"currency" gorm:"default:'BRL'"`
+	DateFormat           string                `json:"dateFormat" gorm:"default:'DD/MM/YYYY'"`
+	Timezone             string                `json:"timezone" gorm:"default:'America/Sao_Paulo'"`
+	NumberFormat         string                `json:"numberFormat" gorm:"default:'1.234,56'"`
+	FirstDayOfWeek       string                `json:"firstDayOfWeek" gorm:"default:'SUNDAY'"`
+	QuietHoursStart      string                `json:"quietHoursStart"` // HH:MM no fuso do usuário; vazio desativa
+	QuietHoursEnd        string                `json:"quietHoursEnd"`
+	DigestFrequency      enums.DigestFrequency `json:"digestFrequency" gorm:"type:varchar(10);default:'WEEKLY'"`
+	BudgetMode           enums.BudgetMode      `json:"budgetMode" gorm:"type:varchar(10);default:'STANDARD'"`
+	CreatedAt            time.Time             `json:"createdAt"`
+	UpdatedAt            time.Time             `json:"updatedAt"`
 }
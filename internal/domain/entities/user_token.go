@@ -0,0 +1,19 @@
+package entities
+
+import (
+	"finanvilla/internal/domain/enums"
+	"time"
+)
+
+// UserToken representa um token de uso único enviado por e-mail (convites,
+// confirmações). Apenas o hash SHA-256 do token é persistido.
+type UserToken struct {
+	ID        string             `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	UserID    string             `json:"userId" gorm:"type:uuid;not null;index"`
+	Purpose   enums.TokenPurpose `json:"purpose" gorm:"type:varchar(30);not null"`
+	TokenHash string             `json:"-" gorm:"unique;not null"`
+	Payload   string             `json:"-"`
+	ExpiresAt time.Time          `json:"expiresAt" gorm:"not null"`
+	UsedAt    *time.Time         `json:"usedAt,omitempty"`
+	CreatedAt time.Time          `json:"createdAt"`
+}
@@ -0,0 +1,22 @@
+package enums
+
+type AccountType string
+
+const (
+	CheckingAccount   AccountType = "CHECKING"
+	SavingsAccount    AccountType = "SAVINGS"
+	CreditCardAccount AccountType = "CREDIT_CARD"
+	CashAccount       AccountType = "CASH"
+	InvestmentAccount AccountType = "INVESTMENT"
+)
+
+var AccountTypes = []AccountType{CheckingAccount, SavingsAccount, CreditCardAccount, CashAccount, InvestmentAccount}
+
+func (t AccountType) IsValid() bool {
+	for _, accountType := range AccountTypes {
+		if t == accountType {
+			return true
+		}
+	}
+	return false
+}
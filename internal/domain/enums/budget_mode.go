@@ -0,0 +1,17 @@
+package enums
+
+// BudgetMode define como o orçamento mensal do usuário funciona
+type BudgetMode string
+
+const (
+	// StandardBudget compara o orçado com o gasto; sobras só passam para o
+	// mês seguinte nas categorias com rollover
+	StandardBudget BudgetMode = "STANDARD"
+	// EnvelopeBudget exige que a receita seja distribuída entre as categorias
+	// antes de ser gasta; todo saldo de envelope passa para o mês seguinte
+	EnvelopeBudget BudgetMode = "ENVELOPE"
+)
+
+func (m BudgetMode) IsValid() bool {
+	return m == StandardBudget || m == EnvelopeBudget
+}
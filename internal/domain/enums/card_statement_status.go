@@ -0,0 +1,12 @@
+package enums
+
+type CardStatementStatus string
+
+const (
+	// OpenStatement ainda recebe lançamentos: o fechamento é hoje ou depois
+	OpenStatement CardStatementStatus = "OPEN"
+	// ClosedStatement já fechou e tem saldo a pagar
+	ClosedStatement CardStatementStatus = "CLOSED"
+	// PaidStatement já fechou e os pagamentos cobrem os gastos
+	PaidStatement CardStatementStatus = "PAID"
+)
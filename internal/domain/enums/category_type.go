@@ -0,0 +1,12 @@
+package enums
+
+type CategoryType string
+
+const (
+	IncomeCategory  CategoryType = "INCOME"
+	ExpenseCategory CategoryType = "EXPENSE"
+)
+
+func (t CategoryType) IsValid() bool {
+	return t == IncomeCategory || t == ExpenseCategory
+}
@@ -0,0 +1,11 @@
+package enums
+
+// HouseholdMemberStatus separa os convites ainda não aceitos dos membros da casa
+type HouseholdMemberStatus string
+
+const (
+	// PendingMember foi convidado e ainda não aceitou: não vê a casa nem entra
+	// nas despesas
+	PendingMember HouseholdMemberStatus = "PENDING"
+	ActiveMember  HouseholdMemberStatus = "ACTIVE"
+)
@@ -0,0 +1,32 @@
+package enums
+
+type NotificationEvent string
+
+const (
+	SecurityAlert NotificationEvent = "SECURITY_ALERT"
+	BudgetWarning NotificationEvent = "BUDGET_WARNING"
+	BillReminder  NotificationEvent = "BILL_REMINDER"
+	// Digest é o resumo periódico; a frequência vem de UserSettings.DigestFrequency
+	Digest NotificationEvent = "DIGEST"
+)
+
+var NotificationEvents = []NotificationEvent{SecurityAlert, BudgetWarning, BillReminder, Digest}
+
+type NotificationChannel string
+
+const (
+	EmailChannel   NotificationChannel = "EMAIL"
+	InAppChannel   NotificationChannel = "IN_APP"
+	WebhookChannel NotificationChannel = "WEBHOOK"
+)
+
+var NotificationChannels = []NotificationChannel{EmailChannel, InAppChannel, WebhookChannel}
+
+type DigestFrequency string
+
+const (
+	DigestNever   DigestFrequency = "NEVER"
+	DigestDaily   DigestFrequency = "DAILY"
+	DigestWeekly  DigestFrequency = "WEEKLY"
+	DigestMonthly DigestFrequency = "MONTHLY"
+)
@@ -0,0 +1,60 @@
+package enums
+
+// RuleField é o dado do lançamento que uma condição de regra examina
+type RuleField string
+
+const (
+	DescriptionField RuleField = "DESCRIPTION"
+	PayeeField       RuleField = "PAYEE"
+	// AmountField compara o valor movimentado na conta, sem sinal
+	AmountField  RuleField = "AMOUNT"
+	AccountField RuleField = "ACCOUNT"
+)
+
+var RuleFields = []RuleField{DescriptionField, PayeeField, AmountField, AccountField}
+
+func (f RuleField) IsValid() bool {
+	for _, field := range RuleFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// RuleOperator é a comparação feita pela condição. Textos são comparados sem
+// diferenciar maiúsculas de minúsculas.
+type RuleOperator string
+
+const (
+	ContainsOperator   RuleOperator = "CONTAINS"
+	EqualsOperator     RuleOperator = "EQUALS"
+	StartsWithOperator RuleOperator = "STARTS_WITH"
+	RegexOperator      RuleOperator = "REGEX"
+	// BetweenOperator aceita Min, Max ou os dois, inclusive
+	BetweenOperator RuleOperator = "BETWEEN"
+)
+
+var RuleOperators = []RuleOperator{ContainsOperator, EqualsOperator, StartsWithOperator, RegexOperator, BetweenOperator}
+
+func (o RuleOperator) IsValid() bool {
+	for _, operator := range RuleOperators {
+		if o == operator {
+			return true
+		}
+	}
+	return false
+}
+
+// Accepts diz se o operador serve para o campo
+func (o RuleOperator) Accepts(f RuleField) bool {
+	switch f {
+	case DescriptionField, PayeeField:
+		return o == ContainsOperator || o == EqualsOperator || o == StartsWithOperator || o == RegexOperator
+	case AmountField:
+		return o == BetweenOperator
+	case AccountField:
+		return o == EqualsOperator
+	}
+	return false
+}
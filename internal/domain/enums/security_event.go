@@ -0,0 +1,20 @@
+package enums
+
+type SecurityEventType string
+
+const (
+	LoginEvent            SecurityEventType = "LOGIN"
+	TokenRefreshEvent     SecurityEventType = "TOKEN_REFRESH"
+	LogoutEvent           SecurityEventType = "LOGOUT"
+	LogoutAllEvent        SecurityEventType = "LOGOUT_ALL"
+	PasswordChangeEvent   SecurityEventType = "PASSWORD_CHANGE"
+	PermissionChangeEvent SecurityEventType = "PERMISSION_CHANGE"
+	EmailChangeEvent      SecurityEventType = "EMAIL_CHANGE"
+)
+
+type SecurityEventOutcome string
+
+const (
+	OutcomeSuccess SecurityEventOutcome = "SUCCESS"
+	OutcomeFailure SecurityEventOutcome = "FAILURE"
+)
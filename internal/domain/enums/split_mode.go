@@ -0,0 +1,26 @@
+package enums
+
+// SplitMode define como uma despesa compartilhada é dividida
+type SplitMode string
+
+const (
+	// EqualSplit divide em partes iguais entre os participantes
+	EqualSplit SplitMode = "EQUAL"
+	// PercentageSplit divide por percentuais que somam 100
+	PercentageSplit SplitMode = "PERCENTAGE"
+	// ExactSplit recebe o valor de cada participante, que devem somar o total
+	ExactSplit SplitMode = "EXACT"
+	// SharesSplit divide proporcionalmente ao número de cotas de cada um
+	SharesSplit SplitMode = "SHARES"
+)
+
+var SplitModes = []SplitMode{EqualSplit, PercentageSplit, ExactSplit, SharesSplit}
+
+func (m SplitMode) IsValid() bool {
+	for _, mode := range SplitModes {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,9 @@
+package enums
+
+type TokenPurpose string
+
+const (
+	InvitationToken  TokenPurpose = "INVITATION"
+	EmailChangeToken TokenPurpose = "EMAIL_CHANGE"
+	EmailRevertToken TokenPurpose = "EMAIL_REVERT"
+)
@@ -0,0 +1,17 @@
+package enums
+
+type TransactionStatus string
+
+const (
+	PendingTransaction    TransactionStatus = "PENDING"
+	ClearedTransaction    TransactionStatus = "CLEARED"
+	ReconciledTransaction TransactionStatus = "RECONCILED"
+)
+
+func (s TransactionStatus) IsValid() bool {
+	switch s {
+	case PendingTransaction, ClearedTransaction, ReconciledTransaction:
+		return true
+	}
+	return false
+}
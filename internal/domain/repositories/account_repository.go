@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/pkg/money"
+	"time"
+)
+
+type AccountFilter struct {
+	Type            enums.AccountType
+	IncludeArchived bool
+}
+
+// AccountRepository sempre filtra pelo dono da conta; uma conta de outro
+// usuário é tratada como inexistente.
+type AccountRepository interface {
+	Create(ctx context.Context, account *entities.Account) error
+	Update(ctx context.Context, account *entities.Account) error
+	Delete(ctx context.Context, userID, id string) error
+	GetByID(ctx context.Context, userID, id string) (*entities.Account, error)
+	List(ctx context.Context, userID string, filter AccountFilter) ([]entities.Account, error)
+	// BalanceAsOf devolve o saldo da conta ao fim do dia date
+	BalanceAsOf(ctx context.Context, userID, accountID string, date time.Time) (money.Money, error)
+	// FreezeStatementDates grava nas pernas do cartão sem fatura fixa a fatura
+	// que elas têm pelo dia de fechamento informado
+	FreezeStatementDates(ctx context.Context, accountID string, closingDay int) error
+	NextDisplayOrder(ctx context.Context, userID string) (int, error)
+	UpdateDisplayOrder(ctx context.Context, userID string, accountIDs []string) error
+}
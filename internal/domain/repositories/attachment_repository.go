@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"finanvilla/internal/domain/entities"
+	"time"
+)
+
+// AttachmentFilter escolhe o dono dos anexos; vazio traz todos do usuário
+type AttachmentFilter struct {
+	TransactionID string
+	AccountID     string
+}
+
+type AttachmentRepository interface {
+	// FindFile devolve o arquivo do usuário com o hash, ou nil se não houver
+	FindFile(ctx context.Context, userID, sha256 string) (*entities.AttachmentFile, error)
+	// CreateFile grava o arquivo; se outro upload igual chegou antes, file
+	// passa a ser o que já estava gravado
+	CreateFile(ctx context.Context, file *entities.AttachmentFile) error
+	Create(ctx context.Context, attachment *entities.Attachment) error
+	GetByID(ctx context.Context, userID, id string) (*entities.Attachment, error)
+	List(ctx context.Context, userID string, filter AttachmentFilter) ([]entities.Attachment, error)
+	Delete(ctx context.Context, userID, id string) error
+	// DeleteUnusedFiles apaga os arquivos sem nenhum anexo criados antes de
+	// before (só os de fileIDs, se informados) e devolve o que foi apagado,
+	// para a remoção no armazenamento
+	DeleteUnusedFiles(ctx context.Context, before time.Time, fileIDs ...string) ([]entities.AttachmentFile, error)
+}
@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+	"finanvilla/internal/domain/entities"
+	"time"
+)
+
+type BudgetRepository interface {
+	// Upsert cria ou substitui o orçamento da categoria no mês
+	Upsert(ctx context.Context, budget *entities.Budget) error
+	Delete(ctx context.Context, userID, categoryID string, month time.Time) error
+	Get(ctx context.Context, userID, categoryID string, month time.Time) (*entities.Budget, error)
+	List(ctx context.Context, userID string, month time.Time) ([]entities.Budget, error)
+	// LatestMonth devolve o último mês com orçamento na moeda, ou nil se não houver
+	LatestMonth(ctx context.Context, userID, currency string) (*time.Time, error)
+	// CopyMonth replica os orçamentos de from em to, ignorando categorias
+	// arquivadas; sem overwrite, os já existentes em to são mantidos.
+	CopyMonth(ctx context.Context, userID string, from, to time.Time, overwrite bool) (int64, error)
+	// MergeCategory soma os orçamentos de sourceID aos de targetID, mês a mês
+	MergeCategory(ctx context.Context, userID, sourceID, targetID string) error
+	// Report calcula orçado, realizado, sobra acumulada e saldo de cada
+	// categoria de despesa no mês; com envelope, todo saldo passa adiante.
+	Report(ctx context.Context, userID, currency string, month time.Time, envelope bool) ([]entities.BudgetLine, error)
+	// MonthTotals devolve receita, total orçado e valor a distribuir de cada mês entre from e to
+	MonthTotals(ctx context.Context, userID, currency string, from, to time.Time) ([]entities.BudgetMonthTotals, error)
+}
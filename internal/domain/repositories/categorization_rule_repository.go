@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"finanvilla/internal/domain/entities"
+)
+
+type CategorizationRuleRepository interface {
+	Create(ctx context.Context, rule *entities.CategorizationRule) error
+	Update(ctx context.Context, rule *entities.CategorizationRule) error
+	Delete(ctx context.Context, userID, id string) error
+	GetByID(ctx context.Context, userID, id string) (*entities.CategorizationRule, error)
+	// List traz as regras pela ordem em que são testadas
+	List(ctx context.Context, userID string, activeOnly bool) ([]entities.CategorizationRule, error)
+	// UpdatePositions grava a ordem conforme a posição de cada ID na lista
+	UpdatePositions(ctx context.Context, userID string, ruleIDs []string) error
+	// NextPosition devolve a posição logo depois da última regra
+	NextPosition(ctx context.Context, userID string) (int, error)
+
+	// RecordRecategorization conta mais uma recategorização manual para a
+	// palavra-chave e a categoria
+	RecordRecategorization(ctx context.Context, userID, keyword, categoryID string) error
+	// Suggestions traz as sugestões não descartadas com pelo menos
+	// minOccurrences, das mais frequentes às menos
+	Suggestions(ctx context.Context, userID string, minOccurrences int) ([]entities.RuleSuggestion, error)
+	GetSuggestion(ctx context.Context, userID, id string) (*entities.RuleSuggestion, error)
+	DismissSuggestion(ctx context.Context, userID, id string) error
+	DeleteSuggestion(ctx context.Context, userID, id string) error
+}
@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"time"
+)
+
+type CategoryFilter struct {
+	Type            enums.CategoryType
+	IncludeArchived bool
+}
+
+type CategoryRepository interface {
+	Create(ctx context.Context, category *entities.Category) error
+	// CreateMany insere em lote e preenche os IDs gerados
+	CreateMany(ctx context.Context, categories []entities.Category) error
+	Update(ctx context.Context, category *entities.Category) error
+	Delete(ctx context.Context, userID, id string) error
+	GetByID(ctx context.Context, userID, id string) (*entities.Category, error)
+	List(ctx context.Context, userID string, filter CategoryFilter) ([]entities.Category, error)
+	// Reparent move as subcategorias de fromParentID para toParentID
+	Reparent(ctx context.Context, userID, fromParentID string, toParentID *string) error
+	// ReassignPostings troca a categoria das pernas do usuário e devolve quantas
+	// foram alteradas; from e to, quando informados, limitam pela data do lançamento.
+	ReassignPostings(ctx context.Context, userID string, fromIDs []string, toID string, from, to *time.Time) (int64, error)
+	CountPostings(ctx context.Context, categoryID string) (int64, error)
+}
@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"context"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/pkg/money"
+	"time"
+)
+
+// CardStatementTotals são os totais de uma fatura na moeda do cartão; status
+// e saldo devido ficam por conta do serviço
+type CardStatementTotals struct {
+	ClosingDate time.Time
+	Entries     int
+	Charges     money.Money
+	Credits     money.Money
+}
+
+// CreditCardRepository guarda as compras parceladas e lê as faturas. A fatura
+// de cada perna é a gravada em statement_date ou, sem ela, a calculada pela
+// data do lançamento e pelo dia de fechamento do cartão.
+type CreditCardRepository interface {
+	CreatePurchase(ctx context.Context, purchase *entities.InstallmentPurchase) error
+	UpdatePurchase(ctx context.Context, purchase *entities.InstallmentPurchase) error
+	// DeletePurchase remove a compra e, em cascata, todas as parcelas
+	DeletePurchase(ctx context.Context, userID, id string) error
+	// GetPurchase traz a compra com as parcelas e suas pernas
+	GetPurchase(ctx context.Context, userID, id string) (*entities.InstallmentPurchase, error)
+	ListPurchases(ctx context.Context, userID, accountID string) ([]entities.InstallmentPurchase, error)
+	// StatementTotals devolve as faturas do cartão, da mais recente à mais antiga
+	StatementTotals(ctx context.Context, userID, accountID string) ([]CardStatementTotals, error)
+	StatementEntries(ctx context.Context, userID, accountID string, closingDate time.Time) ([]entities.CardStatementEntry, error)
+}
@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+	"finanvilla/internal/domain/entities"
+)
+
+type CSVImportProfileRepository interface {
+	Create(ctx context.Context, profile *entities.CSVImportProfile) error
+	Update(ctx context.Context, profile *entities.CSVImportProfile) error
+	Delete(ctx context.Context, userID, id string) error
+	GetByID(ctx context.Context, userID, id string) (*entities.CSVImportProfile, error)
+	List(ctx context.Context, userID string) ([]entities.CSVImportProfile, error)
+}
@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"finanvilla/internal/domain/entities"
+)
+
+// HouseholdRepository só enxerga as casas de que o usuário é membro ativo; as
+// demais, inclusive as com convite pendente, são tratadas como inexistentes.
+type HouseholdRepository interface {
+	// Create grava a casa e os membros informados
+	Create(ctx context.Context, household *entities.Household) error
+	Update(ctx context.Context, household *entities.Household) error
+	// Delete remove a casa com despesas e acertos
+	Delete(ctx context.Context, id string) error
+	// GetByID traz a casa com os membros
+	GetByID(ctx context.Context, userID, id string) (*entities.Household, error)
+	List(ctx context.Context, userID string) ([]entities.Household, error)
+	// AddMember grava o membro ou, com status PENDING, o convite
+	AddMember(ctx context.Context, member *entities.HouseholdMember) error
+	UpdateMember(ctx context.Context, member *entities.HouseholdMember) error
+	RemoveMember(ctx context.Context, householdID, userID string) error
+	// ListInvitations traz as casas com convite pendente para o usuário
+	ListInvitations(ctx context.Context, userID string) ([]entities.Household, error)
+	// AcceptInvitation torna ativo o convite pendente do usuário
+	AcceptInvitation(ctx context.Context, householdID, userID string) error
+	// DeclineInvitation apaga o convite pendente do usuário
+	DeclineInvitation(ctx context.Context, householdID, userID string) error
+}
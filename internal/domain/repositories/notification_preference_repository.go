@@ -0,0 +1,11 @@
+package repositories
+
+import (
+	"context"
+	"finanvilla/internal/domain/entities"
+)
+
+type NotificationPreferenceRepository interface {
+	ListByUser(ctx context.Context, userID string) ([]entities.NotificationPreference, error)
+	Upsert(ctx context.Context, prefs []entities.NotificationPreference) error
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+	"finanvilla/internal/domain/entities"
+	"time"
+)
+
+type RecurringTransactionRepository interface {
+	Create(ctx context.Context, recurring *entities.RecurringTransaction) error
+	// Update regrava os campos e substitui todas as pernas do modelo
+	Update(ctx context.Context, recurring *entities.RecurringTransaction) error
+	Delete(ctx context.Context, userID, id string) error
+	GetByID(ctx context.Context, userID, id string) (*entities.RecurringTransaction, error)
+	List(ctx context.Context, userID string) ([]entities.RecurringTransaction, error)
+	// ListDue devolve modelos ativos de todos os usuários com ocorrência até asOf
+	ListDue(ctx context.Context, asOf time.Time, limit int) ([]entities.RecurringTransaction, error)
+	SetNextDate(ctx context.Context, id string, next *time.Time) error
+	// ReassignCategory troca a categoria das pernas dos modelos do usuário
+	ReassignCategory(ctx context.Context, userID, fromID, toID string) error
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"finanvilla/internal/domain/entities"
+)
+
+type SavedSearchRepository interface {
+	Create(ctx context.Context, search *entities.SavedSearch) error
+	Update(ctx context.Context, search *entities.SavedSearch) error
+	Delete(ctx context.Context, userID, id string) error
+	GetByID(ctx context.Context, userID, id string) (*entities.SavedSearch, error)
+	List(ctx context.Context, userID string) ([]entities.SavedSearch, error)
+	// ListBudgeted traz as pesquisas com limite mensal na moeda
+	ListBudgeted(ctx context.Context, userID, currency string) ([]entities.SavedSearch, error)
+}
@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"time"
+)
+
+type SecurityEventFilter struct {
+	UserID  string
+	Email   string
+	Type    enums.SecurityEventType
+	Outcome enums.SecurityEventOutcome
+	IP      string
+	From    *time.Time
+	To      *time.Time
+}
+
+type SecurityEventRepository interface {
+	Create(ctx context.Context, event *entities.SecurityEvent) error
+	List(ctx context.Context, filter SecurityEventFilter, page, limit int) ([]entities.SecurityEvent, int, error)
+	DeleteOlderThan(ctx context.Context, before time.Time) error
+}
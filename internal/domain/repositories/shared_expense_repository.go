@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"context"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/pkg/settleup"
+	"time"
+)
+
+type SharedExpenseFilter struct {
+	From   *time.Time
+	To     *time.Time
+	UserID string // pagas por ele ou com parte dele
+}
+
+// SharedExpenseRepository guarda despesas e acertos de uma casa. Quem chama
+// já conferiu que o usuário é membro dela.
+type SharedExpenseRepository interface {
+	Create(ctx context.Context, expense *entities.SharedExpense) error
+	// Update grava a despesa e substitui as partes
+	Update(ctx context.Context, expense *entities.SharedExpense) error
+	Delete(ctx context.Context, householdID, id string) error
+	GetByID(ctx context.Context, householdID, id string) (*entities.SharedExpense, error)
+	List(ctx context.Context, householdID string, filter SharedExpenseFilter) ([]entities.SharedExpense, error)
+
+	CreateSettlement(ctx context.Context, settlement *entities.Settlement) error
+	DeleteSettlement(ctx context.Context, householdID, id string) error
+	GetSettlement(ctx context.Context, householdID, id string) (*entities.Settlement, error)
+	ListSettlements(ctx context.Context, householdID string) ([]entities.Settlement, error)
+
+	// Debts devolve quem deve a quem pelas despesas, já compensadas pelos
+	// acertos, agregado por par e na moeda da casa
+	Debts(ctx context.Context, householdID string) ([]settleup.Debt, error)
+}
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+	"finanvilla/internal/domain/entities"
+)
+
+type TagRepository interface {
+	// Resolve devolve as etiquetas com os nomes dados, criando as que faltam
+	Resolve(ctx context.Context, userID string, names []string) ([]entities.Tag, error)
+	GetByID(ctx context.Context, userID, id string) (*entities.Tag, error)
+	// List traz as etiquetas com a contagem de lançamentos
+	List(ctx context.Context, userID string) ([]entities.Tag, error)
+	Rename(ctx context.Context, tag *entities.Tag) error
+	// Merge passa os lançamentos e as pesquisas salvas de sourceID para
+	// targetID e apaga sourceID
+	Merge(ctx context.Context, userID, sourceID, targetID string) error
+	Delete(ctx context.Context, userID, id string) error
+}
@@ -0,0 +1,9 @@
+package repositories
+
+import "context"
+
+// TransactionManager executa fn dentro de uma transação de banco. Os
+// repositórios que recebem o ctx repassado a fn participam da mesma transação.
+type TransactionManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"context"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/pkg/money"
+	"time"
+)
+
+// TransactionFilter combina os filtros da listagem; campos vazios são ignorados.
+// Várias contas ou categorias aceitam qualquer uma delas; várias etiquetas
+// exigem todas. MinAmount e MaxAmount comparam o valor movimentado (soma das
+// pernas positivas).
+type TransactionFilter struct {
+	AccountIDs  []string
+	CategoryIDs []string
+	TagIDs      []string
+	Status      enums.TransactionStatus
+	From        *time.Time
+	To          *time.Time
+	MinAmount   *money.Money
+	MaxAmount   *money.Money
+	Text        string
+	// Uncategorized traz só lançamentos com contrapartida sem categoria
+	Uncategorized bool
+}
+
+// TransactionSummaryRow soma as pernas de categoria dos lançamentos filtrados
+// num mês e numa categoria (nil para sem categoria). Inflow é o que entrou
+// nas contas (receitas, estornos) e Outflow o que saiu, ambos positivos.
+type TransactionSummaryRow struct {
+	Month      time.Time
+	CategoryID *string
+	Inflow     money.Money
+	Outflow    money.Money
+}
+
+type TransactionRepository interface {
+	Create(ctx context.Context, transaction *entities.Transaction) error
+	// CreateOccurrence grava o lançamento de uma ocorrência recorrente e devolve
+	// false, sem erro, se essa ocorrência já tinha sido lançada
+	CreateOccurrence(ctx context.Context, transaction *entities.Transaction) (bool, error)
+	// ExistingExternalIDs devolve quais dos identificadores externos já estão em
+	// pernas da conta
+	ExistingExternalIDs(ctx context.Context, accountID string, externalIDs []string) (map[string]bool, error)
+	// Update regrava os campos e substitui todas as pernas do lançamento
+	Update(ctx context.Context, transaction *entities.Transaction) error
+	UpdateStatus(ctx context.Context, userID, id string, status enums.TransactionStatus) error
+	Delete(ctx context.Context, userID, id string) error
+	GetByID(ctx context.Context, userID, id string) (*entities.Transaction, error)
+	List(ctx context.Context, userID string, filter TransactionFilter, page, limit int) ([]entities.Transaction, int, error)
+	// Summary agrupa por mês e categoria os lançamentos filtrados na moeda e
+	// devolve também quantos são. Com CategoryIDs, só as pernas dessas
+	// categorias entram na soma.
+	Summary(ctx context.Context, userID, currency string, filter TransactionFilter) ([]TransactionSummaryRow, int, error)
+	// Register devolve o extrato da conta com saldo acumulado, do mais recente ao mais antigo
+	Register(ctx context.Context, userID, accountID string, from, to *time.Time, page, limit int) ([]entities.RegisterEntry, int, error)
+	// ListByAccount devolve os lançamentos que movimentam a conta e passam no
+	// filtro, do mais antigo ao mais recente, para exportação em lotes
+	ListByAccount(ctx context.Context, userID, accountID string, filter TransactionFilter, offset, limit int) ([]entities.Transaction, error)
+}
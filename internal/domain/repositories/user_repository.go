@@ -12,8 +12,13 @@ type UserRepository interface {
 	Delete(ctx context.Context, id string) error
 	GetByID(ctx context.Context, id string) (*entities.User, error)
 	GetByEmail(ctx context.Context, email string) (*entities.User, error)
+	UpdateEmail(ctx context.Context, id, email string) error
+	UpdateAvatar(ctx context.Context, id, avatarKey string) error
+	UpdateProfile(ctx context.Context, user *entities.User) error
 	List(ctx context.Context, page, limit int) ([]entities.User, int, error)
 	UpdateSettings(ctx context.Context, settings *entities.UserSettings) error
+	// UpdateSettingsFields atualiza colunas específicas, inclusive para valores zero (false, "")
+	UpdateSettingsFields(ctx context.Context, userID string, fields map[string]interface{}) error
 	AddPermissions(ctx context.Context, userID string, permissions []string) error
 	RemovePermissions(ctx context.Context, userID string, permissions []string) error
 }
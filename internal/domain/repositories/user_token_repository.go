@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+)
+
+type UserTokenRepository interface {
+	Create(ctx context.Context, token *entities.UserToken) error
+	GetActiveByHash(ctx context.Context, purpose enums.TokenPurpose, tokenHash string) (*entities.UserToken, error)
+	MarkUsed(ctx context.Context, id string) error
+	InvalidateByUser(ctx context.Context, userID string, purpose enums.TokenPurpose) error
+	DeleteExpired(ctx context.Context) error
+}
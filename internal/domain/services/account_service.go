@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/internal/domain/repositories"
+	"finanvilla/pkg/errors"
+	"finanvilla/pkg/locale"
+	"finanvilla/pkg/money"
+	"fmt"
+	"strings"
+)
+
+type AccountService struct {
+	accountRepo repositories.AccountRepository
+	userService *UserService
+}
+
+func NewAccountService(accountRepo repositories.AccountRepository, userService *UserService) *AccountService {
+	return &AccountService{
+		accountRepo: accountRepo,
+		userService: userService,
+	}
+}
+
+func (s *AccountService) Create(ctx context.Context, userID string, req *dtos.CreateAccountRequest) (*entities.Account, error) {
+	if !req.Type.IsValid() {
+		return nil, fmt.Errorf("%w: unknown account type %q", errors.ErrInvalidInput, req.Type)
+	}
+
+	currency := req.Currency
+	if currency == "" {
+		user, err := s.userService.GetByID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		currency = locale.Default.Currency()
+		if user.Settings != nil && user.Settings.Currency != "" {
+			currency = user.Settings.Currency
+		}
+	}
+	currency, err := locale.ParseCurrency(currency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrInvalidInput, err)
+	}
+
+	openingBalance, err := req.OpeningBalance.WithCurrency(currency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: opening balance: %v", errors.ErrInvalidInput, err)
+	}
+
+	account := &entities.Account{
+		UserID:         userID,
+		Name:           strings.TrimSpace(req.Name),
+		Type:           req.Type,
+		Institution:    strings.TrimSpace(req.Institution),
+		Currency:       currency,
+		OpeningBalance: openingBalance,
+	}
+	if account.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", errors.ErrInvalidInput)
+	}
+	if err := applyCardSettings(account, req.ClosingDay, req.DueDay, req.CreditLimit); err != nil {
+		return nil, err
+	}
+
+	if req.DisplayOrder != nil {
+		account.DisplayOrder = *req.DisplayOrder
+	} else {
+		next, err := s.accountRepo.NextDisplayOrder(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		account.DisplayOrder = next
+	}
+
+	if err := s.accountRepo.Create(ctx, account); err != nil {
+		return nil, err
+	}
+
+	return s.accountRepo.GetByID(ctx, userID, account.ID)
+}
+
+func (s *AccountService) Update(ctx context.Context, userID, id string, req *dtos.UpdateAccountRequest) (*entities.Account, error) {
+	account, err := s.accountRepo.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		account.Name = strings.TrimSpace(*req.Name)
+		if account.Name == "" {
+			return nil, fmt.Errorf("%w: name is required", errors.ErrInvalidInput)
+		}
+	}
+	if req.Type != nil {
+		if !req.Type.IsValid() {
+			return nil, fmt.Errorf("%w: unknown account type %q", errors.ErrInvalidInput, *req.Type)
+		}
+		account.Type = *req.Type
+	}
+	if req.Institution != nil {
+		account.Institution = strings.TrimSpace(*req.Institution)
+	}
+	if req.OpeningBalance != nil {
+		if account.OpeningBalance, err = req.OpeningBalance.WithCurrency(account.Currency); err != nil {
+			return nil, fmt.Errorf("%w: opening balance: %v", errors.ErrInvalidInput, err)
+		}
+	}
+	if req.Archived != nil {
+		account.Archived = *req.Archived
+	}
+	if req.DisplayOrder != nil {
+		account.DisplayOrder = *req.DisplayOrder
+	}
+
+	previousClosingDay := account.ClosingDay
+	if err := applyCardSettings(account, req.ClosingDay, req.DueDay, req.CreditLimit); err != nil {
+		return nil, err
+	}
+	// As faturas já formadas não mudam com o novo dia de fechamento. Se a
+	// atualização da conta falhar, as datas gravadas são as mesmas que já
+	// valiam, então não há o que desfazer.
+	if previousClosingDay != nil && (account.ClosingDay == nil || *account.ClosingDay != *previousClosingDay) {
+		if err := s.accountRepo.FreezeStatementDates(ctx, account.ID, *previousClosingDay); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.accountRepo.Update(ctx, account); err != nil {
+		return nil, err
+	}
+
+	return s.accountRepo.GetByID(ctx, userID, id)
+}
+
+func (s *AccountService) Delete(ctx context.Context, userID, id string) error {
+	return s.accountRepo.Delete(ctx, userID, id)
+}
+
+func (s *AccountService) GetByID(ctx context.Context, userID, id string) (*entities.Account, error) {
+	return s.accountRepo.GetByID(ctx, userID, id)
+}
+
+func (s *AccountService) List(ctx context.Context, userID string, accountType enums.AccountType, includeArchived bool) ([]entities.Account, error) {
+	if accountType != "" && !accountType.IsValid() {
+		return nil, fmt.Errorf("%w: unknown account type %q", errors.ErrInvalidInput, accountType)
+	}
+	return s.accountRepo.List(ctx, userID, repositories.AccountFilter{
+		Type:            accountType,
+		IncludeArchived: includeArchived,
+	})
+}
+
+// Reorder grava a ordem de exibição conforme a posição de cada conta na lista
+func (s *AccountService) Reorder(ctx context.Context, userID string, accountIDs []string) ([]entities.Account, error) {
+	seen := make(map[string]bool, len(accountIDs))
+	for _, id := range accountIDs {
+		if seen[id] {
+			return nil, fmt.Errorf("%w: duplicated account %s", errors.ErrInvalidInput, id)
+		}
+		seen[id] = true
+	}
+
+	if err := s.accountRepo.UpdateDisplayOrder(ctx, userID, accountIDs); err != nil {
+		return nil, err
+	}
+
+	return s.accountRepo.List(ctx, userID, repositories.AccountFilter{IncludeArchived: true})
+}
+
+// applyCardSettings grava os dados do cartão. Contas de outros tipos não os
+// aceitam e os perdem quando deixam de ser cartão.
+func applyCardSettings(account *entities.Account, closingDay, dueDay *int, creditLimit *money.Money) error {
+	if account.Type != enums.CreditCardAccount {
+		if closingDay != nil || dueDay != nil || creditLimit != nil {
+			return fmt.Errorf("%w: closingDay, dueDay and creditLimit only apply to credit cards", errors.ErrInvalidInput)
+		}
+		account.ClosingDay, account.DueDay, account.CreditLimit = nil, nil, nil
+		return nil
+	}
+
+	if closingDay != nil {
+		account.ClosingDay = closingDay
+	}
+	if dueDay != nil {
+		account.DueDay = dueDay
+	}
+	if creditLimit != nil {
+		limit, err := creditLimit.WithCurrency(account.Currency)
+		if err != nil {
+			return fmt.Errorf("%w: credit limit: %v", errors.ErrInvalidInput, err)
+		}
+		if limit.Sign() < 0 {
+			return fmt.Errorf("%w: credit limit cannot be negative", errors.ErrInvalidInput)
+		}
+		account.CreditLimit = &limit
+	}
+	return nil
+}
@@ -0,0 +1,275 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/repositories"
+	"finanvilla/pkg/errors"
+	"finanvilla/pkg/imaging"
+	"finanvilla/pkg/storage"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	MaxAttachmentSize      = 10 << 20 // 10 MB
+	attachmentThumbnailMax = 320
+	maxFilenameLength      = 255
+	// attachmentCleanupGrace protege arquivos recém-gravados de uma limpeza
+	// que rode no meio do upload
+	attachmentCleanupGrace = time.Hour
+)
+
+// Tipos aceitos, pelo conteúdo do arquivo, e a extensão usada quando o nome
+// enviado não tem nenhuma
+var attachmentTypes = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+}
+
+// AttachmentService guarda comprovantes e documentos de lançamentos e contas.
+// Arquivos iguais (mesmo SHA-256) do mesmo usuário são gravados uma vez só.
+type AttachmentService struct {
+	attachmentRepo  repositories.AttachmentRepository
+	transactionRepo repositories.TransactionRepository
+	accountRepo     repositories.AccountRepository
+	storage         storage.Storage
+	txManager       repositories.TransactionManager
+}
+
+func NewAttachmentService(
+	attachmentRepo repositories.AttachmentRepository,
+	transactionRepo repositories.TransactionRepository,
+	accountRepo repositories.AccountRepository,
+	storage storage.Storage,
+	txManager repositories.TransactionManager,
+) *AttachmentService {
+	return &AttachmentService{
+		attachmentRepo:  attachmentRepo,
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+		storage:         storage,
+		txManager:       txManager,
+	}
+}
+
+func (s *AttachmentService) AttachToTransaction(ctx context.Context, userID, transactionID, filename string, data []byte) (*entities.Attachment, error) {
+	if _, err := s.transactionRepo.GetByID(ctx, userID, transactionID); err != nil {
+		return nil, err
+	}
+	return s.attach(ctx, &entities.Attachment{UserID: userID, TransactionID: &transactionID}, filename, data)
+}
+
+func (s *AttachmentService) AttachToAccount(ctx context.Context, userID, accountID, filename string, data []byte) (*entities.Attachment, error) {
+	if _, err := s.accountRepo.GetByID(ctx, userID, accountID); err != nil {
+		return nil, err
+	}
+	return s.attach(ctx, &entities.Attachment{UserID: userID, AccountID: &accountID}, filename, data)
+}
+
+func (s *AttachmentService) ListByTransaction(ctx context.Context, userID, transactionID string) ([]entities.Attachment, error) {
+	if _, err := s.transactionRepo.GetByID(ctx, userID, transactionID); err != nil {
+		return nil, err
+	}
+	return s.attachmentRepo.List(ctx, userID, repositories.AttachmentFilter{TransactionID: transactionID})
+}
+
+func (s *AttachmentService) ListByAccount(ctx context.Context, userID, accountID string) ([]entities.Attachment, error) {
+	if _, err := s.accountRepo.GetByID(ctx, userID, accountID); err != nil {
+		return nil, err
+	}
+	return s.attachmentRepo.List(ctx, userID, repositories.AttachmentFilter{AccountID: accountID})
+}
+
+func (s *AttachmentService) GetByID(ctx context.Context, userID, id string) (*entities.Attachment, error) {
+	return s.attachmentRepo.GetByID(ctx, userID, id)
+}
+
+// Open abre o conteúdo do anexo, ou a miniatura JPEG se thumbnail for true.
+// Quem chama fecha o leitor.
+func (s *AttachmentService) Open(ctx context.Context, userID, id string, thumbnail bool) (io.ReadCloser, *entities.Attachment, error) {
+	attachment, err := s.attachmentRepo.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key := attachment.StorageKey
+	if thumbnail {
+		if attachment.ThumbnailKey == nil {
+			return nil, nil, fmt.Errorf("%w: attachment has no thumbnail", errors.ErrAttachmentNotFound)
+		}
+		key = *attachment.ThumbnailKey
+	}
+
+	body, _, err := s.storage.Get(ctx, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	return body, attachment, nil
+}
+
+// Delete remove o anexo e, se ninguém mais usa o arquivo, o conteúdo também
+func (s *AttachmentService) Delete(ctx context.Context, userID, id string) error {
+	attachment, err := s.attachmentRepo.GetByID(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.attachmentRepo.Delete(ctx, userID, id); err != nil {
+		return err
+	}
+
+	files, err := s.attachmentRepo.DeleteUnusedFiles(ctx, time.Now(), attachment.FileID)
+	if err != nil {
+		// O arquivo fica para a limpeza periódica
+		log.Printf("Error deleting attachment file %s: %v", attachment.FileID, err)
+		return nil
+	}
+	s.deleteFiles(ctx, files)
+	return nil
+}
+
+// Cleanup apaga os arquivos que ficaram sem anexos, como os de lançamentos e
+// contas excluídos, e devolve quantos foram removidos
+func (s *AttachmentService) Cleanup(ctx context.Context) (int, error) {
+	files, err := s.attachmentRepo.DeleteUnusedFiles(ctx, time.Now().Add(-attachmentCleanupGrace))
+	if err != nil {
+		return 0, err
+	}
+	s.deleteFiles(ctx, files)
+	return len(files), nil
+}
+
+func (s *AttachmentService) attach(ctx context.Context, attachment *entities.Attachment, filename string, data []byte) (*entities.Attachment, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: file is empty", errors.ErrInvalidInput)
+	}
+	if len(data) > MaxAttachmentSize {
+		return nil, errors.ErrFileTooLarge
+	}
+	contentType := http.DetectContentType(data)
+	if _, ok := attachmentTypes[contentType]; !ok {
+		return nil, errors.ErrUnsupportedMedia
+	}
+	attachment.Filename = sanitizeFilename(filename, contentType)
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+
+	file, err := s.attachmentRepo.FindFile(ctx, attachment.UserID, hash)
+	if err != nil {
+		return nil, err
+	}
+	if file == nil {
+		if file, err = s.storeFile(ctx, attachment.UserID, hash, contentType, data); err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.attachmentRepo.CreateFile(ctx, file); err != nil {
+			return err
+		}
+		attachment.FileID = file.ID
+		return s.attachmentRepo.Create(ctx, attachment)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.attachmentRepo.GetByID(ctx, attachment.UserID, attachment.ID)
+}
+
+// storeFile grava o conteúdo e, para imagens, uma miniatura JPEG sem
+// metadados. O original é mantido como veio, para conferir com o hash.
+func (s *AttachmentService) storeFile(ctx context.Context, userID, hash, contentType string, data []byte) (*entities.AttachmentFile, error) {
+	file := &entities.AttachmentFile{
+		UserID:      userID,
+		SHA256:      hash,
+		Size:        int64(len(data)),
+		ContentType: contentType,
+		StorageKey:  path.Join("attachments", userID, hash[:2], hash),
+	}
+
+	var thumbnail []byte
+	if strings.HasPrefix(contentType, "image/") {
+		img, err := imaging.Decode(data)
+		if err != nil {
+			return nil, errors.ErrUnsupportedMedia
+		}
+		if thumbnail, err = imaging.EncodeJPEG(imaging.Fit(img, attachmentThumbnailMax), 80); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.storage.Put(ctx, file.StorageKey, bytes.NewReader(data), file.Size, contentType); err != nil {
+		return nil, fmt.Errorf("failed to store attachment: %w", err)
+	}
+	if thumbnail != nil {
+		key := file.StorageKey + "-thumb.jpg"
+		if err := s.storage.Put(ctx, key, bytes.NewReader(thumbnail), int64(len(thumbnail)), "image/jpeg"); err != nil {
+			return nil, fmt.Errorf("failed to store attachment thumbnail: %w", err)
+		}
+		file.ThumbnailKey = &key
+	}
+	return file, nil
+}
+
+// deleteFiles é best-effort: o registro já foi apagado e um objeto órfão no
+// armazenamento não afeta o usuário
+func (s *AttachmentService) deleteFiles(ctx context.Context, files []entities.AttachmentFile) {
+	for _, f := range files {
+		_ = s.storage.Delete(ctx, f.StorageKey)
+		if f.ThumbnailKey != nil {
+			_ = s.storage.Delete(ctx, *f.ThumbnailKey)
+		}
+	}
+}
+
+// sanitizeFilename fica só com o nome base, sem caracteres de controle e com
+// no máximo maxFilenameLength bytes, garantindo uma extensão para o tipo
+func sanitizeFilename(name, contentType string) string {
+	name = strings.ReplaceAll(name, "\\", "/")
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' {
+			return -1
+		}
+		return r
+	}, path.Base(name))
+	name = strings.TrimSpace(name)
+	if name == "." || name == "/" || name == ".." {
+		name = ""
+	}
+
+	ext := attachmentTypes[contentType]
+	if name == "" {
+		return "attachment" + ext
+	}
+	if path.Ext(name) == "" {
+		name += ext
+	}
+
+	if len(name) > maxFilenameLength {
+		ext := path.Ext(name)
+		if len(ext) > 10 {
+			ext = ""
+		}
+		cut := maxFilenameLength - len(ext)
+		for cut > 0 && !utf8.RuneStart(name[cut]) {
+			cut--
+		}
+		name = name[:cut] + ext
+	}
+	return name
+}
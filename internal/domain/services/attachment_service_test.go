@@ -0,0 +1,283 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/repositories"
+	appErrors "finanvilla/pkg/errors"
+	"image"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+func TestSanitizeFilename(t *testing.T) {
+	for _, tc := range []struct {
+		name, contentType, want string
+	}{
+		{"recibo.pdf", "application/pdf", "recibo.pdf"},
+		{`C:\Users\ana\Nota "fiscal".jpg`, "image/jpeg", "Nota fiscal.jpg"},
+		{"../../etc/passwd", "application/pdf", "passwd.pdf"},
+		{"comprovante", "image/png", "comprovante.png"},
+		{"  \x00 ", "image/webp", "attachment.webp"},
+		{"", "application/pdf", "attachment.pdf"},
+	} {
+		if got := sanitizeFilename(tc.name, tc.contentType); got != tc.want {
+			t.Errorf("sanitizeFilename(%q) = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+
+	long := sanitizeFilename(strings.Repeat("ç", 200)+".pdf", "application/pdf")
+	if len(long) > maxFilenameLength || !utf8.ValidString(long) || !strings.HasSuffix(long, ".pdf") {
+		t.Errorf("long name truncated to %q (%d bytes)", long, len(long))
+	}
+}
+
+type fakeAttachmentRepository struct {
+	files       map[string]*entities.AttachmentFile
+	attachments map[string]*entities.Attachment
+}
+
+func newFakeAttachmentRepository() *fakeAttachmentRepository {
+	return &fakeAttachmentRepository{
+		files:       map[string]*entities.AttachmentFile{},
+		attachments: map[string]*entities.Attachment{},
+	}
+}
+
+func (r *fakeAttachmentRepository) FindFile(_ context.Context, userID, sha256 string) (*entities.AttachmentFile, error) {
+	for _, file := range r.files {
+		if file.UserID == userID && file.SHA256 == sha256 {
+			found := *file
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeAttachmentRepository) CreateFile(ctx context.Context, file *entities.AttachmentFile) error {
+	if file.ID != "" {
+		return nil
+	}
+	if existing, _ := r.FindFile(ctx, file.UserID, file.SHA256); existing != nil {
+		*file = *existing
+		return nil
+	}
+	file.ID, file.CreatedAt = uuid.NewString(), time.Now()
+	stored := *file
+	r.files[file.ID] = &stored
+	return nil
+}
+
+func (r *fakeAttachmentRepository) Create(_ context.Context, attachment *entities.Attachment) error {
+	attachment.ID = uuid.NewString()
+	stored := *attachment
+	r.attachments[attachment.ID] = &stored
+	return nil
+}
+
+func (r *fakeAttachmentRepository) GetByID(_ context.Context, userID, id string) (*entities.Attachment, error) {
+	attachment, ok := r.attachments[id]
+	if !ok || attachment.UserID != userID {
+		return nil, appErrors.ErrAttachmentNotFound
+	}
+	found := *attachment
+	file := r.files[found.FileID]
+	found.ContentType, found.Size, found.SHA256 = file.ContentType, file.Size, file.SHA256
+	found.StorageKey, found.ThumbnailKey, found.HasThumbnail = file.StorageKey, file.ThumbnailKey, file.ThumbnailKey != nil
+	return &found, nil
+}
+
+func (r *fakeAttachmentRepository) List(context.Context, string, repositories.AttachmentFilter) ([]entities.Attachment, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (r *fakeAttachmentRepository) Delete(_ context.Context, userID, id string) error {
+	if attachment, ok := r.attachments[id]; !ok || attachment.UserID != userID {
+		return appErrors.ErrAttachmentNotFound
+	}
+	delete(r.attachments, id)
+	return nil
+}
+
+func (r *fakeAttachmentRepository) DeleteUnusedFiles(_ context.Context, before time.Time, fileIDs ...string) ([]entities.AttachmentFile, error) {
+	var deleted []entities.AttachmentFile
+	for id, file := range r.files {
+		if len(fileIDs) > 0 && !containsString(fileIDs, id) || !file.CreatedAt.Before(before) {
+			continue
+		}
+		used := false
+		for _, attachment := range r.attachments {
+			used = used || attachment.FileID == id
+		}
+		if !used {
+			deleted = append(deleted, *file)
+			delete(r.files, id)
+		}
+	}
+	return deleted, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+type fakeTransactionRepository struct {
+	repositories.TransactionRepository
+}
+
+func (fakeTransactionRepository) GetByID(_ context.Context, userID, id string) (*entities.Transaction, error) {
+	return &entities.Transaction{ID: id, UserID: userID}, nil
+}
+
+type memoryStorage struct {
+	objects map[string][]byte
+	puts    int
+}
+
+func (s *memoryStorage) Put(_ context.Context, key string, r io.Reader, _ int64, _ string) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	s.objects[key] = data
+	s.puts++
+	return nil
+}
+
+func (s *memoryStorage) Get(_ context.Context, key string) (io.ReadCloser, string, error) {
+	data, ok := s.objects[key]
+	if !ok {
+		return nil, "", errors.New("not found")
+	}
+	return io.NopCloser(bytes.NewReader(data)), "", nil
+}
+
+func (s *memoryStorage) Delete(_ context.Context, key string) error {
+	delete(s.objects, key)
+	return nil
+}
+
+func (s *memoryStorage) SignedURL(context.Context, string, time.Duration) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func newTestAttachmentService() (*AttachmentService, *fakeAttachmentRepository, *memoryStorage) {
+	repo := newFakeAttachmentRepository()
+	store := &memoryStorage{objects: map[string][]byte{}}
+	return NewAttachmentService(repo, fakeTransactionRepository{}, nil, store, inlineTransactionManager{}), repo, store
+}
+
+var testPDF = []byte("%PDF-1.4\n1 0 obj << /Type /Catalog >> endobj\n%%EOF\n")
+
+func testPNG(t *testing.T) []byte {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 640, 480))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestAttachDeduplicatesFiles(t *testing.T) {
+	service, repo, store := newTestAttachmentService()
+	ctx := context.Background()
+
+	first, err := service.AttachToTransaction(ctx, "user", "t1", "recibo.pdf", testPDF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := service.AttachToTransaction(ctx, "user", "t2", "copia.pdf", testPDF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.FileID != second.FileID || first.ID == second.ID {
+		t.Errorf("same content stored as files %s and %s", first.FileID, second.FileID)
+	}
+	if second.Filename != "copia.pdf" || second.ContentType != "application/pdf" {
+		t.Errorf("second attachment = %s %s, want copia.pdf application/pdf", second.Filename, second.ContentType)
+	}
+	if store.puts != 1 || len(repo.files) != 1 {
+		t.Errorf("stored %d objects in %d files, want 1 and 1", store.puts, len(repo.files))
+	}
+
+	other, err := service.AttachToTransaction(ctx, "other", "t3", "recibo.pdf", testPDF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.FileID == first.FileID {
+		t.Error("files are shared across users")
+	}
+}
+
+func TestAttachRejectsInvalidFiles(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"empty", nil, appErrors.ErrInvalidInput},
+		{"too large", append(append([]byte{}, testPDF...), make([]byte, MaxAttachmentSize)...), appErrors.ErrFileTooLarge},
+		{"text", []byte("just some notes"), appErrors.ErrUnsupportedMedia},
+		{"broken image", append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 64)...), appErrors.ErrUnsupportedMedia},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repo, store := newTestAttachmentService()
+			_, err := service.AttachToTransaction(context.Background(), "user", "t1", "file", tt.data)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+			if len(store.objects) != 0 || len(repo.files) != 0 || len(repo.attachments) != 0 {
+				t.Errorf("rejected file left %d objects, %d files, %d attachments", len(store.objects), len(repo.files), len(repo.attachments))
+			}
+		})
+	}
+}
+
+func TestDeleteRemovesFileWithLastAttachment(t *testing.T) {
+	service, repo, store := newTestAttachmentService()
+	ctx := context.Background()
+	photo := testPNG(t)
+
+	first, err := service.AttachToTransaction(ctx, "user", "t1", "foto.png", photo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := service.AttachToTransaction(ctx, "user", "t2", "foto.png", photo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !first.HasThumbnail || len(store.objects) != 2 {
+		t.Fatalf("image stored as %d objects (thumbnail %v), want original and thumbnail", len(store.objects), first.HasThumbnail)
+	}
+
+	if err := service.Delete(ctx, "user", first.ID); err != nil {
+		t.Fatal(err)
+	}
+	if len(store.objects) != 2 || len(repo.files) != 1 {
+		t.Errorf("file still in use was removed: %d objects, %d files", len(store.objects), len(repo.files))
+	}
+
+	if err := service.Delete(ctx, "user", second.ID); err != nil {
+		t.Fatal(err)
+	}
+	if len(store.objects) != 0 || len(repo.files) != 0 {
+		t.Errorf("last attachment deleted but %d objects and %d files remain", len(store.objects), len(repo.files))
+	}
+
+	if err := service.Delete(ctx, "user", second.ID); !errors.Is(err, appErrors.ErrAttachmentNotFound) {
+		t.Errorf("deleting twice: err = %v, want ErrAttachmentNotFound", err)
+	}
+}
@@ -17,6 +17,7 @@ import (
 type AuthService struct {
 	userService        *UserService
 	refreshTokenRepo   repositories.RefreshTokenRepository
+	securityEvents     *SecurityEventService
 	jwtSecret          string
 	refreshTokenSecret string
 	accessTokenTTL     time.Duration
@@ -26,12 +27,14 @@ type AuthService struct {
 func NewAuthService(
 	userService *UserService,
 	refreshTokenRepo repositories.RefreshTokenRepository,
+	securityEvents *SecurityEventService,
 	jwtSecret string,
 	refreshTokenSecret string,
 ) *AuthService {
 	return &AuthService{
 		userService:        userService,
 		refreshTokenRepo:   refreshTokenRepo,
+		securityEvents:     securityEvents,
 		jwtSecret:          jwtSecret,
 		refreshTokenSecret: refreshTokenSecret,
 		accessTokenTTL:     15 * time.Minute,   // Token JWT expira em 15 minutos
@@ -75,6 +78,7 @@ func (s *AuthService) Register(ctx context.Context, req *dtos.RegisterRequest) (
 func (s *AuthService) Login(ctx context.Context, req *dtos.LoginRequest) (*TokenPair, error) {
 	user, err := s.userService.Authenticate(ctx, req.Email, req.Password)
 	if err != nil {
+		s.recordFailedLogin(ctx, req.Email, err)
 		return nil, err
 	}
 
@@ -86,7 +90,28 @@ func (s *AuthService) Login(ctx context.Context, req *dtos.LoginRequest) (*Token
 		return nil, err
 	}
 
-	return s.generateTokenPair(ctx, user)
+	tokens, err := s.generateTokenPair(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	s.securityEvents.RecordForUser(ctx, user, enums.LoginEvent, enums.OutcomeSuccess, "")
+	return tokens, nil
+}
+
+// recordFailedLogin registra a tentativa; se o e-mail existir, o evento
+// aparece também no histórico do próprio usuário.
+func (s *AuthService) recordFailedLogin(ctx context.Context, email string, cause error) {
+	event := &entities.SecurityEvent{
+		Email:   email,
+		Type:    enums.LoginEvent,
+		Outcome: enums.OutcomeFailure,
+		Reason:  cause.Error(),
+	}
+	if user, err := s.userService.GetByEmail(ctx, email); err == nil {
+		event.UserID = &user.ID
+	}
+	s.securityEvents.Record(ctx, event)
 }
 
 func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*TokenPair, error) {
@@ -95,20 +120,27 @@ func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*T
 		return nil, err
 	}
 
+	user, err := s.userService.GetByID(ctx, rt.UserID.String())
+	if err != nil {
+		return nil, err
+	}
+
 	if rt.ExpiresAt.Before(time.Now()) {
+		s.securityEvents.RecordForUser(ctx, user, enums.TokenRefreshEvent, enums.OutcomeFailure, "refresh token expired")
 		return nil, fmt.Errorf("refresh token expired")
 	}
 
-	user, err := s.userService.GetByID(ctx, rt.UserID.String())
-	if err != nil {
+	if err := s.refreshTokenRepo.RevokeToken(ctx, refreshToken); err != nil {
 		return nil, err
 	}
 
-	if err := s.refreshTokenRepo.RevokeToken(ctx, refreshToken); err != nil {
+	tokens, err := s.generateTokenPair(ctx, user)
+	if err != nil {
 		return nil, err
 	}
 
-	return s.generateTokenPair(ctx, user)
+	s.securityEvents.RecordForUser(ctx, user, enums.TokenRefreshEvent, enums.OutcomeSuccess, "")
+	return tokens, nil
 }
 
 func (s *AuthService) generateTokenPair(ctx context.Context, user *entities.User) (*TokenPair, error) {
@@ -155,11 +187,19 @@ func (s *AuthService) Logout(ctx context.Context, refreshToken string) error {
 		return errors.ErrInternalServer
 	}
 
+	rt, lookupErr := s.refreshTokenRepo.GetByToken(ctx, refreshToken)
+
 	err := s.refreshTokenRepo.RevokeToken(ctx, refreshToken)
 	if err != nil {
 		return err
 	}
 
+	if lookupErr == nil {
+		if user, err := s.userService.GetByID(ctx, rt.UserID.String()); err == nil {
+			s.securityEvents.RecordForUser(ctx, user, enums.LogoutEvent, enums.OutcomeSuccess, "")
+		}
+	}
+
 	return nil
 }
 
@@ -173,5 +213,9 @@ func (s *AuthService) LogoutAll(ctx context.Context, userID uuid.UUID) error {
 		return err
 	}
 
+	if user, err := s.userService.GetByID(ctx, userID.String()); err == nil {
+		s.securityEvents.RecordForUser(ctx, user, enums.LogoutAllEvent, enums.OutcomeSuccess, "")
+	}
+
 	return nil
 }
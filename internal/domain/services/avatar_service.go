@@ -0,0 +1,150 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/pkg/errors"
+	"finanvilla/pkg/imaging"
+	"finanvilla/pkg/storage"
+	"fmt"
+	"image"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxAvatarSize      = 5 << 20 // 5 MB
+	avatarMaxDimension = 1024
+	avatarURLTTL       = time.Hour
+)
+
+// Tamanhos (em pixels) das miniaturas quadradas geradas para cada avatar
+var avatarThumbnailSizes = []int{256, 128, 64}
+
+var allowedAvatarTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+type AvatarService struct {
+	userService *UserService
+	storage     storage.Storage
+}
+
+func NewAvatarService(userService *UserService, storage storage.Storage) *AvatarService {
+	return &AvatarService{
+		userService: userService,
+		storage:     storage,
+	}
+}
+
+// Upload valida a imagem enviada, reescreve-a como JPEG (removendo metadados)
+// junto com as miniaturas e substitui o avatar anterior do usuário.
+func (s *AvatarService) Upload(ctx context.Context, userID string, data []byte) (*entities.User, error) {
+	if len(data) > MaxAvatarSize {
+		return nil, errors.ErrFileTooLarge
+	}
+	if !allowedAvatarTypes[http.DetectContentType(data)] {
+		return nil, errors.ErrUnsupportedMedia
+	}
+
+	user, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := imaging.Decode(data)
+	if err != nil {
+		return nil, errors.ErrUnsupportedMedia
+	}
+
+	// Cada upload ganha um prefixo novo, o que evita servir versões antigas em cache
+	prefix := path.Join("avatars", user.ID, uuid.NewString())
+
+	variants := map[string]image.Image{"original": imaging.Fit(img, avatarMaxDimension)}
+	for _, size := range avatarThumbnailSizes {
+		variants[strconv.Itoa(size)] = imaging.Square(img, size)
+	}
+
+	for name, variant := range variants {
+		encoded, err := imaging.EncodeJPEG(variant, 85)
+		if err != nil {
+			return nil, err
+		}
+		key := path.Join(prefix, name+".jpg")
+		if err := s.storage.Put(ctx, key, bytes.NewReader(encoded), int64(len(encoded)), "image/jpeg"); err != nil {
+			s.deleteVariants(ctx, prefix)
+			return nil, fmt.Errorf("failed to store avatar: %w", err)
+		}
+	}
+
+	if err := s.userService.SetAvatar(ctx, user.ID, prefix); err != nil {
+		s.deleteVariants(ctx, prefix)
+		return nil, err
+	}
+
+	if user.AvatarKey != "" {
+		s.deleteVariants(ctx, user.AvatarKey)
+	}
+	user.AvatarKey = prefix
+
+	if err := s.ResolveURLs(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *AvatarService) Delete(ctx context.Context, userID string) error {
+	user, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.AvatarKey == "" {
+		return nil
+	}
+
+	if err := s.userService.SetAvatar(ctx, user.ID, ""); err != nil {
+		return err
+	}
+	s.deleteVariants(ctx, user.AvatarKey)
+	return nil
+}
+
+// ResolveURLs preenche as URLs assinadas do avatar do usuário, se houver
+func (s *AvatarService) ResolveURLs(ctx context.Context, user *entities.User) error {
+	if user.AvatarKey == "" {
+		return nil
+	}
+
+	url, err := s.storage.SignedURL(ctx, path.Join(user.AvatarKey, "original.jpg"), avatarURLTTL)
+	if err != nil {
+		return err
+	}
+	user.AvatarURL = url
+
+	user.AvatarThumbnails = make(map[string]string, len(avatarThumbnailSizes))
+	for _, size := range avatarThumbnailSizes {
+		name := strconv.Itoa(size)
+		url, err := s.storage.SignedURL(ctx, path.Join(user.AvatarKey, name+".jpg"), avatarURLTTL)
+		if err != nil {
+			return err
+		}
+		user.AvatarThumbnails[name] = url
+	}
+	return nil
+}
+
+// deleteVariants é best-effort: arquivos órfãos não afetam o usuário
+func (s *AvatarService) deleteVariants(ctx context.Context, prefix string) {
+	_ = s.storage.Delete(ctx, path.Join(prefix, "original.jpg"))
+	for _, size := range avatarThumbnailSizes {
+		_ = s.storage.Delete(ctx, path.Join(prefix, strconv.Itoa(size)+".jpg"))
+	}
+}
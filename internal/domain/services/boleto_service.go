@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/internal/domain/repositories"
+	"finanvilla/pkg/boleto"
+	"finanvilla/pkg/errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BoletoService lê boletos colados pelo usuário e agenda o pagamento
+type BoletoService struct {
+	transactionService *TransactionService
+	transactionRepo    repositories.TransactionRepository
+	userService        *UserService
+	txManager          repositories.TransactionManager
+}
+
+func NewBoletoService(
+	transactionService *TransactionService,
+	transactionRepo repositories.TransactionRepository,
+	userService *UserService,
+	txManager repositories.TransactionManager,
+) *BoletoService {
+	return &BoletoService{
+		transactionService: transactionService,
+		transactionRepo:    transactionRepo,
+		userService:        userService,
+		txManager:          txManager,
+	}
+}
+
+func (s *BoletoService) Parse(ctx context.Context, userID, line string) (*dtos.BoletoResponse, error) {
+	today, err := s.today(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	b, err := boleto.Parse(line, today)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrInvalidInput, err)
+	}
+	return boletoDTO(b, today), nil
+}
+
+// Schedule cria o lançamento pendente do pagamento, saindo da conta na data
+// de vencimento. O código de barras fica como identificador externo da perna
+// da conta, o que impede agendar o mesmo boleto duas vezes.
+func (s *BoletoService) Schedule(ctx context.Context, userID string, req *dtos.BoletoScheduleRequest) (*entities.Transaction, error) {
+	today, err := s.today(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	b, err := boleto.Parse(req.Line, today)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrInvalidInput, err)
+	}
+
+	amount := b.Amount
+	if !req.Amount.IsZero() {
+		amount = req.Amount
+	}
+	if amount.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: the boleto has no amount; inform it", errors.ErrInvalidInput)
+	}
+
+	date := req.DueDate
+	if date == "" {
+		if b.DueDate.IsZero() {
+			return nil, fmt.Errorf("%w: the boleto has no due date; inform it", errors.ErrInvalidInput)
+		}
+		date = b.DueDate.Format("2006-01-02")
+	}
+
+	transactionReq := &dtos.TransactionRequest{
+		Date:        date,
+		Description: firstNonEmpty(strings.TrimSpace(req.Description), boletoDescription(b)),
+		Payee:       req.Payee,
+		Notes:       "Linha digitável: " + boleto.FormatTypedLine(b.TypedLine),
+		Status:      enums.PendingTransaction,
+		AccountID:   &req.AccountID,
+		CategoryID:  req.CategoryID,
+		Amount:      amount.Neg(),
+	}
+
+	var transaction *entities.Transaction
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// buildTransaction confere antes que a conta é do usuário
+		var err error
+		if transaction, err = s.transactionService.buildTransaction(ctx, userID, transactionReq, nil); err != nil {
+			return err
+		}
+
+		externalID := "boleto:" + b.Barcode
+		existing, err := s.transactionRepo.ExistingExternalIDs(ctx, req.AccountID, []string{externalID})
+		if err != nil {
+			return err
+		}
+		if existing[externalID] {
+			return errors.ErrBoletoScheduled
+		}
+		for i := range transaction.Postings {
+			if p := &transaction.Postings[i]; p.AccountID != nil && *p.AccountID == req.AccountID {
+				p.ExternalID = &externalID
+			}
+		}
+		return s.transactionRepo.Create(ctx, transaction)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.transactionRepo.GetByID(ctx, userID, transaction.ID)
+}
+
+func (s *BoletoService) today(ctx context.Context, userID string) (time.Time, error) {
+	user, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	y, m, d := time.Now().In(FormatterFor(user).Location()).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC), nil
+}
+
+func boletoDescription(b *boleto.Boleto) string {
+	switch {
+	case b.Kind == boleto.Utility && b.SegmentName != "":
+		return "Conta - " + b.SegmentName
+	case b.BankName != "":
+		return "Boleto " + b.BankName
+	case b.Kind == boleto.Bank:
+		return "Boleto banco " + b.BankCode
+	}
+	return "Boleto"
+}
+
+func boletoDTO(b *boleto.Boleto, today time.Time) *dtos.BoletoResponse {
+	dto := &dtos.BoletoResponse{
+		Kind:               string(b.Kind),
+		Barcode:            b.Barcode,
+		TypedLine:          b.TypedLine,
+		FormattedTypedLine: boleto.FormatTypedLine(b.TypedLine),
+		BankCode:           b.BankCode,
+		BankName:           b.BankName,
+		Segment:            b.Segment,
+		SegmentName:        b.SegmentName,
+		CompanyID:          b.CompanyID,
+	}
+	if !b.DueDate.IsZero() {
+		due := b.DueDate
+		dto.DueDate = &due
+		dto.Overdue = due.Before(today)
+	}
+	if !b.Amount.IsZero() {
+		amount := b.Amount
+		dto.Amount = &amount
+	}
+	return dto
+}
@@ -0,0 +1,329 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/internal/domain/repositories"
+	appErrors "finanvilla/pkg/errors"
+	"finanvilla/pkg/locale"
+	"finanvilla/pkg/money"
+	"fmt"
+	"time"
+)
+
+type BudgetService struct {
+	budgetRepo      repositories.BudgetRepository
+	categoryRepo    repositories.CategoryRepository
+	savedSearchRepo repositories.SavedSearchRepository
+	transactionRepo repositories.TransactionRepository
+	userService     *UserService
+	txManager       repositories.TransactionManager
+}
+
+func NewBudgetService(
+	budgetRepo repositories.BudgetRepository,
+	categoryRepo repositories.CategoryRepository,
+	savedSearchRepo repositories.SavedSearchRepository,
+	transactionRepo repositories.TransactionRepository,
+	userService *UserService,
+	txManager repositories.TransactionManager,
+) *BudgetService {
+	return &BudgetService{
+		budgetRepo:      budgetRepo,
+		categoryRepo:    categoryRepo,
+		savedSearchRepo: savedSearchRepo,
+		transactionRepo: transactionRepo,
+		userService:     userService,
+		txManager:       txManager,
+	}
+}
+
+// Report devolve orçado x realizado x saldo de cada categoria no mês. Os
+// valores por categoria vêm prontos do banco; aqui só são somados os totais.
+func (s *BudgetService) Report(ctx context.Context, userID string, month time.Time) (*dtos.BudgetReport, error) {
+	month = startOfMonth(month)
+	currency, mode, err := s.budgetSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := s.budgetRepo.Report(ctx, userID, currency, month, mode == enums.EnvelopeBudget)
+	if err != nil {
+		return nil, err
+	}
+	totals, err := s.budgetRepo.MonthTotals(ctx, userID, currency, month, month)
+	if err != nil {
+		return nil, err
+	}
+
+	zero, err := money.Zero(currency)
+	if err != nil {
+		return nil, err
+	}
+	report := &dtos.BudgetReport{
+		Month:      month.Format("2006-01"),
+		Currency:   currency,
+		Mode:       mode,
+		Categories: lines,
+		Budgeted:   zero,
+		Actual:     zero,
+		Remaining:  zero,
+		Income:     zero,
+	}
+	if report.Categories == nil {
+		report.Categories = []entities.BudgetLine{}
+	}
+
+	for _, line := range lines {
+		if report.Budgeted, err = report.Budgeted.Add(line.Budgeted); err != nil {
+			return nil, err
+		}
+		if report.Actual, err = report.Actual.Add(line.Actual); err != nil {
+			return nil, err
+		}
+		if report.Remaining, err = report.Remaining.Add(line.Remaining); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(totals) > 0 {
+		report.Income = totals[0].Income
+		if mode == enums.EnvelopeBudget {
+			report.ToBeAssigned = &totals[0].ToBeAssigned
+		}
+	}
+
+	if report.Searches, err = s.searchLines(ctx, userID, currency, month); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// searchLines compara o limite mensal de cada pesquisa salva com o que saiu
+// das contas no mês pelos lançamentos dela, descontados estornos e receitas.
+// Essas linhas ficam fora dos totais, porque repetem gastos das categorias.
+func (s *BudgetService) searchLines(ctx context.Context, userID, currency string, month time.Time) ([]dtos.SearchBudgetLine, error) {
+	searches, err := s.savedSearchRepo.ListBudgeted(ctx, userID, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	last := month.AddDate(0, 1, -1)
+	lines := make([]dtos.SearchBudgetLine, 0, len(searches))
+	for _, search := range searches {
+		filter, err := searchFilter(search.Filter)
+		if err != nil {
+			return nil, err
+		}
+
+		actual, err := money.Zero(currency)
+		if err != nil {
+			return nil, err
+		}
+		if narrowPeriod(&filter, &month, &last) {
+			rows, _, err := s.transactionRepo.Summary(ctx, userID, currency, filter)
+			if err != nil {
+				return nil, err
+			}
+			for _, row := range rows {
+				if actual, err = actual.Add(row.Outflow); err != nil {
+					return nil, err
+				}
+				if actual, err = actual.Sub(row.Inflow); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		remaining, err := search.Budget.Sub(actual)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, dtos.SearchBudgetLine{
+			SavedSearchID: search.ID,
+			Name:          search.Name,
+			Budgeted:      *search.Budget,
+			Actual:        actual,
+			Remaining:     remaining,
+		})
+	}
+	return lines, nil
+}
+
+// Set define o orçamento de uma categoria de despesa no mês. No modo envelope,
+// aumentar o valor exige receita ainda não distribuída.
+func (s *BudgetService) Set(ctx context.Context, userID, categoryID string, month time.Time, req *dtos.SetBudgetRequest) (*entities.Budget, error) {
+	month = startOfMonth(month)
+	currency, mode, err := s.budgetSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := req.Amount.WithCurrency(currency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: amount: %v", appErrors.ErrInvalidInput, err)
+	}
+	if amount.Sign() < 0 {
+		return nil, fmt.Errorf("%w: amount cannot be negative", appErrors.ErrInvalidInput)
+	}
+
+	budget := &entities.Budget{
+		UserID:     userID,
+		CategoryID: categoryID,
+		Month:      month,
+		Amount:     amount,
+		Currency:   currency,
+		Rollover:   req.Rollover,
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		category, err := s.categoryRepo.GetByID(ctx, userID, categoryID)
+		if err != nil {
+			return err
+		}
+		if category.Type != enums.ExpenseCategory {
+			return fmt.Errorf("%w: budgets are only available for expense categories", appErrors.ErrInvalidInput)
+		}
+		if category.Archived {
+			return fmt.Errorf("%w: category is archived", appErrors.ErrInvalidInput)
+		}
+
+		previous, err := s.budgetRepo.Get(ctx, userID, categoryID, month)
+		if errors.Is(err, appErrors.ErrBudgetNotFound) {
+			previous = nil
+		} else if err != nil {
+			return err
+		}
+
+		if err := s.budgetRepo.Upsert(ctx, budget); err != nil {
+			return err
+		}
+		if mode == enums.EnvelopeBudget && budgetIncreased(previous, amount) {
+			return s.checkAssignable(ctx, userID, currency, month)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return budget, nil
+}
+
+func (s *BudgetService) Delete(ctx context.Context, userID, categoryID string, month time.Time) error {
+	return s.budgetRepo.Delete(ctx, userID, categoryID, startOfMonth(month))
+}
+
+// CopyMonth copia os orçamentos de from (nil: mês anterior) para to e devolve
+// quantas categorias foram preenchidas
+func (s *BudgetService) CopyMonth(ctx context.Context, userID string, from *time.Time, to time.Time, overwrite bool) (int64, error) {
+	source, to, err := copyMonths(from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	currency, mode, err := s.budgetSettings(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	var copied int64
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if copied, err = s.budgetRepo.CopyMonth(ctx, userID, source, to, overwrite); err != nil {
+			return err
+		}
+		if mode == enums.EnvelopeBudget && copied > 0 {
+			return s.checkAssignable(ctx, userID, currency, to)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return copied, nil
+}
+
+// checkAssignable garante que nenhum mês a partir de month ficou com mais
+// dinheiro orçado do que a receita disponível
+func (s *BudgetService) checkAssignable(ctx context.Context, userID, currency string, month time.Time) error {
+	latest, err := s.budgetRepo.LatestMonth(ctx, userID, currency)
+	if err != nil {
+		return err
+	}
+
+	totals, err := s.budgetRepo.MonthTotals(ctx, userID, currency, month, assignableUntil(month, latest))
+	if err != nil {
+		return err
+	}
+	return overAssigned(totals)
+}
+
+func (s *BudgetService) budgetSettings(ctx context.Context, userID string) (string, enums.BudgetMode, error) {
+	user, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return "", "", err
+	}
+
+	currency, mode := locale.Default.Currency(), enums.StandardBudget
+	if user.Settings != nil {
+		if user.Settings.Currency != "" {
+			currency = user.Settings.Currency
+		}
+		if user.Settings.BudgetMode.IsValid() {
+			mode = user.Settings.BudgetMode
+		}
+	}
+	return currency, mode, nil
+}
+
+// budgetIncreased diz se o novo valor aumenta o orçamento anterior (nil: não
+// havia). Só aumentos precisam de receita a distribuir no envelope; moedas
+// diferentes contam como aumento.
+func budgetIncreased(previous *entities.Budget, amount money.Money) bool {
+	if previous == nil {
+		return true
+	}
+	cmp, err := amount.Cmp(previous.Amount)
+	return err != nil || cmp > 0
+}
+
+// assignableUntil é o último mês a conferir: orçar um mês consome a receita
+// acumulada e afeta o valor a distribuir de todos os meses seguintes que já
+// têm orçamento
+func assignableUntil(month time.Time, latest *time.Time) time.Time {
+	if latest != nil && latest.After(month) {
+		return startOfMonth(*latest)
+	}
+	return month
+}
+
+// overAssigned devolve ErrBudgetOverAssigned no primeiro mês com mais
+// dinheiro orçado do que a receita disponível
+func overAssigned(totals []entities.BudgetMonthTotals) error {
+	for _, t := range totals {
+		if t.ToBeAssigned.Sign() < 0 {
+			return fmt.Errorf("%w: %s short in %s", appErrors.ErrBudgetOverAssigned, t.ToBeAssigned.Abs().Decimal(), t.Month.Format("2006-01"))
+		}
+	}
+	return nil
+}
+
+// copyMonths normaliza os meses de origem (nil: o anterior a to) e destino
+func copyMonths(from *time.Time, to time.Time) (time.Time, time.Time, error) {
+	to = startOfMonth(to)
+	source := to.AddDate(0, -1, 0)
+	if from != nil {
+		source = startOfMonth(*from)
+	}
+	if source.Equal(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: source and target months must differ", appErrors.ErrInvalidInput)
+	}
+	return source, to, nil
+}
+
+func startOfMonth(t time.Time) time.Time {
+	y, m, _ := t.Date()
+	return time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"errors"
+	"finanvilla/internal/domain/entities"
+	appErrors "finanvilla/pkg/errors"
+	"finanvilla/pkg/money"
+	"testing"
+)
+
+func TestBudgetIncreased(t *testing.T) {
+	previous := &entities.Budget{Amount: brl(50000)}
+	usd := money.MustNew(60000, "USD")
+
+	tests := []struct {
+		name     string
+		previous *entities.Budget
+		amount   money.Money
+		want     bool
+	}{
+		{"new budget", nil, brl(50000), true},
+		{"raise", previous, brl(60000), true},
+		{"same amount", previous, brl(50000), false},
+		{"cut", previous, brl(10000), false},
+		{"other currency", previous, usd, true},
+	}
+	for _, tt := range tests {
+		if got := budgetIncreased(tt.previous, tt.amount); got != tt.want {
+			t.Errorf("%s: budgetIncreased = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestOverAssigned(t *testing.T) {
+	totals := []entities.BudgetMonthTotals{
+		{Month: mustDate("2024-01-01"), ToBeAssigned: brl(10000)},
+		{Month: mustDate("2024-02-01"), ToBeAssigned: brl(0)},
+	}
+	if err := overAssigned(totals); err != nil {
+		t.Errorf("overAssigned = %v, want nil", err)
+	}
+
+	totals = append(totals, entities.BudgetMonthTotals{Month: mustDate("2024-03-01"), ToBeAssigned: brl(-2550)})
+	err := overAssigned(totals)
+	if !errors.Is(err, appErrors.ErrBudgetOverAssigned) {
+		t.Fatalf("overAssigned = %v, want ErrBudgetOverAssigned", err)
+	}
+	if want := appErrors.ErrBudgetOverAssigned.Error() + ": 25.50 short in 2024-03"; err.Error() != want {
+		t.Errorf("error = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestAssignableUntil(t *testing.T) {
+	month := mustDate("2024-03-01")
+	earlier, later := mustDate("2024-01-01"), mustDate("2024-06-15")
+
+	if got := assignableUntil(month, nil); !got.Equal(month) {
+		t.Errorf("no budgets: %s, want %s", got, month)
+	}
+	if got := assignableUntil(month, &earlier); !got.Equal(month) {
+		t.Errorf("latest before month: %s, want %s", got, month)
+	}
+	if got, want := assignableUntil(month, &later), mustDate("2024-06-01"); !got.Equal(want) {
+		t.Errorf("latest after month: %s, want %s", got, want)
+	}
+}
+
+func TestCopyMonths(t *testing.T) {
+	source, target, err := copyMonths(nil, mustDate("2024-03-20"))
+	if err != nil || !source.Equal(mustDate("2024-02-01")) || !target.Equal(mustDate("2024-03-01")) {
+		t.Errorf("default source = %s, %s, %v; want 2024-02-01, 2024-03-01", source, target, err)
+	}
+
+	from := mustDate("2023-12-31")
+	if source, _, err = copyMonths(&from, mustDate("2024-03-01")); err != nil || !source.Equal(mustDate("2023-12-01")) {
+		t.Errorf("explicit source = %s, %v; want 2023-12-01", source, err)
+	}
+
+	from = mustDate("2024-03-05")
+	if _, _, err := copyMonths(&from, mustDate("2024-03-20")); !errors.Is(err, appErrors.ErrInvalidInput) {
+		t.Errorf("same month: err = %v, want ErrInvalidInput", err)
+	}
+}
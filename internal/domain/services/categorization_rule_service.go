@@ -0,0 +1,809 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/internal/domain/repositories"
+	appErrors "finanvilla/pkg/errors"
+	"finanvilla/pkg/money"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// ruleSuggestionThreshold é quantas recategorizações iguais viram sugestão
+	ruleSuggestionThreshold = 2
+	// maxPreviewMatches limita os lançamentos listados na prévia de uma regra
+	maxPreviewMatches = 50
+)
+
+// CategorizationRuleService cuida das regras de categorização: cadastro,
+// prévia sobre o histórico, aplicação sob demanda e as sugestões tiradas das
+// recategorizações manuais. A importação de extratos usa o mesmo ruleSet.
+type CategorizationRuleService struct {
+	ruleRepo        repositories.CategorizationRuleRepository
+	transactionRepo repositories.TransactionRepository
+	accountRepo     repositories.AccountRepository
+	categoryRepo    repositories.CategoryRepository
+	tagRepo         repositories.TagRepository
+	txManager       repositories.TransactionManager
+}
+
+func NewCategorizationRuleService(
+	ruleRepo repositories.CategorizationRuleRepository,
+	transactionRepo repositories.TransactionRepository,
+	accountRepo repositories.AccountRepository,
+	categoryRepo repositories.CategoryRepository,
+	tagRepo repositories.TagRepository,
+	txManager repositories.TransactionManager,
+) *CategorizationRuleService {
+	return &CategorizationRuleService{
+		ruleRepo:        ruleRepo,
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+		categoryRepo:    categoryRepo,
+		tagRepo:         tagRepo,
+		txManager:       txManager,
+	}
+}
+
+func (s *CategorizationRuleService) List(ctx context.Context, userID string) ([]entities.CategorizationRule, error) {
+	return s.ruleRepo.List(ctx, userID, false)
+}
+
+func (s *CategorizationRuleService) GetByID(ctx context.Context, userID, id string) (*entities.CategorizationRule, error) {
+	return s.ruleRepo.GetByID(ctx, userID, id)
+}
+
+// Create grava a regra depois das existentes, ou seja, com a menor prioridade
+func (s *CategorizationRuleService) Create(ctx context.Context, userID string, req *dtos.CategorizationRuleRequest) (*entities.CategorizationRule, error) {
+	rule, err := s.buildRule(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+	if rule.Position, err = s.ruleRepo.NextPosition(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.ruleRepo.Create(ctx, rule); err != nil {
+		return nil, err
+	}
+	return s.ruleRepo.GetByID(ctx, userID, rule.ID)
+}
+
+// Update substitui nome, condições e ações; a posição só muda pelo Reorder
+func (s *CategorizationRuleService) Update(ctx context.Context, userID, id string, req *dtos.CategorizationRuleRequest) (*entities.CategorizationRule, error) {
+	existing, err := s.ruleRepo.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := s.buildRule(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+	rule.ID = existing.ID
+	if req.Active == nil {
+		rule.Active = existing.Active
+	}
+
+	if err := s.ruleRepo.Update(ctx, rule); err != nil {
+		return nil, err
+	}
+	return s.ruleRepo.GetByID(ctx, userID, id)
+}
+
+func (s *CategorizationRuleService) Delete(ctx context.Context, userID, id string) error {
+	return s.ruleRepo.Delete(ctx, userID, id)
+}
+
+// Reorder grava a ordem em que as regras são testadas. Regras fora da lista
+// mantêm a posição que tinham.
+func (s *CategorizationRuleService) Reorder(ctx context.Context, userID string, ruleIDs []string) ([]entities.CategorizationRule, error) {
+	seen := make(map[string]bool, len(ruleIDs))
+	for _, id := range ruleIDs {
+		if seen[id] {
+			return nil, fmt.Errorf("%w: duplicated rule %s", appErrors.ErrInvalidInput, id)
+		}
+		seen[id] = true
+	}
+
+	if err := s.ruleRepo.UpdatePositions(ctx, userID, ruleIDs); err != nil {
+		return nil, err
+	}
+	return s.ruleRepo.List(ctx, userID, false)
+}
+
+// Preview testa uma regra, gravada ou não, contra todo o histórico do
+// usuário, sem mudar nada
+func (s *CategorizationRuleService) Preview(ctx context.Context, userID string, req *dtos.CategorizationRuleRequest) (*dtos.RulePreview, error) {
+	rule, err := s.buildRule(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+	compiled, err := compileRule(*rule)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &dtos.RulePreview{Transactions: []dtos.RulePreviewMatch{}}
+	for page := 1; ; page++ {
+		transactions, _, err := s.transactionRepo.List(ctx, userID, repositories.TransactionFilter{}, page, exportBatchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range transactions {
+			t := &transactions[i]
+			if !compiled.matches(t) {
+				continue
+			}
+			leg, counter := ruleLegs(t)
+			change := ruleTarget(t)
+			preview.Matches++
+			if change {
+				preview.Changes++
+			}
+			if len(preview.Transactions) == maxPreviewMatches {
+				preview.Truncated = true
+				continue
+			}
+
+			match := dtos.RulePreviewMatch{
+				TransactionID: t.ID,
+				Date:          t.Date,
+				Description:   t.Description,
+				Payee:         t.Payee,
+				Amount:        leg.Amount,
+				WouldChange:   change,
+			}
+			if counter != nil {
+				match.CategoryID = counter.CategoryID
+			}
+			preview.Transactions = append(preview.Transactions, match)
+		}
+
+		if len(transactions) < exportBatchSize {
+			return preview, nil
+		}
+	}
+}
+
+// Apply roda as regras ativas (ou só as pedidas) sobre os lançamentos que
+// ainda têm contrapartida sem categoria. Os lançamentos são escolhidos antes
+// de qualquer gravação, para que a paginação não mude no meio do caminho.
+func (s *CategorizationRuleService) Apply(ctx context.Context, userID string, req *dtos.ApplyRulesRequest) (*dtos.RuleApplyReport, error) {
+	filter := repositories.TransactionFilter{Uncategorized: true}
+	if req.AccountID != "" {
+		filter.AccountIDs = []string{req.AccountID}
+	}
+	if req.From != "" {
+		from, err := time.Parse("2006-01-02", req.From)
+		if err != nil {
+			return nil, fmt.Errorf("%w: from must be YYYY-MM-DD", appErrors.ErrInvalidInput)
+		}
+		filter.From = &from
+	}
+	if req.To != "" {
+		to, err := time.Parse("2006-01-02", req.To)
+		if err != nil {
+			return nil, fmt.Errorf("%w: to must be YYYY-MM-DD", appErrors.ErrInvalidInput)
+		}
+		filter.To = &to
+	}
+	if filter.From != nil && filter.To != nil && filter.To.Before(*filter.From) {
+		return nil, fmt.Errorf("%w: to must not be before from", appErrors.ErrInvalidInput)
+	}
+
+	set, err := s.ruleSet(ctx, userID, req.RuleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &dtos.RuleApplyReport{Rules: []dtos.RuleApplyCount{}}
+	type candidate struct {
+		transaction entities.Transaction
+		rule        *compiledRule
+	}
+	var candidates []candidate
+	for page := 1; ; page++ {
+		transactions, _, err := s.transactionRepo.List(ctx, userID, filter, page, exportBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range transactions {
+			if !ruleTarget(&t) {
+				continue
+			}
+			report.Scanned++
+			if rule := set.match(&t); rule != nil {
+				candidates = append(candidates, candidate{transaction: t, rule: rule})
+			}
+		}
+		if len(transactions) < exportBatchSize {
+			break
+		}
+	}
+
+	counts := make(map[string]int)
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		for i := range candidates {
+			c := &candidates[i]
+			changed, err := set.apply(ctx, &c.transaction, c.rule, true)
+			if err != nil {
+				return err
+			}
+			if !changed {
+				continue
+			}
+			if err := s.transactionRepo.Update(ctx, &c.transaction); err != nil {
+				return err
+			}
+			counts[c.rule.rule.ID]++
+			report.Updated++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range set.rules {
+		if n := counts[r.rule.ID]; n > 0 {
+			report.Rules = append(report.Rules, dtos.RuleApplyCount{RuleID: r.rule.ID, Name: r.rule.Name, Transactions: n})
+		}
+	}
+	return report, nil
+}
+
+// Suggestions lista as palavras-chave recategorizadas várias vezes para a
+// mesma categoria que ainda não são cobertas por uma regra ativa
+func (s *CategorizationRuleService) Suggestions(ctx context.Context, userID string) ([]entities.RuleSuggestion, error) {
+	suggestions, err := s.ruleRepo.Suggestions(ctx, userID, ruleSuggestionThreshold)
+	if err != nil {
+		return nil, err
+	}
+	set, err := s.ruleSet(ctx, userID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	open := make([]entities.RuleSuggestion, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		if !set.coversKeyword(suggestion.Keyword) {
+			open = append(open, suggestion)
+		}
+	}
+	return open, nil
+}
+
+// AcceptSuggestion cria a regra "descrição contém a palavra-chave" para a
+// categoria sugerida e descarta a sugestão
+func (s *CategorizationRuleService) AcceptSuggestion(ctx context.Context, userID, id string) (*entities.CategorizationRule, error) {
+	suggestion, err := s.ruleRepo.GetSuggestion(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryID := suggestion.CategoryID
+	req := &dtos.CategorizationRuleRequest{
+		Name: fmt.Sprintf("%s → %s", suggestion.Keyword, suggestion.CategoryName),
+		Conditions: []dtos.RuleConditionRequest{{
+			Field:    enums.DescriptionField,
+			Operator: enums.ContainsOperator,
+			Value:    suggestion.Keyword,
+		}},
+		Actions: dtos.RuleActionsRequest{CategoryID: &categoryID},
+	}
+
+	var rule *entities.CategorizationRule
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if rule, err = s.Create(ctx, userID, req); err != nil {
+			return err
+		}
+		return s.ruleRepo.DeleteSuggestion(ctx, userID, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// DismissSuggestion esconde a sugestão; novas recategorizações continuam
+// sendo contadas, mas ela não volta a aparecer
+func (s *CategorizationRuleService) DismissSuggestion(ctx context.Context, userID, id string) error {
+	return s.ruleRepo.DismissSuggestion(ctx, userID, id)
+}
+
+func (s *CategorizationRuleService) ruleSet(ctx context.Context, userID string, ruleIDs []string) (*ruleSet, error) {
+	rules, err := s.ruleRepo.List(ctx, userID, len(ruleIDs) == 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(ruleIDs) > 0 {
+		wanted := make(map[string]bool, len(ruleIDs))
+		for _, id := range ruleIDs {
+			wanted[id] = true
+		}
+		selected := rules[:0]
+		for _, rule := range rules {
+			if wanted[rule.ID] {
+				selected = append(selected, rule)
+				delete(wanted, rule.ID)
+			}
+		}
+		if len(wanted) > 0 {
+			return nil, appErrors.ErrRuleNotFound
+		}
+		rules = selected
+	}
+	return newRuleSet(userID, rules, s.accountRepo, s.categoryRepo, s.tagRepo), nil
+}
+
+// buildRule valida a requisição. Contas e categorias precisam ser do usuário;
+// a categoria não pode estar arquivada.
+func (s *CategorizationRuleService) buildRule(ctx context.Context, userID string, req *dtos.CategorizationRuleRequest) (*entities.CategorizationRule, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", appErrors.ErrInvalidInput)
+	}
+
+	conditions, err := buildRuleConditions(req.Conditions)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range conditions {
+		if c.Field == enums.AccountField {
+			if _, err := s.accountRepo.GetByID(ctx, userID, c.Value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	actions, err := buildRuleActions(req.Actions)
+	if err != nil {
+		return nil, err
+	}
+	if actions.CategoryID != nil {
+		category, err := s.categoryRepo.GetByID(ctx, userID, *actions.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		if category.Archived {
+			return nil, fmt.Errorf("%w: category %q is archived", appErrors.ErrInvalidInput, category.Name)
+		}
+	}
+	if actions.TransferAccountID != nil {
+		if _, err := s.accountRepo.GetByID(ctx, userID, *actions.TransferAccountID); err != nil {
+			return nil, err
+		}
+	}
+
+	rule := &entities.CategorizationRule{
+		UserID:     userID,
+		Name:       name,
+		Active:     req.Active == nil || *req.Active,
+		Conditions: conditions,
+		Actions:    actions,
+	}
+	if _, err := compileRule(*rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func buildRuleConditions(reqs []dtos.RuleConditionRequest) (entities.RuleConditions, error) {
+	if len(reqs) == 0 {
+		return nil, fmt.Errorf("%w: at least one condition is required", appErrors.ErrInvalidInput)
+	}
+
+	conditions := make(entities.RuleConditions, 0, len(reqs))
+	for i, req := range reqs {
+		if !req.Field.IsValid() {
+			return nil, fmt.Errorf("%w: condition %d: unknown field %q", appErrors.ErrInvalidInput, i+1, req.Field)
+		}
+		if !req.Operator.IsValid() || !req.Operator.Accepts(req.Field) {
+			return nil, fmt.Errorf("%w: condition %d: operator %q does not apply to %s", appErrors.ErrInvalidInput, i+1, req.Operator, req.Field)
+		}
+
+		condition := entities.RuleCondition{Field: req.Field, Operator: req.Operator}
+		if req.Field == enums.AmountField {
+			if req.Min == nil && req.Max == nil {
+				return nil, fmt.Errorf("%w: condition %d: min or max is required", appErrors.ErrInvalidInput, i+1)
+			}
+			for _, bound := range []*money.Money{req.Min, req.Max} {
+				if bound != nil && bound.Sign() < 0 {
+					return nil, fmt.Errorf("%w: condition %d: amounts are unsigned and must not be negative", appErrors.ErrInvalidInput, i+1)
+				}
+			}
+			if req.Min != nil && req.Max != nil {
+				if cmp, err := req.Min.Cmp(*req.Max); err != nil || cmp > 0 {
+					return nil, fmt.Errorf("%w: condition %d: min must not be greater than max", appErrors.ErrInvalidInput, i+1)
+				}
+			}
+			condition.Min, condition.Max = req.Min, req.Max
+		} else {
+			condition.Value = strings.TrimSpace(req.Value)
+			if condition.Value == "" {
+				return nil, fmt.Errorf("%w: condition %d: value is required", appErrors.ErrInvalidInput, i+1)
+			}
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions, nil
+}
+
+func buildRuleActions(req dtos.RuleActionsRequest) (entities.RuleActions, error) {
+	actions := entities.RuleActions{
+		CategoryID:        req.CategoryID,
+		Payee:             strings.TrimSpace(req.Payee),
+		TransferAccountID: req.TransferAccountID,
+	}
+	if actions.CategoryID != nil && actions.TransferAccountID != nil {
+		return actions, fmt.Errorf("%w: a rule either sets a category or marks a transfer, not both", appErrors.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(actions.Payee) > 255 {
+		return actions, fmt.Errorf("%w: payee is longer than 255 characters", appErrors.ErrInvalidInput)
+	}
+	if len(req.Tags) > 0 {
+		tags, err := normalizeTagNames(req.Tags)
+		if err != nil {
+			return actions, err
+		}
+		actions.Tags = tags
+	}
+
+	if actions.CategoryID == nil && actions.TransferAccountID == nil && actions.Payee == "" && len(actions.Tags) == 0 {
+		return actions, fmt.Errorf("%w: at least one action is required", appErrors.ErrInvalidInput)
+	}
+	return actions, nil
+}
+
+// compiledRule é uma regra com as expressões regulares já compiladas, na
+// mesma ordem das condições (nil nas que não são REGEX)
+type compiledRule struct {
+	rule    entities.CategorizationRule
+	regexps []*regexp.Regexp
+}
+
+// compileRule compila as expressões das condições REGEX, sempre sem
+// diferenciar maiúsculas
+func compileRule(rule entities.CategorizationRule) (*compiledRule, error) {
+	compiled := &compiledRule{rule: rule, regexps: make([]*regexp.Regexp, len(rule.Conditions))}
+	for i, c := range rule.Conditions {
+		if c.Operator != enums.RegexOperator {
+			continue
+		}
+		re, err := regexp.Compile("(?i)" + c.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: condition %d: invalid regular expression: %v", appErrors.ErrInvalidInput, i+1, err)
+		}
+		compiled.regexps[i] = re
+	}
+	return compiled, nil
+}
+
+// matches diz se todas as condições batem. Só lançamentos com uma única
+// perna de conta são considerados: transferências já estão classificadas.
+func (r *compiledRule) matches(t *entities.Transaction) bool {
+	leg, _ := ruleLegs(t)
+	if leg == nil {
+		return false
+	}
+	for i, c := range r.rule.Conditions {
+		var ok bool
+		switch c.Field {
+		case enums.DescriptionField:
+			ok = matchText(c, r.regexps[i], t.Description)
+		case enums.PayeeField:
+			ok = matchText(c, r.regexps[i], t.Payee)
+		case enums.AmountField:
+			ok = matchAmount(c, leg.Amount.Abs())
+		case enums.AccountField:
+			ok = *leg.AccountID == c.Value
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func matchText(c entities.RuleCondition, re *regexp.Regexp, text string) bool {
+	if re != nil {
+		return re.MatchString(text)
+	}
+	text, value := strings.ToLower(strings.TrimSpace(text)), strings.ToLower(c.Value)
+	switch c.Operator {
+	case enums.ContainsOperator:
+		return strings.Contains(text, value)
+	case enums.EqualsOperator:
+		return text == value
+	case enums.StartsWithOperator:
+		return strings.HasPrefix(text, value)
+	}
+	return false
+}
+
+// matchAmount compara o valor com os limites na moeda do lançamento; um limite
+// com mais casas do que a moeda aceita nunca bate
+func matchAmount(c entities.RuleCondition, amount money.Money) bool {
+	if c.Min != nil {
+		min, err := c.Min.WithCurrency(amount.Currency())
+		if err != nil {
+			return false
+		}
+		if cmp, err := amount.Cmp(min); err != nil || cmp < 0 {
+			return false
+		}
+	}
+	if c.Max != nil {
+		max, err := c.Max.WithCurrency(amount.Currency())
+		if err != nil {
+			return false
+		}
+		if cmp, err := amount.Cmp(max); err != nil || cmp > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ruleLegs devolve a única perna de conta do lançamento e, se houver uma só,
+// a contrapartida
+func ruleLegs(t *entities.Transaction) (leg, counter *entities.Posting) {
+	for i := range t.Postings {
+		p := &t.Postings[i]
+		if p.AccountID == nil {
+			continue
+		}
+		if leg != nil {
+			return nil, nil
+		}
+		leg = p
+	}
+	if leg != nil && len(t.Postings) == 2 {
+		for i := range t.Postings {
+			if &t.Postings[i] != leg {
+				counter = &t.Postings[i]
+			}
+		}
+	}
+	return leg, counter
+}
+
+// ruleTarget diz se as regras podem mudar o lançamento: uma perna de conta e
+// uma contrapartida sem categoria, fora de conciliação e de parcelamento
+func ruleTarget(t *entities.Transaction) bool {
+	if t.Status == enums.ReconciledTransaction || t.InstallmentPurchaseID != nil {
+		return false
+	}
+	_, counter := ruleLegs(t)
+	return counter != nil && counter.AccountID == nil && counter.CategoryID == nil
+}
+
+// ruleSet aplica as regras de um usuário na ordem de prioridade, guardando as
+// contas, categorias e etiquetas já consultadas
+type ruleSet struct {
+	userID       string
+	rules        []*compiledRule
+	accountRepo  repositories.AccountRepository
+	categoryRepo repositories.CategoryRepository
+	tagRepo      repositories.TagRepository
+	accounts     map[string]*entities.Account
+	categories   map[string]*entities.Category
+	tags         map[string][]entities.Tag
+}
+
+// newRuleSet compila as regras. Uma regra gravada que não compila mais é
+// ignorada em vez de travar a importação.
+func newRuleSet(
+	userID string,
+	rules []entities.CategorizationRule,
+	accountRepo repositories.AccountRepository,
+	categoryRepo repositories.CategoryRepository,
+	tagRepo repositories.TagRepository,
+) *ruleSet {
+	set := &ruleSet{
+		userID:       userID,
+		accountRepo:  accountRepo,
+		categoryRepo: categoryRepo,
+		tagRepo:      tagRepo,
+		accounts:     make(map[string]*entities.Account),
+		categories:   make(map[string]*entities.Category),
+		tags:         make(map[string][]entities.Tag),
+	}
+	for _, rule := range rules {
+		if compiled, err := compileRule(rule); err == nil {
+			set.rules = append(set.rules, compiled)
+		}
+	}
+	return set
+}
+
+// match devolve a primeira regra que bate com o lançamento, se ele puder ser
+// mudado pelas regras
+func (s *ruleSet) match(t *entities.Transaction) *compiledRule {
+	if !ruleTarget(t) {
+		return nil
+	}
+	for _, rule := range s.rules {
+		if rule.matches(t) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// apply executa as ações da regra no lançamento e diz se algo mudou. Categoria
+// apagada ou arquivada e conta de transferência inexistente, em outra moeda ou
+// igual à do lançamento fazem a ação ser pulada. Sem withTags, as etiquetas
+// não são criadas nem aplicadas, como na prévia da importação.
+func (s *ruleSet) apply(ctx context.Context, t *entities.Transaction, rule *compiledRule, withTags bool) (bool, error) {
+	leg, counter := ruleLegs(t)
+	actions := rule.rule.Actions
+	changed := false
+
+	if actions.CategoryID != nil {
+		category, err := s.category(ctx, *actions.CategoryID)
+		if err != nil {
+			return false, err
+		}
+		if category != nil && !category.Archived {
+			counter.CategoryID = &category.ID
+			changed = true
+		}
+	}
+	if actions.TransferAccountID != nil && *actions.TransferAccountID != *leg.AccountID {
+		account, err := s.account(ctx, *actions.TransferAccountID)
+		if err != nil {
+			return false, err
+		}
+		if account != nil && account.Currency == t.Currency {
+			counter.AccountID = &account.ID
+			changed = true
+		}
+	}
+	if actions.Payee != "" && actions.Payee != t.Payee {
+		t.Payee = actions.Payee
+		changed = true
+	}
+	if withTags && len(actions.Tags) > 0 {
+		tags, err := s.ruleTags(ctx, rule)
+		if err != nil {
+			return false, err
+		}
+		var added bool
+		t.Tags, added = mergeTags(t.Tags, tags)
+		changed = changed || added
+	}
+	return changed, nil
+}
+
+// coversKeyword diz se alguma regra com condição de descrição já pegaria uma
+// descrição igual à palavra-chave
+func (s *ruleSet) coversKeyword(keyword string) bool {
+	for _, rule := range s.rules {
+		for i, c := range rule.rule.Conditions {
+			if c.Field == enums.DescriptionField && matchText(c, rule.regexps[i], keyword) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *ruleSet) category(ctx context.Context, id string) (*entities.Category, error) {
+	if category, ok := s.categories[id]; ok {
+		return category, nil
+	}
+	category, err := s.categoryRepo.GetByID(ctx, s.userID, id)
+	if errors.Is(err, appErrors.ErrCategoryNotFound) {
+		category, err = nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.categories[id] = category
+	return category, nil
+}
+
+func (s *ruleSet) account(ctx context.Context, id string) (*entities.Account, error) {
+	if account, ok := s.accounts[id]; ok {
+		return account, nil
+	}
+	account, err := s.accountRepo.GetByID(ctx, s.userID, id)
+	if errors.Is(err, appErrors.ErrAccountNotFound) {
+		account, err = nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.accounts[id] = account
+	return account, nil
+}
+
+func (s *ruleSet) ruleTags(ctx context.Context, rule *compiledRule) ([]entities.Tag, error) {
+	if tags, ok := s.tags[rule.rule.ID]; ok {
+		return tags, nil
+	}
+	tags, err := s.tagRepo.Resolve(ctx, s.userID, rule.rule.Actions.Tags)
+	if err != nil {
+		return nil, err
+	}
+	s.tags[rule.rule.ID] = tags
+	return tags, nil
+}
+
+// mergeTags soma às etiquetas do lançamento as que ele ainda não tem
+func mergeTags(current, tags []entities.Tag) ([]entities.Tag, bool) {
+	has := make(map[string]bool, len(current))
+	for _, tag := range current {
+		has[tag.ID] = true
+	}
+	added := false
+	for _, tag := range tags {
+		if !has[tag.ID] {
+			current = append(current, tag)
+			has[tag.ID] = true
+			added = true
+		}
+	}
+	return current, added
+}
+
+// keywordNoise são palavras de extrato que não identificam o estabelecimento
+var keywordNoise = map[string]bool{
+	"pag": true, "pagto": true, "pgto": true, "pagamento": true, "compra": true,
+	"debito": true, "débito": true, "deb": true, "credito": true, "crédito": true,
+	"cartao": true, "cartão": true, "pix": true, "ted": true, "doc": true,
+	"tef": true, "transf": true, "transferencia": true, "transferência": true,
+	"enviado": true, "enviada": true, "recebido": true, "recebida": true,
+	"elo": true, "visa": true, "master": true, "mastercard": true, "maestro": true,
+	"parc": true, "parcela": true, "aut": true, "int": true, "intl": true,
+	"online": true, "ltda": true, "eireli": true, "bra": true, "www": true,
+	"com": true, "http": true, "https": true,
+}
+
+// descriptionKeyword tira da descrição a palavra que identifica o
+// estabelecimento: a primeira com três letras ou mais, sem números, que não
+// seja jargão do extrato. "PAG*IFOOD SAO PAULO" vira "ifood".
+func descriptionKeyword(description string) string {
+	words := strings.FieldsFunc(strings.ToLower(description), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		if utf8.RuneCountInString(word) < 3 || keywordNoise[word] || strings.IndexFunc(word, unicode.IsDigit) >= 0 {
+			continue
+		}
+		return truncate(word, 50)
+	}
+	return ""
+}
+
+// recategorization detecta a troca manual da categoria de um lançamento
+// simples e devolve a palavra-chave da descrição e a nova categoria
+func recategorization(before, after *entities.Transaction) (string, string, bool) {
+	_, old := ruleLegs(before)
+	_, counter := ruleLegs(after)
+	if old == nil || counter == nil || old.AccountID != nil || counter.AccountID != nil || counter.CategoryID == nil {
+		return "", "", false
+	}
+	if old.CategoryID != nil && *old.CategoryID == *counter.CategoryID {
+		return "", "", false
+	}
+
+	keyword := descriptionKeyword(after.Description)
+	if keyword == "" {
+		return "", "", false
+	}
+	return keyword, *counter.CategoryID, true
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/pkg/money"
+	"testing"
+)
+
+func TestCategorizationRuleMatches(t *testing.T) {
+	checking, food := "checking", "food"
+	min, _ := money.Parse("10", "")
+	max, _ := money.Parse("50.00", "")
+
+	rules := []entities.CategorizationRule{
+		{ID: "regex", Conditions: entities.RuleConditions{
+			{Field: enums.DescriptionField, Operator: enums.RegexOperator, Value: `^pag\*ifood`},
+			{Field: enums.AmountField, Operator: enums.BetweenOperator, Min: &min, Max: &max},
+		}},
+		{ID: "contains", Conditions: entities.RuleConditions{
+			{Field: enums.DescriptionField, Operator: enums.ContainsOperator, Value: "IFOOD"},
+			{Field: enums.AccountField, Operator: enums.EqualsOperator, Value: checking},
+		}},
+	}
+	set := newRuleSet("user", rules, nil, nil, nil)
+
+	transaction := func(description string, amount int64, categoryID *string) *entities.Transaction {
+		return &entities.Transaction{
+			Description: description,
+			Currency:    "BRL",
+			Postings: []entities.Posting{
+				{AccountID: &checking, Amount: brl(-amount)},
+				{CategoryID: categoryID, Amount: brl(amount)},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		transaction *entities.Transaction
+		want        string
+	}{
+		{"first rule wins", transaction("PAG*IFOOD SAO PAULO", 3590, nil), "regex"},
+		{"amount outside range falls through", transaction("PAG*IFOOD SAO PAULO", 8000, nil), "contains"},
+		{"range is inclusive", transaction("pag*ifood", 5000, nil), "regex"},
+		{"no match", transaction("UBER *TRIP", 3590, nil), ""},
+		{"already categorized", transaction("PAG*IFOOD SAO PAULO", 3590, &food), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ""
+			if rule := set.match(tt.transaction); rule != nil {
+				got = rule.rule.ID
+			}
+			if got != tt.want {
+				t.Errorf("match = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	transfer := transaction("PAG*IFOOD", 3590, nil)
+	savings := "savings"
+	transfer.Postings[1].AccountID = &savings
+	if set.match(transfer) != nil {
+		t.Error("transfer between accounts should not match")
+	}
+}
+
+func TestCompileRuleRejectsInvalidRegex(t *testing.T) {
+	rule := entities.CategorizationRule{Conditions: entities.RuleConditions{
+		{Field: enums.PayeeField, Operator: enums.RegexOperator, Value: "(unclosed"},
+	}}
+	if _, err := compileRule(rule); err == nil {
+		t.Error("invalid regular expression was accepted")
+	}
+}
+
+func TestDescriptionKeyword(t *testing.T) {
+	tests := map[string]string{
+		"PAG*IFOOD SAO PAULO":          "ifood",
+		"COMPRA CARTAO DEB UBER *TRIP": "uber",
+		"NETFLIX.COM":                  "netflix",
+		"PIX 12/03 1234":               "",
+		"Padaria Pão de Açúcar":        "padaria",
+	}
+	for description, want := range tests {
+		if got := descriptionKeyword(description); got != want {
+			t.Errorf("descriptionKeyword(%q) = %q, want %q", description, got, want)
+		}
+	}
+}
+
+func TestRecategorization(t *testing.T) {
+	checking, food, travel := "checking", "food", "travel"
+	transaction := func(categoryID *string) *entities.Transaction {
+		return &entities.Transaction{
+			Description: "PAG*IFOOD SAO PAULO",
+			Postings: []entities.Posting{
+				{AccountID: &checking, Amount: brl(-3590)},
+				{CategoryID: categoryID, Amount: brl(3590)},
+			},
+		}
+	}
+
+	keyword, categoryID, ok := recategorization(transaction(nil), transaction(&food))
+	if !ok || keyword != "ifood" || categoryID != food {
+		t.Errorf("recategorization = %q, %q, %v; want ifood, food, true", keyword, categoryID, ok)
+	}
+	if _, _, ok := recategorization(transaction(&travel), transaction(&food)); !ok {
+		t.Error("changing the category should be recorded")
+	}
+	if _, _, ok := recategorization(transaction(&food), transaction(&food)); ok {
+		t.Error("keeping the category should not be recorded")
+	}
+	if _, _, ok := recategorization(transaction(&food), transaction(nil)); ok {
+		t.Error("clearing the category should not be recorded")
+	}
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/internal/domain/repositories"
+	"strings"
+)
+
+// localizedName traz o nome da categoria em cada idioma suportado
+type localizedName struct {
+	ptBR string
+	enUS string
+}
+
+func (n localizedName) in(language string) string {
+	if strings.HasPrefix(strings.ToLower(language), "pt") {
+		return n.ptBR
+	}
+	return n.enUS
+}
+
+type defaultCategory struct {
+	name     localizedName
+	kind     enums.CategoryType
+	color    string
+	icon     string
+	children []localizedName
+}
+
+// defaultCategoryTree é a árvore criada para cada novo usuário. Idiomas sem
+// tradução própria recebem os nomes em inglês.
+var defaultCategoryTree = []defaultCategory{
+	{localizedName{"Moradia", "Housing"}, enums.ExpenseCategory, "#8D6E63", "home", []localizedName{
+		{"Aluguel", "Rent"},
+		{"Condomínio", "HOA fees"},
+		{"Energia", "Electricity"},
+		{"Água", "Water"},
+		{"Gás", "Gas"},
+		{"Internet e telefone", "Internet and phone"},
+		{"Manutenção", "Maintenance"},
+	}},
+	{localizedName{"Alimentação", "Food"}, enums.ExpenseCategory, "#EF6C00", "restaurant", []localizedName{
+		{"Supermercado", "Groceries"},
+		{"Restaurantes", "Restaurants"},
+		{"Delivery", "Delivery"},
+		{"Padaria e lanches", "Coffee and snacks"},
+	}},
+	{localizedName{"Transporte", "Transportation"}, enums.ExpenseCategory, "#1E88E5", "directions_car", []localizedName{
+		{"Combustível", "Fuel"},
+		{"Transporte público", "Public transit"},
+		{"Aplicativos de transporte", "Rideshare"},
+		{"Estacionamento e pedágio", "Parking and tolls"},
+		{"Manutenção do veículo", "Car maintenance"},
+	}},
+	{localizedName{"Saúde", "Health"}, enums.ExpenseCategory, "#E53935", "favorite", []localizedName{
+		{"Plano de saúde", "Health insurance"},
+		{"Farmácia", "Pharmacy"},
+		{"Consultas e exames", "Doctor and tests"},
+		{"Academia", "Gym"},
+	}},
+	{localizedName{"Educação", "Education"}, enums.ExpenseCategory, "#5E35B1", "school", []localizedName{
+		{"Mensalidade escolar", "Tuition"},
+		{"Cursos", "Courses"},
+		{"Livros e material", "Books and supplies"},
+	}},
+	{localizedName{"Lazer", "Entertainment"}, enums.ExpenseCategory, "#00ACC1", "celebration", []localizedName{
+		{"Viagens", "Travel"},
+		{"Assinaturas e streaming", "Subscriptions and streaming"},
+		{"Cinema, shows e eventos", "Movies, shows and events"},
+		{"Bares", "Bars"},
+	}},
+	{localizedName{"Compras", "Shopping"}, enums.ExpenseCategory, "#D81B60", "shopping_bag", []localizedName{
+		{"Vestuário", "Clothing"},
+		{"Eletrônicos", "Electronics"},
+		{"Casa e decoração", "Home goods"},
+	}},
+	{localizedName{"Cuidados pessoais", "Personal"}, enums.ExpenseCategory, "#8E24AA", "spa", []localizedName{
+		{"Beleza e higiene", "Personal care"},
+		{"Presentes e doações", "Gifts and donations"},
+		{"Pets", "Pets"},
+	}},
+	{localizedName{"Impostos e tarifas", "Taxes and fees"}, enums.ExpenseCategory, "#546E7A", "receipt_long", []localizedName{
+		{"IPTU", "Property tax"},
+		{"IPVA", "Vehicle tax"},
+		{"Imposto de renda", "Income tax"},
+		{"Tarifas bancárias", "Bank fees"},
+		{"Juros e multas", "Interest and penalties"},
+	}},
+	{localizedName{"Receitas", "Income"}, enums.IncomeCategory, "#43A047", "payments", []localizedName{
+		{"Salário", "Salary"},
+		{"Décimo terceiro", "Bonus"},
+		{"Freelance", "Freelance"},
+		{"Rendimentos", "Investment income"},
+		{"Reembolsos", "Refunds"},
+		{"Outras receitas", "Other income"},
+	}},
+}
+
+// seedDefaultCategories cria a árvore padrão no idioma do usuário: primeiro as
+// categorias raiz, depois as subcategorias já com o ID do pai.
+func seedDefaultCategories(ctx context.Context, categoryRepo repositories.CategoryRepository, userID, language string) error {
+	roots := make([]entities.Category, len(defaultCategoryTree))
+	for i, def := range defaultCategoryTree {
+		roots[i] = entities.Category{
+			UserID: userID,
+			Name:   def.name.in(language),
+			Type:   def.kind,
+			Color:  def.color,
+			Icon:   def.icon,
+		}
+	}
+	if err := categoryRepo.CreateMany(ctx, roots); err != nil {
+		return err
+	}
+
+	var children []entities.Category
+	for i, def := range defaultCategoryTree {
+		parentID := roots[i].ID
+		for _, child := range def.children {
+			children = append(children, entities.Category{
+				UserID:   userID,
+				ParentID: &parentID,
+				Name:     child.in(language),
+				Type:     def.kind,
+				Color:    def.color,
+				Icon:     def.icon,
+			})
+		}
+	}
+	return categoryRepo.CreateMany(ctx, children)
+}
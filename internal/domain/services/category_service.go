@@ -0,0 +1,337 @@
+package services
+
+import (
+	"context"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/internal/domain/repositories"
+	"finanvilla/pkg/errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+type CategoryService struct {
+	categoryRepo  repositories.CategoryRepository
+	budgetRepo    repositories.BudgetRepository
+	recurringRepo repositories.RecurringTransactionRepository
+	txManager     repositories.TransactionManager
+}
+
+func NewCategoryService(
+	categoryRepo repositories.CategoryRepository,
+	budgetRepo repositories.BudgetRepository,
+	recurringRepo repositories.RecurringTransactionRepository,
+	txManager repositories.TransactionManager,
+) *CategoryService {
+	return &CategoryService{
+		categoryRepo:  categoryRepo,
+		budgetRepo:    budgetRepo,
+		recurringRepo: recurringRepo,
+		txManager:     txManager,
+	}
+}
+
+// List devolve as categorias em árvore ou, com flat, na lista plana
+func (s *CategoryService) List(ctx context.Context, userID string, filter repositories.CategoryFilter, flat bool) ([]entities.Category, error) {
+	if filter.Type != "" && !filter.Type.IsValid() {
+		return nil, fmt.Errorf("%w: unknown category type %q", errors.ErrInvalidInput, filter.Type)
+	}
+
+	categories, err := s.categoryRepo.List(ctx, userID, filter)
+	if err != nil {
+		return nil, err
+	}
+	if flat {
+		return categories, nil
+	}
+	return BuildCategoryTree(categories), nil
+}
+
+func (s *CategoryService) GetByID(ctx context.Context, userID, id string) (*entities.Category, error) {
+	return s.categoryRepo.GetByID(ctx, userID, id)
+}
+
+func (s *CategoryService) Create(ctx context.Context, userID string, req *dtos.CreateCategoryRequest) (*entities.Category, error) {
+	category := &entities.Category{
+		UserID: userID,
+		Name:   strings.TrimSpace(req.Name),
+		Type:   req.Type,
+		Color:  strings.ToUpper(req.Color),
+		Icon:   req.Icon,
+	}
+	if category.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", errors.ErrInvalidInput)
+	}
+
+	if req.ParentID != nil {
+		parent, err := s.categoryRepo.GetByID(ctx, userID, *req.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent.Archived {
+			return nil, fmt.Errorf("%w: parent category is archived", errors.ErrInvalidInput)
+		}
+		if category.Type != "" && category.Type != parent.Type {
+			return nil, fmt.Errorf("%w: subcategories must have the parent's type", errors.ErrInvalidInput)
+		}
+		category.ParentID = &parent.ID
+		category.Type = parent.Type
+	} else if !category.Type.IsValid() {
+		return nil, fmt.Errorf("%w: type must be INCOME or EXPENSE", errors.ErrInvalidInput)
+	}
+
+	if err := s.categoryRepo.Create(ctx, category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+func (s *CategoryService) Update(ctx context.Context, userID, id string, req *dtos.UpdateCategoryRequest) (*entities.Category, error) {
+	var updated *entities.Category
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		all, err := s.categoryRepo.List(ctx, userID, repositories.CategoryFilter{IncludeArchived: true})
+		if err != nil {
+			return err
+		}
+		byID := indexCategories(all)
+
+		category, ok := byID[id]
+		if !ok {
+			return errors.ErrCategoryNotFound
+		}
+
+		if req.Name != nil {
+			category.Name = strings.TrimSpace(*req.Name)
+			if category.Name == "" {
+				return fmt.Errorf("%w: name is required", errors.ErrInvalidInput)
+			}
+		}
+		if req.Color != nil {
+			category.Color = strings.ToUpper(*req.Color)
+		}
+		if req.Icon != nil {
+			category.Icon = *req.Icon
+		}
+
+		if req.ParentID != nil {
+			if *req.ParentID == "" {
+				category.ParentID = nil
+			} else {
+				parent, ok := byID[*req.ParentID]
+				if !ok {
+					return errors.ErrCategoryNotFound
+				}
+				if isInSubtree(byID, category.ID, parent.ID) {
+					return fmt.Errorf("%w: a category cannot be moved under itself or its subcategories", errors.ErrInvalidInput)
+				}
+				if parent.Type != category.Type {
+					return fmt.Errorf("%w: subcategories must have the parent's type", errors.ErrInvalidInput)
+				}
+				category.ParentID = &parent.ID
+			}
+		}
+
+		if req.Archived != nil && *req.Archived != category.Archived {
+			if !*req.Archived && category.ParentID != nil && byID[*category.ParentID].Archived {
+				return fmt.Errorf("%w: unarchive the parent category first", errors.ErrInvalidInput)
+			}
+			category.Archived = *req.Archived
+
+			// Arquivar esconde também toda a subárvore
+			if category.Archived {
+				for _, c := range all {
+					if c.ID != category.ID && !c.Archived && isInSubtree(byID, category.ID, c.ID) {
+						c.Archived = true
+						if err := s.categoryRepo.Update(ctx, &c); err != nil {
+							return err
+						}
+					}
+				}
+			}
+		}
+
+		if err := s.categoryRepo.Update(ctx, category); err != nil {
+			return err
+		}
+		updated = category
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// Delete só exclui categorias sem subcategorias e sem lançamentos; nos demais
+// casos o caminho é mesclar ou arquivar.
+func (s *CategoryService) Delete(ctx context.Context, userID, id string) error {
+	return s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.categoryRepo.GetByID(ctx, userID, id); err != nil {
+			return err
+		}
+
+		count, err := s.categoryRepo.CountPostings(ctx, id)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return errors.ErrCategoryInUse
+		}
+
+		// Subcategorias fazem a exclusão falhar pela FK de parent_id
+		return s.categoryRepo.Delete(ctx, userID, id)
+	})
+}
+
+// Merge move os lançamentos, os recorrentes, os orçamentos e as subcategorias de
+// sourceID para targetID e exclui a categoria de origem.
+func (s *CategoryService) Merge(ctx context.Context, userID, sourceID, targetID string) (*entities.Category, error) {
+	var target *entities.Category
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		all, err := s.categoryRepo.List(ctx, userID, repositories.CategoryFilter{IncludeArchived: true})
+		if err != nil {
+			return err
+		}
+		byID := indexCategories(all)
+
+		source, ok := byID[sourceID]
+		if !ok {
+			return errors.ErrCategoryNotFound
+		}
+		if target, ok = byID[targetID]; !ok {
+			return errors.ErrCategoryNotFound
+		}
+		if isInSubtree(byID, source.ID, target.ID) {
+			return fmt.Errorf("%w: cannot merge a category into itself or its subcategories", errors.ErrInvalidInput)
+		}
+		if source.Type != target.Type {
+			return fmt.Errorf("%w: categories must have the same type", errors.ErrInvalidInput)
+		}
+
+		if _, err := s.categoryRepo.ReassignPostings(ctx, userID, []string{source.ID}, target.ID, nil, nil); err != nil {
+			return err
+		}
+		if err := s.recurringRepo.ReassignCategory(ctx, userID, source.ID, target.ID); err != nil {
+			return err
+		}
+		if err := s.budgetRepo.MergeCategory(ctx, userID, source.ID, target.ID); err != nil {
+			return err
+		}
+		if err := s.categoryRepo.Reparent(ctx, userID, source.ID, &target.ID); err != nil {
+			return err
+		}
+		return s.categoryRepo.Delete(ctx, userID, source.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// MoveTransactions troca a categoria dos lançamentos de sourceID para targetID,
+// opcionalmente só no período, e devolve quantas pernas foram alteradas
+func (s *CategoryService) MoveTransactions(ctx context.Context, userID, sourceID, targetID string, from, to *time.Time) (int64, error) {
+	if _, err := s.categoryRepo.GetByID(ctx, userID, sourceID); err != nil {
+		return 0, err
+	}
+	target, err := s.categoryRepo.GetByID(ctx, userID, targetID)
+	if err != nil {
+		return 0, err
+	}
+	if target.Archived {
+		return 0, fmt.Errorf("%w: target category is archived", errors.ErrInvalidInput)
+	}
+	if sourceID == targetID {
+		return 0, nil
+	}
+
+	return s.categoryRepo.ReassignPostings(ctx, userID, []string{sourceID}, targetID, from, to)
+}
+
+// BuildCategoryTree monta a árvore a partir da lista plana. Categorias cujo pai
+// não está na lista (por exemplo, filtrado por arquivamento) sobem para a raiz.
+func BuildCategoryTree(categories []entities.Category) []entities.Category {
+	children := make(map[string][]entities.Category)
+	present := make(map[string]bool, len(categories))
+	for _, c := range categories {
+		present[c.ID] = true
+	}
+
+	var roots []entities.Category
+	for _, c := range categories {
+		if c.ParentID != nil && present[*c.ParentID] {
+			children[*c.ParentID] = append(children[*c.ParentID], c)
+		} else {
+			roots = append(roots, c)
+		}
+	}
+
+	var attach func(nodes []entities.Category) []entities.Category
+	attach = func(nodes []entities.Category) []entities.Category {
+		sort.SliceStable(nodes, func(i, j int) bool {
+			if nodes[i].Type != nodes[j].Type {
+				return nodes[i].Type == enums.ExpenseCategory
+			}
+			return strings.ToLower(nodes[i].Name) < strings.ToLower(nodes[j].Name)
+		})
+		for i := range nodes {
+			nodes[i].Children = attach(children[nodes[i].ID])
+		}
+		return nodes
+	}
+	return attach(roots)
+}
+
+func indexCategories(categories []entities.Category) map[string]*entities.Category {
+	byID := make(map[string]*entities.Category, len(categories))
+	for i := range categories {
+		byID[categories[i].ID] = &categories[i]
+	}
+	return byID
+}
+
+// categoryPaths devolve o caminho completo de cada categoria ("Casa:Luz"),
+// como QIF e outros programas identificam subcategorias
+func categoryPaths(categories []entities.Category) map[string]string {
+	byID := indexCategories(categories)
+	paths := make(map[string]string, len(categories))
+
+	var path func(c *entities.Category, depth int) string
+	path = func(c *entities.Category, depth int) string {
+		if p, ok := paths[c.ID]; ok {
+			return p
+		}
+		p := c.Name
+		if c.ParentID != nil && depth < len(byID) {
+			if parent, ok := byID[*c.ParentID]; ok {
+				p = path(parent, depth+1) + ":" + c.Name
+			}
+		}
+		paths[c.ID] = p
+		return p
+	}
+	for i := range categories {
+		path(&categories[i], 0)
+	}
+	return paths
+}
+
+// isInSubtree informa se id é rootID ou descende dele
+func isInSubtree(byID map[string]*entities.Category, rootID, id string) bool {
+	for steps := 0; steps <= len(byID); steps++ {
+		if id == rootID {
+			return true
+		}
+		node, ok := byID[id]
+		if !ok || node.ParentID == nil {
+			return false
+		}
+		id = *node.ParentID
+	}
+	return false
+}
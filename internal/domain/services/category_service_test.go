@@ -0,0 +1,49 @@
+package services
+
+import (
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"testing"
+)
+
+func TestBuildCategoryTree(t *testing.T) {
+	housing, rent, utilities, salary := "housing", "rent", "utilities", "salary"
+	categories := []entities.Category{
+		{ID: salary, Name: "Salário", Type: enums.IncomeCategory},
+		{ID: rent, ParentID: &housing, Name: "Aluguel", Type: enums.ExpenseCategory},
+		{ID: housing, Name: "Moradia", Type: enums.ExpenseCategory},
+		{ID: utilities, ParentID: &housing, Name: "Água", Type: enums.ExpenseCategory},
+		{ID: "orphan", ParentID: strPtr("archived-parent"), Name: "Órfã", Type: enums.ExpenseCategory},
+	}
+
+	tree := BuildCategoryTree(categories)
+	if len(tree) != 3 {
+		t.Fatalf("expected 3 roots, got %d", len(tree))
+	}
+	if tree[0].ID != housing || tree[1].ID != "orphan" || tree[2].ID != salary {
+		t.Fatalf("unexpected root order: %s, %s, %s", tree[0].Name, tree[1].Name, tree[2].Name)
+	}
+	if len(tree[0].Children) != 2 || tree[0].Children[0].ID != rent {
+		t.Fatalf("unexpected children: %+v", tree[0].Children)
+	}
+
+	byID := indexCategories(categories)
+	if !isInSubtree(byID, housing, rent) || isInSubtree(byID, rent, housing) || !isInSubtree(byID, housing, housing) {
+		t.Fatal("unexpected subtree membership")
+	}
+}
+
+func TestDefaultCategoryNames(t *testing.T) {
+	root := defaultCategoryTree[0]
+	if got := root.name.in("pt-BR"); got != "Moradia" {
+		t.Errorf("pt-BR = %q", got)
+	}
+	if got := root.name.in("en-US"); got != "Housing" {
+		t.Errorf("en-US = %q", got)
+	}
+	if got := root.name.in("de-DE"); got != "Housing" {
+		t.Errorf("fallback = %q", got)
+	}
+}
+
+func strPtr(s string) *string { return &s }
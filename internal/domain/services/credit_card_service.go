@@ -0,0 +1,603 @@
+package services
+
+import (
+	"context"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/internal/domain/repositories"
+	"finanvilla/pkg/errors"
+	"finanvilla/pkg/money"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CreditCardService cuida das faturas dos cartões e das compras parceladas.
+// Uma fatura é identificada pela data de fechamento: reúne o que foi lançado
+// desde o fechamento anterior (inclusive) até o dia anterior ao seu.
+type CreditCardService struct {
+	creditCardRepo     repositories.CreditCardRepository
+	accountRepo        repositories.AccountRepository
+	transactionRepo    repositories.TransactionRepository
+	transactionService *TransactionService
+	userService        *UserService
+	txManager          repositories.TransactionManager
+}
+
+func NewCreditCardService(
+	creditCardRepo repositories.CreditCardRepository,
+	accountRepo repositories.AccountRepository,
+	transactionRepo repositories.TransactionRepository,
+	transactionService *TransactionService,
+	userService *UserService,
+	txManager repositories.TransactionManager,
+) *CreditCardService {
+	return &CreditCardService{
+		creditCardRepo:     creditCardRepo,
+		accountRepo:        accountRepo,
+		transactionRepo:    transactionRepo,
+		transactionService: transactionService,
+		userService:        userService,
+		txManager:          txManager,
+	}
+}
+
+// Statements devolve o limite do cartão e as faturas, da mais recente à mais
+// antiga. A fatura aberta aparece mesmo sem lançamentos.
+func (s *CreditCardService) Statements(ctx context.Context, userID, accountID string) (*dtos.CardStatementsResponse, error) {
+	card, err := s.card(ctx, userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	today, err := s.today(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := s.creditCardRepo.StatementTotals(ctx, userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	open := statementClosing(today, *card.ClosingDay)
+	hasOpen := false
+	for _, t := range totals {
+		if t.ClosingDate.Equal(open) {
+			hasOpen = true
+		}
+	}
+	if !hasOpen {
+		zero := money.MustNew(0, card.Currency)
+		totals = append(totals, repositories.CardStatementTotals{ClosingDate: open, Charges: zero, Credits: zero})
+		sort.Slice(totals, func(i, j int) bool { return totals[i].ClosingDate.After(totals[j].ClosingDate) })
+	}
+
+	response := &dtos.CardStatementsResponse{
+		AccountID:  card.ID,
+		Currency:   card.Currency,
+		ClosingDay: *card.ClosingDay,
+		DueDay:     *card.DueDay,
+		Used:       money.MustNew(0, card.Currency),
+		Statements: make([]entities.CardStatement, len(totals)),
+	}
+	for i, t := range totals {
+		if response.Statements[i], err = cardStatement(card, t, today); err != nil {
+			return nil, err
+		}
+	}
+
+	if card.CurrentBalance.Sign() < 0 {
+		response.Used = card.CurrentBalance.Neg()
+	}
+	if card.CreditLimit != nil {
+		available, err := card.CreditLimit.Add(card.CurrentBalance)
+		if err != nil {
+			return nil, err
+		}
+		response.CreditLimit = card.CreditLimit
+		response.AvailableLimit = &available
+	}
+	return response, nil
+}
+
+// Statement devolve uma fatura com os lançamentos
+func (s *CreditCardService) Statement(ctx context.Context, userID, accountID string, closingDate time.Time) (*dtos.CardStatementResponse, error) {
+	card, err := s.card(ctx, userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkClosingDate(closingDate, *card.ClosingDay); err != nil {
+		return nil, err
+	}
+	today, err := s.today(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.creditCardRepo.StatementEntries(ctx, userID, accountID, closingDate)
+	if err != nil {
+		return nil, err
+	}
+	totals, err := statementTotals(closingDate, card.Currency, entries)
+	if err != nil {
+		return nil, err
+	}
+	statement, err := cardStatement(card, totals, today)
+	if err != nil {
+		return nil, err
+	}
+	return &dtos.CardStatementResponse{CardStatement: statement, Entries: entries}, nil
+}
+
+// PayStatement lança a transferência da conta de origem para o cartão. A
+// perna do cartão fica presa à fatura paga, qualquer que seja a data.
+func (s *CreditCardService) PayStatement(ctx context.Context, userID, accountID string, closingDate time.Time, req *dtos.PayCardStatementRequest) (*entities.Transaction, error) {
+	card, err := s.card(ctx, userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkClosingDate(closingDate, *card.ClosingDay); err != nil {
+		return nil, err
+	}
+	if req.FromAccountID == card.ID {
+		return nil, fmt.Errorf("%w: pay the statement from another account", errors.ErrInvalidInput)
+	}
+
+	amount := req.Amount
+	if amount.IsZero() {
+		entries, err := s.creditCardRepo.StatementEntries(ctx, userID, accountID, closingDate)
+		if err != nil {
+			return nil, err
+		}
+		totals, err := statementTotals(closingDate, card.Currency, entries)
+		if err != nil {
+			return nil, err
+		}
+		if amount, err = totals.Charges.Sub(totals.Credits); err != nil {
+			return nil, err
+		}
+		if amount.Sign() <= 0 {
+			return nil, fmt.Errorf("%w: the statement has nothing left to pay", errors.ErrInvalidInput)
+		}
+	}
+
+	date := req.Date
+	if date == "" {
+		today, err := s.today(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		date = today.Format("2006-01-02")
+	}
+
+	transaction, err := s.transactionService.buildTransaction(ctx, userID, &dtos.TransactionRequest{
+		Date:        date,
+		Description: "Pagamento da fatura " + card.Name + " " + closingDate.Format("01/2006"),
+		AccountID:   &req.FromAccountID,
+		ToAccountID: &card.ID,
+		Amount:      amount,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	transaction.Status = enums.PendingTransaction
+	for i := range transaction.Postings {
+		if p := &transaction.Postings[i]; p.AccountID != nil && *p.AccountID == card.ID {
+			p.StatementDate = &closingDate
+		}
+	}
+
+	if err := s.transactionRepo.Create(ctx, transaction); err != nil {
+		return nil, err
+	}
+	return s.transactionRepo.GetByID(ctx, userID, transaction.ID)
+}
+
+func (s *CreditCardService) GetPurchase(ctx context.Context, userID, id string) (*entities.InstallmentPurchase, error) {
+	return s.creditCardRepo.GetPurchase(ctx, userID, id)
+}
+
+func (s *CreditCardService) ListPurchases(ctx context.Context, userID, accountID string) ([]entities.InstallmentPurchase, error) {
+	if accountID != "" {
+		if _, err := s.accountRepo.GetByID(ctx, userID, accountID); err != nil {
+			return nil, err
+		}
+	}
+	return s.creditCardRepo.ListPurchases(ctx, userID, accountID)
+}
+
+// CreatePurchase grava a compra e lança todas as parcelas, cada uma na sua fatura
+func (s *CreditCardService) CreatePurchase(ctx context.Context, userID string, req *dtos.InstallmentPurchaseRequest) (*entities.InstallmentPurchase, error) {
+	var purchase *entities.InstallmentPurchase
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		card, err := s.card(ctx, userID, req.AccountID)
+		if err != nil {
+			return err
+		}
+		if purchase, err = buildPurchase(userID, card, req); err != nil {
+			return err
+		}
+		if err := s.checkCategory(ctx, userID, req.CategoryID, nil); err != nil {
+			return err
+		}
+
+		if err := s.creditCardRepo.CreatePurchase(ctx, purchase); err != nil {
+			return err
+		}
+		return s.createInstallments(ctx, purchase, card, req.CategoryID, purchase.TotalAmount, 1)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.creditCardRepo.GetPurchase(ctx, userID, purchase.ID)
+}
+
+// UpdatePurchase substitui a compra. Parcelas que já estão em faturas
+// fechadas (ou conciliadas) ficam como estão; o restante do total é
+// redistribuído entre as parcelas seguintes, que são lançadas de novo.
+func (s *CreditCardService) UpdatePurchase(ctx context.Context, userID, id string, req *dtos.InstallmentPurchaseRequest) (*entities.InstallmentPurchase, error) {
+	today, err := s.today(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		existing, err := s.creditCardRepo.GetPurchase(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+		if req.AccountID != existing.AccountID {
+			return fmt.Errorf("%w: an installment purchase cannot move to another card", errors.ErrInvalidInput)
+		}
+		card, err := s.card(ctx, userID, existing.AccountID)
+		if err != nil {
+			return err
+		}
+		purchase, err := buildPurchase(userID, card, req)
+		if err != nil {
+			return err
+		}
+		purchase.ID = existing.ID
+
+		billed := billedInstallments(existing.Transactions, card.ID, today)
+		remaining := purchase.TotalAmount
+		if billed > 0 {
+			if !purchase.PurchaseDate.Equal(existing.PurchaseDate) {
+				return fmt.Errorf("%w: the purchase date cannot change once an installment is billed", errors.ErrInvalidInput)
+			}
+			if req.FirstStatement == "" {
+				purchase.FirstStatement = existing.FirstStatement
+			} else if !purchase.FirstStatement.Equal(existing.FirstStatement) {
+				return fmt.Errorf("%w: the first statement cannot change once an installment is billed", errors.ErrInvalidInput)
+			}
+			if purchase.Installments < billed {
+				return fmt.Errorf("%w: %d installments are already billed", errors.ErrInvalidInput, billed)
+			}
+			for _, t := range existing.Transactions[:billed] {
+				if remaining, err = remaining.Sub(installmentAmount(t, card.ID)); err != nil {
+					return err
+				}
+			}
+		}
+		switch left := purchase.Installments - billed; {
+		case left == 0 && !remaining.IsZero():
+			return fmt.Errorf("%w: every installment is billed; the total can no longer change", errors.ErrInvalidInput)
+		case left > 0 && remaining.Sign() <= 0:
+			return fmt.Errorf("%w: the billed installments already reach the total amount", errors.ErrInvalidInput)
+		}
+
+		var previous *entities.Transaction
+		if len(existing.Transactions) > 0 {
+			previous = &existing.Transactions[0]
+		}
+		if err := s.checkCategory(ctx, userID, req.CategoryID, previous); err != nil {
+			return err
+		}
+
+		for _, t := range existing.Transactions[billed:] {
+			if t.Status == enums.ReconciledTransaction {
+				return errors.ErrTransactionLocked
+			}
+			if err := s.transactionRepo.Delete(ctx, userID, t.ID); err != nil {
+				return err
+			}
+		}
+		if err := s.creditCardRepo.UpdatePurchase(ctx, purchase); err != nil {
+			return err
+		}
+		if purchase.Installments == billed {
+			return nil
+		}
+		return s.createInstallments(ctx, purchase, card, req.CategoryID, remaining, billed+1)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.creditCardRepo.GetPurchase(ctx, userID, id)
+}
+
+// DeletePurchase remove a compra com todas as parcelas
+func (s *CreditCardService) DeletePurchase(ctx context.Context, userID, id string) error {
+	return s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		purchase, err := s.creditCardRepo.GetPurchase(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+		for _, t := range purchase.Transactions {
+			if t.Status == enums.ReconciledTransaction {
+				return errors.ErrTransactionLocked
+			}
+		}
+		return s.creditCardRepo.DeletePurchase(ctx, userID, id)
+	})
+}
+
+func (s *CreditCardService) createInstallments(ctx context.Context, purchase *entities.InstallmentPurchase, card *entities.Account, categoryID *string, amount money.Money, from int) error {
+	plan, err := planInstallments(purchase, *card.ClosingDay, amount, from)
+	if err != nil {
+		return err
+	}
+	for _, inst := range plan {
+		number, statement := inst.Number, inst.Statement
+		transaction := &entities.Transaction{
+			UserID:                purchase.UserID,
+			Date:                  inst.Date,
+			Description:           fmt.Sprintf("%s (%d/%d)", purchase.Description, inst.Number, purchase.Installments),
+			Payee:                 purchase.Payee,
+			Notes:                 purchase.Notes,
+			Status:                enums.PendingTransaction,
+			Currency:              purchase.Currency,
+			InstallmentPurchaseID: &purchase.ID,
+			InstallmentNumber:     &number,
+			Postings: []entities.Posting{
+				{AccountID: &card.ID, Amount: inst.Amount.Neg(), StatementDate: &statement},
+				{CategoryID: categoryID, Amount: inst.Amount},
+			},
+		}
+		if err := s.transactionRepo.Create(ctx, transaction); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *CreditCardService) checkCategory(ctx context.Context, userID string, categoryID *string, previous *entities.Transaction) error {
+	if categoryID == nil {
+		return nil
+	}
+	return s.transactionService.checkCategories(ctx, userID, []entities.Posting{{CategoryID: categoryID}}, previous)
+}
+
+// card carrega a conta e confere que é um cartão com fechamento e vencimento definidos
+func (s *CreditCardService) card(ctx context.Context, userID, accountID string) (*entities.Account, error) {
+	account, err := s.accountRepo.GetByID(ctx, userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.Type != enums.CreditCardAccount {
+		return nil, fmt.Errorf("%w: account %q is not a credit card", errors.ErrInvalidInput, account.Name)
+	}
+	if account.ClosingDay == nil || account.DueDay == nil {
+		return nil, fmt.Errorf("%w: set the card's closingDay and dueDay first", errors.ErrInvalidInput)
+	}
+	return account, nil
+}
+
+func (s *CreditCardService) today(ctx context.Context, userID string) (time.Time, error) {
+	user, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	y, m, d := time.Now().In(FormatterFor(user).Location()).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC), nil
+}
+
+func buildPurchase(userID string, card *entities.Account, req *dtos.InstallmentPurchaseRequest) (*entities.InstallmentPurchase, error) {
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, fmt.Errorf("%w: date must be YYYY-MM-DD", errors.ErrInvalidInput)
+	}
+	description := strings.TrimSpace(req.Description)
+	if description == "" {
+		return nil, fmt.Errorf("%w: description is required", errors.ErrInvalidInput)
+	}
+	if req.Installments < 2 {
+		return nil, fmt.Errorf("%w: an installment purchase needs at least 2 installments", errors.ErrInvalidInput)
+	}
+	total, err := req.TotalAmount.WithCurrency(card.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: total amount: %v", errors.ErrInvalidInput, err)
+	}
+	if total.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: total amount must be positive", errors.ErrInvalidInput)
+	}
+
+	first := statementClosing(date, *card.ClosingDay)
+	if req.FirstStatement != "" {
+		if first, err = time.Parse("2006-01-02", req.FirstStatement); err != nil {
+			return nil, fmt.Errorf("%w: firstStatement must be YYYY-MM-DD", errors.ErrInvalidInput)
+		}
+		if err := checkClosingDate(first, *card.ClosingDay); err != nil {
+			return nil, err
+		}
+		if !first.After(date) {
+			return nil, fmt.Errorf("%w: the first statement must close after the purchase date", errors.ErrInvalidInput)
+		}
+	}
+
+	return &entities.InstallmentPurchase{
+		UserID:         userID,
+		AccountID:      card.ID,
+		Description:    description,
+		Payee:          strings.TrimSpace(req.Payee),
+		Notes:          req.Notes,
+		PurchaseDate:   date,
+		TotalAmount:    total,
+		Installments:   req.Installments,
+		FirstStatement: first,
+		Currency:       card.Currency,
+	}, nil
+}
+
+// cardStatement calcula saldo devido e status da fatura. Ela fica aberta até
+// a véspera do fechamento; fechada, está paga quando os créditos cobrem os
+// gastos.
+func cardStatement(card *entities.Account, totals repositories.CardStatementTotals, today time.Time) (entities.CardStatement, error) {
+	due, err := totals.Charges.Sub(totals.Credits)
+	if err != nil {
+		return entities.CardStatement{}, err
+	}
+	if due.Sign() < 0 {
+		due = money.MustNew(0, card.Currency)
+	}
+
+	statement := entities.CardStatement{
+		ClosingDate: totals.ClosingDate,
+		DueDate:     statementDueDate(totals.ClosingDate, *card.ClosingDay, *card.DueDay),
+		Entries:     totals.Entries,
+		Charges:     totals.Charges,
+		Credits:     totals.Credits,
+		AmountDue:   due,
+	}
+	switch {
+	case today.Before(totals.ClosingDate):
+		statement.Status = enums.OpenStatement
+	case due.IsZero():
+		statement.Status = enums.PaidStatement
+	default:
+		statement.Status = enums.ClosedStatement
+		statement.Overdue = today.After(statement.DueDate)
+	}
+	return statement, nil
+}
+
+func statementTotals(closingDate time.Time, currency string, entries []entities.CardStatementEntry) (repositories.CardStatementTotals, error) {
+	totals := repositories.CardStatementTotals{
+		ClosingDate: closingDate,
+		Entries:     len(entries),
+		Charges:     money.MustNew(0, currency),
+		Credits:     money.MustNew(0, currency),
+	}
+	for _, e := range entries {
+		var err error
+		if e.Amount.Sign() < 0 {
+			totals.Charges, err = totals.Charges.Add(e.Amount.Neg())
+		} else {
+			totals.Credits, err = totals.Credits.Add(e.Amount)
+		}
+		if err != nil {
+			return totals, err
+		}
+	}
+	return totals, nil
+}
+
+// installment é uma parcela a lançar
+type installment struct {
+	Number    int
+	Date      time.Time
+	Statement time.Time
+	Amount    money.Money
+}
+
+// planInstallments reparte amount entre as parcelas from até
+// purchase.Installments. A parcela n cai na fatura n-1 meses depois da
+// primeira e é datada no fechamento anterior, dia em que essa fatura começa;
+// a primeira fica com a data da compra. Os centavos que sobram da divisão vão
+// para as primeiras parcelas.
+func planInstallments(purchase *entities.InstallmentPurchase, closingDay int, amount money.Money, from int) ([]installment, error) {
+	parts, err := amount.Split(purchase.Installments - from + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := make([]installment, len(parts))
+	for i, part := range parts {
+		n := from + i
+		date := purchase.PurchaseDate
+		if n > 1 {
+			date = addStatements(purchase.FirstStatement, n-2, closingDay)
+		}
+		plan[i] = installment{
+			Number:    n,
+			Date:      date,
+			Statement: addStatements(purchase.FirstStatement, n-1, closingDay),
+			Amount:    part,
+		}
+	}
+	return plan, nil
+}
+
+// billedInstallments conta as parcelas, a partir da primeira, que já estão
+// em faturas fechadas ou foram conciliadas
+func billedInstallments(transactions []entities.Transaction, cardID string, today time.Time) int {
+	billed := 0
+	for _, t := range transactions {
+		closed := false
+		for _, p := range t.Postings {
+			if p.AccountID != nil && *p.AccountID == cardID && p.StatementDate != nil {
+				closed = !today.Before(*p.StatementDate)
+			}
+		}
+		if !closed && t.Status != enums.ReconciledTransaction {
+			break
+		}
+		billed++
+	}
+	return billed
+}
+
+// installmentAmount é o valor cobrado no cartão pela parcela
+func installmentAmount(t entities.Transaction, cardID string) money.Money {
+	amount := money.MustNew(0, t.Currency)
+	for _, p := range t.Postings {
+		if p.AccountID != nil && *p.AccountID == cardID {
+			if sum, err := amount.Sub(p.Amount); err == nil {
+				amount = sum
+			}
+		}
+	}
+	return amount
+}
+
+// statementClosing devolve o fechamento da fatura em que cai um lançamento
+// de date; espelha a função card_statement_date do banco
+func statementClosing(date time.Time, closingDay int) time.Time {
+	closing := closingDate(date.Year(), date.Month(), closingDay)
+	if date.Before(closing) {
+		return closing
+	}
+	return closingDate(date.Year(), date.Month()+1, closingDay)
+}
+
+// addStatements avança n faturas a partir do fechamento closing
+func addStatements(closing time.Time, n, closingDay int) time.Time {
+	return closingDate(closing.Year(), closing.Month()+time.Month(n), closingDay)
+}
+
+// statementDueDate é o primeiro dia de vencimento depois do fechamento: no
+// mesmo mês quando o vencimento é posterior ao dia de fechamento, senão no
+// mês seguinte
+func statementDueDate(closing time.Time, closingDay, dueDay int) time.Time {
+	if dueDay > closingDay {
+		return closingDate(closing.Year(), closing.Month(), dueDay)
+	}
+	return closingDate(closing.Year(), closing.Month()+1, dueDay)
+}
+
+// closingDate é o dia day do mês, limitado ao último dia
+func closingDate(year int, month time.Month, day int) time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+func checkClosingDate(date time.Time, closingDay int) error {
+	if !date.Equal(closingDate(date.Year(), date.Month(), closingDay)) {
+		return fmt.Errorf("%w: %s is not a closing date of this card (closing day %d)", errors.ErrInvalidInput, date.Format("2006-01-02"), closingDay)
+	}
+	return nil
+}
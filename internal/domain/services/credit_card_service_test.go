@@ -0,0 +1,153 @@
+package services
+
+import (
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/internal/domain/repositories"
+	"finanvilla/pkg/money"
+	"testing"
+	"time"
+)
+
+func mustDate(s string) time.Time {
+	d, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func TestStatementClosing(t *testing.T) {
+	for _, tc := range []struct {
+		date       string
+		closingDay int
+		want       string
+	}{
+		{"2026-03-04", 5, "2026-03-05"},
+		// O dia do fechamento já entra na fatura seguinte
+		{"2026-03-05", 5, "2026-04-05"},
+		{"2026-12-20", 5, "2027-01-05"},
+		// Dia 31 vira o último dia dos meses mais curtos
+		{"2026-02-10", 31, "2026-02-28"},
+		{"2026-02-28", 31, "2026-03-31"},
+		{"2028-02-28", 30, "2028-02-29"},
+	} {
+		if got := statementClosing(mustDate(tc.date), tc.closingDay); !got.Equal(mustDate(tc.want)) {
+			t.Errorf("statementClosing(%s, %d) = %s, want %s", tc.date, tc.closingDay, got.Format("2006-01-02"), tc.want)
+		}
+	}
+
+	if got := statementDueDate(mustDate("2026-03-05"), 5, 12); !got.Equal(mustDate("2026-03-12")) {
+		t.Errorf("due date = %s, want 2026-03-12", got.Format("2006-01-02"))
+	}
+	if got := statementDueDate(mustDate("2026-01-28"), 28, 5); !got.Equal(mustDate("2026-02-05")) {
+		t.Errorf("due date = %s, want 2026-02-05", got.Format("2006-01-02"))
+	}
+}
+
+func TestPlanInstallments(t *testing.T) {
+	purchase := &entities.InstallmentPurchase{
+		PurchaseDate:   mustDate("2026-01-20"),
+		Installments:   3,
+		FirstStatement: mustDate("2026-01-31"),
+	}
+
+	plan, err := planInstallments(purchase, 31, brl(10000), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		date, statement string
+		amount          int64
+	}{
+		{"2026-01-20", "2026-01-31", 3334},
+		{"2026-01-31", "2026-02-28", 3333},
+		{"2026-02-28", "2026-03-31", 3333},
+	}
+	if len(plan) != len(want) {
+		t.Fatalf("got %d installments, want %d", len(plan), len(want))
+	}
+	for i, w := range want {
+		p := plan[i]
+		if p.Number != i+1 || !p.Date.Equal(mustDate(w.date)) || !p.Statement.Equal(mustDate(w.statement)) || p.Amount.MinorUnits() != w.amount {
+			t.Errorf("installment %d = %d %s %s %d, want %s %s %d", i+1, p.Number,
+				p.Date.Format("2006-01-02"), p.Statement.Format("2006-01-02"), p.Amount.MinorUnits(), w.date, w.statement, w.amount)
+		}
+	}
+
+	// Reprogramação a partir da terceira parcela de uma compra agora em 5 vezes
+	purchase.Installments = 5
+	plan, err = planInstallments(purchase, 31, brl(5000), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan) != 3 || plan[0].Number != 3 || !plan[2].Statement.Equal(mustDate("2026-05-31")) || plan[0].Amount.MinorUnits() != 1667 {
+		t.Errorf("unexpected re-flow: %+v", plan)
+	}
+}
+
+func TestBilledInstallments(t *testing.T) {
+	card := "card"
+	installment := func(statement string, status enums.TransactionStatus) entities.Transaction {
+		closing := mustDate(statement)
+		return entities.Transaction{
+			Status:   status,
+			Currency: "BRL",
+			Postings: []entities.Posting{{AccountID: &card, Amount: brl(-1000), StatementDate: &closing}},
+		}
+	}
+	transactions := []entities.Transaction{
+		installment("2026-01-31", enums.ClearedTransaction),
+		installment("2026-02-28", enums.PendingTransaction),
+		installment("2026-03-31", enums.PendingTransaction),
+	}
+
+	if got := billedInstallments(transactions, card, mustDate("2026-02-10")); got != 1 {
+		t.Errorf("billed = %d, want 1", got)
+	}
+	// A fatura de fevereiro fecha no próprio dia 28
+	if got := billedInstallments(transactions, card, mustDate("2026-02-28")); got != 2 {
+		t.Errorf("billed = %d, want 2", got)
+	}
+	if got := installmentAmount(transactions[0], card); got.MinorUnits() != 1000 {
+		t.Errorf("installment amount = %d, want 1000", got.MinorUnits())
+	}
+}
+
+func TestCardStatementStatus(t *testing.T) {
+	closing, due := 5, 12
+	card := &entities.Account{Currency: "BRL", ClosingDay: &closing, DueDay: &due}
+	totals := func(charges, credits int64) repositories.CardStatementTotals {
+		return repositories.CardStatementTotals{
+			ClosingDate: mustDate("2026-03-05"),
+			Charges:     money.MustNew(charges, "BRL"),
+			Credits:     money.MustNew(credits, "BRL"),
+		}
+	}
+
+	for _, tc := range []struct {
+		name    string
+		totals  repositories.CardStatementTotals
+		today   string
+		status  enums.CardStatementStatus
+		overdue bool
+		due     int64
+	}{
+		{"open", totals(50000, 0), "2026-03-04", enums.OpenStatement, false, 50000},
+		{"closed", totals(50000, 20000), "2026-03-05", enums.ClosedStatement, false, 30000},
+		{"overdue", totals(50000, 20000), "2026-03-13", enums.ClosedStatement, true, 30000},
+		{"paid", totals(50000, 50000), "2026-03-13", enums.PaidStatement, false, 0},
+		{"overpaid", totals(50000, 60000), "2026-03-13", enums.PaidStatement, false, 0},
+	} {
+		statement, err := cardStatement(card, tc.totals, mustDate(tc.today))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if statement.Status != tc.status || statement.Overdue != tc.overdue || statement.AmountDue.MinorUnits() != tc.due {
+			t.Errorf("%s: got %s overdue=%v due=%d", tc.name, statement.Status, statement.Overdue, statement.AmountDue.MinorUnits())
+		}
+		if !statement.DueDate.Equal(mustDate("2026-03-12")) {
+			t.Errorf("%s: due date %s", tc.name, statement.DueDate.Format("2006-01-02"))
+		}
+	}
+}
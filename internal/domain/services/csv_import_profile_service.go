@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/repositories"
+	"finanvilla/pkg/errors"
+	"fmt"
+	"strings"
+)
+
+type CSVImportProfileService struct {
+	profileRepo repositories.CSVImportProfileRepository
+}
+
+func NewCSVImportProfileService(profileRepo repositories.CSVImportProfileRepository) *CSVImportProfileService {
+	return &CSVImportProfileService{profileRepo: profileRepo}
+}
+
+func (s *CSVImportProfileService) Create(ctx context.Context, userID string, req *dtos.CSVImportProfileRequest) (*entities.CSVImportProfile, error) {
+	profile, err := buildCSVProfile(userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.profileRepo.Create(ctx, profile); err != nil {
+		return nil, err
+	}
+	return s.profileRepo.GetByID(ctx, userID, profile.ID)
+}
+
+func (s *CSVImportProfileService) Update(ctx context.Context, userID, id string, req *dtos.CSVImportProfileRequest) (*entities.CSVImportProfile, error) {
+	profile, err := buildCSVProfile(userID, req)
+	if err != nil {
+		return nil, err
+	}
+	profile.ID = id
+
+	if err := s.profileRepo.Update(ctx, profile); err != nil {
+		return nil, err
+	}
+	return s.profileRepo.GetByID(ctx, userID, id)
+}
+
+func (s *CSVImportProfileService) Delete(ctx context.Context, userID, id string) error {
+	return s.profileRepo.Delete(ctx, userID, id)
+}
+
+func (s *CSVImportProfileService) GetByID(ctx context.Context, userID, id string) (*entities.CSVImportProfile, error) {
+	return s.profileRepo.GetByID(ctx, userID, id)
+}
+
+func (s *CSVImportProfileService) List(ctx context.Context, userID string) ([]entities.CSVImportProfile, error) {
+	return s.profileRepo.List(ctx, userID)
+}
+
+func buildCSVProfile(userID string, req *dtos.CSVImportProfileRequest) (*entities.CSVImportProfile, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", errors.ErrInvalidInput)
+	}
+	if err := validateCSVMapping(&req.CSVMapping); err != nil {
+		return nil, err
+	}
+
+	// O delimitador é gravado como o próprio caractere
+	dialect, _ := csvDialect(&req.CSVMapping)
+	delimiter := ""
+	if dialect.Delimiter != 0 {
+		delimiter = string(dialect.Delimiter)
+	}
+
+	return &entities.CSVImportProfile{
+		UserID:            userID,
+		Name:              name,
+		Institution:       strings.TrimSpace(req.Institution),
+		Delimiter:         delimiter,
+		Encoding:          string(dialect.Encoding),
+		HasHeader:         req.HasHeader == nil || *req.HasHeader,
+		SkipRows:          req.SkipRows,
+		DateColumn:        strings.TrimSpace(req.DateColumn),
+		DescriptionColumn: strings.TrimSpace(req.DescriptionColumn),
+		AmountColumn:      strings.TrimSpace(req.AmountColumn),
+		DebitColumn:       strings.TrimSpace(req.DebitColumn),
+		CreditColumn:      strings.TrimSpace(req.CreditColumn),
+		PayeeColumn:       strings.TrimSpace(req.PayeeColumn),
+		MemoColumn:        strings.TrimSpace(req.MemoColumn),
+		ExternalIDColumn:  strings.TrimSpace(req.ExternalIDColumn),
+		DateFormat:        req.DateFormat,
+		NumberFormat:      req.NumberFormat,
+		InvertSign:        req.InvertSign,
+	}, nil
+}
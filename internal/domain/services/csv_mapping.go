@@ -0,0 +1,215 @@
+package services
+
+import (
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/pkg/csvimport"
+	"finanvilla/pkg/errors"
+	"finanvilla/pkg/locale"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Formatos tentados, nessa ordem, ao sugerir o formato de data de um arquivo
+var csvDateFormats = []string{"DD/MM/YYYY", "YYYY-MM-DD", "DD.MM.YYYY", "DD-MM-YYYY", "MM/DD/YYYY"}
+
+const (
+	defaultCSVDateFormat   = "DD/MM/YYYY"
+	defaultCSVNumberFormat = locale.NumberFormatCommaDecimal
+)
+
+// validateCSVMapping confere o mapeamento sem depender do arquivo; nomes de
+// coluna só são conferidos contra o cabeçalho na importação
+func validateCSVMapping(m *dtos.CSVMapping) error {
+	if _, err := csvDialect(m); err != nil {
+		return err
+	}
+
+	switch {
+	case strings.TrimSpace(m.DateColumn) == "" || strings.TrimSpace(m.DescriptionColumn) == "":
+		return fmt.Errorf("%w: dateColumn and descriptionColumn are required", errors.ErrInvalidInput)
+	case m.AmountColumn == "" && m.DebitColumn == "" && m.CreditColumn == "":
+		return fmt.Errorf("%w: amountColumn or debitColumn/creditColumn is required", errors.ErrInvalidInput)
+	case m.AmountColumn != "" && (m.DebitColumn != "" || m.CreditColumn != ""):
+		return fmt.Errorf("%w: use either amountColumn or debitColumn/creditColumn", errors.ErrInvalidInput)
+	case m.DateFormat != "" && locale.ValidateDateFormat(m.DateFormat) != nil:
+		return fmt.Errorf("%w: unsupported dateFormat %q", errors.ErrInvalidInput, m.DateFormat)
+	case m.NumberFormat != "" && locale.ValidateNumberFormat(m.NumberFormat) != nil:
+		return fmt.Errorf("%w: unsupported numberFormat %q", errors.ErrInvalidInput, m.NumberFormat)
+	}
+	return nil
+}
+
+func csvDialect(m *dtos.CSVMapping) (csvimport.Dialect, error) {
+	var d csvimport.Dialect
+
+	if m.Encoding != "" {
+		d.Encoding = csvimport.Encoding(strings.ToUpper(m.Encoding))
+		if d.Encoding == "LATIN1" || d.Encoding == "ISO-8859-1" {
+			d.Encoding = csvimport.Windows1252
+		}
+		if !d.Encoding.IsValid() {
+			return d, fmt.Errorf("%w: unsupported encoding %q", errors.ErrInvalidInput, m.Encoding)
+		}
+	}
+
+	switch m.Delimiter {
+	case "":
+	case "tab", `\t`, "\t":
+		d.Delimiter = '\t'
+	case ";", ",", "|":
+		d.Delimiter = rune(m.Delimiter[0])
+	default:
+		return d, fmt.Errorf("%w: delimiter must be one of ; , | tab", errors.ErrInvalidInput)
+	}
+	return d, nil
+}
+
+// buildCSVMapping completa o mapeamento com as preferências do usuário e o
+// converte para o pacote csvimport
+func buildCSVMapping(m *dtos.CSVMapping, settings *entities.UserSettings) (csvimport.Mapping, csvimport.Dialect, error) {
+	if err := validateCSVMapping(m); err != nil {
+		return csvimport.Mapping{}, csvimport.Dialect{}, err
+	}
+	dialect, _ := csvDialect(m)
+
+	dateFormat, numberFormat := m.DateFormat, m.NumberFormat
+	if settings != nil {
+		if dateFormat == "" {
+			dateFormat = settings.DateFormat
+		}
+		if numberFormat == "" {
+			numberFormat = settings.NumberFormat
+		}
+	}
+	if dateFormat == "" {
+		dateFormat = defaultCSVDateFormat
+	}
+	if numberFormat == "" {
+		numberFormat = defaultCSVNumberFormat
+	}
+
+	layout, err := locale.DateLayout(dateFormat)
+	if err != nil {
+		return csvimport.Mapping{}, dialect, fmt.Errorf("%w: unsupported dateFormat %q", errors.ErrInvalidInput, dateFormat)
+	}
+	decimal, err := locale.DecimalSeparator(numberFormat)
+	if err != nil {
+		return csvimport.Mapping{}, dialect, fmt.Errorf("%w: unsupported numberFormat %q", errors.ErrInvalidInput, numberFormat)
+	}
+
+	return csvimport.Mapping{
+		HasHeader:   m.HasHeader == nil || *m.HasHeader,
+		SkipRows:    m.SkipRows,
+		Date:        m.DateColumn,
+		Description: m.DescriptionColumn,
+		Amount:      m.AmountColumn,
+		Debit:       m.DebitColumn,
+		Credit:      m.CreditColumn,
+		Payee:       m.PayeeColumn,
+		Memo:        m.MemoColumn,
+		ExternalID:  m.ExternalIDColumn,
+		DateLayout:  layout,
+		Decimal:     decimal,
+		InvertSign:  m.InvertSign,
+	}, dialect, nil
+}
+
+func profileMapping(p *entities.CSVImportProfile) dtos.CSVMapping {
+	hasHeader := p.HasHeader
+	return dtos.CSVMapping{
+		Delimiter:         p.Delimiter,
+		Encoding:          p.Encoding,
+		HasHeader:         &hasHeader,
+		SkipRows:          p.SkipRows,
+		DateColumn:        p.DateColumn,
+		DescriptionColumn: p.DescriptionColumn,
+		AmountColumn:      p.AmountColumn,
+		DebitColumn:       p.DebitColumn,
+		CreditColumn:      p.CreditColumn,
+		PayeeColumn:       p.PayeeColumn,
+		MemoColumn:        p.MemoColumn,
+		ExternalIDColumn:  p.ExternalIDColumn,
+		DateFormat:        p.DateFormat,
+		NumberFormat:      p.NumberFormat,
+		InvertSign:        p.InvertSign,
+	}
+}
+
+// suggestCSVMapping propõe colunas pelos nomes do cabeçalho e formatos de
+// data e número pelos valores das primeiras linhas
+func suggestCSVMapping(header []string, rows [][]string) dtos.CSVMapping {
+	s := csvimport.Suggest(header)
+	hasHeader := true
+	m := dtos.CSVMapping{
+		HasHeader:         &hasHeader,
+		DateColumn:        s.Date,
+		DescriptionColumn: s.Description,
+		AmountColumn:      s.Amount,
+		DebitColumn:       s.Debit,
+		CreditColumn:      s.Credit,
+		PayeeColumn:       s.Payee,
+		ExternalIDColumn:  s.ExternalID,
+	}
+
+	column := func(name string) []string {
+		var values []string
+		for i, h := range header {
+			if h != name || name == "" {
+				continue
+			}
+			for _, row := range rows {
+				if i < len(row) && row[i] != "" {
+					values = append(values, row[i])
+				}
+			}
+		}
+		return values
+	}
+
+	m.DateFormat = guessDateFormat(column(s.Date))
+	m.NumberFormat = guessNumberFormat(append(column(s.Amount), append(column(s.Debit), column(s.Credit)...)...))
+	return m
+}
+
+func guessDateFormat(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	for _, format := range csvDateFormats {
+		layout, _ := locale.DateLayout(format)
+		ok := true
+		for _, v := range values {
+			if len(v) < len(layout) {
+				ok = false
+				break
+			}
+			if _, err := time.Parse(layout, v[:len(layout)]); err != nil {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return format
+		}
+	}
+	return ""
+}
+
+// guessNumberFormat olha o último separador de cada valor: seguido de um ou
+// dois dígitos, ele é o decimal
+func guessNumberFormat(values []string) string {
+	for _, v := range values {
+		v = strings.TrimRight(v, " DdCc")
+		i := strings.LastIndexAny(v, ",.")
+		if i < 0 || len(v)-i-1 > 2 || len(v)-i-1 == 0 {
+			continue
+		}
+		if v[i] == ',' {
+			return locale.NumberFormatCommaDecimal
+		}
+		return locale.NumberFormatDotDecimal
+	}
+	return ""
+}
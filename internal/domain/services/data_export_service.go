@@ -0,0 +1,184 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/repositories"
+	"fmt"
+	"io"
+	"path"
+	"time"
+)
+
+// DataExportService monta o arquivo com os dados do usuário: contas,
+// categorias, lançamentos e anexos com o conteúdo original
+type DataExportService struct {
+	userService     *UserService
+	accountRepo     repositories.AccountRepository
+	categoryRepo    repositories.CategoryRepository
+	transactionRepo repositories.TransactionRepository
+	attachmentRepo  repositories.AttachmentRepository
+	attachments     *AttachmentService
+}
+
+func NewDataExportService(
+	userService *UserService,
+	accountRepo repositories.AccountRepository,
+	categoryRepo repositories.CategoryRepository,
+	transactionRepo repositories.TransactionRepository,
+	attachmentRepo repositories.AttachmentRepository,
+	attachments *AttachmentService,
+) *DataExportService {
+	return &DataExportService{
+		userService:     userService,
+		accountRepo:     accountRepo,
+		categoryRepo:    categoryRepo,
+		transactionRepo: transactionRepo,
+		attachmentRepo:  attachmentRepo,
+		attachments:     attachments,
+	}
+}
+
+// DataExport guarda o que é pequeno e já foi lido; lançamentos e conteúdo dos
+// anexos são lidos durante Write
+type DataExport struct {
+	User *entities.User
+
+	s           *DataExportService
+	accounts    []entities.Account
+	categories  []entities.Category
+	attachments []entities.Attachment
+}
+
+func (s *DataExportService) Export(ctx context.Context, userID string) (*DataExport, error) {
+	user, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	accounts, err := s.accountRepo.List(ctx, userID, repositories.AccountFilter{IncludeArchived: true})
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.categoryRepo.List(ctx, userID, repositories.CategoryFilter{IncludeArchived: true})
+	if err != nil {
+		return nil, err
+	}
+	attachments, err := s.attachmentRepo.List(ctx, userID, repositories.AttachmentFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	// Listas vazias saem como [] e não null
+	if accounts == nil {
+		accounts = []entities.Account{}
+	}
+	if categories == nil {
+		categories = []entities.Category{}
+	}
+	if attachments == nil {
+		attachments = []entities.Attachment{}
+	}
+
+	return &DataExport{
+		User:        user,
+		s:           s,
+		accounts:    accounts,
+		categories:  categories,
+		attachments: attachments,
+	}, nil
+}
+
+// Write grava o ZIP: um JSON por tipo de dado e os anexos em
+// attachments/<id>/<nome do arquivo>
+func (e *DataExport) Write(ctx context.Context, w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	for _, entry := range []struct {
+		name string
+		data interface{}
+	}{
+		{"user.json", e.User},
+		{"accounts.json", e.accounts},
+		{"categories.json", e.categories},
+		{"attachments.json", e.attachments},
+	} {
+		if err := writeJSONEntry(zw, entry.name, entry.data); err != nil {
+			return err
+		}
+	}
+
+	if err := e.writeTransactions(ctx, zw); err != nil {
+		return err
+	}
+
+	for _, a := range e.attachments {
+		body, _, err := e.s.attachments.Open(ctx, e.User.ID, a.ID, false)
+		if err != nil {
+			return err
+		}
+		f, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     path.Join("attachments", a.ID, a.Filename),
+			Method:   zip.Store, // imagens e PDFs já são comprimidos
+			Modified: a.CreatedAt,
+		})
+		if err == nil {
+			_, err = io.Copy(f, body)
+		}
+		body.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// writeTransactions grava os lançamentos em lotes, como um único array JSON
+func (e *DataExport) writeTransactions(ctx context.Context, zw *zip.Writer) error {
+	f, err := zw.CreateHeader(&zip.FileHeader{Name: "transactions.json", Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(f, "["); err != nil {
+		return err
+	}
+
+	first := true
+	for page := 1; ; page++ {
+		batch, _, err := e.s.transactionRepo.List(ctx, e.User.ID, repositories.TransactionFilter{}, page, exportBatchSize)
+		if err != nil {
+			return err
+		}
+		for i := range batch {
+			data, err := json.Marshal(&batch[i])
+			if err != nil {
+				return err
+			}
+			if !first {
+				data = append([]byte(","), data...)
+			}
+			first = false
+			if _, err := f.Write(data); err != nil {
+				return err
+			}
+		}
+		if len(batch) < exportBatchSize {
+			break
+		}
+	}
+
+	_, err = io.WriteString(f, "]")
+	return err
+}
+
+func writeJSONEntry(zw *zip.Writer, name string, data interface{}) error {
+	f, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(f).Encode(data); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/internal/domain/repositories"
+	"finanvilla/pkg/crypto"
+	"finanvilla/pkg/errors"
+	"finanvilla/pkg/mailer"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailChangeService implementa a troca de e-mail com dupla confirmação: o
+// novo endereço confirma a troca e o endereço antigo recebe um link para desfazê-la.
+type EmailChangeService struct {
+	userService      *UserService
+	tokenRepo        repositories.UserTokenRepository
+	refreshTokenRepo repositories.RefreshTokenRepository
+	txManager        repositories.TransactionManager
+	mailer           mailer.Mailer
+	baseURL          string
+	confirmTTL       time.Duration
+	revertTTL        time.Duration
+}
+
+func NewEmailChangeService(
+	userService *UserService,
+	tokenRepo repositories.UserTokenRepository,
+	refreshTokenRepo repositories.RefreshTokenRepository,
+	txManager repositories.TransactionManager,
+	mailer mailer.Mailer,
+	baseURL string,
+) *EmailChangeService {
+	return &EmailChangeService{
+		userService:      userService,
+		tokenRepo:        tokenRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		txManager:        txManager,
+		mailer:           mailer,
+		baseURL:          baseURL,
+		confirmTTL:       24 * time.Hour,     // Link de confirmação expira em 24 horas
+		revertTTL:        5 * 24 * time.Hour, // Link para desfazer expira em 5 dias
+	}
+}
+
+// RequestChange valida a senha atual e envia o link de confirmação para o novo endereço
+func (s *EmailChangeService) RequestChange(ctx context.Context, userID, newEmail, currentPassword string) error {
+	newEmail = strings.ToLower(strings.TrimSpace(newEmail))
+
+	user, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.userService.Authenticate(ctx, user.Email, currentPassword); err != nil {
+		return errors.ErrInvalidPassword
+	}
+
+	if strings.EqualFold(user.Email, newEmail) {
+		return errors.ErrSameEmail
+	}
+
+	if _, err := s.userService.GetByEmail(ctx, newEmail); err == nil {
+		return errors.ErrEmailAlreadyUsed
+	}
+
+	token, err := s.issueToken(ctx, user.ID, enums.EmailChangeToken, newEmail, s.confirmTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      newEmail,
+		Subject: "Confirme seu novo e-mail no Finanvilla",
+		Body: fmt.Sprintf(
+			"Olá, %s!\n\nRecebemos um pedido para alterar o e-mail da sua conta para este endereço. Para confirmar, use o link abaixo (válido até %s):\n\n%s/email/confirm?token=%s\n\nSe você não fez este pedido, ignore esta mensagem.\n",
+			user.Name, FormatterFor(user).FormatDateTime(time.Now().Add(s.confirmTTL)), s.baseURL, token,
+		),
+	})
+}
+
+// ConfirmChange aplica a troca, notifica o endereço antigo e encerra todas as sessões
+func (s *EmailChangeService) ConfirmChange(ctx context.Context, token string) (*entities.User, error) {
+	var user *entities.User
+	var oldEmail, revertToken string
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		change, err := s.tokenRepo.GetActiveByHash(ctx, enums.EmailChangeToken, crypto.HashToken(token))
+		if err != nil {
+			return errors.ErrInvalidToken
+		}
+
+		user, err = s.userService.GetByID(ctx, change.UserID)
+		if err != nil {
+			return err
+		}
+		oldEmail = user.Email
+
+		if err := s.userService.ChangeEmail(ctx, user.ID, change.Payload); err != nil {
+			return err
+		}
+		user.Email = change.Payload
+
+		if err := s.tokenRepo.InvalidateByUser(ctx, user.ID, enums.EmailChangeToken); err != nil {
+			return err
+		}
+
+		revertToken, err = s.issueToken(ctx, user.ID, enums.EmailRevertToken, oldEmail, s.revertTTL)
+		if err != nil {
+			return err
+		}
+
+		return s.revokeSessions(ctx, user.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// O aviso leva o único link para desfazer a troca, então vai sempre, sem
+	// passar pelas preferências de notificação. A troca já foi gravada: uma
+	// falha no envio só é registrada, para que o cliente não receba erro por
+	// uma alteração que aconteceu
+	formatter := FormatterFor(user)
+	err = s.mailer.Send(ctx, mailer.Message{
+		To:      oldEmail,
+		Subject: "O e-mail da sua conta Finanvilla foi alterado",
+		Body: fmt.Sprintf(
+			"Olá, %s!\n\nEm %s o e-mail da sua conta foi alterado para %s e todas as sessões foram encerradas.\n\nSe não foi você, desfaça a alteração pelo link abaixo (válido até %s):\n\n%s/email/revert?token=%s\n",
+			user.Name, formatter.FormatDateTime(time.Now()), user.Email, formatter.FormatDateTime(time.Now().Add(s.revertTTL)), s.baseURL, revertToken,
+		),
+	})
+	if err != nil {
+		log.Printf("Error sending email change notice to user %s: %v", user.ID, err)
+	}
+
+	user.Password = ""
+	return user, nil
+}
+
+// RevertChange restaura o e-mail anterior a partir do link enviado ao endereço antigo
+func (s *EmailChangeService) RevertChange(ctx context.Context, token string) (*entities.User, error) {
+	var user *entities.User
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		revert, err := s.tokenRepo.GetActiveByHash(ctx, enums.EmailRevertToken, crypto.HashToken(token))
+		if err != nil {
+			return errors.ErrInvalidToken
+		}
+
+		user, err = s.userService.GetByID(ctx, revert.UserID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.userService.ChangeEmail(ctx, user.ID, revert.Payload); err != nil {
+			return err
+		}
+		user.Email = revert.Payload
+
+		if err := s.tokenRepo.InvalidateByUser(ctx, user.ID, enums.EmailChangeToken); err != nil {
+			return err
+		}
+		if err := s.tokenRepo.InvalidateByUser(ctx, user.ID, enums.EmailRevertToken); err != nil {
+			return err
+		}
+
+		return s.revokeSessions(ctx, user.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	user.Password = ""
+	return user, nil
+}
+
+func (s *EmailChangeService) issueToken(ctx context.Context, userID string, purpose enums.TokenPurpose, payload string, ttl time.Duration) (string, error) {
+	token, err := crypto.GenerateToken(32)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.tokenRepo.InvalidateByUser(ctx, userID, purpose); err != nil {
+		return "", err
+	}
+
+	err = s.tokenRepo.Create(ctx, &entities.UserToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: crypto.HashToken(token),
+		Payload:   payload,
+		ExpiresAt: time.Now().Add(ttl),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (s *EmailChangeService) revokeSessions(ctx context.Context, userID string) error {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return errors.ErrInvalidUserID
+	}
+	return s.refreshTokenRepo.RevokeByUserID(ctx, id)
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/internal/domain/repositories"
+	"finanvilla/pkg/crypto"
+	"finanvilla/pkg/mailer"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+type fakeUserRepository struct {
+	repositories.UserRepository
+	users map[string]*entities.User
+}
+
+func (r *fakeUserRepository) GetByID(_ context.Context, id string) (*entities.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	found := *user
+	return &found, nil
+}
+
+func (r *fakeUserRepository) GetByEmail(_ context.Context, email string) (*entities.User, error) {
+	for _, user := range r.users {
+		if user.Email == email {
+			found := *user
+			return &found, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (r *fakeUserRepository) UpdateEmail(_ context.Context, id, email string) error {
+	r.users[id].Email = email
+	return nil
+}
+
+type fakeUserTokenRepository struct {
+	repositories.UserTokenRepository
+	tokens []*entities.UserToken
+}
+
+func (r *fakeUserTokenRepository) Create(_ context.Context, token *entities.UserToken) error {
+	r.tokens = append(r.tokens, token)
+	return nil
+}
+
+func (r *fakeUserTokenRepository) GetActiveByHash(_ context.Context, purpose enums.TokenPurpose, hash string) (*entities.UserToken, error) {
+	for _, token := range r.tokens {
+		if token.Purpose == purpose && token.TokenHash == hash {
+			return token, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (r *fakeUserTokenRepository) InvalidateByUser(_ context.Context, userID string, purpose enums.TokenPurpose) error {
+	kept := r.tokens[:0]
+	for _, token := range r.tokens {
+		if token.UserID != userID || token.Purpose != purpose {
+			kept = append(kept, token)
+		}
+	}
+	r.tokens = kept
+	return nil
+}
+
+type fakeRefreshTokenRepository struct {
+	repositories.RefreshTokenRepository
+	revoked []uuid.UUID
+}
+
+func (r *fakeRefreshTokenRepository) RevokeByUserID(_ context.Context, userID uuid.UUID) error {
+	r.revoked = append(r.revoked, userID)
+	return nil
+}
+
+type inlineTransactionManager struct{}
+
+func (inlineTransactionManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type fakeMailer struct {
+	err  error
+	sent []mailer.Message
+}
+
+func (m *fakeMailer) Send(_ context.Context, message mailer.Message) error {
+	m.sent = append(m.sent, message)
+	return m.err
+}
+
+func TestConfirmChange(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings *entities.UserSettings
+		prefs    []entities.NotificationPreference
+		inactive bool
+		mailErr  error
+	}{
+		{name: "mailer failure", mailErr: errors.New("smtp unavailable")},
+		{
+			name:     "security alerts disabled",
+			settings: &entities.UserSettings{NotificationsEnabled: false},
+			prefs:    []entities.NotificationPreference{{Event: enums.SecurityAlert, Channel: enums.EmailChannel, Enabled: false}},
+		},
+		{name: "inactive account", inactive: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID := uuid.NewString()
+			users := &fakeUserRepository{users: map[string]*entities.User{
+				userID: {ID: userID, Name: "Ana", Email: "old@example.com", Active: !tt.inactive, Settings: tt.settings, NotificationPreferences: tt.prefs},
+			}}
+			tokens := &fakeUserTokenRepository{tokens: []*entities.UserToken{{
+				UserID:    userID,
+				Purpose:   enums.EmailChangeToken,
+				TokenHash: crypto.HashToken("confirm"),
+				Payload:   "new@example.com",
+			}}}
+			sessions := &fakeRefreshTokenRepository{}
+			mail := &fakeMailer{err: tt.mailErr}
+
+			userService := NewUserService(users, nil, inlineTransactionManager{}, nil)
+			service := NewEmailChangeService(userService, tokens, sessions, inlineTransactionManager{}, mail, "https://app.example.com")
+
+			user, err := service.ConfirmChange(context.Background(), "confirm")
+			if err != nil {
+				t.Fatalf("ConfirmChange returned %v after the change was applied", err)
+			}
+			if user.Email != "new@example.com" || users.users[userID].Email != "new@example.com" {
+				t.Errorf("email = %q, stored %q; want new@example.com", user.Email, users.users[userID].Email)
+			}
+			if len(sessions.revoked) != 1 {
+				t.Errorf("sessions revoked %d times, want 1", len(sessions.revoked))
+			}
+
+			var revert *entities.UserToken
+			for _, token := range tokens.tokens {
+				if token.Purpose == enums.EmailRevertToken {
+					revert = token
+				}
+			}
+			if revert == nil || revert.Payload != "old@example.com" {
+				t.Errorf("revert token = %+v, want one restoring old@example.com", revert)
+			}
+
+			if len(mail.sent) != 1 || mail.sent[0].To != "old@example.com" || !strings.Contains(mail.sent[0].Body, "/email/revert?token=") {
+				t.Errorf("notices = %+v, want one revert link sent to old@example.com", mail.sent)
+			}
+		})
+	}
+}
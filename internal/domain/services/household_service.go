@@ -0,0 +1,638 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/internal/domain/repositories"
+	appErrors "finanvilla/pkg/errors"
+	"finanvilla/pkg/locale"
+	"finanvilla/pkg/money"
+	"finanvilla/pkg/pix"
+	"finanvilla/pkg/settleup"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// HouseholdService cuida das casas que dividem despesas: membros, despesas
+// compartilhadas, saldos entre os membros e acertos
+type HouseholdService struct {
+	householdRepo repositories.HouseholdRepository
+	expenseRepo   repositories.SharedExpenseRepository
+	userService   *UserService
+}
+
+func NewHouseholdService(
+	householdRepo repositories.HouseholdRepository,
+	expenseRepo repositories.SharedExpenseRepository,
+	userService *UserService,
+) *HouseholdService {
+	return &HouseholdService{
+		householdRepo: householdRepo,
+		expenseRepo:   expenseRepo,
+		userService:   userService,
+	}
+}
+
+func (s *HouseholdService) Create(ctx context.Context, userID string, req *dtos.CreateHouseholdRequest) (*entities.Household, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", appErrors.ErrInvalidInput)
+	}
+
+	currency := req.Currency
+	if currency == "" {
+		user, err := s.userService.GetByID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		currency = locale.Default.Currency()
+		if user.Settings != nil && user.Settings.Currency != "" {
+			currency = user.Settings.Currency
+		}
+	}
+	currency, err := locale.ParseCurrency(currency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", appErrors.ErrInvalidInput, err)
+	}
+
+	household := &entities.Household{
+		Name:      name,
+		Currency:  currency,
+		CreatedBy: &userID,
+		Members:   []entities.HouseholdMember{{UserID: userID, Status: enums.ActiveMember}},
+	}
+	if err := s.householdRepo.Create(ctx, household); err != nil {
+		return nil, err
+	}
+	return s.householdRepo.GetByID(ctx, userID, household.ID)
+}
+
+func (s *HouseholdService) Update(ctx context.Context, userID, id string, req *dtos.UpdateHouseholdRequest) (*entities.Household, error) {
+	household, err := s.householdRepo.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	household.Name = strings.TrimSpace(req.Name)
+	if household.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", appErrors.ErrInvalidInput)
+	}
+
+	if err := s.householdRepo.Update(ctx, household); err != nil {
+		return nil, err
+	}
+	return s.householdRepo.GetByID(ctx, userID, id)
+}
+
+// Delete apaga a casa com todo o histórico; só quem a criou pode fazê-lo
+func (s *HouseholdService) Delete(ctx context.Context, userID, id string) error {
+	household, err := s.householdRepo.GetByID(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if household.CreatedBy != nil && *household.CreatedBy != userID {
+		return fmt.Errorf("%w: only the creator can delete the household", appErrors.ErrForbidden)
+	}
+	return s.householdRepo.Delete(ctx, id)
+}
+
+func (s *HouseholdService) GetByID(ctx context.Context, userID, id string) (*entities.Household, error) {
+	return s.householdRepo.GetByID(ctx, userID, id)
+}
+
+func (s *HouseholdService) List(ctx context.Context, userID string) ([]entities.Household, error) {
+	return s.householdRepo.List(ctx, userID)
+}
+
+// InviteMember convida para a casa o usuário com o e-mail. O convidado só vira
+// membro, e passa a entrar nas despesas, quando aceitar. E-mail sem cadastro,
+// membro atual ou convite repetido não são erro: a resposta é sempre a mesma,
+// para não revelar quais e-mails estão cadastrados.
+func (s *HouseholdService) InviteMember(ctx context.Context, userID, id string, req *dtos.AddHouseholdMemberRequest) error {
+	if _, err := s.householdRepo.GetByID(ctx, userID, id); err != nil {
+		return err
+	}
+
+	user, err := s.userService.GetByEmail(ctx, strings.ToLower(strings.TrimSpace(req.Email)))
+	if err == nil {
+		err = s.householdRepo.AddMember(ctx, &entities.HouseholdMember{
+			HouseholdID: id,
+			UserID:      user.ID,
+			Status:      enums.PendingMember,
+			InvitedBy:   &userID,
+		})
+	}
+	if err != nil && !errors.Is(err, appErrors.ErrUserNotFound) && !errors.Is(err, appErrors.ErrAlreadyMember) {
+		return err
+	}
+	return nil
+}
+
+// ListInvitations traz as casas com convite pendente para o usuário
+func (s *HouseholdService) ListInvitations(ctx context.Context, userID string) ([]entities.Household, error) {
+	return s.householdRepo.ListInvitations(ctx, userID)
+}
+
+func (s *HouseholdService) AcceptInvitation(ctx context.Context, userID, id string) (*entities.Household, error) {
+	if err := s.householdRepo.AcceptInvitation(ctx, id, userID); err != nil {
+		return nil, err
+	}
+	return s.householdRepo.GetByID(ctx, userID, id)
+}
+
+func (s *HouseholdService) DeclineInvitation(ctx context.Context, userID, id string) error {
+	return s.householdRepo.DeclineInvitation(ctx, id, userID)
+}
+
+// UpdateMember grava a chave Pix do próprio usuário na casa
+func (s *HouseholdService) UpdateMember(ctx context.Context, userID, id string, req *dtos.UpdateHouseholdMemberRequest) (*entities.Household, error) {
+	if _, err := s.householdRepo.GetByID(ctx, userID, id); err != nil {
+		return nil, err
+	}
+
+	member := &entities.HouseholdMember{HouseholdID: id, UserID: userID}
+	if key := strings.TrimSpace(req.PixKey); key != "" {
+		normalized, _, err := pix.NormalizeKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", appErrors.ErrInvalidInput, err)
+		}
+		member.PixKey = normalized
+		member.PixCity = strings.TrimSpace(req.PixCity)
+		if member.PixCity == "" {
+			return nil, fmt.Errorf("%w: pixCity is required with a Pix key", appErrors.ErrInvalidInput)
+		}
+	}
+
+	if err := s.householdRepo.UpdateMember(ctx, member); err != nil {
+		return nil, err
+	}
+	return s.householdRepo.GetByID(ctx, userID, id)
+}
+
+// RemoveMember tira um membro da casa. Cada um pode sair por conta própria e
+// quem criou a casa pode remover os demais, desde que o saldo esteja zerado.
+func (s *HouseholdService) RemoveMember(ctx context.Context, userID, id, memberID string) error {
+	household, err := s.householdRepo.GetByID(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if findMember(household, memberID) == nil {
+		return appErrors.ErrUserNotFound
+	}
+	if memberID != userID && (household.CreatedBy == nil || *household.CreatedBy != userID) {
+		return fmt.Errorf("%w: only the creator can remove other members", appErrors.ErrForbidden)
+	}
+	if len(household.Members) == 1 {
+		return fmt.Errorf("%w: the last member cannot leave; delete the household instead", appErrors.ErrInvalidInput)
+	}
+
+	debts, err := s.expenseRepo.Debts(ctx, id)
+	if err != nil {
+		return err
+	}
+	balances, err := settleup.Balances(debts)
+	if err != nil {
+		return err
+	}
+	if balance, ok := balances[memberID]; ok && !balance.IsZero() {
+		return appErrors.ErrOutstandingBalance
+	}
+
+	return s.householdRepo.RemoveMember(ctx, id, memberID)
+}
+
+func (s *HouseholdService) ListExpenses(ctx context.Context, userID, id string, filter repositories.SharedExpenseFilter) ([]entities.SharedExpense, error) {
+	if _, err := s.householdRepo.GetByID(ctx, userID, id); err != nil {
+		return nil, err
+	}
+	return s.expenseRepo.List(ctx, id, filter)
+}
+
+func (s *HouseholdService) GetExpense(ctx context.Context, userID, id, expenseID string) (*entities.SharedExpense, error) {
+	if _, err := s.householdRepo.GetByID(ctx, userID, id); err != nil {
+		return nil, err
+	}
+	return s.expenseRepo.GetByID(ctx, id, expenseID)
+}
+
+func (s *HouseholdService) CreateExpense(ctx context.Context, userID, id string, req *dtos.SharedExpenseRequest) (*entities.SharedExpense, error) {
+	household, err := s.householdRepo.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	expense, err := buildSharedExpense(userID, household, req)
+	if err != nil {
+		return nil, err
+	}
+	expense.CreatedBy = &userID
+
+	if err := s.expenseRepo.Create(ctx, expense); err != nil {
+		return nil, err
+	}
+	return s.expenseRepo.GetByID(ctx, id, expense.ID)
+}
+
+// UpdateExpense substitui a despesa; só quem a lançou ou quem pagou pode alterá-la
+func (s *HouseholdService) UpdateExpense(ctx context.Context, userID, id, expenseID string, req *dtos.SharedExpenseRequest) (*entities.SharedExpense, error) {
+	household, err := s.householdRepo.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := s.expenseRepo.GetByID(ctx, id, expenseID)
+	if err != nil {
+		return nil, err
+	}
+	if !canEditExpense(userID, previous) {
+		return nil, fmt.Errorf("%w: only who created or paid the expense can change it", appErrors.ErrForbidden)
+	}
+
+	expense, err := buildSharedExpense(userID, household, req)
+	if err != nil {
+		return nil, err
+	}
+	expense.ID = previous.ID
+
+	if err := s.expenseRepo.Update(ctx, expense); err != nil {
+		return nil, err
+	}
+	return s.expenseRepo.GetByID(ctx, id, expenseID)
+}
+
+func (s *HouseholdService) DeleteExpense(ctx context.Context, userID, id, expenseID string) error {
+	if _, err := s.householdRepo.GetByID(ctx, userID, id); err != nil {
+		return err
+	}
+	expense, err := s.expenseRepo.GetByID(ctx, id, expenseID)
+	if err != nil {
+		return err
+	}
+	if !canEditExpense(userID, expense) {
+		return fmt.Errorf("%w: only who created or paid the expense can delete it", appErrors.ErrForbidden)
+	}
+	return s.expenseRepo.Delete(ctx, id, expenseID)
+}
+
+// Balances devolve os saldos da casa, as dívidas diretas entre cada par e a
+// sugestão com o menor número de transferências para zerar tudo
+func (s *HouseholdService) Balances(ctx context.Context, userID, id string) (*dtos.HouseholdBalancesResponse, error) {
+	household, err := s.householdRepo.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	debts, err := s.expenseRepo.Debts(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	balances, err := settleup.Balances(debts)
+	if err != nil {
+		return nil, err
+	}
+	pairwise, err := settleup.Pairwise(debts)
+	if err != nil {
+		return nil, err
+	}
+	suggestions, err := settleup.Simplify(balances)
+	if err != nil {
+		return nil, err
+	}
+
+	zero, err := money.Zero(household.Currency)
+	if err != nil {
+		return nil, err
+	}
+	response := &dtos.HouseholdBalancesResponse{
+		Currency:    household.Currency,
+		Members:     make([]dtos.MemberBalance, len(household.Members)),
+		Pairwise:    debtResponses(pairwise),
+		Suggestions: debtResponses(suggestions),
+	}
+	for i, member := range household.Members {
+		balance, ok := balances[member.UserID]
+		if !ok {
+			balance = zero
+		}
+		response.Members[i] = dtos.MemberBalance{UserID: member.UserID, Name: member.Name, Balance: balance}
+	}
+	return response, nil
+}
+
+func (s *HouseholdService) ListSettlements(ctx context.Context, userID, id string) ([]entities.Settlement, error) {
+	if _, err := s.householdRepo.GetByID(ctx, userID, id); err != nil {
+		return nil, err
+	}
+	return s.expenseRepo.ListSettlements(ctx, id)
+}
+
+// Settle registra o pagamento de um membro a outro. O usuário precisa ser
+// uma das pontas; sem valor, acerta o que a sugestão manda um pagar ao outro
+// ou, se ela não ligar os dois, a dívida direta entre eles.
+func (s *HouseholdService) Settle(ctx context.Context, userID, id string, req *dtos.SettlementRequest) (*entities.Settlement, error) {
+	household, err := s.householdRepo.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	from := firstNonEmpty(req.FromUserID, userID)
+	if from == req.ToUserID {
+		return nil, fmt.Errorf("%w: a member cannot settle with themselves", appErrors.ErrInvalidInput)
+	}
+	if userID != from && userID != req.ToUserID {
+		return nil, fmt.Errorf("%w: only the payer or the recipient can record a settlement", appErrors.ErrForbidden)
+	}
+	recipient := findMember(household, req.ToUserID)
+	if findMember(household, from) == nil || recipient == nil {
+		return nil, fmt.Errorf("%w: both sides must be members of the household", appErrors.ErrInvalidInput)
+	}
+
+	date, err := s.today(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Date != "" {
+		if date, err = time.Parse("2006-01-02", req.Date); err != nil {
+			return nil, fmt.Errorf("%w: date must be YYYY-MM-DD", appErrors.ErrInvalidInput)
+		}
+	}
+
+	amount, err := req.Amount.WithCurrency(household.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: amount: %v", appErrors.ErrInvalidInput, err)
+	}
+	if amount.IsZero() {
+		if amount, err = s.owed(ctx, id, from, req.ToUserID); err != nil {
+			return nil, err
+		}
+	}
+	if amount.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: amount must be positive", appErrors.ErrInvalidInput)
+	}
+
+	settlement := &entities.Settlement{
+		HouseholdID: id,
+		FromUserID:  from,
+		ToUserID:    req.ToUserID,
+		Amount:      amount,
+		Currency:    household.Currency,
+		Date:        date,
+		Note:        strings.TrimSpace(req.Note),
+		CreatedBy:   &userID,
+	}
+	if req.Pix {
+		if recipient.PixKey == "" {
+			return nil, fmt.Errorf("%w: %s has no Pix key in this household", appErrors.ErrInvalidInput, recipient.Name)
+		}
+		if settlement.PixPayload, err = pix.Encode(pix.Payload{
+			Key:          recipient.PixKey,
+			MerchantName: recipient.Name,
+			MerchantCity: recipient.PixCity,
+			Amount:       amount,
+		}); err != nil {
+			return nil, fmt.Errorf("%w: %v", appErrors.ErrInvalidInput, err)
+		}
+	}
+
+	if err := s.expenseRepo.CreateSettlement(ctx, settlement); err != nil {
+		return nil, err
+	}
+	return s.expenseRepo.GetSettlement(ctx, id, settlement.ID)
+}
+
+// DeleteSettlement desfaz um acerto lançado por engano; só as pontas podem
+func (s *HouseholdService) DeleteSettlement(ctx context.Context, userID, id, settlementID string) error {
+	if _, err := s.householdRepo.GetByID(ctx, userID, id); err != nil {
+		return err
+	}
+	settlement, err := s.expenseRepo.GetSettlement(ctx, id, settlementID)
+	if err != nil {
+		return err
+	}
+	if userID != settlement.FromUserID && userID != settlement.ToUserID {
+		return fmt.Errorf("%w: only the payer or the recipient can delete a settlement", appErrors.ErrForbidden)
+	}
+	return s.expenseRepo.DeleteSettlement(ctx, id, settlementID)
+}
+
+// owed é o que from deve pagar a to para acertar as contas
+func (s *HouseholdService) owed(ctx context.Context, householdID, from, to string) (money.Money, error) {
+	debts, err := s.expenseRepo.Debts(ctx, householdID)
+	if err != nil {
+		return money.Money{}, err
+	}
+	balances, err := settleup.Balances(debts)
+	if err != nil {
+		return money.Money{}, err
+	}
+	suggestions, err := settleup.Simplify(balances)
+	if err != nil {
+		return money.Money{}, err
+	}
+	pairwise, err := settleup.Pairwise(debts)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	for _, list := range [][]settleup.Debt{suggestions, pairwise} {
+		for _, debt := range list {
+			if debt.From == from && debt.To == to {
+				return debt.Amount, nil
+			}
+		}
+	}
+	return money.Money{}, fmt.Errorf("%w: nothing to settle between these members", appErrors.ErrInvalidInput)
+}
+
+func (s *HouseholdService) today(ctx context.Context, userID string) (time.Time, error) {
+	user, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	y, m, d := time.Now().In(FormatterFor(user).Location()).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC), nil
+}
+
+func buildSharedExpense(userID string, household *entities.Household, req *dtos.SharedExpenseRequest) (*entities.SharedExpense, error) {
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, fmt.Errorf("%w: date must be YYYY-MM-DD", appErrors.ErrInvalidInput)
+	}
+	description := strings.TrimSpace(req.Description)
+	if description == "" {
+		return nil, fmt.Errorf("%w: description is required", appErrors.ErrInvalidInput)
+	}
+	amount, err := req.Amount.WithCurrency(household.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: amount: %v", appErrors.ErrInvalidInput, err)
+	}
+	if amount.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: amount must be positive", appErrors.ErrInvalidInput)
+	}
+	paidBy := firstNonEmpty(req.PaidBy, userID)
+	if findMember(household, paidBy) == nil {
+		return nil, fmt.Errorf("%w: paidBy must be a member of the household", appErrors.ErrInvalidInput)
+	}
+
+	members := make([]string, len(household.Members))
+	for i, member := range household.Members {
+		members[i] = member.UserID
+	}
+	shares, err := splitExpense(amount, req.SplitMode, members, req.Shares)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.SharedExpense{
+		HouseholdID: household.ID,
+		PaidBy:      paidBy,
+		Description: description,
+		Notes:       strings.TrimSpace(req.Notes),
+		Date:        date,
+		Amount:      amount,
+		Currency:    household.Currency,
+		SplitMode:   req.SplitMode,
+		Shares:      shares,
+	}, nil
+}
+
+// splitExpense divide total entre os participantes conforme o modo. As
+// sobras de centavos das divisões proporcionais vão para os primeiros da
+// lista, e a soma das partes é sempre exatamente o total.
+func splitExpense(total money.Money, mode enums.SplitMode, members []string, lines []dtos.ExpenseShareRequest) ([]entities.SharedExpenseShare, error) {
+	if !mode.IsValid() {
+		return nil, fmt.Errorf("%w: unknown split mode %q", appErrors.ErrInvalidInput, mode)
+	}
+
+	isMember := make(map[string]bool, len(members))
+	for _, id := range members {
+		isMember[id] = true
+	}
+	seen := make(map[string]bool, len(lines))
+	for _, line := range lines {
+		if !isMember[line.UserID] {
+			return nil, fmt.Errorf("%w: %s is not a member of the household", appErrors.ErrInvalidInput, line.UserID)
+		}
+		if seen[line.UserID] {
+			return nil, fmt.Errorf("%w: duplicated share for %s", appErrors.ErrInvalidInput, line.UserID)
+		}
+		seen[line.UserID] = true
+	}
+	if len(lines) == 0 && mode != enums.EqualSplit {
+		return nil, fmt.Errorf("%w: %s split needs the share of each participant", appErrors.ErrInvalidInput, mode)
+	}
+
+	shares := make([]entities.SharedExpenseShare, len(lines))
+	for i, line := range lines {
+		shares[i].UserID = line.UserID
+	}
+
+	var parts []money.Money
+	var err error
+	switch mode {
+	case enums.EqualSplit:
+		if len(lines) == 0 {
+			shares = make([]entities.SharedExpenseShare, len(members))
+			for i, id := range members {
+				shares[i].UserID = id
+			}
+		}
+		parts, err = total.Split(len(shares))
+
+	case enums.PercentageSplit:
+		weights := make([]int64, len(lines))
+		var sum int64
+		for i, line := range lines {
+			if weights[i], err = basisPoints(line.Percent); err != nil {
+				return nil, err
+			}
+			sum += weights[i]
+			shares[i].Weight = &weights[i]
+		}
+		if sum != 100_00 {
+			return nil, fmt.Errorf("%w: percentages add up to %s, expected 100", appErrors.ErrInvalidInput, big.NewRat(sum, 100).FloatString(2))
+		}
+		parts, err = total.Allocate(weights...)
+
+	case enums.ExactSplit:
+		parts = make([]money.Money, len(lines))
+		for i, line := range lines {
+			if line.Amount == nil {
+				return nil, fmt.Errorf("%w: amount is required for every share", appErrors.ErrInvalidInput)
+			}
+			if parts[i], err = line.Amount.WithCurrency(total.Currency()); err != nil {
+				return nil, fmt.Errorf("%w: share amount: %v", appErrors.ErrInvalidInput, err)
+			}
+			if parts[i].Sign() < 0 {
+				return nil, fmt.Errorf("%w: share amounts cannot be negative", appErrors.ErrInvalidInput)
+			}
+		}
+		sum, err := money.Sum(parts...)
+		if err != nil {
+			return nil, err
+		}
+		if !sum.Equal(total) {
+			return nil, fmt.Errorf("%w: shares add up to %s, expected %s", appErrors.ErrInvalidInput, sum.Decimal(), total.Decimal())
+		}
+
+	case enums.SharesSplit:
+		weights := make([]int64, len(lines))
+		var sum int64
+		for i, line := range lines {
+			if line.Shares < 0 {
+				return nil, fmt.Errorf("%w: shares cannot be negative", appErrors.ErrInvalidInput)
+			}
+			weights[i] = line.Shares
+			sum += line.Shares
+			shares[i].Weight = &weights[i]
+		}
+		if sum == 0 {
+			return nil, fmt.Errorf("%w: at least one participant needs shares", appErrors.ErrInvalidInput)
+		}
+		parts, err = total.Allocate(weights...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range shares {
+		shares[i].Amount = parts[i]
+	}
+	return shares, nil
+}
+
+// basisPoints converte um percentual decimal ("33.33") em centésimos de
+// ponto percentual (3333)
+func basisPoints(percent string) (int64, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(percent))
+	if !ok {
+		return 0, fmt.Errorf("%w: percent is required for every share", appErrors.ErrInvalidInput)
+	}
+	r.Mul(r, big.NewRat(100, 1))
+	if !r.IsInt() || r.Sign() < 0 || r.Num().Cmp(big.NewInt(100_00)) > 0 {
+		return 0, fmt.Errorf("%w: percent must be between 0 and 100 with at most two decimals", appErrors.ErrInvalidInput)
+	}
+	return r.Num().Int64(), nil
+}
+
+func canEditExpense(userID string, expense *entities.SharedExpense) bool {
+	return expense.PaidBy == userID || (expense.CreatedBy != nil && *expense.CreatedBy == userID)
+}
+
+func findMember(household *entities.Household, userID string) *entities.HouseholdMember {
+	for i := range household.Members {
+		if household.Members[i].UserID == userID {
+			return &household.Members[i]
+		}
+	}
+	return nil
+}
+
+func debtResponses(debts []settleup.Debt) []dtos.DebtResponse {
+	responses := make([]dtos.DebtResponse, len(debts))
+	for i, debt := range debts {
+		responses[i] = dtos.DebtResponse{FromUserID: debt.From, ToUserID: debt.To, Amount: debt.Amount}
+	}
+	return responses
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/internal/domain/repositories"
+	appErrors "finanvilla/pkg/errors"
+	"finanvilla/pkg/money"
+	"testing"
+)
+
+func TestSplitExpense(t *testing.T) {
+	members := []string{"ana", "bia", "caio"}
+	// Valores como chegam do JSON, ainda sem moeda
+	amount := func(s string) *money.Money {
+		m, err := money.Parse(s, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &m
+	}
+
+	for _, tc := range []struct {
+		name  string
+		mode  enums.SplitMode
+		lines []dtos.ExpenseShareRequest
+		want  map[string]int64
+	}{
+		{"equal among all", enums.EqualSplit, nil, map[string]int64{"ana": 3334, "bia": 3333, "caio": 3333}},
+		{"equal among some", enums.EqualSplit, []dtos.ExpenseShareRequest{{UserID: "bia"}, {UserID: "caio"}},
+			map[string]int64{"bia": 5000, "caio": 5000}},
+		{"percentage", enums.PercentageSplit, []dtos.ExpenseShareRequest{
+			{UserID: "ana", Percent: "33.33"}, {UserID: "bia", Percent: "33.33"}, {UserID: "caio", Percent: "33.34"},
+		}, map[string]int64{"ana": 3333, "bia": 3333, "caio": 3334}},
+		{"exact", enums.ExactSplit, []dtos.ExpenseShareRequest{
+			{UserID: "ana", Amount: amount("70.00")}, {UserID: "caio", Amount: amount("30")},
+		}, map[string]int64{"ana": 7000, "caio": 3000}},
+		{"shares", enums.SharesSplit, []dtos.ExpenseShareRequest{
+			{UserID: "ana", Shares: 2}, {UserID: "bia", Shares: 1}, {UserID: "caio", Shares: 0},
+		}, map[string]int64{"ana": 6667, "bia": 3333, "caio": 0}},
+	} {
+		shares, err := splitExpense(brl(10000), tc.mode, members, tc.lines)
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+		if len(shares) != len(tc.want) {
+			t.Errorf("%s: got %d shares, want %d", tc.name, len(shares), len(tc.want))
+			continue
+		}
+		for _, share := range shares {
+			if want, ok := tc.want[share.UserID]; !ok || share.Amount.MinorUnits() != want || share.Amount.Currency() != "BRL" {
+				t.Errorf("%s: %s = %s, want %d", tc.name, share.UserID, share.Amount, want)
+			}
+		}
+	}
+
+	for _, tc := range []struct {
+		name  string
+		mode  enums.SplitMode
+		lines []dtos.ExpenseShareRequest
+	}{
+		{"percent off", enums.PercentageSplit, []dtos.ExpenseShareRequest{{UserID: "ana", Percent: "50"}, {UserID: "bia", Percent: "49.99"}}},
+		{"percent too precise", enums.PercentageSplit, []dtos.ExpenseShareRequest{{UserID: "ana", Percent: "99.995"}, {UserID: "bia", Percent: "0.005"}}},
+		{"exact off by a cent", enums.ExactSplit, []dtos.ExpenseShareRequest{{UserID: "ana", Amount: amount("50.00")}, {UserID: "bia", Amount: amount("49.99")}}},
+		{"no shares", enums.SharesSplit, []dtos.ExpenseShareRequest{{UserID: "ana"}}},
+		{"not a member", enums.EqualSplit, []dtos.ExpenseShareRequest{{UserID: "duda"}}},
+		{"duplicated", enums.EqualSplit, []dtos.ExpenseShareRequest{{UserID: "ana"}, {UserID: "ana"}}},
+		{"missing lines", enums.ExactSplit, nil},
+	} {
+		if _, err := splitExpense(brl(10000), tc.mode, members, tc.lines); err == nil {
+			t.Errorf("%s: expected an error", tc.name)
+		}
+	}
+}
+
+type fakeHouseholdRepository struct {
+	repositories.HouseholdRepository
+	members []entities.HouseholdMember
+}
+
+func (r *fakeHouseholdRepository) GetByID(_ context.Context, userID, id string) (*entities.Household, error) {
+	household := &entities.Household{ID: id}
+	member := false
+	for _, m := range r.members {
+		if m.HouseholdID == id && m.Status == enums.ActiveMember {
+			household.Members = append(household.Members, m)
+			member = member || m.UserID == userID
+		}
+	}
+	if !member {
+		return nil, appErrors.ErrHouseholdNotFound
+	}
+	return household, nil
+}
+
+func (r *fakeHouseholdRepository) AddMember(_ context.Context, member *entities.HouseholdMember) error {
+	for _, m := range r.members {
+		if m.HouseholdID == member.HouseholdID && m.UserID == member.UserID {
+			return appErrors.ErrAlreadyMember
+		}
+	}
+	r.members = append(r.members, *member)
+	return nil
+}
+
+func (r *fakeHouseholdRepository) AcceptInvitation(_ context.Context, householdID, userID string) error {
+	for i, m := range r.members {
+		if m.HouseholdID == householdID && m.UserID == userID && m.Status == enums.PendingMember {
+			r.members[i].Status = enums.ActiveMember
+			return nil
+		}
+	}
+	return appErrors.ErrHouseholdNotFound
+}
+
+func TestInviteMember(t *testing.T) {
+	users := &fakeUserRepository{users: map[string]*entities.User{
+		"ana": {ID: "ana", Email: "ana@example.com"},
+		"bia": {ID: "bia", Email: "bia@example.com"},
+	}}
+	households := &fakeHouseholdRepository{members: []entities.HouseholdMember{
+		{HouseholdID: "casa", UserID: "ana", Status: enums.ActiveMember},
+	}}
+	service := NewHouseholdService(households, nil, NewUserService(users, nil, inlineTransactionManager{}, nil))
+	ctx := context.Background()
+	invite := func(userID, email string) error {
+		return service.InviteMember(ctx, userID, "casa", &dtos.AddHouseholdMemberRequest{Email: email})
+	}
+
+	if err := invite("ana", "  BIA@Example.com "); err != nil {
+		t.Fatal(err)
+	}
+	if len(households.members) != 2 {
+		t.Fatalf("got %d memberships, want the invitation", len(households.members))
+	}
+	if m := households.members[1]; m.UserID != "bia" || m.Status != enums.PendingMember || m.InvitedBy == nil || *m.InvitedBy != "ana" {
+		t.Errorf("invitation = %+v, want bia pending, invited by ana", m)
+	}
+
+	// Convidado ainda não é membro: não vê a casa nem pode convidar
+	if err := invite("bia", "ana@example.com"); !errors.Is(err, appErrors.ErrHouseholdNotFound) {
+		t.Errorf("pending member invited someone: err = %v", err)
+	}
+
+	// Sem cadastro, convite repetido ou membro atual: mesma resposta, nada gravado
+	for _, email := range []string{"nobody@example.com", "bia@example.com", "ana@example.com"} {
+		if err := invite("ana", email); err != nil {
+			t.Errorf("invite %s: err = %v, want nil", email, err)
+		}
+	}
+	if len(households.members) != 2 {
+		t.Errorf("got %d memberships after repeated invitations, want 2", len(households.members))
+	}
+
+	household, err := service.AcceptInvitation(ctx, "bia", "casa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if findMember(household, "bia") == nil {
+		t.Error("accepted invitation did not make bia a member")
+	}
+	if _, err := service.AcceptInvitation(ctx, "bia", "casa"); !errors.Is(err, appErrors.ErrHouseholdNotFound) {
+		t.Errorf("accepting twice: err = %v, want ErrHouseholdNotFound", err)
+	}
+}
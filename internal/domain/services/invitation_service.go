@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/internal/domain/repositories"
+	"finanvilla/pkg/crypto"
+	"finanvilla/pkg/errors"
+	"finanvilla/pkg/mailer"
+	"fmt"
+	"time"
+)
+
+type InvitationService struct {
+	userService *UserService
+	tokenRepo   repositories.UserTokenRepository
+	txManager   repositories.TransactionManager
+	mailer      mailer.Mailer
+	baseURL     string
+	ttl         time.Duration
+}
+
+func NewInvitationService(
+	userService *UserService,
+	tokenRepo repositories.UserTokenRepository,
+	txManager repositories.TransactionManager,
+	mailer mailer.Mailer,
+	baseURL string,
+) *InvitationService {
+	return &InvitationService{
+		userService: userService,
+		tokenRepo:   tokenRepo,
+		txManager:   txManager,
+		mailer:      mailer,
+		baseURL:     baseURL,
+		ttl:         7 * 24 * time.Hour, // Convite expira em 7 dias
+	}
+}
+
+// CreateInvitation registra um token de convite para o usuário e devolve o
+// token em texto puro, que deve ser entregue via SendInvitation.
+func (s *InvitationService) CreateInvitation(ctx context.Context, user *entities.User) (string, error) {
+	token, err := crypto.GenerateToken(32)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.tokenRepo.InvalidateByUser(ctx, user.ID, enums.InvitationToken); err != nil {
+		return "", err
+	}
+
+	err = s.tokenRepo.Create(ctx, &entities.UserToken{
+		UserID:    user.ID,
+		Purpose:   enums.InvitationToken,
+		TokenHash: crypto.HashToken(token),
+		ExpiresAt: time.Now().Add(s.ttl),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (s *InvitationService) SendInvitation(ctx context.Context, user *entities.User, token string) error {
+	link := fmt.Sprintf("%s/invitations/accept?token=%s", s.baseURL, token)
+	expiresAt := FormatterFor(user).FormatDateTime(time.Now().Add(s.ttl))
+
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Você foi convidado para o Finanvilla",
+		Body: fmt.Sprintf(
+			"Olá, %s!\n\nUma conta foi criada para você no Finanvilla. Para definir sua senha e acessar, use o link abaixo (válido até %s):\n\n%s\n",
+			user.Name, expiresAt, link,
+		),
+	})
+}
+
+// Accept consome o token de convite e define a senha escolhida pelo usuário
+func (s *InvitationService) Accept(ctx context.Context, token, password string) (*entities.User, error) {
+	var user *entities.User
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		invitation, err := s.tokenRepo.GetActiveByHash(ctx, enums.InvitationToken, crypto.HashToken(token))
+		if err != nil {
+			return errors.ErrInvalidToken
+		}
+
+		user, err = s.userService.GetByID(ctx, invitation.UserID)
+		if err != nil {
+			return err
+		}
+
+		user.Password = password
+		if err := s.userService.UpdateUser(ctx, user); err != nil {
+			return err
+		}
+
+		return s.tokenRepo.MarkUsed(ctx, invitation.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	user.Password = ""
+	return user, nil
+}
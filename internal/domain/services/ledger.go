@@ -0,0 +1,137 @@
+package services
+
+import (
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/pkg/errors"
+	"finanvilla/pkg/money"
+	"fmt"
+	"strings"
+)
+
+// BuildPostings converte a requisição em pernas do lançamento, expandindo as
+// formas simplificadas de despesa/receita, dividida e de transferência.
+func BuildPostings(req *dtos.TransactionRequest) ([]entities.Posting, error) {
+	if len(req.Postings) > 0 {
+		if req.AccountID != nil || req.ToAccountID != nil || req.CategoryID != nil || !req.Amount.IsZero() || len(req.Splits) > 0 {
+			return nil, fmt.Errorf("%w: send either postings or accountId/amount, not both", errors.ErrInvalidInput)
+		}
+
+		postings := make([]entities.Posting, len(req.Postings))
+		for i, p := range req.Postings {
+			postings[i] = entities.Posting{
+				AccountID:  p.AccountID,
+				CategoryID: p.CategoryID,
+				Amount:     p.Amount,
+				Memo:       p.Memo,
+			}
+		}
+		return postings, nil
+	}
+
+	if req.AccountID == nil || req.Amount.IsZero() {
+		return nil, fmt.Errorf("%w: postings or accountId and a non-zero amount are required", errors.ErrInvalidInput)
+	}
+
+	if len(req.Splits) > 0 {
+		if req.ToAccountID != nil {
+			return nil, fmt.Errorf("%w: transfers cannot be split", errors.ErrInvalidInput)
+		}
+		if req.CategoryID != nil {
+			return nil, fmt.Errorf("%w: send either categoryId or splits, not both", errors.ErrInvalidInput)
+		}
+		legs, err := SplitPostings(req.Amount, req.Splits)
+		if err != nil {
+			return nil, err
+		}
+		return append([]entities.Posting{{AccountID: req.AccountID, Amount: req.Amount}}, legs...), nil
+	}
+
+	if req.ToAccountID != nil {
+		if req.CategoryID != nil {
+			return nil, fmt.Errorf("%w: transfers do not take a category", errors.ErrInvalidInput)
+		}
+		if req.Amount.Sign() < 0 {
+			return nil, fmt.Errorf("%w: transfer amount must be positive", errors.ErrInvalidInput)
+		}
+		return []entities.Posting{
+			{AccountID: req.AccountID, Amount: req.Amount.Neg()},
+			{AccountID: req.ToAccountID, Amount: req.Amount},
+		}, nil
+	}
+
+	return []entities.Posting{
+		{AccountID: req.AccountID, Amount: req.Amount},
+		{CategoryID: req.CategoryID, Amount: req.Amount.Neg()},
+	}, nil
+}
+
+// SplitPostings monta as pernas de categoria de um lançamento dividido. As
+// linhas vêm no sinal do total (negativas numa despesa) e precisam somar
+// exatamente o total; cada perna leva o valor com sinal invertido.
+func SplitPostings(total money.Money, splits []dtos.SplitRequest) ([]entities.Posting, error) {
+	if len(splits) == 0 {
+		return nil, fmt.Errorf("%w: at least one split is required", errors.ErrInvalidInput)
+	}
+
+	sum := splits[0].Amount
+	legs := make([]entities.Posting, len(splits))
+	for i, split := range splits {
+		if split.Amount.IsZero() {
+			return nil, fmt.Errorf("%w: split %d has a zero amount", errors.ErrInvalidInput, i+1)
+		}
+		if i > 0 {
+			var err error
+			if sum, err = sum.Add(split.Amount); err != nil {
+				return nil, fmt.Errorf("%w: split %d: %v", errors.ErrInvalidInput, i+1, err)
+			}
+		}
+		legs[i] = entities.Posting{
+			CategoryID: split.CategoryID,
+			Amount:     split.Amount.Neg(),
+			Memo:       strings.TrimSpace(split.Memo),
+		}
+	}
+
+	if cmp, err := sum.Cmp(total); err != nil || cmp != 0 {
+		return nil, fmt.Errorf("%w: splits add up to %s but the total is %s", errors.ErrInvalidInput, sum.Decimal(), total.Decimal())
+	}
+	return legs, nil
+}
+
+// ValidatePostings aplica as regras de partidas dobradas: ao menos duas pernas,
+// ao menos uma conta envolvida, nenhuma perna zerada e soma igual a zero.
+func ValidatePostings(postings []entities.Posting) error {
+	if len(postings) < 2 {
+		return fmt.Errorf("%w: a transaction needs at least two postings", errors.ErrInvalidInput)
+	}
+
+	sum := postings[0].Amount
+	hasAccount := false
+	for i, p := range postings {
+		if p.Amount.IsZero() {
+			return fmt.Errorf("%w: posting %d has a zero amount", errors.ErrInvalidInput, i+1)
+		}
+		if p.AccountID != nil && p.CategoryID != nil {
+			return fmt.Errorf("%w: posting %d must reference an account or a category, not both", errors.ErrInvalidInput, i+1)
+		}
+		if p.AccountID != nil {
+			hasAccount = true
+		}
+		if i == 0 {
+			continue
+		}
+		var err error
+		if sum, err = sum.Add(p.Amount); err != nil {
+			return fmt.Errorf("%w: %v", errors.ErrInvalidInput, err)
+		}
+	}
+
+	if !hasAccount {
+		return fmt.Errorf("%w: a transaction must move at least one account", errors.ErrInvalidInput)
+	}
+	if !sum.IsZero() {
+		return fmt.Errorf("%w: postings must balance to zero (off by %s)", errors.ErrInvalidInput, sum.Decimal())
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"errors"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/entities"
+	appErrors "finanvilla/pkg/errors"
+	"finanvilla/pkg/money"
+	"testing"
+)
+
+func TestBuildPostings(t *testing.T) {
+	checking, savings, groceries := "checking", "savings", "groceries"
+
+	t.Run("expense", func(t *testing.T) {
+		postings, err := BuildPostings(&dtos.TransactionRequest{AccountID: &checking, CategoryID: &groceries, Amount: brl(-15090)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(postings) != 2 || *postings[0].AccountID != checking || postings[0].Amount.MinorUnits() != -15090 ||
+			*postings[1].CategoryID != groceries || postings[1].Amount.MinorUnits() != 15090 {
+			t.Fatalf("unexpected postings: %+v", postings)
+		}
+		if err := ValidatePostings(postings); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("transfer", func(t *testing.T) {
+		postings, err := BuildPostings(&dtos.TransactionRequest{AccountID: &checking, ToAccountID: &savings, Amount: brl(50000)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *postings[0].AccountID != checking || postings[0].Amount.MinorUnits() != -50000 ||
+			*postings[1].AccountID != savings || postings[1].Amount.MinorUnits() != 50000 {
+			t.Fatalf("unexpected postings: %+v", postings)
+		}
+	})
+
+	t.Run("negative transfer", func(t *testing.T) {
+		_, err := BuildPostings(&dtos.TransactionRequest{AccountID: &checking, ToAccountID: &savings, Amount: brl(-1)})
+		if !errors.Is(err, appErrors.ErrInvalidInput) {
+			t.Fatalf("expected invalid input, got %v", err)
+		}
+	})
+
+	t.Run("split", func(t *testing.T) {
+		pharmacy := "pharmacy"
+		total, _ := money.Parse("-150.9", "")
+		food, _ := money.Parse("-100.35", "")
+		medicine, _ := money.Parse("-50.55", "")
+		postings, err := BuildPostings(&dtos.TransactionRequest{
+			AccountID: &checking,
+			Amount:    total,
+			Splits: []dtos.SplitRequest{
+				{CategoryID: &groceries, Amount: food, Memo: " arroz e feijão "},
+				{CategoryID: &pharmacy, Amount: medicine},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(postings) != 3 || *postings[1].CategoryID != groceries || postings[1].Amount.Decimal() != "100.35" ||
+			postings[1].Memo != "arroz e feijão" || *postings[2].CategoryID != pharmacy || postings[2].Amount.Decimal() != "50.55" {
+			t.Fatalf("unexpected postings: %+v", postings)
+		}
+		if err := ValidatePostings(postings); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("split off by a cent", func(t *testing.T) {
+		_, err := BuildPostings(&dtos.TransactionRequest{
+			AccountID: &checking,
+			Amount:    brl(-15090),
+			Splits: []dtos.SplitRequest{
+				{CategoryID: &groceries, Amount: brl(-10000)},
+				{Amount: brl(-5089)},
+			},
+		})
+		if !errors.Is(err, appErrors.ErrInvalidInput) {
+			t.Fatalf("expected invalid input, got %v", err)
+		}
+	})
+
+	t.Run("split transfer", func(t *testing.T) {
+		_, err := BuildPostings(&dtos.TransactionRequest{
+			AccountID:   &checking,
+			ToAccountID: &savings,
+			Amount:      brl(100),
+			Splits:      []dtos.SplitRequest{{Amount: brl(100)}},
+		})
+		if !errors.Is(err, appErrors.ErrInvalidInput) {
+			t.Fatalf("expected invalid input, got %v", err)
+		}
+	})
+
+	t.Run("postings and shortcut together", func(t *testing.T) {
+		_, err := BuildPostings(&dtos.TransactionRequest{
+			AccountID: &checking,
+			Amount:    brl(100),
+			Postings:  []dtos.PostingRequest{{AccountID: &checking, Amount: brl(100)}},
+		})
+		if !errors.Is(err, appErrors.ErrInvalidInput) {
+			t.Fatalf("expected invalid input, got %v", err)
+		}
+	})
+}
+
+func TestValidatePostings(t *testing.T) {
+	checking, groceries := "checking", "groceries"
+
+	tests := []struct {
+		name     string
+		postings []entities.Posting
+		valid    bool
+	}{
+		{"balanced split", []entities.Posting{
+			{AccountID: &checking, Amount: brl(-10000)},
+			{CategoryID: &groceries, Amount: brl(7000)},
+			{Amount: brl(3000)},
+		}, true},
+		{"single posting", []entities.Posting{{AccountID: &checking, Amount: brl(0)}}, false},
+		{"unbalanced", []entities.Posting{
+			{AccountID: &checking, Amount: brl(-10000)},
+			{CategoryID: &groceries, Amount: brl(9999)},
+		}, false},
+		{"zero amount", []entities.Posting{
+			{AccountID: &checking, Amount: brl(0)},
+			{CategoryID: &groceries, Amount: brl(0)},
+		}, false},
+		{"no account", []entities.Posting{
+			{CategoryID: &groceries, Amount: brl(-100)},
+			{Amount: brl(100)},
+		}, false},
+		{"account and category on one posting", []entities.Posting{
+			{AccountID: &checking, CategoryID: &groceries, Amount: brl(-100)},
+			{Amount: brl(100)},
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePostings(tt.postings)
+			if tt.valid && err != nil {
+				t.Fatalf("expected valid, got %v", err)
+			}
+			if !tt.valid && !errors.Is(err, appErrors.ErrInvalidInput) {
+				t.Fatalf("expected invalid input, got %v", err)
+			}
+		})
+	}
+}
+
+func brl(minorUnits int64) money.Money {
+	return money.MustNew(minorUnits, "BRL")
+}
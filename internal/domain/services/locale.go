@@ -0,0 +1,29 @@
+package services
+
+import (
+	"finanvilla/internal/domain/entities"
+	"finanvilla/pkg/locale"
+)
+
+// FormatterFor devolve o formatador regional do usuário. Toda saída gerada
+// no servidor (e-mails, exportações, relatórios) deve passar por ele.
+func FormatterFor(user *entities.User) *locale.Formatter {
+	if user == nil || user.Settings == nil {
+		return locale.Default
+	}
+
+	settings := user.Settings
+	f, err := locale.New(locale.Options{
+		Language:       settings.Language,
+		Currency:       settings.Currency,
+		Timezone:       settings.Timezone,
+		DateFormat:     settings.DateFormat,
+		NumberFormat:   settings.NumberFormat,
+		FirstDayOfWeek: settings.FirstDayOfWeek,
+	})
+	if err != nil {
+		// Preferências gravadas antes da validação atual não devem impedir a saída
+		return locale.Default
+	}
+	return f
+}
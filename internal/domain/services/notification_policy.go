@@ -0,0 +1,98 @@
+package services
+
+import (
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"time"
+)
+
+// defaultNotificationPreferences vale para os pares evento/canal que o usuário não configurou
+var defaultNotificationPreferences = map[enums.NotificationEvent]map[enums.NotificationChannel]bool{
+	enums.SecurityAlert: {enums.EmailChannel: true, enums.InAppChannel: true, enums.WebhookChannel: false},
+	enums.BudgetWarning: {enums.EmailChannel: true, enums.InAppChannel: true, enums.WebhookChannel: false},
+	enums.BillReminder:  {enums.EmailChannel: true, enums.InAppChannel: true, enums.WebhookChannel: false},
+	enums.Digest:        {enums.EmailChannel: true, enums.InAppChannel: false, enums.WebhookChannel: false},
+}
+
+// ShouldNotify é o ponto único de decisão para qualquer produtor de
+// notificações. O usuário deve ter sido carregado com Settings e
+// NotificationPreferences (UserService.GetByID já faz isso).
+func ShouldNotify(user *entities.User, event enums.NotificationEvent, channel enums.NotificationChannel) bool {
+	return shouldNotifyAt(user, event, channel, time.Now())
+}
+
+func shouldNotifyAt(user *entities.User, event enums.NotificationEvent, channel enums.NotificationChannel, now time.Time) bool {
+	if user == nil || !user.Active {
+		return false
+	}
+
+	// Alertas de segurança por e-mail não podem ser desativados nem silenciados
+	if event == enums.SecurityAlert && channel == enums.EmailChannel {
+		return true
+	}
+
+	settings := user.Settings
+	if settings != nil && !settings.NotificationsEnabled {
+		return false
+	}
+
+	if event == enums.Digest && settings != nil && settings.DigestFrequency == enums.DigestNever {
+		return false
+	}
+
+	if !NotificationPreferenceEnabled(user.NotificationPreferences, event, channel) {
+		return false
+	}
+
+	// O horário de silêncio adia apenas canais que interrompem o usuário; o
+	// registro in-app é passivo e os alertas de segurança são sempre entregues.
+	if channel != enums.InAppChannel && event != enums.SecurityAlert && inQuietHours(settings, now) {
+		return false
+	}
+
+	return true
+}
+
+// NotificationPreferenceEnabled devolve a preferência explícita ou, na falta dela, o padrão
+func NotificationPreferenceEnabled(prefs []entities.NotificationPreference, event enums.NotificationEvent, channel enums.NotificationChannel) bool {
+	for _, p := range prefs {
+		if p.Event == event && p.Channel == channel {
+			return p.Enabled
+		}
+	}
+	return defaultNotificationPreferences[event][channel]
+}
+
+func inQuietHours(settings *entities.UserSettings, now time.Time) bool {
+	if settings == nil || settings.QuietHoursStart == "" || settings.QuietHoursEnd == "" {
+		return false
+	}
+
+	start, err1 := parseClock(settings.QuietHoursStart)
+	end, err2 := parseClock(settings.QuietHoursEnd)
+	if err1 != nil || err2 != nil || start == end {
+		return false
+	}
+
+	loc, err := time.LoadLocation(settings.Timezone)
+	if err != nil || settings.Timezone == "" {
+		loc = time.UTC
+	}
+	local := now.In(loc)
+	current := local.Hour()*60 + local.Minute()
+
+	if start < end {
+		return current >= start && current < end
+	}
+	// Janela que atravessa a meia-noite (ex.: 22:00 às 07:00)
+	return current >= start || current < end
+}
+
+// parseClock converte "HH:MM" em minutos desde a meia-noite
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
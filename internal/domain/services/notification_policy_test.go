@@ -0,0 +1,84 @@
+package services
+
+import (
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"testing"
+	"time"
+)
+
+func TestShouldNotify(t *testing.T) {
+	// 23:30 em São Paulo (UTC-3)
+	night := time.Date(2026, 3, 10, 2, 30, 0, 0, time.UTC)
+	// 15:00 em São Paulo
+	afternoon := time.Date(2026, 3, 10, 18, 0, 0, 0, time.UTC)
+
+	newUser := func() *entities.User {
+		return &entities.User{
+			Active: true,
+			Settings: &entities.UserSettings{
+				NotificationsEnabled: true,
+				Timezone:             "America/Sao_Paulo",
+				QuietHoursStart:      "22:00",
+				QuietHoursEnd:        "07:00",
+				DigestFrequency:      enums.DigestWeekly,
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(u *entities.User)
+		event   enums.NotificationEvent
+		channel enums.NotificationChannel
+		now     time.Time
+		want    bool
+	}{
+		{"default email budget warning", nil, enums.BudgetWarning, enums.EmailChannel, afternoon, true},
+		{"webhook is off by default", nil, enums.BillReminder, enums.WebhookChannel, afternoon, false},
+		{"quiet hours across midnight", nil, enums.BillReminder, enums.EmailChannel, night, false},
+		{"in-app ignores quiet hours", nil, enums.BillReminder, enums.InAppChannel, night, true},
+		{"security alerts ignore quiet hours", nil, enums.SecurityAlert, enums.InAppChannel, night, true},
+		{
+			"explicit opt-out",
+			func(u *entities.User) {
+				u.NotificationPreferences = []entities.NotificationPreference{
+					{Event: enums.BudgetWarning, Channel: enums.EmailChannel, Enabled: false},
+				}
+			},
+			enums.BudgetWarning, enums.EmailChannel, afternoon, false,
+		},
+		{
+			"master switch off",
+			func(u *entities.User) { u.Settings.NotificationsEnabled = false },
+			enums.BillReminder, enums.InAppChannel, afternoon, false,
+		},
+		{
+			"security email is mandatory",
+			func(u *entities.User) { u.Settings.NotificationsEnabled = false },
+			enums.SecurityAlert, enums.EmailChannel, night, true,
+		},
+		{
+			"digest disabled by frequency",
+			func(u *entities.User) { u.Settings.DigestFrequency = enums.DigestNever },
+			enums.Digest, enums.EmailChannel, afternoon, false,
+		},
+		{
+			"inactive user",
+			func(u *entities.User) { u.Active = false },
+			enums.SecurityAlert, enums.EmailChannel, afternoon, false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := newUser()
+			if tt.mutate != nil {
+				tt.mutate(user)
+			}
+			if got := shouldNotifyAt(user, tt.event, tt.channel, tt.now); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/internal/domain/repositories"
+	"finanvilla/pkg/errors"
+	"fmt"
+)
+
+type NotificationPreferenceService struct {
+	userService *UserService
+	prefRepo    repositories.NotificationPreferenceRepository
+	txManager   repositories.TransactionManager
+}
+
+func NewNotificationPreferenceService(
+	userService *UserService,
+	prefRepo repositories.NotificationPreferenceRepository,
+	txManager repositories.TransactionManager,
+) *NotificationPreferenceService {
+	return &NotificationPreferenceService{
+		userService: userService,
+		prefRepo:    prefRepo,
+		txManager:   txManager,
+	}
+}
+
+// GetPreferences devolve a matriz completa evento x canal, já com os padrões aplicados
+func (s *NotificationPreferenceService) GetPreferences(ctx context.Context, userID string) (*dtos.NotificationPreferencesResponse, error) {
+	user, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dtos.NotificationPreferencesResponse{
+		Enabled:         true,
+		DigestFrequency: enums.DigestWeekly,
+		Preferences:     make(map[enums.NotificationEvent]map[enums.NotificationChannel]bool),
+	}
+
+	if settings := user.Settings; settings != nil {
+		resp.Enabled = settings.NotificationsEnabled
+		resp.Timezone = settings.Timezone
+		if settings.DigestFrequency != "" {
+			resp.DigestFrequency = settings.DigestFrequency
+		}
+		if settings.QuietHoursStart != "" && settings.QuietHoursEnd != "" {
+			resp.QuietHours = &dtos.QuietHours{Start: settings.QuietHoursStart, End: settings.QuietHoursEnd}
+		}
+	}
+
+	for _, event := range enums.NotificationEvents {
+		resp.Preferences[event] = make(map[enums.NotificationChannel]bool)
+		for _, channel := range enums.NotificationChannels {
+			resp.Preferences[event][channel] = NotificationPreferenceEnabled(user.NotificationPreferences, event, channel)
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *NotificationPreferenceService) UpdatePreferences(ctx context.Context, userID string, req *dtos.UpdateNotificationPreferencesRequest) (*dtos.NotificationPreferencesResponse, error) {
+	if _, err := s.userService.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]interface{})
+
+	if req.Enabled != nil {
+		fields["notifications_enabled"] = *req.Enabled
+	}
+
+	if req.QuietHours != nil {
+		start, end := req.QuietHours.Start, req.QuietHours.End
+		if (start == "") != (end == "") {
+			return nil, fmt.Errorf("%w: quiet hours need both start and end", errors.ErrInvalidInput)
+		}
+		if start != "" {
+			if _, err := parseClock(start); err != nil {
+				return nil, fmt.Errorf("%w: quiet hours must use the HH:MM format", errors.ErrInvalidInput)
+			}
+			if _, err := parseClock(end); err != nil {
+				return nil, fmt.Errorf("%w: quiet hours must use the HH:MM format", errors.ErrInvalidInput)
+			}
+		}
+		fields["quiet_hours_start"] = start
+		fields["quiet_hours_end"] = end
+	}
+
+	if req.DigestFrequency != nil {
+		switch *req.DigestFrequency {
+		case enums.DigestNever, enums.DigestDaily, enums.DigestWeekly, enums.DigestMonthly:
+			fields["digest_frequency"] = *req.DigestFrequency
+		default:
+			return nil, fmt.Errorf("%w: unknown digest frequency %q", errors.ErrInvalidInput, *req.DigestFrequency)
+		}
+	}
+
+	var prefs []entities.NotificationPreference
+	for event, channels := range req.Preferences {
+		if _, ok := defaultNotificationPreferences[event]; !ok {
+			return nil, fmt.Errorf("%w: unknown notification event %q", errors.ErrInvalidInput, event)
+		}
+		for channel, enabled := range channels {
+			if _, ok := defaultNotificationPreferences[event][channel]; !ok {
+				return nil, fmt.Errorf("%w: unknown notification channel %q", errors.ErrInvalidInput, channel)
+			}
+			prefs = append(prefs, entities.NotificationPreference{
+				UserID:  userID,
+				Event:   event,
+				Channel: channel,
+				Enabled: enabled,
+			})
+		}
+	}
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userService.UpdateSettingsFields(ctx, userID, fields); err != nil {
+			return err
+		}
+		return s.prefRepo.Upsert(ctx, prefs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetPreferences(ctx, userID)
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/pkg/errors"
+	"finanvilla/pkg/pix"
+	"finanvilla/pkg/qrcode"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// defaultQRCodeSize é o lado, em pixels, do PNG quando o cliente não informa
+const defaultQRCodeSize = 400
+
+// PixService gera cobranças Pix (BR Code) e lê os códigos colados pelo usuário
+type PixService struct {
+	userService *UserService
+}
+
+func NewPixService(userService *UserService) *PixService {
+	return &PixService{userService: userService}
+}
+
+// Charge monta o BR Code de uma cobrança, para pedir dinheiro a outros
+// membros da casa ou a clientes
+func (s *PixService) Charge(ctx context.Context, userID string, req *dtos.PixChargeRequest) (*dtos.PixPayload, error) {
+	name := strings.TrimSpace(req.MerchantName)
+	if name == "" {
+		user, err := s.userService.GetByID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		name = user.Name
+	}
+
+	payload, err := pix.Encode(pix.Payload{
+		Key:          req.Key,
+		URL:          req.URL,
+		Description:  req.Description,
+		MerchantName: name,
+		MerchantCity: req.MerchantCity,
+		PostalCode:   req.PostalCode,
+		Amount:       req.Amount,
+		TxID:         req.TxID,
+		SingleUse:    req.SingleUse,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrInvalidInput, err)
+	}
+
+	// Devolve o que foi de fato gravado no código, já normalizado e cortado
+	p, err := pix.Parse(payload)
+	if err != nil {
+		return nil, err
+	}
+	return pixPayloadDTO(payload, p), nil
+}
+
+// Parse lê um "Pix copia e cola" e sugere o lançamento do pagamento, com a
+// data de hoje no fuso do usuário
+func (s *PixService) Parse(ctx context.Context, userID string, req *dtos.PixParseRequest) (*dtos.PixParseResponse, error) {
+	p, err := pix.Parse(req.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrInvalidInput, err)
+	}
+
+	user, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	today := time.Now().In(FormatterFor(user).Location())
+
+	payee := firstNonEmpty(p.MerchantName, p.Key)
+	var notes []string
+	if p.Description != "" {
+		notes = append(notes, p.Description)
+	}
+	if p.TxID != "" {
+		notes = append(notes, "txid "+p.TxID)
+	}
+
+	return &dtos.PixParseResponse{
+		PixPayload: *pixPayloadDTO(strings.TrimSpace(req.Payload), p),
+		Transaction: dtos.TransactionRequest{
+			Date:        today.Format("2006-01-02"),
+			Description: "Pix " + payee,
+			Payee:       payee,
+			Notes:       strings.Join(notes, " - "),
+			AccountID:   req.AccountID,
+			// Saída da conta; sem valor no código, o usuário completa
+			Amount: p.Amount.Neg(),
+		},
+	}, nil
+}
+
+// QRCode desenha o BR Code como PNG, depois de conferir que ele é válido
+func (s *PixService) QRCode(req *dtos.PixQRCodeRequest) ([]byte, error) {
+	payload := strings.TrimSpace(req.Payload)
+	if _, err := pix.Parse(payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrInvalidInput, err)
+	}
+
+	// Nível M, como recomenda o manual do BR Code
+	code, err := qrcode.Encode([]byte(payload), qrcode.Medium)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrInvalidInput, err)
+	}
+	size := req.Size
+	if size == 0 {
+		size = defaultQRCodeSize
+	}
+	return code.PNG(size)
+}
+
+func pixPayloadDTO(payload string, p *pix.Payload) *dtos.PixPayload {
+	dto := &dtos.PixPayload{
+		Payload:      payload,
+		Dynamic:      p.Dynamic(),
+		Key:          p.Key,
+		URL:          p.URL,
+		Description:  p.Description,
+		MerchantName: p.MerchantName,
+		MerchantCity: p.MerchantCity,
+		TxID:         p.TxID,
+		SingleUse:    p.SingleUse,
+	}
+	if p.Key != "" {
+		if _, kind, err := pix.NormalizeKey(p.Key); err == nil {
+			dto.KeyType = string(kind)
+		}
+	}
+	if !p.Amount.IsZero() {
+		amount := p.Amount
+		dto.Amount = &amount
+	}
+	return dto
+}
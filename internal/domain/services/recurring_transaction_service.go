@@ -0,0 +1,318 @@
+package services
+
+import (
+	"context"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/internal/domain/repositories"
+	"finanvilla/pkg/errors"
+	"finanvilla/pkg/locale"
+	"finanvilla/pkg/recurrence"
+	"fmt"
+	"log"
+	"time"
+)
+
+const (
+	defaultUpcomingCount = 12
+	// materializeBatchSize limita quantos modelos cada execução do job processa
+	materializeBatchSize = 200
+)
+
+type RecurringTransactionService struct {
+	recurringRepo      repositories.RecurringTransactionRepository
+	transactionRepo    repositories.TransactionRepository
+	transactionService *TransactionService
+	txManager          repositories.TransactionManager
+}
+
+func NewRecurringTransactionService(
+	recurringRepo repositories.RecurringTransactionRepository,
+	transactionRepo repositories.TransactionRepository,
+	transactionService *TransactionService,
+	txManager repositories.TransactionManager,
+) *RecurringTransactionService {
+	return &RecurringTransactionService{
+		recurringRepo:      recurringRepo,
+		transactionRepo:    transactionRepo,
+		transactionService: transactionService,
+		txManager:          txManager,
+	}
+}
+
+// Create salva o modelo; ocorrências a partir de startDate, inclusive as já
+// passadas, são lançadas na próxima execução do job
+func (s *RecurringTransactionService) Create(ctx context.Context, userID string, req *dtos.RecurringTransactionRequest) (*entities.RecurringTransaction, error) {
+	recurring, rule, err := s.build(ctx, userID, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	next, ok := rule.Next(recurring.StartDate, recurring.StartDate)
+	if !ok {
+		return nil, fmt.Errorf("%w: rule has no occurrences after startDate", errors.ErrInvalidInput)
+	}
+	recurring.NextDate = &next
+	recurring.Active = true
+
+	if err := s.recurringRepo.Create(ctx, recurring); err != nil {
+		return nil, err
+	}
+	return s.recurringRepo.GetByID(ctx, userID, recurring.ID)
+}
+
+// Update substitui o modelo. Lançamentos já gerados não mudam; a próxima
+// ocorrência é recalculada a partir de hoje (ou da pendente mais antiga).
+func (s *RecurringTransactionService) Update(ctx context.Context, userID, id string, req *dtos.RecurringTransactionRequest) (*entities.RecurringTransaction, error) {
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		existing, err := s.recurringRepo.GetByID(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+
+		recurring, rule, err := s.build(ctx, userID, req, existing)
+		if err != nil {
+			return err
+		}
+		recurring.ID = id
+		recurring.Active = existing.Active
+
+		after := today()
+		if existing.NextDate != nil && existing.NextDate.Before(after) {
+			after = *existing.NextDate
+		}
+		recurring.NextDate = nextOccurrence(rule, recurring.StartDate, after)
+
+		return s.recurringRepo.Update(ctx, recurring)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.recurringRepo.GetByID(ctx, userID, id)
+}
+
+// SetActive pausa ou retoma o modelo. Ao retomar, ocorrências do período
+// pausado são puladas.
+func (s *RecurringTransactionService) SetActive(ctx context.Context, userID, id string, active bool) (*entities.RecurringTransaction, error) {
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		recurring, err := s.recurringRepo.GetByID(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+		if recurring.Active == active {
+			return nil
+		}
+
+		recurring.Active = active
+		if active {
+			rule, err := recurrence.Parse(recurring.Rule)
+			if err != nil {
+				return fmt.Errorf("%w: %v", errors.ErrInvalidInput, err)
+			}
+			recurring.NextDate = nextOccurrence(rule, recurring.StartDate, today())
+		}
+		return s.recurringRepo.Update(ctx, recurring)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.recurringRepo.GetByID(ctx, userID, id)
+}
+
+func (s *RecurringTransactionService) Delete(ctx context.Context, userID, id string) error {
+	return s.recurringRepo.Delete(ctx, userID, id)
+}
+
+func (s *RecurringTransactionService) GetByID(ctx context.Context, userID, id string) (*entities.RecurringTransaction, error) {
+	return s.recurringRepo.GetByID(ctx, userID, id)
+}
+
+func (s *RecurringTransactionService) List(ctx context.Context, userID string) ([]entities.RecurringTransaction, error) {
+	return s.recurringRepo.List(ctx, userID)
+}
+
+// Upcoming devolve as próximas ocorrências ainda não lançadas do modelo
+func (s *RecurringTransactionService) Upcoming(ctx context.Context, userID, id string, count int) ([]time.Time, error) {
+	recurring, err := s.recurringRepo.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !recurring.Active || recurring.NextDate == nil {
+		return []time.Time{}, nil
+	}
+
+	rule, err := recurrence.Parse(recurring.Rule)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrInvalidInput, err)
+	}
+	return rule.Upcoming(recurring.StartDate, *recurring.NextDate, normalizeUpcomingCount(count)), nil
+}
+
+// Preview calcula as primeiras ocorrências de uma regra antes de salvá-la
+func (s *RecurringTransactionService) Preview(req *dtos.RecurrencePreviewRequest) ([]time.Time, error) {
+	rule, err := recurrence.Parse(req.Rule)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrInvalidInput, err)
+	}
+	start, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("%w: startDate must be YYYY-MM-DD", errors.ErrInvalidInput)
+	}
+	return rule.Upcoming(start, start, normalizeUpcomingCount(req.Count)), nil
+}
+
+// MaterializeDue lança como pendentes todas as ocorrências vencidas até hoje.
+// O índice único por (modelo, data da ocorrência) torna a operação idempotente:
+// rodar de novo, ou em duas instâncias ao mesmo tempo, não duplica lançamentos.
+func (s *RecurringTransactionService) MaterializeDue(ctx context.Context) (int, error) {
+	asOf := today()
+
+	due, err := s.recurringRepo.ListDue(ctx, asOf, materializeBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for i := range due {
+		n, err := s.materialize(ctx, &due[i], asOf)
+		if err != nil {
+			// Um modelo com problema não impede os demais
+			log.Printf("Error materializing recurring transaction %s: %v", due[i].ID, err)
+			continue
+		}
+		created += n
+	}
+	return created, nil
+}
+
+func (s *RecurringTransactionService) materialize(ctx context.Context, recurring *entities.RecurringTransaction, asOf time.Time) (int, error) {
+	rule, err := recurrence.Parse(recurring.Rule)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, date := range rule.Between(recurring.StartDate, *recurring.NextDate, asOf) {
+			ok, err := s.transactionRepo.CreateOccurrence(ctx, occurrenceTransaction(recurring, date))
+			if err != nil {
+				return err
+			}
+			if ok {
+				created++
+			}
+		}
+		return s.recurringRepo.SetNextDate(ctx, recurring.ID, nextOccurrence(rule, recurring.StartDate, asOf.AddDate(0, 0, 1)))
+	})
+	return created, err
+}
+
+// build valida o modelo como se fosse um lançamento na data de início
+func (s *RecurringTransactionService) build(
+	ctx context.Context,
+	userID string,
+	req *dtos.RecurringTransactionRequest,
+	previous *entities.RecurringTransaction,
+) (*entities.RecurringTransaction, recurrence.Rule, error) {
+	rule, err := recurrence.Parse(req.Rule)
+	if err != nil {
+		return nil, rule, fmt.Errorf("%w: %v", errors.ErrInvalidInput, err)
+	}
+
+	var previousTransaction *entities.Transaction
+	if previous != nil {
+		previousTransaction = &entities.Transaction{}
+		for _, p := range previous.Postings {
+			previousTransaction.Postings = append(previousTransaction.Postings, entities.Posting{CategoryID: p.CategoryID})
+		}
+	}
+
+	transaction, err := s.transactionService.buildTransaction(ctx, userID, &dtos.TransactionRequest{
+		Date:        req.StartDate,
+		Description: req.Description,
+		Payee:       req.Payee,
+		Notes:       req.Notes,
+		Postings:    req.Postings,
+		AccountID:   req.AccountID,
+		ToAccountID: req.ToAccountID,
+		CategoryID:  req.CategoryID,
+		Amount:      req.Amount,
+	}, previousTransaction)
+	if err != nil {
+		return nil, rule, err
+	}
+
+	postings := make([]entities.RecurringPosting, len(transaction.Postings))
+	for i, p := range transaction.Postings {
+		postings[i] = entities.RecurringPosting{
+			AccountID:  p.AccountID,
+			CategoryID: p.CategoryID,
+			Amount:     p.Amount,
+			Memo:       p.Memo,
+		}
+	}
+
+	return &entities.RecurringTransaction{
+		UserID:      userID,
+		Description: transaction.Description,
+		Payee:       transaction.Payee,
+		Notes:       transaction.Notes,
+		Currency:    transaction.Currency,
+		Rule:        rule.String(),
+		StartDate:   transaction.Date,
+		Postings:    postings,
+	}, rule, nil
+}
+
+func occurrenceTransaction(recurring *entities.RecurringTransaction, date time.Time) *entities.Transaction {
+	postings := make([]entities.Posting, len(recurring.Postings))
+	for i, p := range recurring.Postings {
+		postings[i] = entities.Posting{
+			AccountID:  p.AccountID,
+			CategoryID: p.CategoryID,
+			Amount:     p.Amount,
+			Memo:       p.Memo,
+		}
+	}
+
+	occurrence := date
+	return &entities.Transaction{
+		UserID:         recurring.UserID,
+		Date:           date,
+		Description:    recurring.Description,
+		Payee:          recurring.Payee,
+		Notes:          recurring.Notes,
+		Status:         enums.PendingTransaction,
+		Currency:       recurring.Currency,
+		Postings:       postings,
+		RecurringID:    &recurring.ID,
+		OccurrenceDate: &occurrence,
+	}
+}
+
+// nextOccurrence devolve a primeira ocorrência em after (ou em start, se for
+// depois) ou nil quando a série terminou
+func nextOccurrence(rule recurrence.Rule, start, after time.Time) *time.Time {
+	if start.After(after) {
+		after = start
+	}
+	next, ok := rule.Next(start, after)
+	if !ok {
+		return nil
+	}
+	return &next
+}
+
+func normalizeUpcomingCount(count int) int {
+	if count < 1 || count > 100 {
+		return defaultUpcomingCount
+	}
+	return count
+}
+
+// today é a data corrente no fuso padrão do sistema, como data sem hora
+func today() time.Time {
+	y, m, d := time.Now().In(locale.Default.Location()).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,21 @@
+package services
+
+import "context"
+
+// RequestMeta carrega dados da requisição HTTP que os serviços de domínio
+// registram em trilhas de auditoria sem depender do framework web.
+type RequestMeta struct {
+	IP        string
+	UserAgent string
+}
+
+type requestMetaKey struct{}
+
+func ContextWithRequestMeta(ctx context.Context, meta RequestMeta) context.Context {
+	return context.WithValue(ctx, requestMetaKey{}, meta)
+}
+
+func RequestMetaFrom(ctx context.Context) RequestMeta {
+	meta, _ := ctx.Value(requestMetaKey{}).(RequestMeta)
+	return meta
+}
@@ -0,0 +1,327 @@
+package services
+
+import (
+	"context"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/repositories"
+	"finanvilla/pkg/errors"
+	"finanvilla/pkg/locale"
+	"finanvilla/pkg/money"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SavedSearchService guarda combinações de filtros de lançamentos e as usa na
+// listagem e no relatório da pesquisa. O orçamento e a exportação QIF usam
+// as mesmas pesquisas pelos seus próprios serviços.
+type SavedSearchService struct {
+	savedSearchRepo repositories.SavedSearchRepository
+	transactionRepo repositories.TransactionRepository
+	categoryRepo    repositories.CategoryRepository
+	userService     *UserService
+}
+
+func NewSavedSearchService(
+	savedSearchRepo repositories.SavedSearchRepository,
+	transactionRepo repositories.TransactionRepository,
+	categoryRepo repositories.CategoryRepository,
+	userService *UserService,
+) *SavedSearchService {
+	return &SavedSearchService{
+		savedSearchRepo: savedSearchRepo,
+		transactionRepo: transactionRepo,
+		categoryRepo:    categoryRepo,
+		userService:     userService,
+	}
+}
+
+func (s *SavedSearchService) List(ctx context.Context, userID string) ([]entities.SavedSearch, error) {
+	return s.savedSearchRepo.List(ctx, userID)
+}
+
+func (s *SavedSearchService) GetByID(ctx context.Context, userID, id string) (*entities.SavedSearch, error) {
+	return s.savedSearchRepo.GetByID(ctx, userID, id)
+}
+
+// Create grava a pesquisa na moeda do usuário, que é a moeda do limite e do
+// relatório
+func (s *SavedSearchService) Create(ctx context.Context, userID string, req *dtos.SavedSearchRequest) (*entities.SavedSearch, error) {
+	user, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	currency := locale.Default.Currency()
+	if user.Settings != nil && user.Settings.Currency != "" {
+		currency = user.Settings.Currency
+	}
+
+	search := &entities.SavedSearch{UserID: userID, Currency: currency}
+	if err := applySavedSearchRequest(search, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.savedSearchRepo.Create(ctx, search); err != nil {
+		return nil, err
+	}
+	return search, nil
+}
+
+// Update substitui nome, filtros e limite; a moeda continua a da criação
+func (s *SavedSearchService) Update(ctx context.Context, userID, id string, req *dtos.SavedSearchRequest) (*entities.SavedSearch, error) {
+	search, err := s.savedSearchRepo.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := applySavedSearchRequest(search, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.savedSearchRepo.Update(ctx, search); err != nil {
+		return nil, err
+	}
+	return search, nil
+}
+
+func (s *SavedSearchService) Delete(ctx context.Context, userID, id string) error {
+	return s.savedSearchRepo.Delete(ctx, userID, id)
+}
+
+// Transactions lista os lançamentos que passam nos filtros da pesquisa
+func (s *SavedSearchService) Transactions(ctx context.Context, userID, id string, page, limit int) ([]entities.Transaction, int, error) {
+	search, err := s.savedSearchRepo.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, 0, err
+	}
+	filter, err := searchFilter(search.Filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	page, limit = normalizePage(page, limit)
+	return s.transactionRepo.List(ctx, userID, filter, page, limit)
+}
+
+// Report resume a pesquisa por categoria e por mês. from e to, opcionais,
+// restringem o período dos próprios filtros.
+func (s *SavedSearchService) Report(ctx context.Context, userID, id string, from, to *time.Time) (*dtos.SearchReport, error) {
+	search, err := s.savedSearchRepo.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := searchFilter(search.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []repositories.TransactionSummaryRow
+	count := 0
+	if narrowPeriod(&filter, from, to) {
+		if rows, count, err = s.transactionRepo.Summary(ctx, userID, search.Currency, filter); err != nil {
+			return nil, err
+		}
+	}
+
+	categories, err := s.categoryRepo.List(ctx, userID, repositories.CategoryFilter{IncludeArchived: true})
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := summarizeSearch(search.Currency, rows, categoryPaths(categories))
+	if err != nil {
+		return nil, err
+	}
+	report.SavedSearchID = search.ID
+	report.Name = search.Name
+	report.Transactions = count
+	if filter.From != nil {
+		report.From = filter.From.Format("2006-01-02")
+	}
+	if filter.To != nil {
+		report.To = filter.To.Format("2006-01-02")
+	}
+	return report, nil
+}
+
+func applySavedSearchRequest(search *entities.SavedSearch, req *dtos.SavedSearchRequest) error {
+	search.Name = strings.TrimSpace(req.Name)
+	if search.Name == "" {
+		return fmt.Errorf("%w: name is required", errors.ErrInvalidInput)
+	}
+
+	search.Filter = entities.SearchFilter{
+		AccountIDs:  req.Filter.AccountIDs,
+		CategoryIDs: req.Filter.CategoryIDs,
+		TagIDs:      req.Filter.TagIDs,
+		Status:      req.Filter.Status,
+		From:        req.Filter.From,
+		To:          req.Filter.To,
+		MinAmount:   req.Filter.MinAmount,
+		MaxAmount:   req.Filter.MaxAmount,
+		Text:        strings.TrimSpace(req.Filter.Text),
+	}
+	if _, err := searchFilter(search.Filter); err != nil {
+		return err
+	}
+
+	search.Budget = nil
+	if req.Budget != nil {
+		budget, err := req.Budget.WithCurrency(search.Currency)
+		if err != nil {
+			return fmt.Errorf("%w: budget: %v", errors.ErrInvalidInput, err)
+		}
+		if budget.Sign() <= 0 {
+			return fmt.Errorf("%w: budget must be positive", errors.ErrInvalidInput)
+		}
+		search.Budget = &budget
+	}
+	return nil
+}
+
+// searchFilter converte e valida os filtros gravados da pesquisa
+func searchFilter(f entities.SearchFilter) (repositories.TransactionFilter, error) {
+	filter := repositories.TransactionFilter{
+		AccountIDs:  f.AccountIDs,
+		CategoryIDs: f.CategoryIDs,
+		TagIDs:      f.TagIDs,
+		Status:      f.Status,
+		MinAmount:   f.MinAmount,
+		MaxAmount:   f.MaxAmount,
+		Text:        f.Text,
+	}
+
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return filter, fmt.Errorf("%w: unknown status %q", errors.ErrInvalidInput, filter.Status)
+	}
+	if filter.MinAmount != nil && filter.MaxAmount != nil {
+		if cmp, err := filter.MinAmount.Cmp(*filter.MaxAmount); err == nil && cmp > 0 {
+			return filter, fmt.Errorf("%w: minAmount is greater than maxAmount", errors.ErrInvalidInput)
+		}
+	}
+	for _, d := range []struct {
+		value string
+		dst   **time.Time
+	}{{f.From, &filter.From}, {f.To, &filter.To}} {
+		if d.value == "" {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", d.value)
+		if err != nil {
+			return filter, fmt.Errorf("%w: invalid date %q", errors.ErrInvalidInput, d.value)
+		}
+		*d.dst = &date
+	}
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		return filter, fmt.Errorf("%w: from is after to", errors.ErrInvalidInput)
+	}
+	return filter, nil
+}
+
+// narrowPeriod restringe o período do filtro a from..to e devolve false se
+// não sobrar nenhum dia
+func narrowPeriod(filter *repositories.TransactionFilter, from, to *time.Time) bool {
+	if from != nil && (filter.From == nil || from.After(*filter.From)) {
+		start := *from
+		filter.From = &start
+	}
+	if to != nil && (filter.To == nil || to.Before(*filter.To)) {
+		end := *to
+		filter.To = &end
+	}
+	return filter.From == nil || filter.To == nil || !filter.From.After(*filter.To)
+}
+
+// summarizeSearch soma as linhas do banco em totais, por categoria (do maior
+// gasto ao menor) e por mês (em ordem cronológica)
+func summarizeSearch(currency string, rows []repositories.TransactionSummaryRow, categoryNames map[string]string) (*dtos.SearchReport, error) {
+	zero, err := money.Zero(currency)
+	if err != nil {
+		return nil, err
+	}
+	report := &dtos.SearchReport{
+		Currency:   currency,
+		Inflow:     zero,
+		Outflow:    zero,
+		Net:        zero,
+		Categories: []dtos.SearchReportCategory{},
+		Months:     []dtos.SearchReportMonth{},
+	}
+
+	categories := make(map[string]int)
+	months := make(map[string]int)
+	for _, row := range rows {
+		key := ""
+		if row.CategoryID != nil {
+			key = *row.CategoryID
+		}
+		i, ok := categories[key]
+		if !ok {
+			name := "Uncategorized"
+			if row.CategoryID != nil {
+				name = categoryNames[key]
+			}
+			i = len(report.Categories)
+			categories[key] = i
+			report.Categories = append(report.Categories, dtos.SearchReportCategory{
+				CategoryID: row.CategoryID, Name: name, Inflow: zero, Outflow: zero,
+			})
+		}
+		category := &report.Categories[i]
+		if category.Inflow, err = category.Inflow.Add(row.Inflow); err != nil {
+			return nil, err
+		}
+		if category.Outflow, err = category.Outflow.Add(row.Outflow); err != nil {
+			return nil, err
+		}
+
+		label := row.Month.Format("2006-01")
+		j, ok := months[label]
+		if !ok {
+			j = len(report.Months)
+			months[label] = j
+			report.Months = append(report.Months, dtos.SearchReportMonth{Month: label, Inflow: zero, Outflow: zero})
+		}
+		month := &report.Months[j]
+		if month.Inflow, err = month.Inflow.Add(row.Inflow); err != nil {
+			return nil, err
+		}
+		if month.Outflow, err = month.Outflow.Add(row.Outflow); err != nil {
+			return nil, err
+		}
+
+		if report.Inflow, err = report.Inflow.Add(row.Inflow); err != nil {
+			return nil, err
+		}
+		if report.Outflow, err = report.Outflow.Add(row.Outflow); err != nil {
+			return nil, err
+		}
+	}
+
+	if report.Net, err = report.Inflow.Sub(report.Outflow); err != nil {
+		return nil, err
+	}
+	for i := range report.Categories {
+		c := &report.Categories[i]
+		if c.Net, err = c.Inflow.Sub(c.Outflow); err != nil {
+			return nil, err
+		}
+	}
+	for i := range report.Months {
+		m := &report.Months[i]
+		if m.Net, err = m.Inflow.Sub(m.Outflow); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(report.Categories, func(i, j int) bool {
+		cmp, _ := report.Categories[i].Outflow.Cmp(report.Categories[j].Outflow)
+		if cmp != 0 {
+			return cmp > 0
+		}
+		return report.Categories[i].Name < report.Categories[j].Name
+	})
+	sort.Slice(report.Months, func(i, j int) bool { return report.Months[i].Month < report.Months[j].Month })
+	return report, nil
+}
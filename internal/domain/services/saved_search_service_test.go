@@ -0,0 +1,82 @@
+package services
+
+import (
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/repositories"
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeTagNames(t *testing.T) {
+	got, err := normalizeTagNames([]string{" #Viagem ", "viagem", "férias  2026", "Trabalho"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"Viagem", "férias 2026", "Trabalho"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("normalizeTagNames = %q, want %q", got, want)
+	}
+
+	if _, err := normalizeTagNames([]string{"#"}); err == nil {
+		t.Error("empty tag was accepted")
+	}
+}
+
+func TestSearchFilterPeriod(t *testing.T) {
+	filter, err := searchFilter(entities.SearchFilter{From: "2026-01-10", To: "2026-03-31"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	from, to := mustDate("2026-01-01"), mustDate("2026-01-31")
+	if !narrowPeriod(&filter, &from, &to) {
+		t.Fatal("period should not be empty")
+	}
+	if !filter.From.Equal(mustDate("2026-01-10")) || !filter.To.Equal(to) {
+		t.Errorf("period = %s..%s, want 2026-01-10..2026-01-31", filter.From, filter.To)
+	}
+
+	from, to = mustDate("2026-04-01"), mustDate("2026-04-30")
+	if narrowPeriod(&filter, &from, &to) {
+		t.Error("April should be outside the search period")
+	}
+
+	if _, err := searchFilter(entities.SearchFilter{From: "2026-02-01", To: "2026-01-01"}); err == nil {
+		t.Error("from after to was accepted")
+	}
+}
+
+func TestSummarizeSearch(t *testing.T) {
+	food, travel := "food", "travel"
+	jan, feb := mustDate("2026-01-01"), mustDate("2026-02-01")
+	rows := []repositories.TransactionSummaryRow{
+		{Month: feb, CategoryID: &food, Inflow: brl(0), Outflow: brl(3000)},
+		{Month: jan, CategoryID: &travel, Inflow: brl(500), Outflow: brl(20000)},
+		{Month: jan, CategoryID: &food, Inflow: brl(0), Outflow: brl(4500)},
+		{Month: jan, CategoryID: nil, Inflow: brl(1000), Outflow: brl(0)},
+	}
+
+	report, err := summarizeSearch("BRL", rows, map[string]string{food: "Food", travel: "Travel"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Outflow.MinorUnits() != 27500 || report.Inflow.MinorUnits() != 1500 || report.Net.MinorUnits() != -26000 {
+		t.Errorf("totals = %s in, %s out, %s net", report.Inflow.Decimal(), report.Outflow.Decimal(), report.Net.Decimal())
+	}
+
+	var names []string
+	for _, c := range report.Categories {
+		names = append(names, c.Name)
+	}
+	if want := []string{"Travel", "Food", "Uncategorized"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("categories = %q, want %q", names, want)
+	}
+	if report.Categories[1].Outflow.MinorUnits() != 7500 {
+		t.Errorf("food outflow = %s, want 75.00", report.Categories[1].Outflow.Decimal())
+	}
+
+	if len(report.Months) != 2 || report.Months[0].Month != "2026-01" || report.Months[0].Net.MinorUnits() != -23000 {
+		t.Errorf("months = %+v", report.Months)
+	}
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/internal/domain/repositories"
+	"log"
+	"time"
+)
+
+const maxUserAgentLength = 512
+
+type SecurityEventService struct {
+	eventRepo repositories.SecurityEventRepository
+	retention time.Duration
+}
+
+func NewSecurityEventService(eventRepo repositories.SecurityEventRepository, retention time.Duration) *SecurityEventService {
+	if retention <= 0 {
+		retention = 180 * 24 * time.Hour // Eventos são mantidos por 180 dias por padrão
+	}
+	return &SecurityEventService{
+		eventRepo: eventRepo,
+		retention: retention,
+	}
+}
+
+// Record grava o evento com o IP e o user agent da requisição em ctx. A
+// gravação é best-effort: uma falha aqui não deve interromper login ou logout.
+// Dentro de uma transação, o evento vai num savepoint e some junto com ela
+// se quem chamou desfizer a operação.
+func (s *SecurityEventService) Record(ctx context.Context, event *entities.SecurityEvent) {
+	if s == nil {
+		return
+	}
+
+	meta := RequestMetaFrom(ctx)
+	event.IP = meta.IP
+	event.UserAgent = meta.UserAgent
+	if len(event.UserAgent) > maxUserAgentLength {
+		event.UserAgent = event.UserAgent[:maxUserAgentLength]
+	}
+
+	if err := s.eventRepo.Create(ctx, event); err != nil {
+		log.Printf("Error recording security event %s: %v", event.Type, err)
+	}
+}
+
+// RecordForUser é um atalho para eventos de um usuário conhecido
+func (s *SecurityEventService) RecordForUser(
+	ctx context.Context,
+	user *entities.User,
+	eventType enums.SecurityEventType,
+	outcome enums.SecurityEventOutcome,
+	reason string,
+) {
+	if user == nil {
+		return
+	}
+
+	userID := user.ID
+	s.Record(ctx, &entities.SecurityEvent{
+		UserID:  &userID,
+		Email:   user.Email,
+		Type:    eventType,
+		Outcome: outcome,
+		Reason:  reason,
+	})
+}
+
+func (s *SecurityEventService) ListForUser(ctx context.Context, userID string, page, limit int) ([]entities.SecurityEvent, int, error) {
+	return s.Search(ctx, repositories.SecurityEventFilter{UserID: userID}, page, limit)
+}
+
+func (s *SecurityEventService) Search(ctx context.Context, filter repositories.SecurityEventFilter, page, limit int) ([]entities.SecurityEvent, int, error) {
+	page, limit = normalizePage(page, limit)
+	return s.eventRepo.List(ctx, filter, page, limit)
+}
+
+// Cleanup remove eventos mais antigos que o período de retenção configurado
+func (s *SecurityEventService) Cleanup(ctx context.Context) error {
+	return s.eventRepo.DeleteOlderThan(ctx, time.Now().Add(-s.retention))
+}
@@ -0,0 +1,298 @@
+package services
+
+import (
+	"context"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/internal/domain/repositories"
+	"finanvilla/pkg/errors"
+	"finanvilla/pkg/money"
+	"finanvilla/pkg/qif"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// exportBatchSize é quantos lançamentos são lidos do banco por vez na exportação
+const exportBatchSize = 500
+
+// StatementExportService exporta o histórico de uma conta para outros programas
+type StatementExportService struct {
+	transactionRepo repositories.TransactionRepository
+	accountRepo     repositories.AccountRepository
+	categoryRepo    repositories.CategoryRepository
+	savedSearchRepo repositories.SavedSearchRepository
+	userService     *UserService
+}
+
+func NewStatementExportService(
+	transactionRepo repositories.TransactionRepository,
+	accountRepo repositories.AccountRepository,
+	categoryRepo repositories.CategoryRepository,
+	savedSearchRepo repositories.SavedSearchRepository,
+	userService *UserService,
+) *StatementExportService {
+	return &StatementExportService{
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+		categoryRepo:    categoryRepo,
+		savedSearchRepo: savedSearchRepo,
+		userService:     userService,
+	}
+}
+
+// QIFExport tem tudo o que a exportação precisa além dos lançamentos, que
+// são lidos em lotes durante Write. Assim os erros de cadastro aparecem antes
+// de a resposta começar a ser enviada.
+type QIFExport struct {
+	Account *entities.Account
+	// Search é a pesquisa salva que restringe os lançamentos, se houver
+	Search *entities.SavedSearch
+
+	s          *StatementExportService
+	userID     string
+	filter     repositories.TransactionFilter
+	order      qif.DateOrder
+	accounts   map[string]string
+	categories map[string]string
+	incomes    map[string]bool
+}
+
+// ExportQIF prepara a exportação da conta. dateOrder (MDY ou DMY) vazio segue
+// o formato de data do usuário. Com searchID, só saem os lançamentos da
+// pesquisa salva, sem o registro de saldo inicial.
+func (s *StatementExportService) ExportQIF(ctx context.Context, userID, accountID, dateOrder, searchID string) (*QIFExport, error) {
+	account, err := s.accountRepo.GetByID(ctx, userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	var search *entities.SavedSearch
+	var filter repositories.TransactionFilter
+	if searchID != "" {
+		if search, err = s.savedSearchRepo.GetByID(ctx, userID, searchID); err != nil {
+			return nil, err
+		}
+		if filter, err = searchFilter(search.Filter); err != nil {
+			return nil, err
+		}
+	}
+
+	order := qif.DateOrder(dateOrder)
+	switch order {
+	case qif.MonthFirst, qif.DayFirst:
+	case "":
+		order = qif.MonthFirst
+		user, err := s.userService.GetByID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if user.Settings != nil && strings.HasPrefix(user.Settings.DateFormat, "DD") {
+			order = qif.DayFirst
+		}
+	default:
+		return nil, fmt.Errorf("%w: dateOrder must be MDY or DMY", errors.ErrInvalidInput)
+	}
+
+	accounts, err := s.accountRepo.List(ctx, userID, repositories.AccountFilter{IncludeArchived: true})
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.categoryRepo.List(ctx, userID, repositories.CategoryFilter{IncludeArchived: true})
+	if err != nil {
+		return nil, err
+	}
+
+	export := &QIFExport{
+		Account:    account,
+		Search:     search,
+		s:          s,
+		userID:     userID,
+		filter:     filter,
+		order:      order,
+		accounts:   make(map[string]string, len(accounts)),
+		categories: categoryPaths(categories),
+		incomes:    make(map[string]bool, len(categories)),
+	}
+	for _, a := range accounts {
+		export.accounts[a.ID] = a.Name
+	}
+	for _, c := range categories {
+		export.incomes[c.ID] = c.Type == enums.IncomeCategory
+	}
+	return export, nil
+}
+
+// Write grava as categorias, a conta com o saldo inicial e os lançamentos
+func (e *QIFExport) Write(ctx context.Context, w io.Writer) error {
+	qw := qif.NewWriter(w, e.order)
+
+	if len(e.categories) > 0 {
+		categories := make([]qif.Category, 0, len(e.categories))
+		for id, path := range e.categories {
+			categories = append(categories, qif.Category{Name: path, Income: e.incomes[id]})
+		}
+		sort.Slice(categories, func(i, j int) bool { return categories[i].Name < categories[j].Name })
+		if err := qw.WriteCategories(categories); err != nil {
+			return err
+		}
+	}
+
+	section := qifSection(e.Account.Type)
+	if err := qw.WriteAccount(qif.Account{Name: e.Account.Name, Type: section, Description: e.Account.Institution}); err != nil {
+		return err
+	}
+
+	for offset := 0; ; offset += exportBatchSize {
+		batch, err := e.s.transactionRepo.ListByAccount(ctx, e.userID, e.Account.ID, e.filter, offset, exportBatchSize)
+		if err != nil {
+			return err
+		}
+
+		if offset == 0 && e.Search == nil {
+			opening := qif.Transaction{
+				Date:     e.Account.CreatedAt,
+				Amount:   e.Account.OpeningBalance,
+				Payee:    "Opening Balance",
+				Cleared:  qif.Reconciled,
+				Transfer: e.Account.Name,
+			}
+			if len(batch) > 0 {
+				opening.Date = batch[0].Date
+			}
+			if section == qif.Investment {
+				opening.Action = "XIn"
+				opening.TransferAmount = opening.Amount
+			}
+			if err := qw.WriteTransaction(opening); err != nil {
+				return err
+			}
+		}
+
+		for i := range batch {
+			for _, record := range e.records(&batch[i], section) {
+				if err := qw.WriteTransaction(record); err != nil {
+					return err
+				}
+			}
+		}
+		if len(batch) < exportBatchSize {
+			break
+		}
+	}
+	return qw.Flush()
+}
+
+// records converte o lançamento do ponto de vista da conta exportada. Em
+// contas bancárias, várias contrapartidas viram splits; em investimentos, que
+// não têm splits no QIF, cada contrapartida vira um registro.
+func (e *QIFExport) records(t *entities.Transaction, section qif.SectionType) []qif.Transaction {
+	base := qif.Transaction{
+		Date:    t.Date,
+		Payee:   firstNonEmpty(t.Payee, t.Description),
+		Memo:    t.Notes,
+		Cleared: qifCleared(t.Status),
+	}
+	if t.Payee != "" && t.Description != t.Payee {
+		base.Memo = strings.TrimSpace(t.Description + " " + t.Notes)
+	}
+
+	var amount money.Money
+	var others []entities.Posting
+	for _, p := range t.Postings {
+		if p.AccountID != nil && *p.AccountID == e.Account.ID {
+			if amount.Currency() == "" {
+				amount = p.Amount
+			} else if sum, err := amount.Add(p.Amount); err == nil {
+				amount = sum
+			}
+			continue
+		}
+		others = append(others, p)
+	}
+	base.Amount = amount
+
+	if section == qif.Investment {
+		if len(others) == 0 {
+			return []qif.Transaction{investmentRecord(base, amount, "", "")}
+		}
+		records := make([]qif.Transaction, len(others))
+		for i, p := range others {
+			category, transfer := e.target(p)
+			records[i] = investmentRecord(base, p.Amount.Neg(), category, transfer)
+		}
+		return records
+	}
+
+	switch len(others) {
+	case 0:
+	case 1:
+		base.Category, base.Transfer = e.target(others[0])
+	default:
+		for _, p := range others {
+			category, transfer := e.target(p)
+			base.Splits = append(base.Splits, qif.Split{
+				Category: category,
+				Transfer: transfer,
+				Memo:     p.Memo,
+				Amount:   p.Amount.Neg(),
+			})
+		}
+	}
+	return []qif.Transaction{base}
+}
+
+// target devolve o caminho da categoria ou o nome da conta da perna
+func (e *QIFExport) target(p entities.Posting) (string, string) {
+	switch {
+	case p.AccountID != nil:
+		return "", e.accounts[*p.AccountID]
+	case p.CategoryID != nil:
+		return e.categories[*p.CategoryID], ""
+	}
+	return "", ""
+}
+
+func investmentRecord(base qif.Transaction, amount money.Money, category, transfer string) qif.Transaction {
+	record := base
+	record.Amount = amount.Abs()
+	record.Category, record.Transfer = category, transfer
+
+	switch {
+	case transfer != "" && amount.Sign() >= 0:
+		record.Action = "XIn"
+	case transfer != "":
+		record.Action = "XOut"
+	case amount.Sign() >= 0:
+		record.Action = "MiscInc"
+	default:
+		record.Action = "MiscExp"
+	}
+	if transfer != "" {
+		record.TransferAmount = record.Amount
+	}
+	return record
+}
+
+func qifSection(t enums.AccountType) qif.SectionType {
+	switch t {
+	case enums.CreditCardAccount:
+		return qif.CreditCard
+	case enums.CashAccount:
+		return qif.Cash
+	case enums.InvestmentAccount:
+		return qif.Investment
+	}
+	return qif.Bank
+}
+
+func qifCleared(status enums.TransactionStatus) qif.ClearedStatus {
+	switch status {
+	case enums.ClearedTransaction:
+		return qif.Cleared
+	case enums.ReconciledTransaction:
+		return qif.Reconciled
+	}
+	return qif.Uncleared
+}
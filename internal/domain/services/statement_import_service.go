@@ -0,0 +1,696 @@
+package services
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/internal/domain/repositories"
+	"finanvilla/pkg/csvimport"
+	"finanvilla/pkg/errors"
+	"finanvilla/pkg/money"
+	"finanvilla/pkg/ofx"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	// importBatchSize é quantas movimentações são deduplicadas e gravadas por vez
+	importBatchSize = 500
+	// maxReportedEntries limita as linhas novas e duplicadas listadas no
+	// relatório; linhas com erro são sempre listadas
+	maxReportedEntries = 1000
+	csvInspectRows     = 10
+)
+
+// StatementImportService importa extratos bancários para uma conta. Cada
+// formato só converte o arquivo em statementEntry; a deduplicação pelo
+// identificador externo, a conferência de saldo e a gravação são comuns.
+type StatementImportService struct {
+	transactionRepo repositories.TransactionRepository
+	accountRepo     repositories.AccountRepository
+	categoryRepo    repositories.CategoryRepository
+	profileRepo     repositories.CSVImportProfileRepository
+	ruleRepo        repositories.CategorizationRuleRepository
+	tagRepo         repositories.TagRepository
+	userService     *UserService
+	txManager       repositories.TransactionManager
+}
+
+func NewStatementImportService(
+	transactionRepo repositories.TransactionRepository,
+	accountRepo repositories.AccountRepository,
+	categoryRepo repositories.CategoryRepository,
+	profileRepo repositories.CSVImportProfileRepository,
+	ruleRepo repositories.CategorizationRuleRepository,
+	tagRepo repositories.TagRepository,
+	userService *UserService,
+	txManager repositories.TransactionManager,
+) *StatementImportService {
+	return &StatementImportService{
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+		categoryRepo:    categoryRepo,
+		profileRepo:     profileRepo,
+		ruleRepo:        ruleRepo,
+		tagRepo:         tagRepo,
+		userService:     userService,
+		txManager:       txManager,
+	}
+}
+
+// statementEntry é uma movimentação do extrato, já no sinal da conta
+// (positivo entra). Err marca linhas que não puderam ser lidas.
+type statementEntry struct {
+	Row         int
+	ExternalID  string
+	Date        time.Time
+	Amount      money.Money
+	Payee       string
+	Description string
+	Memo        string
+	// Status vazio grava o lançamento como conferido
+	Status enums.TransactionStatus
+	// Splits classificam a contrapartida; o que sobrar fica sem categoria
+	Splits []entrySplit
+	Err    error
+}
+
+// entrySplit é uma parte da contrapartida, no mesmo sinal de Amount. Com
+// AccountID é uma transferência, e ExternalID identifica a perna na outra conta.
+type entrySplit struct {
+	Amount     money.Money
+	CategoryID *string
+	AccountID  *string
+	ExternalID string
+	Memo       string
+}
+
+// entrySource devolve uma movimentação por vez; false ao fim do arquivo.
+// Um erro interrompe a importação inteira. O contexto é o da transação da
+// importação, para que a origem possa gravar o que precisar junto com ela.
+type entrySource func(ctx context.Context) (statementEntry, bool, error)
+
+func sliceSource(entries []statementEntry) entrySource {
+	next := 0
+	return func(context.Context) (statementEntry, bool, error) {
+		if next >= len(entries) {
+			return statementEntry{}, false, nil
+		}
+		next++
+		return entries[next-1], true, nil
+	}
+}
+
+// statementBalance é o saldo final informado pelo banco
+type statementBalance struct {
+	Amount money.Money
+	AsOf   time.Time
+}
+
+// ImportOFX importa os STMTTRN de um extrato OFX. Com dryRun, só devolve a
+// prévia: o que é novo, o que já foi importado e a conferência do LEDGERBAL.
+func (s *StatementImportService) ImportOFX(ctx context.Context, userID, accountID string, r io.Reader, opts dtos.StatementImportOptions) (*dtos.StatementImportReport, error) {
+	account, err := s.importAccount(ctx, userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	statements, err := ofx.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrInvalidInput, err)
+	}
+	statement, err := chooseStatement(statements, opts.Statement)
+	if err != nil {
+		return nil, err
+	}
+	if statement.Currency != "" && statement.Currency != account.Currency {
+		return nil, fmt.Errorf("%w: statement is in %s but the account is in %s", errors.ErrInvalidInput, statement.Currency, account.Currency)
+	}
+
+	entries := make([]statementEntry, len(statement.Transactions))
+	for i, t := range statement.Transactions {
+		entries[i] = statementEntry{
+			Row:         i + 1,
+			ExternalID:  strings.TrimSpace(t.FITID),
+			Date:        t.Posted,
+			Amount:      t.Amount,
+			Payee:       t.Name,
+			Description: firstNonEmpty(t.Name, t.Memo, t.Type),
+			Memo:        t.Memo,
+		}
+	}
+
+	var balance *statementBalance
+	if statement.LedgerBalance != nil {
+		balance = &statementBalance{Amount: statement.LedgerBalance.Amount, AsOf: statement.LedgerBalance.AsOf}
+		if balance.AsOf.IsZero() {
+			balance.AsOf = statement.End
+		}
+	}
+
+	return s.importEntries(ctx, userID, account, "ofx", sliceSource(entries), balance, opts.DryRun)
+}
+
+// ImportCSV importa um CSV com o mapeamento de um perfil salvo ou o da query
+// string. O arquivo é lido em fluxo; só as linhas do relatório ficam em memória.
+func (s *StatementImportService) ImportCSV(ctx context.Context, userID, accountID string, r io.Reader, opts dtos.CSVImportOptions) (*dtos.StatementImportReport, error) {
+	account, err := s.importAccount(ctx, userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping := opts.CSVMapping
+	if opts.ProfileID != "" {
+		profile, err := s.profileRepo.GetByID(ctx, userID, opts.ProfileID)
+		if err != nil {
+			return nil, err
+		}
+		mapping = profileMapping(profile)
+	}
+
+	settings, err := s.userSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	m, dialect, err := buildCSVMapping(&mapping, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := csvimport.NewReader(r, dialect)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrInvalidInput, err)
+	}
+	header, err := readCSVHeader(reader, m.SkipRows, m.HasHeader)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := m.Columns(header)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrInvalidInput, err)
+	}
+
+	next := func(context.Context) (statementEntry, bool, error) {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return statementEntry{}, false, nil
+		}
+		if err != nil {
+			return statementEntry{}, false, err
+		}
+		if record.Err != nil {
+			return statementEntry{Row: record.Line, Err: record.Err}, true, nil
+		}
+
+		row, err := columns.Parse(record.Fields)
+		return statementEntry{
+			Row:         record.Line,
+			ExternalID:  row.ExternalID,
+			Date:        row.Date,
+			Amount:      row.Amount,
+			Payee:       row.Payee,
+			Description: row.Description,
+			Memo:        row.Memo,
+			Err:         err,
+		}, true, nil
+	}
+
+	return s.importEntries(ctx, userID, account, "csv", next, nil, opts.DryRun)
+}
+
+// InspectCSV devolve o que foi detectado no arquivo, as primeiras linhas e um
+// mapeamento sugerido, para o usuário conferir antes de importar
+func (s *StatementImportService) InspectCSV(r io.Reader, mapping dtos.CSVMapping) (*dtos.CSVInspection, error) {
+	dialect, err := csvDialect(&mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := csvimport.NewReader(r, dialect)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrInvalidInput, err)
+	}
+	hasHeader := mapping.HasHeader == nil || *mapping.HasHeader
+	header, err := readCSVHeader(reader, mapping.SkipRows, hasHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := [][]string{}
+	for len(rows) < csvInspectRows {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errors.ErrInvalidInput, err)
+		}
+		if record.Err == nil {
+			rows = append(rows, record.Fields)
+		}
+	}
+
+	detected := reader.Dialect()
+	delimiter := string(detected.Delimiter)
+	if detected.Delimiter == '\t' {
+		delimiter = "tab"
+	}
+
+	inspection := &dtos.CSVInspection{
+		Encoding:  string(detected.Encoding),
+		Delimiter: delimiter,
+		Header:    header,
+		Rows:      rows,
+		Suggested: suggestCSVMapping(header, rows),
+	}
+	inspection.Suggested.Delimiter = delimiter
+	inspection.Suggested.Encoding = string(detected.Encoding)
+	inspection.Suggested.SkipRows = mapping.SkipRows
+	inspection.Suggested.HasHeader = &hasHeader
+	return inspection, nil
+}
+
+// readCSVHeader pula as linhas iniciais e lê o cabeçalho, se houver
+func readCSVHeader(reader *csvimport.Reader, skipRows int, hasHeader bool) ([]string, error) {
+	for i := 0; i < skipRows; i++ {
+		if _, err := reader.Read(); err != nil {
+			return nil, fmt.Errorf("%w: file ends before the header", errors.ErrInvalidInput)
+		}
+	}
+	if !hasHeader {
+		return nil, nil
+	}
+
+	record, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: file is empty", errors.ErrInvalidInput)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrInvalidInput, err)
+	}
+	if record.Err != nil {
+		return nil, fmt.Errorf("%w: invalid header: %v", errors.ErrInvalidInput, record.Err)
+	}
+	return record.Fields, nil
+}
+
+func (s *StatementImportService) userSettings(ctx context.Context, userID string) (*entities.UserSettings, error) {
+	user, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return user.Settings, nil
+}
+
+func (s *StatementImportService) importAccount(ctx context.Context, userID, accountID string) (*entities.Account, error) {
+	account, err := s.accountRepo.GetByID(ctx, userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.Archived {
+		return nil, fmt.Errorf("%w: cannot import into an archived account", errors.ErrInvalidInput)
+	}
+	return account, nil
+}
+
+func chooseStatement(statements []ofx.Statement, accountID string) (*ofx.Statement, error) {
+	accountID = strings.TrimSpace(accountID)
+	if accountID == "" {
+		if len(statements) == 1 {
+			return &statements[0], nil
+		}
+		ids := make([]string, len(statements))
+		for i, st := range statements {
+			ids[i] = st.AccountID
+		}
+		return nil, fmt.Errorf("%w: file has %d statements (%s); choose one with the statement parameter",
+			errors.ErrInvalidInput, len(statements), strings.Join(ids, ", "))
+	}
+
+	for i := range statements {
+		if statements[i].AccountID == accountID {
+			return &statements[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: statement %q not found in file", errors.ErrInvalidInput, accountID)
+}
+
+// importEntries classifica as movimentações em lotes e, fora da prévia, grava
+// as novas, tudo em uma única transação. Movimentações já importadas (mesmo
+// identificador externo na conta) e linhas com erro ficam de fora. As novas
+// que chegam sem categoria passam pelas regras de categorização do usuário.
+func (s *StatementImportService) importEntries(
+	ctx context.Context,
+	userID string,
+	account *entities.Account,
+	format string,
+	next entrySource,
+	balance *statementBalance,
+	dryRun bool,
+) (*dtos.StatementImportReport, error) {
+	report := &dtos.StatementImportReport{
+		DryRun:    dryRun,
+		Format:    format,
+		AccountID: account.ID,
+		Entries:   []dtos.StatementEntryResult{},
+	}
+
+	rules, err := s.ruleRepo.List(ctx, userID, true)
+	if err != nil {
+		return nil, err
+	}
+	categorizer := newRuleSet(userID, rules, s.accountRepo, s.categoryRepo, s.tagRepo)
+
+	ids := make(externalIDs)
+	seen := make(map[string]bool)
+	// Na prévia, as movimentações novas não estão no banco e entram à parte na conferência do saldo
+	pending := make(map[time.Time]money.Money)
+	var latest time.Time
+	listed := 0
+
+	process := func(ctx context.Context, batch []statementEntry) error {
+		keys := make([]string, len(batch))
+		for i := range batch {
+			ids.assign(&batch[i])
+			keys[i] = batch[i].ExternalID
+		}
+		existing, err := s.transactionRepo.ExistingExternalIDs(ctx, account.ID, keys)
+		if err != nil {
+			return err
+		}
+		mirrored, err := s.existingTransfers(ctx, batch)
+		if err != nil {
+			return err
+		}
+
+		for i := range batch {
+			e := &batch[i]
+			result := dtos.StatementEntryResult{
+				Row:         e.Row,
+				ExternalID:  e.ExternalID,
+				Date:        e.Date,
+				Description: e.Description,
+				Amount:      e.Amount,
+			}
+			report.Total++
+
+			var transaction *entities.Transaction
+			if e.Err == nil {
+				transaction, e.Err = statementTransaction(userID, account, e)
+			}
+			switch {
+			case e.Err != nil:
+				result.Status = dtos.StatementEntryFailed
+				result.Errors = []string{e.Err.Error()}
+				report.Failed++
+			case existing[e.ExternalID] || seen[e.ExternalID] || mirrored.contains(e):
+				result.Status = dtos.StatementEntryDuplicate
+				report.Duplicates++
+			default:
+				amount := transaction.Postings[0].Amount
+				result.Amount = amount
+				result.Status = dtos.StatementEntryNew
+				report.New++
+				// Na prévia, as etiquetas da regra não são criadas. Uma regra que
+				// não mudou nada (categoria arquivada, conta em outra moeda) não conta.
+				if rule := categorizer.match(transaction); rule != nil {
+					changed, err := categorizer.apply(ctx, transaction, rule, !dryRun)
+					if err != nil {
+						return err
+					}
+					if changed {
+						result.RuleID = rule.rule.ID
+						report.Categorized++
+					}
+				}
+				if dryRun {
+					if sum, ok := pending[e.Date]; ok {
+						if amount, err = sum.Add(amount); err != nil {
+							return err
+						}
+					}
+					pending[e.Date] = amount
+					break
+				}
+				if err := s.transactionRepo.Create(ctx, transaction); err != nil {
+					return err
+				}
+				result.Status = dtos.StatementEntryImported
+				result.TransactionID = transaction.ID
+				report.Imported++
+			}
+
+			if e.Err == nil {
+				seen[e.ExternalID] = true
+				if e.Date.After(latest) {
+					latest = e.Date
+				}
+			}
+
+			switch {
+			case result.Status == dtos.StatementEntryFailed:
+				report.Entries = append(report.Entries, result)
+			case listed < maxReportedEntries:
+				report.Entries = append(report.Entries, result)
+				listed++
+			default:
+				report.Truncated = true
+			}
+		}
+		return nil
+	}
+
+	run := func(ctx context.Context) error {
+		batch := make([]statementEntry, 0, importBatchSize)
+		for {
+			entry, ok, err := next(ctx)
+			if err != nil {
+				return fmt.Errorf("%w: %v", errors.ErrInvalidInput, err)
+			}
+			if ok {
+				batch = append(batch, entry)
+			}
+			if len(batch) == importBatchSize || (!ok && len(batch) > 0) {
+				if err := process(ctx, batch); err != nil {
+					return err
+				}
+				batch = batch[:0]
+			}
+			if !ok {
+				break
+			}
+		}
+
+		if balance == nil {
+			return nil
+		}
+		asOf := balance.AsOf
+		if asOf.IsZero() {
+			asOf = latest
+		}
+		var err error
+		report.Balance, err = s.checkBalance(ctx, userID, account, balance.Amount, asOf, pending)
+		return err
+	}
+
+	if dryRun {
+		err = run(ctx)
+	} else {
+		err = s.txManager.WithinTransaction(ctx, run)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// transferIDs guarda, por conta, os identificadores das pernas de
+// transferência que já existem
+type transferIDs map[string]map[string]bool
+
+func (t transferIDs) contains(e *statementEntry) bool {
+	for _, split := range e.Splits {
+		if split.AccountID != nil && t[*split.AccountID][split.ExternalID] {
+			return true
+		}
+	}
+	return false
+}
+
+// existingTransfers procura as pernas de transferência do lote nas outras
+// contas: se já estão lá, a transferência foi importada pelo extrato da
+// outra conta
+func (s *StatementImportService) existingTransfers(ctx context.Context, batch []statementEntry) (transferIDs, error) {
+	keys := make(map[string][]string)
+	for i := range batch {
+		for _, split := range batch[i].Splits {
+			if split.AccountID != nil && split.ExternalID != "" {
+				keys[*split.AccountID] = append(keys[*split.AccountID], split.ExternalID)
+			}
+		}
+	}
+
+	existing := make(transferIDs)
+	for accountID, ids := range keys {
+		found, err := s.transactionRepo.ExistingExternalIDs(ctx, accountID, ids)
+		if err != nil {
+			return nil, err
+		}
+		existing[accountID] = found
+	}
+	return existing, nil
+}
+
+// checkBalance compara o saldo do extrato com o saldo que a conta terá na
+// mesma data após a importação. Gravadas as movimentações novas, o saldo da
+// conta já as inclui; na prévia, pending traz as novas somadas por data.
+func (s *StatementImportService) checkBalance(
+	ctx context.Context,
+	userID string,
+	account *entities.Account,
+	statementAmount money.Money,
+	asOf time.Time,
+	pending map[time.Time]money.Money,
+) (*dtos.StatementBalanceCheck, error) {
+	statementAmount, err := statementAmount.WithCurrency(account.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: statement balance: %v", errors.ErrInvalidInput, err)
+	}
+
+	computed, err := s.accountRepo.BalanceAsOf(ctx, userID, account.ID, asOf)
+	if err != nil {
+		return nil, err
+	}
+	for date, amount := range pending {
+		if date.After(asOf) {
+			continue
+		}
+		if computed, err = computed.Add(amount); err != nil {
+			return nil, err
+		}
+	}
+
+	difference, err := statementAmount.Sub(computed)
+	if err != nil {
+		return nil, err
+	}
+	return &dtos.StatementBalanceCheck{
+		AsOf:       asOf,
+		Statement:  statementAmount,
+		Computed:   computed,
+		Difference: difference,
+		Matches:    difference.IsZero(),
+	}, nil
+}
+
+// statementTransaction monta o lançamento de uma movimentação: a perna da
+// conta carrega o identificador externo e a contrapartida segue os splits, com
+// o restante sem categoria
+func statementTransaction(userID string, account *entities.Account, e *statementEntry) (*entities.Transaction, error) {
+	amount, err := e.Amount.WithCurrency(account.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount: %v", err)
+	}
+	if amount.IsZero() {
+		return nil, fmt.Errorf("amount is zero")
+	}
+	if e.Date.IsZero() {
+		return nil, fmt.Errorf("date is required")
+	}
+
+	description := truncate(strings.TrimSpace(e.Description), 255)
+	if description == "" {
+		return nil, fmt.Errorf("description is required")
+	}
+
+	notes := ""
+	if memo := strings.TrimSpace(e.Memo); memo != description {
+		notes = memo
+	}
+
+	externalID := e.ExternalID
+	postings := []entities.Posting{{AccountID: &account.ID, Amount: amount, ExternalID: &externalID}}
+	rest := amount
+	for i, split := range e.Splits {
+		splitAmount, err := split.Amount.WithCurrency(account.Currency)
+		if err != nil {
+			return nil, fmt.Errorf("split %d: invalid amount: %v", i+1, err)
+		}
+		if splitAmount.IsZero() {
+			continue
+		}
+
+		posting := entities.Posting{
+			AccountID:  split.AccountID,
+			CategoryID: split.CategoryID,
+			Amount:     splitAmount.Neg(),
+			Memo:       truncate(strings.TrimSpace(split.Memo), 255),
+		}
+		if split.ExternalID != "" {
+			id := split.ExternalID
+			posting.ExternalID = &id
+		}
+		postings = append(postings, posting)
+		if rest, err = rest.Sub(splitAmount); err != nil {
+			return nil, err
+		}
+	}
+	if !rest.IsZero() {
+		postings = append(postings, entities.Posting{Amount: rest.Neg()})
+	}
+
+	status := e.Status
+	if status == "" {
+		status = enums.ClearedTransaction
+	}
+
+	return &entities.Transaction{
+		UserID:      userID,
+		Date:        e.Date,
+		Description: description,
+		Payee:       truncate(strings.TrimSpace(e.Payee), 255),
+		Notes:       notes,
+		Status:      status,
+		Currency:    account.Currency,
+		Postings:    postings,
+	}, nil
+}
+
+// externalIDs gera um identificador estável para movimentações sem um do
+// banco, a partir de data, valor e descrição. Movimentações idênticas no
+// mesmo arquivo são diferenciadas pela ordem em que aparecem.
+type externalIDs map[string]int
+
+func (ids externalIDs) assign(e *statementEntry) {
+	if e.ExternalID != "" {
+		e.ExternalID = truncate(strings.TrimSpace(e.ExternalID), 255)
+		return
+	}
+
+	key := strings.Join([]string{e.Date.Format("2006-01-02"), e.Amount.Decimal(), e.Description, e.Memo}, "|")
+	ids[key]++
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s|%d", key, ids[key])))
+	e.ExternalID = "sha1:" + hex.EncodeToString(sum[:])
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max])
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/pkg/cnab"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAssignExternalIDs(t *testing.T) {
+	date := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	entries := []statementEntry{
+		{ExternalID: "bank-1", Date: date, Amount: brl(-1000), Description: "PADARIA"},
+		{Date: date, Amount: brl(-1000), Description: "PADARIA"},
+		{Date: date, Amount: brl(-1000), Description: "PADARIA"},
+	}
+	ids := make(externalIDs)
+	for i := range entries {
+		ids.assign(&entries[i])
+	}
+
+	if entries[0].ExternalID != "bank-1" {
+		t.Errorf("bank id replaced: %q", entries[0].ExternalID)
+	}
+	if !strings.HasPrefix(entries[1].ExternalID, "sha1:") || entries[1].ExternalID == entries[2].ExternalID {
+		t.Errorf("identical entries got ids %q and %q", entries[1].ExternalID, entries[2].ExternalID)
+	}
+
+	// O mesmo arquivo importado de novo gera os mesmos identificadores
+	again := []statementEntry{
+		{Date: date, Amount: brl(-1000), Description: "PADARIA"},
+		{Date: date, Amount: brl(-1000), Description: "PADARIA"},
+	}
+	ids = make(externalIDs)
+	for i := range again {
+		ids.assign(&again[i])
+	}
+	if again[0].ExternalID != entries[1].ExternalID || again[1].ExternalID != entries[2].ExternalID {
+		t.Error("generated ids are not stable")
+	}
+}
+
+func TestStatementTransaction(t *testing.T) {
+	account := &entities.Account{ID: "checking", Currency: "BRL"}
+	entry := &statementEntry{
+		ExternalID:  "bank-1",
+		Date:        time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC),
+		Amount:      brl(-15075),
+		Payee:       "PADARIA SAO JOAO",
+		Description: "PADARIA SAO JOAO",
+		Memo:        "COMPRA CARTAO DEBITO",
+	}
+
+	transaction, err := statementTransaction("user", account, entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if transaction.Status != enums.ClearedTransaction || transaction.Notes != "COMPRA CARTAO DEBITO" {
+		t.Errorf("unexpected transaction: %+v", transaction)
+	}
+	if err := ValidatePostings(transaction.Postings); err != nil {
+		t.Fatal(err)
+	}
+	posting := transaction.Postings[0]
+	if *posting.AccountID != "checking" || *posting.ExternalID != "bank-1" || posting.Amount.MinorUnits() != -15075 {
+		t.Errorf("unexpected account posting: %+v", posting)
+	}
+	if transaction.Postings[1].CategoryID != nil {
+		t.Error("counterpart should be uncategorized")
+	}
+
+	entry.Amount = brl(0)
+	if _, err := statementTransaction("user", account, entry); err == nil {
+		t.Error("zero amount accepted")
+	}
+}
+
+func TestStatementTransactionSplits(t *testing.T) {
+	account := &entities.Account{ID: "checking", Currency: "BRL"}
+	groceries, savings := "groceries", "savings"
+	entry := &statementEntry{
+		ExternalID:  "qif-1",
+		Date:        time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC),
+		Amount:      brl(-150000),
+		Description: "Supermercado",
+		Status:      enums.ReconciledTransaction,
+		Splits: []entrySplit{
+			{Amount: brl(-100000), CategoryID: &groceries, Memo: "Mercado"},
+			{Amount: brl(-30000), AccountID: &savings, ExternalID: "qif:transfer"},
+		},
+	}
+
+	transaction, err := statementTransaction("user", account, entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidatePostings(transaction.Postings); err != nil {
+		t.Fatal(err)
+	}
+	if transaction.Status != enums.ReconciledTransaction || len(transaction.Postings) != 4 {
+		t.Fatalf("unexpected transaction: %+v", transaction)
+	}
+
+	if p := transaction.Postings[1]; *p.CategoryID != groceries || p.Amount.MinorUnits() != 100000 || p.Memo != "Mercado" {
+		t.Errorf("category posting = %+v", p)
+	}
+	if p := transaction.Postings[2]; *p.AccountID != savings || *p.ExternalID != "qif:transfer" || p.Amount.MinorUnits() != 30000 {
+		t.Errorf("transfer posting = %+v", p)
+	}
+	if p := transaction.Postings[3]; p.CategoryID != nil || p.AccountID != nil || p.Amount.MinorUnits() != 20000 {
+		t.Errorf("remainder posting = %+v", p)
+	}
+}
+
+func TestCNABEntryWithFee(t *testing.T) {
+	file := &cnab.File{Bank: "341"}
+	rec := &cnab.Record{
+		Line:         3,
+		Kind:         cnab.Collection,
+		Status:       cnab.Settled,
+		Occurrence:   "06",
+		Description:  "Liquidação",
+		OurNumber:    "12345",
+		Counterparty: "JOSE DA SILVA",
+		OccurredAt:   time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC),
+		CreditDate:   time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC),
+		PaidAmount:   brl(151000),
+		NetAmount:    brl(150750),
+	}
+
+	entry := cnabEntry(file, rec)
+	if entry.ExternalID != "cnab:341:12345:06:20260310" || entry.Amount.MinorUnits() != 150750 || entry.Payee != "JOSE DA SILVA" {
+		t.Fatalf("entry = %+v", entry)
+	}
+
+	transaction, err := statementTransaction("user", &entities.Account{ID: "business", Currency: "BRL"}, &entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidatePostings(transaction.Postings); err != nil {
+		t.Fatal(err)
+	}
+	if len(transaction.Postings) != 3 || transaction.Postings[1].Amount.MinorUnits() != -151000 || transaction.Postings[2].Amount.MinorUnits() != 250 {
+		t.Errorf("postings = %+v", transaction.Postings)
+	}
+}
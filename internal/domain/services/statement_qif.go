@@ -0,0 +1,419 @@
+package services
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/internal/domain/repositories"
+	"finanvilla/pkg/errors"
+	"finanvilla/pkg/money"
+	"finanvilla/pkg/qif"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// qifOpeningBalance é o payee do registro em que o Quicken grava o saldo
+// inicial, como transferência para a própria conta
+const qifOpeningBalance = "opening balance"
+
+// ImportQIF importa as movimentações de uma conta do arquivo QIF. Categorias
+// são associadas pelo caminho ("Casa:Luz") e as que faltam são criadas;
+// transferências ligam a conta de mesmo nome, quando existe.
+func (s *StatementImportService) ImportQIF(ctx context.Context, userID, accountID string, r io.Reader, opts dtos.QIFImportOptions) (*dtos.StatementImportReport, error) {
+	account, err := s.importAccount(ctx, userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	order := qif.DateOrder(opts.DateOrder)
+	if order == "" {
+		settings, err := s.userSettings(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if settings != nil && strings.HasPrefix(settings.DateFormat, "DD") {
+			order = qif.DayFirst
+		}
+	}
+
+	file, err := qif.Parse(r, qif.Options{DateOrder: order})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrInvalidInput, err)
+	}
+	section, err := chooseQIFAccount(file.Accounts, opts.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	importer, err := s.newQIFImporter(ctx, userID, account, section, file.Categories, opts.DryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	next := 0
+	source := func(ctx context.Context) (statementEntry, bool, error) {
+		for next < len(section.Transactions) {
+			t := &section.Transactions[next]
+			next++
+			entry, ok, err := importer.entry(ctx, t)
+			if err != nil || ok {
+				return entry, ok, err
+			}
+		}
+		return statementEntry{}, false, nil
+	}
+
+	report, err := s.importEntries(ctx, userID, account, "qif", source, nil, opts.DryRun)
+	if err != nil {
+		return nil, err
+	}
+	importer.finish(report)
+	return report, nil
+}
+
+func chooseQIFAccount(accounts []qif.Account, name string) (*qif.Account, error) {
+	var candidates []*qif.Account
+	for i := range accounts {
+		if len(accounts[i].Transactions) > 0 {
+			candidates = append(candidates, &accounts[i])
+		}
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		switch len(candidates) {
+		case 0:
+			return nil, fmt.Errorf("%w: file has no transactions", errors.ErrInvalidInput)
+		case 1:
+			return candidates[0], nil
+		}
+		names := make([]string, len(candidates))
+		for i, a := range candidates {
+			names[i] = a.Name
+		}
+		return nil, fmt.Errorf("%w: file has %d accounts (%s); choose one with the account parameter",
+			errors.ErrInvalidInput, len(candidates), strings.Join(names, ", "))
+	}
+
+	for _, a := range candidates {
+		if strings.EqualFold(a.Name, name) {
+			return a, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: account %q not found in file", errors.ErrInvalidInput, name)
+}
+
+// qifImporter converte os registros de uma conta QIF em statementEntry,
+// resolvendo categorias e transferências contra os cadastros do usuário
+type qifImporter struct {
+	s       *StatementImportService
+	userID  string
+	account *entities.Account
+	section *qif.Account
+	dryRun  bool
+
+	accounts   map[string]*entities.Account
+	categories map[string]string
+	// income guarda o tipo declarado em !Type:Cat para as categorias novas
+	income map[string]bool
+
+	transfers      map[string]int
+	newCategories  []string
+	created        map[string]bool
+	missing        map[string]bool
+	openingBalance *money.Money
+	warnings       []string
+	skipped        int
+}
+
+func (s *StatementImportService) newQIFImporter(
+	ctx context.Context,
+	userID string,
+	account *entities.Account,
+	section *qif.Account,
+	fileCategories []qif.Category,
+	dryRun bool,
+) (*qifImporter, error) {
+	accounts, err := s.accountRepo.List(ctx, userID, repositories.AccountFilter{IncludeArchived: true})
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.categoryRepo.List(ctx, userID, repositories.CategoryFilter{IncludeArchived: true})
+	if err != nil {
+		return nil, err
+	}
+
+	im := &qifImporter{
+		s:          s,
+		userID:     userID,
+		account:    account,
+		section:    section,
+		dryRun:     dryRun,
+		accounts:   make(map[string]*entities.Account),
+		categories: make(map[string]string),
+		income:     make(map[string]bool),
+		transfers:  make(map[string]int),
+		created:    make(map[string]bool),
+		missing:    make(map[string]bool),
+	}
+	for i := range accounts {
+		im.accounts[strings.ToLower(accounts[i].Name)] = &accounts[i]
+	}
+	for id, path := range categoryPaths(categories) {
+		im.categories[categoryKey(path)] = id
+	}
+	for _, c := range fileCategories {
+		im.income[categoryKey(c.Name)] = c.Income
+	}
+	return im, nil
+}
+
+// entry devolve false para registros que não viram lançamento: o saldo
+// inicial e operações de investimento que não mudam o saldo da conta
+func (im *qifImporter) entry(ctx context.Context, t *qif.Transaction) (statementEntry, bool, error) {
+	e := statementEntry{
+		Row:         t.Line,
+		Date:        t.Date,
+		Amount:      t.Amount,
+		Payee:       t.Payee,
+		Description: firstNonEmpty(t.Payee, t.Memo, t.Category, t.Transfer, t.Number),
+		Memo:        t.Memo,
+		Status:      qifStatus(t.Cleared),
+		Err:         t.Err,
+	}
+	if e.Err != nil {
+		return e, true, nil
+	}
+
+	if strings.EqualFold(strings.TrimSpace(t.Payee), qifOpeningBalance) && im.isSelf(t.Transfer) {
+		return e, false, im.applyOpeningBalance(ctx, t.Amount)
+	}
+
+	if im.section.Type == qif.Investment {
+		amount, ok := investmentAmount(t)
+		if !ok {
+			im.skipped++
+			return e, false, nil
+		}
+		e.Amount = amount
+		e.Payee = firstNonEmpty(t.Payee, t.Security)
+		e.Description = firstNonEmpty(strings.TrimSpace(t.Action+" "+t.Security), t.Payee, t.Memo)
+		if t.Category == "" && t.Transfer == "" {
+			return e, true, nil
+		}
+		split, err := im.split(ctx, t, t.Category, t.Transfer, amount, "")
+		if err != nil {
+			return e, true, err
+		}
+		e.Splits = []entrySplit{split}
+		e.ExternalID = split.ExternalID
+		return e, true, nil
+	}
+
+	if len(t.Splits) == 0 {
+		if t.Category == "" && t.Transfer == "" {
+			return e, true, nil
+		}
+		split, err := im.split(ctx, t, t.Category, t.Transfer, t.Amount, "")
+		if err != nil {
+			return e, true, err
+		}
+		e.Splits = []entrySplit{split}
+		// Uma transferência simples usa o identificador da perna na outra
+		// conta, para que o extrato dela reconheça o mesmo lançamento
+		e.ExternalID = split.ExternalID
+		return e, true, nil
+	}
+
+	for _, s := range t.Splits {
+		split, err := im.split(ctx, t, s.Category, s.Transfer, s.Amount, s.Memo)
+		if err != nil {
+			return e, true, err
+		}
+		e.Splits = append(e.Splits, split)
+	}
+	return e, true, nil
+}
+
+func (im *qifImporter) split(ctx context.Context, t *qif.Transaction, category, transfer string, amount money.Money, memo string) (entrySplit, error) {
+	split := entrySplit{Amount: amount, Memo: memo}
+
+	if transfer != "" {
+		other, ok := im.accounts[strings.ToLower(transfer)]
+		switch {
+		case im.isSelf(transfer):
+		case !ok:
+			im.warnMissing(fmt.Sprintf("account %q not found; its transfers were left uncategorized", transfer))
+		case other.Currency != im.account.Currency:
+			im.warnMissing(fmt.Sprintf("account %q uses %s; its transfers were left uncategorized", transfer, other.Currency))
+		default:
+			split.AccountID = &other.ID
+			split.ExternalID = im.transferID(t, amount, other.ID)
+		}
+		return split, nil
+	}
+
+	if category != "" {
+		id, err := im.category(ctx, category, amount.Sign() > 0)
+		if err != nil {
+			return split, err
+		}
+		split.CategoryID = id
+	}
+	return split, nil
+}
+
+// transferID identifica a transferência pelos dois lados, para que a
+// importação do arquivo da outra conta gere o mesmo valor
+func (im *qifImporter) transferID(t *qif.Transaction, amount money.Money, otherID string) string {
+	pair := []string{im.account.ID, otherID}
+	sort.Strings(pair)
+	key := strings.Join([]string{t.Date.Format("2006-01-02"), amount.Abs().Decimal(), pair[0], pair[1]}, "|")
+	im.transfers[key]++
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s|%d", key, im.transfers[key])))
+	return "qif:" + hex.EncodeToString(sum[:])
+}
+
+func (im *qifImporter) isSelf(transfer string) bool {
+	return transfer != "" && (strings.EqualFold(transfer, im.section.Name) || strings.EqualFold(transfer, im.account.Name))
+}
+
+// category devolve a categoria do caminho, criando as que faltam. Na prévia
+// nada é criado e a movimentação aparece sem categoria.
+func (im *qifImporter) category(ctx context.Context, path string, income bool) (*string, error) {
+	key := categoryKey(path)
+	if id, ok := im.categories[key]; ok {
+		return &id, nil
+	}
+
+	names := strings.Split(path, ":")
+	name := strings.TrimSpace(names[len(names)-1])
+	if im.dryRun {
+		if !im.created[key] {
+			im.created[key] = true
+			im.newCategories = append(im.newCategories, path)
+		}
+		return nil, nil
+	}
+
+	category := &entities.Category{UserID: im.userID, Name: name, Type: enums.ExpenseCategory}
+	if len(names) > 1 {
+		parentPath := strings.Join(names[:len(names)-1], ":")
+		parentID, err := im.category(ctx, parentPath, income)
+		if err != nil {
+			return nil, err
+		}
+		parent, err := im.s.categoryRepo.GetByID(ctx, im.userID, *parentID)
+		if err != nil {
+			return nil, err
+		}
+		category.ParentID = parentID
+		category.Type = parent.Type
+	} else {
+		if declared, ok := im.income[key]; ok {
+			income = declared
+		}
+		if income {
+			category.Type = enums.IncomeCategory
+		}
+	}
+
+	if err := im.s.categoryRepo.Create(ctx, category); err != nil {
+		return nil, err
+	}
+	im.categories[key] = category.ID
+	im.newCategories = append(im.newCategories, path)
+	return &category.ID, nil
+}
+
+// applyOpeningBalance usa o saldo inicial do arquivo se a conta ainda não
+// tem um; um saldo diferente já cadastrado é mantido, com aviso
+func (im *qifImporter) applyOpeningBalance(ctx context.Context, amount money.Money) error {
+	amount, err := amount.WithCurrency(im.account.Currency)
+	if err != nil {
+		im.warnings = append(im.warnings, fmt.Sprintf("opening balance: %v", err))
+		return nil
+	}
+
+	current := im.account.OpeningBalance
+	if !current.IsZero() {
+		if cmp, err := current.Cmp(amount); err != nil || cmp != 0 {
+			im.warnings = append(im.warnings, fmt.Sprintf(
+				"opening balance %s in the file differs from the account's %s; the account's was kept",
+				amount.Decimal(), current.Decimal()))
+		}
+		return nil
+	}
+	if amount.IsZero() {
+		return nil
+	}
+
+	im.openingBalance = &amount
+	if im.dryRun {
+		return nil
+	}
+	im.account.OpeningBalance = amount
+	return im.s.accountRepo.Update(ctx, im.account)
+}
+
+func (im *qifImporter) warnMissing(message string) {
+	if !im.missing[message] {
+		im.missing[message] = true
+		im.warnings = append(im.warnings, message)
+	}
+}
+
+func (im *qifImporter) finish(report *dtos.StatementImportReport) {
+	report.NewCategories = im.newCategories
+	report.OpeningBalance = im.openingBalance
+	report.Warnings = im.warnings
+	if im.skipped > 0 {
+		report.Warnings = append(report.Warnings, fmt.Sprintf(
+			"%d investment records that do not change the account balance were skipped (buys and sells inside the account, splits, reminders)",
+			im.skipped))
+	}
+}
+
+// investmentAmount traduz uma operação de investimento no efeito sobre o
+// saldo da conta, que representa o valor aplicado. Compra e venda dentro da
+// conta trocam dinheiro por títulos e não mudam o saldo; rendimentos,
+// reinvestimentos e dinheiro vindo ou indo para outra conta mudam. Operações
+// terminadas em X com rendimento pago em outra conta não passam por esta.
+func investmentAmount(t *qif.Transaction) (money.Money, bool) {
+	amount := t.Amount.Abs()
+	if amount.IsZero() {
+		return amount, false
+	}
+
+	switch strings.ToLower(t.Action) {
+	case "xin", "buyx", "shrsin", "div", "intinc", "miscinc", "cglong", "cgmid", "cgshort", "rtrncap",
+		"reinvdiv", "reinvint", "reinvlg", "reinvmd", "reinvsh":
+		return amount, true
+	case "xout", "sellx", "shrsout", "miscexp":
+		return amount.Neg(), true
+	}
+	return amount, false
+}
+
+func qifStatus(c qif.ClearedStatus) enums.TransactionStatus {
+	switch c {
+	case qif.Cleared:
+		return enums.ClearedTransaction
+	case qif.Reconciled:
+		return enums.ReconciledTransaction
+	}
+	return enums.PendingTransaction
+}
+
+func categoryKey(path string) string {
+	names := strings.Split(path, ":")
+	for i, n := range names {
+		names[i] = strings.ToLower(strings.TrimSpace(n))
+	}
+	return strings.Join(names, ":")
+}
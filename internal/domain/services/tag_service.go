@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/repositories"
+	"finanvilla/pkg/errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxTagLength é o tamanho máximo do nome de uma etiqueta, em caracteres
+const maxTagLength = 50
+
+// TagService cuida das etiquetas: a aplicação nos lançamentos fica no
+// TransactionService, aqui ficam renomear, juntar e apagar
+type TagService struct {
+	tagRepo repositories.TagRepository
+}
+
+func NewTagService(tagRepo repositories.TagRepository) *TagService {
+	return &TagService{tagRepo: tagRepo}
+}
+
+func (s *TagService) List(ctx context.Context, userID string) ([]entities.Tag, error) {
+	return s.tagRepo.List(ctx, userID)
+}
+
+// Rename troca o nome da etiqueta. Se o novo nome já for de outra etiqueta,
+// o caminho é juntar as duas com Merge.
+func (s *TagService) Rename(ctx context.Context, userID, id string, req *dtos.RenameTagRequest) (*entities.Tag, error) {
+	tag, err := s.tagRepo.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if tag.Name, err = normalizeTagName(req.Name); err != nil {
+		return nil, err
+	}
+
+	if err := s.tagRepo.Rename(ctx, tag); err != nil {
+		return nil, err
+	}
+	return s.tagRepo.GetByID(ctx, userID, id)
+}
+
+// Merge junta a etiqueta id em req.TargetID: os lançamentos e as pesquisas
+// salvas passam para a etiqueta de destino e a de origem é apagada
+func (s *TagService) Merge(ctx context.Context, userID, id string, req *dtos.MergeTagRequest) (*entities.Tag, error) {
+	if id == req.TargetID {
+		return nil, fmt.Errorf("%w: cannot merge a tag into itself", errors.ErrInvalidInput)
+	}
+	if _, err := s.tagRepo.GetByID(ctx, userID, id); err != nil {
+		return nil, err
+	}
+	if _, err := s.tagRepo.GetByID(ctx, userID, req.TargetID); err != nil {
+		return nil, err
+	}
+
+	if err := s.tagRepo.Merge(ctx, userID, id, req.TargetID); err != nil {
+		return nil, err
+	}
+	return s.tagRepo.GetByID(ctx, userID, req.TargetID)
+}
+
+func (s *TagService) Delete(ctx context.Context, userID, id string) error {
+	return s.tagRepo.Delete(ctx, userID, id)
+}
+
+// normalizeTagName tira espaços das pontas e repetidos e um "#" inicial
+func normalizeTagName(name string) (string, error) {
+	name = strings.Join(strings.Fields(strings.TrimPrefix(strings.TrimSpace(name), "#")), " ")
+	if name == "" {
+		return "", fmt.Errorf("%w: tag name is required", errors.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(name) > maxTagLength {
+		return "", fmt.Errorf("%w: tag %q is longer than %d characters", errors.ErrInvalidInput, name, maxTagLength)
+	}
+	return name, nil
+}
+
+// normalizeTagNames normaliza os nomes e descarta repetidos, sem diferenciar
+// maiúsculas de minúsculas
+func normalizeTagNames(names []string) ([]string, error) {
+	seen := make(map[string]bool, len(names))
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		name, err := normalizeTagName(name)
+		if err != nil {
+			return nil, err
+		}
+		key := strings.ToLower(name)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, name)
+	}
+	return normalized, nil
+}
@@ -0,0 +1,416 @@
+package services
+
+import (
+	"context"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/internal/domain/repositories"
+	"finanvilla/pkg/errors"
+	"finanvilla/pkg/money"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type TransactionService struct {
+	transactionRepo repositories.TransactionRepository
+	accountRepo     repositories.AccountRepository
+	categoryRepo    repositories.CategoryRepository
+	tagRepo         repositories.TagRepository
+	ruleRepo        repositories.CategorizationRuleRepository
+	txManager       repositories.TransactionManager
+}
+
+func NewTransactionService(
+	transactionRepo repositories.TransactionRepository,
+	accountRepo repositories.AccountRepository,
+	categoryRepo repositories.CategoryRepository,
+	tagRepo repositories.TagRepository,
+	ruleRepo repositories.CategorizationRuleRepository,
+	txManager repositories.TransactionManager,
+) *TransactionService {
+	return &TransactionService{
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+		categoryRepo:    categoryRepo,
+		tagRepo:         tagRepo,
+		ruleRepo:        ruleRepo,
+		txManager:       txManager,
+	}
+}
+
+func (s *TransactionService) Create(ctx context.Context, userID string, req *dtos.TransactionRequest) (*entities.Transaction, error) {
+	transaction, err := s.buildTransaction(ctx, userID, req, nil)
+	if err != nil {
+		return nil, err
+	}
+	if transaction.Status == "" {
+		transaction.Status = enums.PendingTransaction
+	}
+
+	if err := s.transactionRepo.Create(ctx, transaction); err != nil {
+		return nil, err
+	}
+
+	return s.transactionRepo.GetByID(ctx, userID, transaction.ID)
+}
+
+// Update substitui o lançamento inteiro, inclusive as pernas. Lançamentos
+// conciliados precisam voltar para outro status antes de serem editados.
+// Trocar a categoria de um lançamento simples conta para as sugestões de
+// regras de categorização.
+func (s *TransactionService) Update(ctx context.Context, userID, id string, req *dtos.TransactionRequest) (*entities.Transaction, error) {
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		existing, err := s.transactionRepo.GetByID(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+		if existing.Status == enums.ReconciledTransaction {
+			return errors.ErrTransactionLocked
+		}
+		if existing.InstallmentPurchaseID != nil {
+			return errors.ErrInstallmentManaged
+		}
+
+		transaction, err := s.buildTransaction(ctx, userID, req, existing)
+		if err != nil {
+			return err
+		}
+		transaction.ID = id
+		if transaction.Status == "" {
+			transaction.Status = existing.Status
+		}
+		keepExternalIDs(existing.Postings, transaction.Postings)
+		if transaction.Date.Equal(existing.Date) {
+			keepStatementDates(existing.Postings, transaction.Postings)
+		}
+
+		if err := s.transactionRepo.Update(ctx, transaction); err != nil {
+			return err
+		}
+		if keyword, categoryID, ok := recategorization(existing, transaction); ok {
+			return s.ruleRepo.RecordRecategorization(ctx, userID, keyword, categoryID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.transactionRepo.GetByID(ctx, userID, id)
+}
+
+// Split troca as pernas de categoria do lançamento pelas linhas informadas.
+// As pernas das contas ficam como estão e as linhas, no sinal do que saiu ou
+// entrou nas contas, precisam somar exatamente esse valor.
+func (s *TransactionService) Split(ctx context.Context, userID, id string, splits []dtos.SplitRequest) (*entities.Transaction, error) {
+	return s.replaceCategoryLegs(ctx, userID, id, func(existing *entities.Transaction, total money.Money) ([]entities.Posting, error) {
+		for i := range splits {
+			amount, err := splits[i].Amount.WithCurrency(existing.Currency)
+			if err != nil {
+				return nil, fmt.Errorf("%w: split %d: %v", errors.ErrInvalidInput, i+1, err)
+			}
+			splits[i].Amount = amount
+		}
+		return SplitPostings(total, splits)
+	})
+}
+
+// Unsplit junta as pernas de categoria numa só. Sem categoria informada, fica
+// a da maior linha.
+func (s *TransactionService) Unsplit(ctx context.Context, userID, id string, categoryID *string) (*entities.Transaction, error) {
+	return s.replaceCategoryLegs(ctx, userID, id, func(existing *entities.Transaction, total money.Money) ([]entities.Posting, error) {
+		if categoryID == nil {
+			largest := -1
+			for i, p := range existing.Postings {
+				if p.AccountID != nil {
+					continue
+				}
+				if largest < 0 {
+					largest = i
+				} else if cmp, err := p.Amount.Abs().Cmp(existing.Postings[largest].Amount.Abs()); err == nil && cmp > 0 {
+					largest = i
+				}
+			}
+			if largest >= 0 {
+				categoryID = existing.Postings[largest].CategoryID
+			}
+		}
+		return []entities.Posting{{CategoryID: categoryID, Amount: total.Neg()}}, nil
+	})
+}
+
+// replaceCategoryLegs mantém as pernas das contas e troca as demais pelas
+// que build devolve a partir do total movimentado nas contas
+func (s *TransactionService) replaceCategoryLegs(ctx context.Context, userID, id string, build func(existing *entities.Transaction, total money.Money) ([]entities.Posting, error)) (*entities.Transaction, error) {
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		existing, err := s.transactionRepo.GetByID(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+		if existing.Status == enums.ReconciledTransaction {
+			return errors.ErrTransactionLocked
+		}
+		if existing.InstallmentPurchaseID != nil {
+			return errors.ErrInstallmentManaged
+		}
+
+		total, err := money.New(0, existing.Currency)
+		if err != nil {
+			return err
+		}
+		var postings []entities.Posting
+		for _, p := range existing.Postings {
+			if p.AccountID == nil {
+				continue
+			}
+			if total, err = total.Add(p.Amount); err != nil {
+				return err
+			}
+			postings = append(postings, p)
+		}
+		if total.IsZero() {
+			return fmt.Errorf("%w: transfers have no category lines to split", errors.ErrInvalidInput)
+		}
+
+		legs, err := build(existing, total)
+		if err != nil {
+			return err
+		}
+		postings = append(postings, legs...)
+		if err := ValidatePostings(postings); err != nil {
+			return err
+		}
+		if err := s.checkCategories(ctx, userID, postings, existing); err != nil {
+			return err
+		}
+
+		existing.Postings = postings
+		return s.transactionRepo.Update(ctx, existing)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.transactionRepo.GetByID(ctx, userID, id)
+}
+
+func (s *TransactionService) UpdateStatus(ctx context.Context, userID, id string, status enums.TransactionStatus) (*entities.Transaction, error) {
+	if !status.IsValid() {
+		return nil, fmt.Errorf("%w: unknown status %q", errors.ErrInvalidInput, status)
+	}
+
+	if err := s.transactionRepo.UpdateStatus(ctx, userID, id, status); err != nil {
+		return nil, err
+	}
+
+	return s.transactionRepo.GetByID(ctx, userID, id)
+}
+
+func (s *TransactionService) Delete(ctx context.Context, userID, id string) error {
+	return s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		existing, err := s.transactionRepo.GetByID(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+		if existing.Status == enums.ReconciledTransaction {
+			return errors.ErrTransactionLocked
+		}
+		if existing.InstallmentPurchaseID != nil {
+			return errors.ErrInstallmentManaged
+		}
+		return s.transactionRepo.Delete(ctx, userID, id)
+	})
+}
+
+func (s *TransactionService) GetByID(ctx context.Context, userID, id string) (*entities.Transaction, error) {
+	return s.transactionRepo.GetByID(ctx, userID, id)
+}
+
+func (s *TransactionService) List(ctx context.Context, userID string, filter repositories.TransactionFilter, page, limit int) ([]entities.Transaction, int, error) {
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, 0, fmt.Errorf("%w: unknown status %q", errors.ErrInvalidInput, filter.Status)
+	}
+	if filter.MinAmount != nil && filter.MaxAmount != nil {
+		if cmp, err := filter.MinAmount.Cmp(*filter.MaxAmount); err == nil && cmp > 0 {
+			return nil, 0, fmt.Errorf("%w: minAmount is greater than maxAmount", errors.ErrInvalidInput)
+		}
+	}
+
+	page, limit = normalizePage(page, limit)
+	return s.transactionRepo.List(ctx, userID, filter, page, limit)
+}
+
+// Register devolve o extrato da conta com o saldo acumulado em cada lançamento
+func (s *TransactionService) Register(ctx context.Context, userID, accountID string, from, to *time.Time, page, limit int) ([]entities.RegisterEntry, int, error) {
+	if _, err := s.accountRepo.GetByID(ctx, userID, accountID); err != nil {
+		return nil, 0, err
+	}
+
+	page, limit = normalizePage(page, limit)
+	return s.transactionRepo.Register(ctx, userID, accountID, from, to, page, limit)
+}
+
+// buildTransaction monta e valida o lançamento. Todas as contas precisam ser do
+// usuário e estar na mesma moeda, que passa a ser a moeda do lançamento.
+// Categorias arquivadas só são aceitas se já estavam no lançamento anterior.
+func (s *TransactionService) buildTransaction(ctx context.Context, userID string, req *dtos.TransactionRequest, previous *entities.Transaction) (*entities.Transaction, error) {
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, fmt.Errorf("%w: date must be YYYY-MM-DD", errors.ErrInvalidInput)
+	}
+	if req.Status != "" && !req.Status.IsValid() {
+		return nil, fmt.Errorf("%w: unknown status %q", errors.ErrInvalidInput, req.Status)
+	}
+
+	description := strings.TrimSpace(req.Description)
+	if description == "" {
+		return nil, fmt.Errorf("%w: description is required", errors.ErrInvalidInput)
+	}
+
+	postings, err := BuildPostings(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidatePostings(postings); err != nil {
+		return nil, err
+	}
+
+	currency := ""
+	accounts := make(map[string]*entities.Account)
+	for _, p := range postings {
+		if p.AccountID == nil {
+			continue
+		}
+		account, ok := accounts[*p.AccountID]
+		if !ok {
+			account, err = s.accountRepo.GetByID(ctx, userID, *p.AccountID)
+			if err != nil {
+				return nil, err
+			}
+			accounts[account.ID] = account
+		}
+		if currency == "" {
+			currency = account.Currency
+		} else if account.Currency != currency {
+			return nil, fmt.Errorf("%w: all accounts in a transaction must use the same currency", errors.ErrInvalidInput)
+		}
+	}
+
+	for i := range postings {
+		if postings[i].Amount, err = postings[i].Amount.WithCurrency(currency); err != nil {
+			return nil, fmt.Errorf("%w: posting %d: %v", errors.ErrInvalidInput, i+1, err)
+		}
+	}
+
+	if err := s.checkCategories(ctx, userID, postings, previous); err != nil {
+		return nil, err
+	}
+
+	tags, err := s.resolveTags(ctx, userID, req.Tags, previous)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.Transaction{
+		UserID:      userID,
+		Date:        date,
+		Description: description,
+		Payee:       strings.TrimSpace(req.Payee),
+		Notes:       req.Notes,
+		Status:      req.Status,
+		Currency:    currency,
+		Postings:    postings,
+		Tags:        tags,
+	}, nil
+}
+
+// resolveTags troca os nomes pelas etiquetas do usuário, criando as novas.
+// Sem nomes (nil), o lançamento fica com as etiquetas que já tinha.
+func (s *TransactionService) resolveTags(ctx context.Context, userID string, names []string, previous *entities.Transaction) ([]entities.Tag, error) {
+	if names == nil {
+		if previous != nil {
+			return previous.Tags, nil
+		}
+		return nil, nil
+	}
+
+	normalized, err := normalizeTagNames(names)
+	if err != nil {
+		return nil, err
+	}
+	return s.tagRepo.Resolve(ctx, userID, normalized)
+}
+
+func (s *TransactionService) checkCategories(ctx context.Context, userID string, postings []entities.Posting, previous *entities.Transaction) error {
+	kept := make(map[string]bool)
+	if previous != nil {
+		for _, p := range previous.Postings {
+			if p.CategoryID != nil {
+				kept[*p.CategoryID] = true
+			}
+		}
+	}
+
+	checked := make(map[string]bool)
+	for _, p := range postings {
+		if p.CategoryID == nil || checked[*p.CategoryID] {
+			continue
+		}
+		category, err := s.categoryRepo.GetByID(ctx, userID, *p.CategoryID)
+		if err != nil {
+			return err
+		}
+		if category.Archived && !kept[category.ID] {
+			return fmt.Errorf("%w: category %q is archived", errors.ErrInvalidInput, category.Name)
+		}
+		checked[category.ID] = true
+	}
+	return nil
+}
+
+// keepExternalIDs devolve às novas pernas os identificadores de extrato das
+// pernas substituídas na mesma conta; sem isso, reimportar o extrato duplicaria
+// o lançamento editado
+func keepExternalIDs(previous, postings []entities.Posting) {
+	for _, old := range previous {
+		if old.ExternalID == nil || old.AccountID == nil {
+			continue
+		}
+		for i := range postings {
+			p := &postings[i]
+			if p.ExternalID == nil && p.AccountID != nil && *p.AccountID == *old.AccountID {
+				p.ExternalID = old.ExternalID
+				break
+			}
+		}
+	}
+}
+
+// keepStatementDates mantém nas pernas do cartão a fatura gravada antes da
+// edição, como a de um pagamento de fatura; só vale se a data não mudou
+func keepStatementDates(previous, postings []entities.Posting) {
+	for _, old := range previous {
+		if old.StatementDate == nil || old.AccountID == nil {
+			continue
+		}
+		for i := range postings {
+			p := &postings[i]
+			if p.StatementDate == nil && p.AccountID != nil && *p.AccountID == *old.AccountID {
+				p.StatementDate = old.StatementDate
+				break
+			}
+		}
+	}
+}
+
+func normalizePage(page, limit int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	return page, limit
+}
@@ -0,0 +1,349 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/internal/domain/repositories"
+	"finanvilla/pkg/crypto"
+	"finanvilla/pkg/errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"strings"
+)
+
+const maxImportRows = 5000
+
+type UserImportService struct {
+	userService       *UserService
+	invitationService *InvitationService
+	txManager         repositories.TransactionManager
+}
+
+func NewUserImportService(
+	userService *UserService,
+	invitationService *InvitationService,
+	txManager repositories.TransactionManager,
+) *UserImportService {
+	return &UserImportService{
+		userService:       userService,
+		invitationService: invitationService,
+		txManager:         txManager,
+	}
+}
+
+type importRow struct {
+	line     int
+	name     string
+	email    string
+	userType enums.UserType
+	roles    []string
+	password string
+	errs     []string
+}
+
+type pendingInvitation struct {
+	result *dtos.UserImportRowResult
+	user   *entities.User
+	token  string
+}
+
+// Import lê um CSV com as colunas name, email, user_type e, opcionalmente,
+// roles (separadas por ";" ou "|") e password, criando os usuários conforme opts.
+func (s *UserImportService) Import(ctx context.Context, r io.Reader, opts dtos.UserImportOptions) (*dtos.UserImportReport, error) {
+	if opts.Mode == "" {
+		opts.Mode = dtos.ImportPerRow
+	}
+	if opts.Mode != dtos.ImportAtomic && opts.Mode != dtos.ImportPerRow {
+		return nil, fmt.Errorf("%w: unknown import mode %q", errors.ErrInvalidInput, opts.Mode)
+	}
+
+	rows, err := parseImportCSV(r)
+	if err != nil {
+		return nil, err
+	}
+
+	s.validateRows(ctx, rows, opts)
+
+	report := &dtos.UserImportReport{
+		DryRun:          opts.DryRun,
+		Mode:            opts.Mode,
+		SendInvitations: opts.SendInvitations,
+		Total:           len(rows),
+		Rows:            make([]dtos.UserImportRowResult, len(rows)),
+	}
+
+	invalid := 0
+	for i, row := range rows {
+		report.Rows[i] = dtos.UserImportRowResult{
+			Row:    row.line,
+			Email:  row.email,
+			Status: dtos.ImportRowValid,
+			Errors: row.errs,
+		}
+		if len(row.errs) > 0 {
+			report.Rows[i].Status = dtos.ImportRowFailed
+			invalid++
+		}
+	}
+
+	switch {
+	case opts.DryRun:
+	case opts.Mode == dtos.ImportAtomic:
+		if invalid > 0 {
+			markRolledBack(report)
+			break
+		}
+		s.importAtomic(ctx, rows, report, opts)
+	default:
+		s.importPerRow(ctx, rows, report, opts)
+	}
+
+	for _, row := range report.Rows {
+		switch row.Status {
+		case dtos.ImportRowValid, dtos.ImportRowCreated:
+			report.Succeeded++
+		default:
+			report.Failed++
+		}
+	}
+
+	return report, nil
+}
+
+func (s *UserImportService) importAtomic(ctx context.Context, rows []*importRow, report *dtos.UserImportReport, opts dtos.UserImportOptions) {
+	var invitations []pendingInvitation
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		for i, row := range rows {
+			user, token, err := s.createFromRow(ctx, row, opts)
+			if err != nil {
+				report.Rows[i].Status = dtos.ImportRowFailed
+				report.Rows[i].Errors = append(report.Rows[i].Errors, err.Error())
+				return err
+			}
+			report.Rows[i].UserID = user.ID
+			report.Rows[i].Status = dtos.ImportRowCreated
+			if token != "" {
+				invitations = append(invitations, pendingInvitation{result: &report.Rows[i], user: user, token: token})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		markRolledBack(report)
+		return
+	}
+
+	s.sendInvitations(ctx, invitations)
+}
+
+func (s *UserImportService) importPerRow(ctx context.Context, rows []*importRow, report *dtos.UserImportReport, opts dtos.UserImportOptions) {
+	var invitations []pendingInvitation
+
+	for i, row := range rows {
+		if len(row.errs) > 0 {
+			continue
+		}
+
+		var user *entities.User
+		var token string
+		err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			user, token, err = s.createFromRow(ctx, row, opts)
+			return err
+		})
+		if err != nil {
+			report.Rows[i].Status = dtos.ImportRowFailed
+			report.Rows[i].Errors = append(report.Rows[i].Errors, err.Error())
+			continue
+		}
+
+		report.Rows[i].UserID = user.ID
+		report.Rows[i].Status = dtos.ImportRowCreated
+		if token != "" {
+			invitations = append(invitations, pendingInvitation{result: &report.Rows[i], user: user, token: token})
+		}
+	}
+
+	s.sendInvitations(ctx, invitations)
+}
+
+func (s *UserImportService) createFromRow(ctx context.Context, row *importRow, opts dtos.UserImportOptions) (*entities.User, string, error) {
+	password := row.password
+	if opts.SendInvitations {
+		// Senha aleatória e descartada: o acesso só é liberado pelo convite
+		random, err := crypto.GenerateToken(32)
+		if err != nil {
+			return nil, "", err
+		}
+		password = random
+	}
+
+	user := &entities.User{
+		Name:     row.name,
+		Email:    row.email,
+		Password: password,
+		UserType: row.userType,
+	}
+
+	if err := s.userService.CreateUser(ctx, user); err != nil {
+		return nil, "", err
+	}
+
+	if len(row.roles) > 0 {
+		if err := s.userService.AddPermissions(ctx, user.ID, row.roles); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if !opts.SendInvitations {
+		return user, "", nil
+	}
+
+	token, err := s.invitationService.CreateInvitation(ctx, user)
+	if err != nil {
+		return nil, "", err
+	}
+	return user, token, nil
+}
+
+// sendInvitations só é chamado após o commit, para não enviar convites de usuários desfeitos
+func (s *UserImportService) sendInvitations(ctx context.Context, invitations []pendingInvitation) {
+	for _, inv := range invitations {
+		if err := s.invitationService.SendInvitation(ctx, inv.user, inv.token); err != nil {
+			inv.result.Errors = append(inv.result.Errors, "failed to send invitation: "+err.Error())
+		}
+	}
+}
+
+func (s *UserImportService) validateRows(ctx context.Context, rows []*importRow, opts dtos.UserImportOptions) {
+	seen := make(map[string]int)
+
+	for _, row := range rows {
+		if len(row.name) < 3 {
+			row.errs = append(row.errs, "name must have at least 3 characters")
+		}
+
+		if addr, err := mail.ParseAddress(row.email); err != nil || addr.Address != row.email {
+			row.errs = append(row.errs, "invalid email")
+		} else if line, ok := seen[row.email]; ok {
+			row.errs = append(row.errs, fmt.Sprintf("email duplicated in row %d", line))
+		} else {
+			seen[row.email] = row.line
+			if _, err := s.userService.GetByEmail(ctx, row.email); err == nil {
+				row.errs = append(row.errs, errors.ErrEmailAlreadyUsed.Error())
+			}
+		}
+
+		if !isValidUserType(row.userType) {
+			row.errs = append(row.errs, fmt.Sprintf("invalid user type %q", row.userType))
+		}
+
+		for _, role := range row.roles {
+			if !isValidPermission(role) {
+				row.errs = append(row.errs, fmt.Sprintf("invalid role %q", role))
+			}
+		}
+
+		if !opts.SendInvitations && len(row.password) < 8 {
+			row.errs = append(row.errs, "password must have at least 8 characters unless invitations are sent")
+		}
+	}
+}
+
+func parseImportCSV(r io.Reader) ([]*importRow, error) {
+	br := bufio.NewReader(r)
+
+	reader := csv.NewReader(br)
+	// Planilhas exportadas em pt-BR costumam usar ";" como separador
+	first, _ := br.Peek(br.Size())
+	header, _, _ := strings.Cut(string(first), "\n")
+	if strings.Count(header, ";") > strings.Count(header, ",") {
+		reader.Comma = ';'
+	}
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	headerFields, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not read CSV header: %v", errors.ErrInvalidInput, err)
+	}
+
+	columns := make(map[string]int)
+	for i, h := range headerFields {
+		name := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+		name = strings.NewReplacer(" ", "_", "usertype", "user_type").Replace(name)
+		columns[name] = i
+	}
+	for _, required := range []string{"name", "email", "user_type"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: missing required column %q", errors.ErrInvalidInput, required)
+		}
+	}
+
+	field := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var rows []*importRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", errors.ErrInvalidInput, line, err)
+		}
+		if len(rows) >= maxImportRows {
+			return nil, fmt.Errorf("%w: at most %d rows can be imported at once", errors.ErrInvalidInput, maxImportRows)
+		}
+
+		row := &importRow{
+			line:     line,
+			name:     field(record, "name"),
+			email:    strings.ToLower(field(record, "email")),
+			userType: enums.UserType(strings.ToUpper(field(record, "user_type"))),
+			password: field(record, "password"),
+		}
+		for _, role := range strings.FieldsFunc(field(record, "roles"), func(r rune) bool { return r == ';' || r == '|' }) {
+			if role = strings.ToUpper(strings.TrimSpace(role)); role != "" {
+				row.roles = append(row.roles, role)
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: CSV has no data rows", errors.ErrInvalidInput)
+	}
+
+	return rows, nil
+}
+
+func markRolledBack(report *dtos.UserImportReport) {
+	for i := range report.Rows {
+		if report.Rows[i].Status != dtos.ImportRowFailed {
+			report.Rows[i].Status = dtos.ImportRowRolledBack
+			report.Rows[i].UserID = ""
+		}
+	}
+}
+
+func isValidUserType(userType enums.UserType) bool {
+	switch userType {
+	case enums.Admin, enums.Manager, enums.Standard:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseImportCSV(t *testing.T) {
+	tests := []struct {
+		name   string
+		csv    string
+		emails []string
+		roles  [][]string
+	}{
+		{
+			name:   "comma",
+			csv:    "name,email,user_type\nAna Souza,ANA@example.com,standard\n",
+			emails: []string{"ana@example.com"},
+			roles:  [][]string{nil},
+		},
+		{
+			name:   "missing required column",
+			csv:    "name;mail;user_type\nAna Souza;ana@example.com;STANDARD\n",
+			emails: nil,
+		},
+		{
+			name:   "semicolon from pt-BR spreadsheets",
+			csv:    "\ufeffName;Email;User Type;Roles\nAna, a Souza;ana@example.com;STANDARD;view_reports|manage_roles\n",
+			emails: []string{"ana@example.com"},
+			roles:  [][]string{{"VIEW_REPORTS", "MANAGE_ROLES"}},
+		},
+		{
+			name:   "comma wins when the header has more commas",
+			csv:    "name,email,user_type,roles\n\"Souza; Ana\",ana@example.com,ADMIN,view_reports;manage_roles\n",
+			emails: []string{"ana@example.com"},
+			roles:  [][]string{{"VIEW_REPORTS", "MANAGE_ROLES"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := parseImportCSV(strings.NewReader(tt.csv))
+			if tt.emails == nil {
+				if err == nil {
+					t.Fatal("header without the required columns was accepted")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(rows) != len(tt.emails) {
+				t.Fatalf("got %d rows, want %d", len(rows), len(tt.emails))
+			}
+			for i, row := range rows {
+				if row.email != tt.emails[i] || !reflect.DeepEqual(row.roles, tt.roles[i]) {
+					t.Errorf("row %d = %s %v; want %s %v", i, row.email, row.roles, tt.emails[i], tt.roles[i])
+				}
+			}
+		})
+	}
+}
+
+func TestUserImportValidation(t *testing.T) {
+	users := &fakeUserRepository{users: map[string]*entities.User{
+		"existing": {ID: "existing", Email: "taken@example.com"},
+	}}
+	service := NewUserImportService(NewUserService(users, nil, inlineTransactionManager{}, nil), nil, inlineTransactionManager{})
+
+	csv := strings.Join([]string{
+		"name,email,user_type,roles,password",
+		"Ana Souza,ana@example.com,STANDARD,,secret123",
+		"Al,bruno@example.com,STANDARD,,secret123",
+		"Carla Dias,not-an-email,STANDARD,,secret123",
+		"Davi Lima,ana@example.com,STANDARD,,secret123",
+		"Eva Rocha,taken@example.com,STANDARD,,secret123",
+		"Fabio Reis,fabio@example.com,ROOT,,secret123",
+		"Gil Nunes,gil@example.com,STANDARD,fly,secret123",
+		"Hugo Melo,hugo@example.com,STANDARD,,short",
+	}, "\n")
+	want := map[int]string{
+		3: "name must have at least 3 characters",
+		4: "invalid email",
+		5: "email duplicated in row 2",
+		6: "email already in use",
+		7: `invalid user type "ROOT"`,
+		8: `invalid role "FLY"`,
+		9: "password must have at least 8 characters unless invitations are sent",
+	}
+
+	for _, mode := range []dtos.UserImportMode{dtos.ImportPerRow, dtos.ImportAtomic} {
+		t.Run(string(mode), func(t *testing.T) {
+			report, err := service.Import(context.Background(), strings.NewReader(csv), dtos.UserImportOptions{DryRun: true, Mode: mode})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if report.Total != 8 || report.Succeeded != 1 || report.Failed != 7 {
+				t.Errorf("total %d, succeeded %d, failed %d; want 8, 1, 7", report.Total, report.Succeeded, report.Failed)
+			}
+			for _, row := range report.Rows {
+				message, invalid := want[row.Row]
+				switch {
+				case !invalid && (row.Status != dtos.ImportRowValid || len(row.Errors) > 0):
+					t.Errorf("row %d = %s %v, want valid", row.Row, row.Status, row.Errors)
+				case invalid && (row.Status != dtos.ImportRowFailed || len(row.Errors) != 1 || row.Errors[0] != message):
+					t.Errorf("row %d = %s %v, want failed with %q", row.Row, row.Status, row.Errors, message)
+				}
+			}
+		})
+	}
+
+	// Sem dry run, o modo atômico desfaz tudo antes de gravar: o repositório
+	// falso não implementa Create e entraria em pânico se fosse chamado
+	report, err := service.Import(context.Background(), strings.NewReader(csv), dtos.UserImportOptions{Mode: dtos.ImportAtomic})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status := report.Rows[0].Status; status != dtos.ImportRowRolledBack {
+		t.Errorf("valid row in a failed atomic import = %s, want %s", status, dtos.ImportRowRolledBack)
+	}
+	if len(users.users) != 1 {
+		t.Errorf("atomic import with invalid rows created %d users", len(users.users)-1)
+	}
+}
+
+func TestUserImportInvitationsSkipPassword(t *testing.T) {
+	users := &fakeUserRepository{users: map[string]*entities.User{}}
+	service := NewUserImportService(NewUserService(users, nil, inlineTransactionManager{}, nil), nil, inlineTransactionManager{})
+
+	csv := "name,email,user_type\nAna Souza,ana@example.com," + string(enums.Standard) + "\n"
+	report, err := service.Import(context.Background(), strings.NewReader(csv), dtos.UserImportOptions{DryRun: true, SendInvitations: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Mode != dtos.ImportPerRow || report.Rows[0].Status != dtos.ImportRowValid {
+		t.Errorf("mode %s, row %s %v; want per_row and a valid row", report.Mode, report.Rows[0].Status, report.Rows[0].Errors)
+	}
+}
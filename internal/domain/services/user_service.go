@@ -2,39 +2,85 @@ package services
 
 import (
 	"context"
+	"finanvilla/internal/application/dtos"
 	"finanvilla/internal/domain/entities"
 	"finanvilla/internal/domain/enums"
 	"finanvilla/internal/domain/repositories"
+	"finanvilla/pkg/brdoc"
 	"finanvilla/pkg/errors"
+	"finanvilla/pkg/locale"
+	"fmt"
+	"strings"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
 type UserService struct {
-	userRepo repositories.UserRepository
+	userRepo       repositories.UserRepository
+	categoryRepo   repositories.CategoryRepository
+	txManager      repositories.TransactionManager
+	securityEvents *SecurityEventService
 }
 
-func NewUserService(userRepo repositories.UserRepository) *UserService {
-	return &UserService{userRepo: userRepo}
+func NewUserService(
+	userRepo repositories.UserRepository,
+	categoryRepo repositories.CategoryRepository,
+	txManager repositories.TransactionManager,
+	securityEvents *SecurityEventService,
+) *UserService {
+	return &UserService{
+		userRepo:       userRepo,
+		categoryRepo:   categoryRepo,
+		txManager:      txManager,
+		securityEvents: securityEvents,
+	}
 }
 
 func (s *UserService) CreateUser(ctx context.Context, user *entities.User) error {
+	if err := normalizeIdentity(user); err != nil {
+		return err
+	}
+
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
 	if err != nil {
 		return err
 	}
 	user.Password = string(hashedPassword)
 
+	// Idioma e moeda enviados no cadastro são mantidos; o resto vem dos padrões
+	language, currency := "pt-BR", "BRL"
+	if user.Settings != nil {
+		if parsed, err := locale.ParseLanguage(user.Settings.Language); err == nil {
+			language = parsed
+		}
+		if parsed, err := locale.ParseCurrency(user.Settings.Currency); err == nil {
+			currency = parsed
+		}
+	}
+
 	user.Settings = &entities.UserSettings{
-		Theme:    "light",
-		Language: "pt-BR",
-		Currency: "BRL",
+		Theme:                "light",
+		Language:             language,
+		Currency:             currency,
+		DateFormat:           "DD/MM/YYYY",
+		Timezone:             "America/Sao_Paulo",
+		NumberFormat:         locale.NumberFormatCommaDecimal,
+		FirstDayOfWeek:       "SUNDAY",
+		NotificationsEnabled: true,
+		DigestFrequency:      enums.DigestWeekly,
+		BudgetMode:           enums.StandardBudget,
 	}
 
 	permissions := getDefaultPermissions(user.UserType)
 	user.Permissions = permissions
 
-	return s.userRepo.Create(ctx, user)
+	return s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			return err
+		}
+		return seedDefaultCategories(ctx, s.categoryRepo, user.ID, language)
+	})
 }
 
 func (s *UserService) UpdateUser(ctx context.Context, user *entities.User) error {
@@ -53,7 +99,17 @@ func (s *UserService) UpdateUser(ctx context.Context, user *entities.User) error
 		user.Password = existingUser.Password
 	}
 
-	return s.userRepo.Update(ctx, user)
+	// O e-mail só pode ser alterado pelo fluxo de confirmação (EmailChangeService)
+	user.Email = existingUser.Email
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	if user.Password != existingUser.Password {
+		s.securityEvents.RecordForUser(ctx, user, enums.PasswordChangeEvent, enums.OutcomeSuccess, "")
+	}
+	return nil
 }
 
 func (s *UserService) DeleteUser(ctx context.Context, id string) error {
@@ -80,6 +136,122 @@ func (s *UserService) GetByEmail(ctx context.Context, email string) (*entities.U
 	return user, nil
 }
 
+func (s *UserService) ChangeEmail(ctx context.Context, id, email string) error {
+	if err := s.userRepo.UpdateEmail(ctx, id, email); err != nil {
+		return err
+	}
+
+	s.securityEvents.Record(ctx, &entities.SecurityEvent{
+		UserID:  &id,
+		Email:   email,
+		Type:    enums.EmailChangeEvent,
+		Outcome: enums.OutcomeSuccess,
+	})
+	return nil
+}
+
+func (s *UserService) SetAvatar(ctx context.Context, id, avatarKey string) error {
+	return s.userRepo.UpdateAvatar(ctx, id, avatarKey)
+}
+
+// UpdateProfile normaliza e grava os dados de identificação (CPF/CNPJ, telefone e nascimento)
+func (s *UserService) UpdateProfile(ctx context.Context, userID string, req *dtos.UpdateProfileRequest) (*entities.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.ErrUserNotFound
+	}
+
+	if req.TaxID != nil {
+		if user.TaxID, err = parseTaxID(*req.TaxID); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Phone != nil {
+		if user.Phone, err = parsePhone(*req.Phone); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.BirthDate != nil {
+		user.BirthDate = nil
+		if *req.BirthDate != "" {
+			birthDate, err := time.Parse("2006-01-02", *req.BirthDate)
+			if err != nil {
+				return nil, fmt.Errorf("%w: birth date must use the YYYY-MM-DD format", errors.ErrInvalidInput)
+			}
+			if err := checkBirthDate(birthDate); err != nil {
+				return nil, err
+			}
+			user.BirthDate = &birthDate
+		}
+	}
+
+	if err := s.userRepo.UpdateProfile(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// normalizeIdentity aplica ao cadastro as mesmas regras do UpdateProfile:
+// CPF/CNPJ com dígito verificador, telefone em E.164 e data de nascimento
+// plausível. Valores vazios são descartados.
+func normalizeIdentity(user *entities.User) error {
+	var err error
+	if user.TaxID != nil {
+		if user.TaxID, err = parseTaxID(user.TaxID.Raw()); err != nil {
+			return err
+		}
+	}
+	if user.Phone != nil {
+		if user.Phone, err = parsePhone(user.Phone.Raw()); err != nil {
+			return err
+		}
+	}
+	if user.BirthDate != nil {
+		if user.BirthDate.IsZero() {
+			user.BirthDate = nil
+		} else if err := checkBirthDate(*user.BirthDate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseTaxID valida e normaliza um CPF ou CNPJ; vazio vira nil
+func parseTaxID(s string) (*brdoc.TaxID, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	normalized, err := brdoc.NormalizeTaxID(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrInvalidInput, err)
+	}
+	taxID := brdoc.TaxID(normalized)
+	return &taxID, nil
+}
+
+// parsePhone valida e normaliza o telefone em E.164; vazio vira nil
+func parsePhone(s string) (*brdoc.Phone, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	normalized, err := brdoc.NormalizePhone(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrInvalidInput, err)
+	}
+	phone := brdoc.Phone(normalized)
+	return &phone, nil
+}
+
+func checkBirthDate(birthDate time.Time) error {
+	if birthDate.After(time.Now()) || birthDate.Before(time.Now().AddDate(-130, 0, 0)) {
+		return fmt.Errorf("%w: birth date out of range", errors.ErrInvalidInput)
+	}
+	return nil
+}
+
 func (s *UserService) List(ctx context.Context, page, limit int) ([]entities.User, int, error) {
 	if page < 1 {
 		page = 1
@@ -100,6 +272,10 @@ func (s *UserService) UpdateSettings(ctx context.Context, userID string, setting
 	return s.userRepo.UpdateSettings(ctx, settings)
 }
 
+func (s *UserService) UpdateSettingsFields(ctx context.Context, userID string, fields map[string]interface{}) error {
+	return s.userRepo.UpdateSettingsFields(ctx, userID, fields)
+}
+
 func (s *UserService) AddPermissions(ctx context.Context, userID string, permissions []string) error {
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
@@ -112,7 +288,12 @@ func (s *UserService) AddPermissions(ctx context.Context, userID string, permiss
 		}
 	}
 
-	return s.userRepo.AddPermissions(ctx, user.ID, permissions)
+	if err := s.userRepo.AddPermissions(ctx, user.ID, permissions); err != nil {
+		return err
+	}
+
+	s.recordPermissionChange(ctx, user, "added: "+strings.Join(permissions, ", "))
+	return nil
 }
 
 func (s *UserService) RemovePermissions(ctx context.Context, userID string, permissions []string) error {
@@ -127,7 +308,23 @@ func (s *UserService) RemovePermissions(ctx context.Context, userID string, perm
 		}
 	}
 
-	return s.userRepo.RemovePermissions(ctx, user.ID, permissions)
+	if err := s.userRepo.RemovePermissions(ctx, user.ID, permissions); err != nil {
+		return err
+	}
+
+	s.recordPermissionChange(ctx, user, "removed: "+strings.Join(permissions, ", "))
+	return nil
+}
+
+func (s *UserService) recordPermissionChange(ctx context.Context, user *entities.User, details string) {
+	userID := user.ID
+	s.securityEvents.Record(ctx, &entities.SecurityEvent{
+		UserID:  &userID,
+		Email:   user.Email,
+		Type:    enums.PermissionChangeEvent,
+		Outcome: enums.OutcomeSuccess,
+		Details: details,
+	})
 }
 
 func (s *UserService) Authenticate(ctx context.Context, email, password string) (*entities.User, error) {
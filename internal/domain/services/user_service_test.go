@@ -0,0 +1,40 @@
+package services
+
+import (
+	"errors"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/pkg/brdoc"
+	appErrors "finanvilla/pkg/errors"
+	"testing"
+	"time"
+)
+
+func TestNormalizeIdentity(t *testing.T) {
+	taxID, phone := brdoc.TaxID("529.982.247-25"), brdoc.Phone("(11) 98765-4321")
+	user := &entities.User{TaxID: &taxID, Phone: &phone}
+	if err := normalizeIdentity(user); err != nil {
+		t.Fatal(err)
+	}
+	if user.TaxID.Raw() != "52998224725" || user.Phone.Raw() != "+5511987654321" {
+		t.Errorf("identity = %s, %s; want 52998224725, +5511987654321", user.TaxID.Raw(), user.Phone.Raw())
+	}
+
+	empty := brdoc.Phone(" ")
+	user = &entities.User{Phone: &empty}
+	if err := normalizeIdentity(user); err != nil || user.Phone != nil {
+		t.Errorf("empty phone = %v, %v; want nil", user.Phone, err)
+	}
+
+	future := time.Now().AddDate(1, 0, 0)
+	invalidTaxID := brdoc.TaxID("529.982.247-26")
+	invalidPhone := brdoc.Phone("12345")
+	for name, user := range map[string]*entities.User{
+		"check digit": {TaxID: &invalidTaxID},
+		"phone":       {Phone: &invalidPhone},
+		"birth date":  {BirthDate: &future},
+	} {
+		if err := normalizeIdentity(user); !errors.Is(err, appErrors.ErrInvalidInput) {
+			t.Errorf("invalid %s: err = %v, want ErrInvalidInput", name, err)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"finanvilla/internal/infrastructure/repositories"
+	"finanvilla/pkg/money"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// migrateTestDatabase aplica todas as migrações de subida, em ordem
+func migrateTestDatabase(t *testing.T, dbURL string) *gorm.DB {
+	db, err := gorm.Open(postgres.Open(dbURL), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := filepath.Glob(filepath.Join("migrations", "*.up.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := sqlDB.Exec(string(content)); err != nil {
+			t.Fatalf("%s: %v", filepath.Base(file), err)
+		}
+	}
+	return db
+}
+
+type budgetFixture struct {
+	category string
+	month    string
+	amount   string
+	rollover bool
+}
+
+type spendingFixture struct {
+	category string
+	date     string
+	amount   string
+}
+
+type budgetLineWant struct {
+	name      string
+	carried   string
+	remaining string
+}
+
+func TestBudgetReport(t *testing.T) {
+	dbURL, cleanup := setupTestDatabase(t)
+	defer cleanup()
+
+	db := migrateTestDatabase(t, dbURL)
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := repositories.NewPostgresBudgetRepository(db)
+	march := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		categories []string
+		// parents liga subcategoria a categoria pai, pelo nome
+		parents  map[string]string
+		budgets  []budgetFixture
+		spending []spendingFixture
+		envelope bool
+		want     []budgetLineWant
+	}{
+		{
+			name:       "rollover carries the leftover",
+			categories: []string{"Food"},
+			budgets: []budgetFixture{
+				{"Food", "2024-01-01", "500", true},
+				{"Food", "2024-02-01", "500", true},
+				{"Food", "2024-03-01", "500", false},
+			},
+			spending: []spendingFixture{
+				{"Food", "2024-01-10", "300"},
+				{"Food", "2024-02-10", "400"},
+				{"Food", "2024-03-10", "100"},
+			},
+			want: []budgetLineWant{{"Food", "300", "700"}},
+		},
+		{
+			name:       "month without rollover closes the period",
+			categories: []string{"Food"},
+			budgets: []budgetFixture{
+				{"Food", "2024-01-01", "500", true},
+				{"Food", "2024-02-01", "500", false},
+				{"Food", "2024-03-01", "500", true},
+			},
+			spending: []spendingFixture{
+				{"Food", "2024-01-10", "300"},
+				{"Food", "2024-02-10", "400"},
+				{"Food", "2024-03-10", "100"},
+			},
+			want: []budgetLineWant{{"Food", "0", "400"}},
+		},
+		{
+			name:       "overspending is carried as a negative amount",
+			categories: []string{"Food"},
+			budgets: []budgetFixture{
+				{"Food", "2024-02-01", "500", true},
+				{"Food", "2024-03-01", "500", true},
+			},
+			spending: []spendingFixture{
+				{"Food", "2024-02-10", "650"},
+				{"Food", "2024-03-10", "100"},
+			},
+			want: []budgetLineWant{{"Food", "-150", "250"}},
+		},
+		{
+			name:       "envelope carries every month",
+			categories: []string{"Food"},
+			budgets:    []budgetFixture{{"Food", "2024-01-01", "500", false}},
+			spending: []spendingFixture{
+				{"Food", "2024-01-10", "300"},
+				{"Food", "2024-03-10", "50"},
+			},
+			envelope: true,
+			want:     []budgetLineWant{{"Food", "200", "150"}},
+		},
+		{
+			name:       "categories do not share the carried amount",
+			categories: []string{"Rent", "Food"},
+			budgets: []budgetFixture{
+				{"Food", "2024-02-01", "500", true},
+				{"Food", "2024-03-01", "100", true},
+				{"Rent", "2024-03-01", "1500", true},
+			},
+			spending: []spendingFixture{
+				{"Food", "2024-02-10", "300"},
+				{"Rent", "2024-03-05", "1500"},
+			},
+			want: []budgetLineWant{{"Food", "200", "300"}, {"Rent", "0", "0"}},
+		},
+		{
+			name:       "category without budget, spending or balance is hidden",
+			categories: []string{"Food", "Fuel"},
+			budgets:    []budgetFixture{{"Food", "2024-02-01", "500", false}},
+			spending: []spendingFixture{
+				{"Food", "2024-02-10", "500"},
+				{"Fuel", "2024-03-10", "120"},
+			},
+			want: []budgetLineWant{{"Fuel", "0", "-120"}},
+		},
+		{
+			name:       "spending counts for the closest budgeted ancestor",
+			categories: []string{"Home", "Power"},
+			parents:    map[string]string{"Power": "Home"},
+			budgets:    []budgetFixture{{"Home", "2024-03-01", "1000", false}},
+			spending:   []spendingFixture{{"Power", "2024-03-15", "200"}},
+			want:       []budgetLineWant{{"Home", "0", "800"}},
+		},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID := seedBudgetUser(t, sqlDB, fmt.Sprintf("budget%d@example.com", i), tt.categories, tt.parents, tt.budgets, tt.spending)
+
+			lines, err := repo.Report(context.Background(), userID, "BRL", march, tt.envelope)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(lines) != len(tt.want) {
+				t.Fatalf("got %d lines, want %d: %+v", len(lines), len(tt.want), lines)
+			}
+			for j, want := range tt.want {
+				line := lines[j]
+				carried, _ := money.Parse(want.carried, "BRL")
+				remaining, _ := money.Parse(want.remaining, "BRL")
+				if line.Name != want.name || !line.CarriedOver.Equal(carried) || !line.Remaining.Equal(remaining) {
+					t.Errorf("line %d = %s carried %s remaining %s; want %s carried %s remaining %s", j,
+						line.Name, line.CarriedOver.Decimal(), line.Remaining.Decimal(),
+						want.name, carried.Decimal(), remaining.Decimal())
+				}
+			}
+		})
+	}
+}
+
+func seedBudgetUser(t *testing.T, db *sql.DB, email string, categories []string, parents map[string]string, budgets []budgetFixture, spending []spendingFixture) string {
+	var userID string
+	err := db.QueryRow(`INSERT INTO users (name, email, password, user_type) VALUES ('Budget', $1, 'x', 'PF') RETURNING id`, email).Scan(&userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := map[string]string{}
+	for _, name := range categories {
+		var parentID *string
+		if parent, ok := parents[name]; ok {
+			id := ids[parent]
+			parentID = &id
+		}
+		var id string
+		err := db.QueryRow(`INSERT INTO categories (user_id, parent_id, name, type) VALUES ($1, $2, $3, 'EXPENSE') RETURNING id`,
+			userID, parentID, name).Scan(&id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[name] = id
+	}
+
+	for _, b := range budgets {
+		_, err := db.Exec(`INSERT INTO budgets (user_id, category_id, month, amount, currency, rollover) VALUES ($1, $2, $3, $4, 'BRL', $5)`,
+			userID, ids[b.category], b.month, b.amount, b.rollover)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, s := range spending {
+		var transactionID string
+		err := db.QueryRow(`INSERT INTO transactions (user_id, date, description, currency) VALUES ($1, $2, 'test', 'BRL') RETURNING id`,
+			userID, s.date).Scan(&transactionID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = db.Exec(`INSERT INTO postings (transaction_id, category_id, amount) VALUES ($1, $2, $3)`,
+			transactionID, ids[s.category], s.amount)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return userID
+}
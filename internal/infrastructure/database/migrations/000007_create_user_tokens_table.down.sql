@@ -0,0 +1,2 @@
+-- 000007_create_user_tokens_table.down.sql
+DROP TABLE IF EXISTS user_tokens;
@@ -0,0 +1,2 @@
+-- 000008_add_avatar_to_users.down.sql
+ALTER TABLE users DROP COLUMN IF EXISTS avatar_key;
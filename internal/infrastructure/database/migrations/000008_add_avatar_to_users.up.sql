@@ -0,0 +1,2 @@
+-- 000008_add_avatar_to_users.up.sql
+ALTER TABLE users ADD COLUMN IF NOT EXISTS avatar_key VARCHAR(255) NOT NULL DEFAULT '';
@@ -0,0 +1,7 @@
+-- 000009_add_identity_fields_to_users.down.sql
+DROP INDEX IF EXISTS idx_users_user_type_tax_id;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS tax_id,
+    DROP COLUMN IF EXISTS phone,
+    DROP COLUMN IF EXISTS birth_date;
@@ -0,0 +1,9 @@
+-- 000009_add_identity_fields_to_users.up.sql
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS tax_id VARCHAR(14),
+    ADD COLUMN IF NOT EXISTS phone VARCHAR(16),
+    ADD COLUMN IF NOT EXISTS birth_date DATE;
+
+-- CPF/CNPJ único por tipo de conta, ignorando usuários removidos
+CREATE UNIQUE INDEX idx_users_user_type_tax_id ON users(user_type, tax_id)
+    WHERE tax_id IS NOT NULL AND deleted_at IS NULL;
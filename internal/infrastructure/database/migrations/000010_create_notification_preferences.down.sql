@@ -0,0 +1,9 @@
+-- 000010_create_notification_preferences.down.sql
+DROP TRIGGER IF EXISTS update_notification_preferences_timestamp ON notification_preferences;
+DROP TABLE IF EXISTS notification_preferences;
+
+ALTER TABLE user_settings
+    DROP COLUMN IF EXISTS timezone,
+    DROP COLUMN IF EXISTS quiet_hours_start,
+    DROP COLUMN IF EXISTS quiet_hours_end,
+    DROP COLUMN IF EXISTS digest_frequency;
@@ -0,0 +1,4 @@
+-- 000011_add_locale_fields_to_user_settings.down.sql
+ALTER TABLE user_settings
+    DROP COLUMN IF EXISTS number_format,
+    DROP COLUMN IF EXISTS first_day_of_week;
@@ -0,0 +1,4 @@
+-- 000011_add_locale_fields_to_user_settings.up.sql
+ALTER TABLE user_settings
+    ADD COLUMN IF NOT EXISTS number_format VARCHAR(10) NOT NULL DEFAULT '1.234,56',
+    ADD COLUMN IF NOT EXISTS first_day_of_week VARCHAR(10) NOT NULL DEFAULT 'SUNDAY';
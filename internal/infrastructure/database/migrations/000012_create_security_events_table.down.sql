@@ -0,0 +1,2 @@
+-- 000012_create_security_events_table.down.sql
+DROP TABLE IF EXISTS security_events;
@@ -0,0 +1,18 @@
+-- 000012_create_security_events_table.up.sql
+CREATE TABLE IF NOT EXISTS security_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255),
+    type VARCHAR(30) NOT NULL,
+    outcome VARCHAR(10) NOT NULL,
+    reason TEXT,
+    details TEXT,
+    ip VARCHAR(45),
+    user_agent TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Criar índices para o feed do usuário, para a consulta administrativa e para a limpeza por retenção
+CREATE INDEX idx_security_events_user_id_created_at ON security_events(user_id, created_at DESC);
+CREATE INDEX idx_security_events_created_at ON security_events(created_at);
+CREATE INDEX idx_security_events_ip ON security_events(ip);
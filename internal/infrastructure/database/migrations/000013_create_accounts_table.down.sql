@@ -0,0 +1,3 @@
+-- 000013_create_accounts_table.down.sql
+DROP TRIGGER IF EXISTS update_accounts_timestamp ON accounts;
+DROP TABLE IF EXISTS accounts;
@@ -0,0 +1,23 @@
+-- 000013_create_accounts_table.up.sql
+CREATE TABLE IF NOT EXISTS accounts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('CHECKING', 'SAVINGS', 'CREDIT_CARD', 'CASH', 'INVESTMENT')),
+    institution VARCHAR(100) NOT NULL DEFAULT '',
+    currency CHAR(3) NOT NULL,
+    opening_balance BIGINT NOT NULL DEFAULT 0,
+    archived BOOLEAN NOT NULL DEFAULT FALSE,
+    display_order INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Nome único por usuário, sem diferenciar maiúsculas
+CREATE UNIQUE INDEX idx_accounts_user_id_name ON accounts(user_id, LOWER(name));
+CREATE INDEX idx_accounts_user_id_display_order ON accounts(user_id, archived, display_order);
+
+CREATE TRIGGER update_accounts_timestamp
+    BEFORE UPDATE ON accounts
+    FOR EACH ROW
+    EXECUTE FUNCTION update_timestamp();
@@ -0,0 +1,4 @@
+-- 000014_create_transactions_tables.down.sql
+DROP TRIGGER IF EXISTS update_transactions_timestamp ON transactions;
+DROP TABLE IF EXISTS postings;
+DROP TABLE IF EXISTS transactions;
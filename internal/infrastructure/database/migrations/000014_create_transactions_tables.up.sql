@@ -0,0 +1,36 @@
+-- 000014_create_transactions_tables.up.sql
+CREATE TABLE IF NOT EXISTS transactions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    description VARCHAR(255) NOT NULL,
+    payee VARCHAR(255) NOT NULL DEFAULT '',
+    notes TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'CLEARED', 'RECONCILED')),
+    currency CHAR(3) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Pernas do lançamento; a soma por transação é validada no TransactionService.
+-- Contas com lançamentos não podem ser excluídas, apenas arquivadas. A FK fica
+-- como NO ACTION (e não RESTRICT) para que excluir o usuário remova tudo em cascata.
+CREATE TABLE IF NOT EXISTS postings (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    transaction_id UUID NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    account_id UUID REFERENCES accounts(id),
+    category_id UUID,
+    amount BIGINT NOT NULL CHECK (amount <> 0),
+    memo VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_transactions_user_id_date ON transactions(user_id, date DESC);
+CREATE INDEX idx_postings_transaction_id ON postings(transaction_id);
+CREATE INDEX idx_postings_account_id ON postings(account_id) INCLUDE (amount);
+CREATE INDEX idx_postings_category_id ON postings(category_id);
+
+CREATE TRIGGER update_transactions_timestamp
+    BEFORE UPDATE ON transactions
+    FOR EACH ROW
+    EXECUTE FUNCTION update_timestamp();
@@ -0,0 +1,39 @@
+-- 000015_use_numeric_money_columns.up.sql
+-- Valores monetários passam de BIGINT em unidades mínimas para NUMERIC exato,
+-- lido e gravado pelo tipo money.Money. A conversão depende das casas decimais
+-- de cada moeda (as mesmas usadas por pkg/money).
+CREATE FUNCTION money_minor_digits(code CHAR(3)) RETURNS INTEGER AS $$
+    SELECT CASE
+        WHEN code IN ('AFN', 'ALL', 'AMD', 'BIF', 'CLP', 'COP', 'DJF', 'GNF', 'IDR', 'IQD', 'IRR', 'ISK',
+                      'JPY', 'KMF', 'KPW', 'KRW', 'LAK', 'LBP', 'MGA', 'MMK', 'MNT', 'PKR', 'PYG', 'RSD',
+                      'RWF', 'SLL', 'SOS', 'SYP', 'TZS', 'UGX', 'UYI', 'UZS', 'VND', 'VUV', 'XAF', 'XOF',
+                      'XPF', 'YER') THEN 0
+        WHEN code IN ('BHD', 'JOD', 'KWD', 'LYD', 'OMR', 'TND') THEN 3
+        WHEN code IN ('CLF') THEN 4
+        ELSE 2
+    END
+$$ LANGUAGE SQL IMMUTABLE;
+
+ALTER TABLE accounts
+    ALTER COLUMN opening_balance DROP DEFAULT,
+    ALTER COLUMN opening_balance TYPE NUMERIC(19,4)
+        USING opening_balance::NUMERIC / POWER(10::NUMERIC, money_minor_digits(currency)),
+    ALTER COLUMN opening_balance SET DEFAULT 0;
+
+-- A moeda da perna está no lançamento, então a conversão passa por uma coluna nova
+ALTER TABLE postings ADD COLUMN amount_decimal NUMERIC(19,4);
+
+UPDATE postings p
+SET amount_decimal = p.amount::NUMERIC / POWER(10::NUMERIC, money_minor_digits(t.currency))
+FROM transactions t
+WHERE t.id = p.transaction_id;
+
+ALTER TABLE postings DROP COLUMN amount;
+ALTER TABLE postings RENAME COLUMN amount_decimal TO amount;
+ALTER TABLE postings
+    ALTER COLUMN amount SET NOT NULL,
+    ADD CONSTRAINT postings_amount_check CHECK (amount <> 0);
+
+CREATE INDEX idx_postings_account_id ON postings(account_id) INCLUDE (amount);
+
+DROP FUNCTION money_minor_digits(CHAR(3));
@@ -0,0 +1,4 @@
+-- 000016_create_categories_table.down.sql
+ALTER TABLE postings DROP CONSTRAINT IF EXISTS fk_postings_category;
+DROP TRIGGER IF EXISTS update_categories_timestamp ON categories;
+DROP TABLE IF EXISTS categories;
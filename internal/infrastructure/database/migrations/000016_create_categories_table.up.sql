@@ -0,0 +1,30 @@
+-- 000016_create_categories_table.up.sql
+CREATE TABLE IF NOT EXISTS categories (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    parent_id UUID REFERENCES categories(id),
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(10) NOT NULL CHECK (type IN ('INCOME', 'EXPENSE')),
+    color VARCHAR(7) NOT NULL DEFAULT '',
+    icon VARCHAR(50) NOT NULL DEFAULT '',
+    archived BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Nome único entre irmãos; categorias raiz usam um UUID nulo como pai no índice
+CREATE UNIQUE INDEX idx_categories_user_parent_name
+    ON categories(user_id, COALESCE(parent_id, '00000000-0000-0000-0000-000000000000'), LOWER(name));
+CREATE INDEX idx_categories_parent_id ON categories(parent_id);
+
+CREATE TRIGGER update_categories_timestamp
+    BEFORE UPDATE ON categories
+    FOR EACH ROW
+    EXECUTE FUNCTION update_timestamp();
+
+-- Até aqui postings.category_id não tinha destino; referências soltas são descartadas
+UPDATE postings SET category_id = NULL
+WHERE category_id IS NOT NULL AND category_id NOT IN (SELECT id FROM categories);
+
+ALTER TABLE postings
+    ADD CONSTRAINT fk_postings_category FOREIGN KEY (category_id) REFERENCES categories(id);
@@ -0,0 +1,5 @@
+-- 000017_create_budgets_table.down.sql
+ALTER TABLE user_settings DROP COLUMN IF EXISTS budget_mode;
+
+DROP TRIGGER IF EXISTS update_budgets_timestamp ON budgets;
+DROP TABLE IF EXISTS budgets;
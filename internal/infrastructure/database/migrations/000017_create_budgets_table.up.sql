@@ -0,0 +1,23 @@
+-- 000017_create_budgets_table.up.sql
+CREATE TABLE IF NOT EXISTS budgets (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    category_id UUID NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    month DATE NOT NULL CHECK (EXTRACT(DAY FROM month) = 1),
+    amount NUMERIC(19,4) NOT NULL CHECK (amount >= 0),
+    currency CHAR(3) NOT NULL,
+    rollover BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_budgets_category_month ON budgets(category_id, month);
+CREATE INDEX idx_budgets_user_month ON budgets(user_id, month);
+
+CREATE TRIGGER update_budgets_timestamp
+    BEFORE UPDATE ON budgets
+    FOR EACH ROW
+    EXECUTE FUNCTION update_timestamp();
+
+ALTER TABLE user_settings
+    ADD COLUMN IF NOT EXISTS budget_mode VARCHAR(10) NOT NULL DEFAULT 'STANDARD';
@@ -0,0 +1,9 @@
+-- 000018_create_recurring_transactions_table.down.sql
+DROP INDEX IF EXISTS idx_transactions_recurring_occurrence;
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS occurrence_date,
+    DROP COLUMN IF EXISTS recurring_id;
+
+DROP TRIGGER IF EXISTS update_recurring_transactions_timestamp ON recurring_transactions;
+DROP TABLE IF EXISTS recurring_postings;
+DROP TABLE IF EXISTS recurring_transactions;
@@ -0,0 +1,47 @@
+-- 000018_create_recurring_transactions_table.up.sql
+CREATE TABLE IF NOT EXISTS recurring_transactions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    description VARCHAR(255) NOT NULL,
+    payee VARCHAR(255) NOT NULL DEFAULT '',
+    notes TEXT NOT NULL DEFAULT '',
+    currency CHAR(3) NOT NULL,
+    rule VARCHAR(255) NOT NULL,
+    start_date DATE NOT NULL,
+    -- Próxima ocorrência ainda não lançada; NULL quando a série terminou
+    next_date DATE,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_recurring_transactions_user_id ON recurring_transactions(user_id);
+CREATE INDEX idx_recurring_transactions_due ON recurring_transactions(next_date) WHERE active;
+
+-- Mesmas regras das pernas de um lançamento comum
+CREATE TABLE IF NOT EXISTS recurring_postings (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    recurring_id UUID NOT NULL REFERENCES recurring_transactions(id) ON DELETE CASCADE,
+    account_id UUID REFERENCES accounts(id),
+    category_id UUID REFERENCES categories(id),
+    amount NUMERIC(19,4) NOT NULL CHECK (amount <> 0),
+    memo VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_recurring_postings_recurring_id ON recurring_postings(recurring_id);
+CREATE INDEX idx_recurring_postings_account_id ON recurring_postings(account_id);
+CREATE INDEX idx_recurring_postings_category_id ON recurring_postings(category_id);
+
+CREATE TRIGGER update_recurring_transactions_timestamp
+    BEFORE UPDATE ON recurring_transactions
+    FOR EACH ROW
+    EXECUTE FUNCTION update_timestamp();
+
+-- Cada ocorrência gera no máximo um lançamento; o índice único torna a
+-- materialização idempotente mesmo com execuções repetidas ou concorrentes
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS recurring_id UUID REFERENCES recurring_transactions(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS occurrence_date DATE;
+
+CREATE UNIQUE INDEX idx_transactions_recurring_occurrence ON transactions(recurring_id, occurrence_date);
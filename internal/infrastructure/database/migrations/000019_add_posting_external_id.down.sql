@@ -0,0 +1,3 @@
+-- 000019_add_posting_external_id.down.sql
+DROP INDEX IF EXISTS idx_postings_account_external_id;
+ALTER TABLE postings DROP COLUMN IF EXISTS external_id;
@@ -0,0 +1,8 @@
+-- 000019_add_posting_external_id.up.sql
+-- Identificador da movimentação no banco (FITID do OFX, nosso número do CNAB...).
+-- O índice único por conta impede que o mesmo extrato seja importado duas vezes.
+ALTER TABLE postings ADD COLUMN IF NOT EXISTS external_id VARCHAR(255);
+
+CREATE UNIQUE INDEX idx_postings_account_external_id
+    ON postings(account_id, external_id)
+    WHERE external_id IS NOT NULL;
@@ -0,0 +1,3 @@
+-- 000020_create_csv_import_profiles_table.down.sql
+DROP TRIGGER IF EXISTS update_csv_import_profiles_timestamp ON csv_import_profiles;
+DROP TABLE IF EXISTS csv_import_profiles;
@@ -0,0 +1,34 @@
+-- 000020_create_csv_import_profiles_table.up.sql
+CREATE TABLE IF NOT EXISTS csv_import_profiles (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    institution VARCHAR(100) NOT NULL DEFAULT '',
+    -- Vazios: detectados a cada importação
+    delimiter VARCHAR(1) NOT NULL DEFAULT '',
+    encoding VARCHAR(20) NOT NULL DEFAULT '',
+    has_header BOOLEAN NOT NULL DEFAULT TRUE,
+    skip_rows INTEGER NOT NULL DEFAULT 0 CHECK (skip_rows >= 0),
+    -- Nome da coluna no cabeçalho ou número, a partir de 1
+    date_column VARCHAR(100) NOT NULL,
+    description_column VARCHAR(100) NOT NULL,
+    amount_column VARCHAR(100) NOT NULL DEFAULT '',
+    debit_column VARCHAR(100) NOT NULL DEFAULT '',
+    credit_column VARCHAR(100) NOT NULL DEFAULT '',
+    payee_column VARCHAR(100) NOT NULL DEFAULT '',
+    memo_column VARCHAR(100) NOT NULL DEFAULT '',
+    external_id_column VARCHAR(100) NOT NULL DEFAULT '',
+    -- Vazios: preferências do usuário
+    date_format VARCHAR(20) NOT NULL DEFAULT '',
+    number_format VARCHAR(20) NOT NULL DEFAULT '',
+    invert_sign BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_csv_import_profiles_user_name ON csv_import_profiles(user_id, LOWER(name));
+
+CREATE TRIGGER update_csv_import_profiles_timestamp
+    BEFORE UPDATE ON csv_import_profiles
+    FOR EACH ROW
+    EXECUTE FUNCTION update_timestamp();
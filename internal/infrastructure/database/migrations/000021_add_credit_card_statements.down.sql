@@ -0,0 +1,18 @@
+-- 000021_add_credit_card_statements.down.sql
+DROP INDEX IF EXISTS idx_transactions_installment;
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS installment_number,
+    DROP COLUMN IF EXISTS installment_purchase_id;
+
+DROP TRIGGER IF EXISTS update_installment_purchases_timestamp ON installment_purchases;
+DROP TABLE IF EXISTS installment_purchases;
+
+DROP INDEX IF EXISTS idx_postings_account_statement;
+ALTER TABLE postings DROP COLUMN IF EXISTS statement_date;
+
+DROP FUNCTION IF EXISTS card_statement_date(DATE, INTEGER);
+
+ALTER TABLE accounts
+    DROP COLUMN IF EXISTS credit_limit,
+    DROP COLUMN IF EXISTS due_day,
+    DROP COLUMN IF EXISTS closing_day;
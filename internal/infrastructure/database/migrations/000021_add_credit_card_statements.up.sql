@@ -0,0 +1,58 @@
+-- 000021_add_credit_card_statements.up.sql
+-- Dados do cartão de crédito: dia de fechamento e de vencimento da fatura e limite
+ALTER TABLE accounts
+    ADD COLUMN IF NOT EXISTS closing_day SMALLINT CHECK (closing_day BETWEEN 1 AND 31),
+    ADD COLUMN IF NOT EXISTS due_day SMALLINT CHECK (due_day BETWEEN 1 AND 31),
+    ADD COLUMN IF NOT EXISTS credit_limit NUMERIC(19,4) CHECK (credit_limit >= 0);
+
+-- Data de fechamento da fatura em que cai um lançamento de d num cartão que
+-- fecha no dia closing_day (limitado ao fim do mês). O que é lançado no
+-- próprio dia do fechamento já entra na fatura seguinte.
+CREATE OR REPLACE FUNCTION card_statement_date(d DATE, closing_day INTEGER) RETURNS DATE AS $$
+    SELECT CASE WHEN d < closing THEN closing ELSE next_closing END
+    FROM (
+        SELECT
+            month + LEAST(closing_day, EXTRACT(DAY FROM month + INTERVAL '1 month - 1 day')::INTEGER) - 1 AS closing,
+            (month + INTERVAL '1 month')::DATE
+                + LEAST(closing_day, EXTRACT(DAY FROM month + INTERVAL '2 months - 1 day')::INTEGER) - 1 AS next_closing
+        FROM (SELECT date_trunc('month', d::TIMESTAMP)::DATE AS month) m
+    ) c
+$$ LANGUAGE SQL IMMUTABLE STRICT;
+
+-- Fatura a que a perna do cartão pertence. NULL segue a data do lançamento;
+-- parcelas e pagamentos de fatura gravam a fatura explicitamente.
+ALTER TABLE postings ADD COLUMN IF NOT EXISTS statement_date DATE;
+
+CREATE INDEX idx_postings_account_statement ON postings(account_id, statement_date)
+    WHERE statement_date IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS installment_purchases (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    description VARCHAR(255) NOT NULL,
+    payee VARCHAR(255) NOT NULL DEFAULT '',
+    notes TEXT NOT NULL DEFAULT '',
+    purchase_date DATE NOT NULL,
+    total_amount NUMERIC(19,4) NOT NULL CHECK (total_amount > 0),
+    installments SMALLINT NOT NULL CHECK (installments >= 2),
+    -- Fechamento da fatura da primeira parcela; as demais seguem mês a mês
+    first_statement DATE NOT NULL,
+    currency CHAR(3) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_installment_purchases_user_account ON installment_purchases(user_id, account_id);
+
+CREATE TRIGGER update_installment_purchases_timestamp
+    BEFORE UPDATE ON installment_purchases
+    FOR EACH ROW
+    EXECUTE FUNCTION update_timestamp();
+
+-- Cada parcela é um lançamento comum ligado à compra
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS installment_purchase_id UUID REFERENCES installment_purchases(id) ON DELETE CASCADE,
+    ADD COLUMN IF NOT EXISTS installment_number SMALLINT;
+
+CREATE UNIQUE INDEX idx_transactions_installment ON transactions(installment_purchase_id, installment_number);
@@ -0,0 +1,8 @@
+-- 000022_create_households_tables.down.sql
+DROP TABLE IF EXISTS settlements;
+DROP TABLE IF EXISTS shared_expense_shares;
+DROP TRIGGER IF EXISTS update_shared_expenses_timestamp ON shared_expenses;
+DROP TABLE IF EXISTS shared_expenses;
+DROP TABLE IF EXISTS household_members;
+DROP TRIGGER IF EXISTS update_households_timestamp ON households;
+DROP TABLE IF EXISTS households;
@@ -0,0 +1,77 @@
+-- 000022_create_households_tables.up.sql
+-- Casas (repúblicas, casais) que dividem despesas entre usuários
+CREATE TABLE IF NOT EXISTS households (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER update_households_timestamp
+    BEFORE UPDATE ON households
+    FOR EACH ROW
+    EXECUTE FUNCTION update_timestamp();
+
+-- A chave Pix do membro é usada nas cobranças de acerto feitas a ele
+CREATE TABLE IF NOT EXISTS household_members (
+    household_id UUID NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    pix_key VARCHAR(77) NOT NULL DEFAULT '',
+    pix_city VARCHAR(100) NOT NULL DEFAULT '',
+    joined_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (household_id, user_id)
+);
+
+CREATE INDEX idx_household_members_user ON household_members(user_id);
+
+CREATE TABLE IF NOT EXISTS shared_expenses (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    household_id UUID NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+    paid_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    description VARCHAR(255) NOT NULL,
+    notes TEXT NOT NULL DEFAULT '',
+    date DATE NOT NULL,
+    amount NUMERIC(19,4) NOT NULL CHECK (amount > 0),
+    currency CHAR(3) NOT NULL,
+    split_mode VARCHAR(20) NOT NULL CHECK (split_mode IN ('EQUAL', 'PERCENTAGE', 'EXACT', 'SHARES')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_shared_expenses_household_date ON shared_expenses(household_id, date);
+
+CREATE TRIGGER update_shared_expenses_timestamp
+    BEFORE UPDATE ON shared_expenses
+    FOR EACH ROW
+    EXECUTE FUNCTION update_timestamp();
+
+-- Parte de cada participante. weight guarda o que foi informado na divisão:
+-- centésimos de ponto percentual (PERCENTAGE) ou número de cotas (SHARES).
+CREATE TABLE IF NOT EXISTS shared_expense_shares (
+    expense_id UUID NOT NULL REFERENCES shared_expenses(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    amount NUMERIC(19,4) NOT NULL CHECK (amount >= 0),
+    weight BIGINT CHECK (weight >= 0),
+    PRIMARY KEY (expense_id, user_id)
+);
+
+-- Acertos: from_user_id pagou amount a to_user_id fora do sistema
+CREATE TABLE IF NOT EXISTS settlements (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    household_id UUID NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+    from_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    to_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    amount NUMERIC(19,4) NOT NULL CHECK (amount > 0),
+    currency CHAR(3) NOT NULL,
+    date DATE NOT NULL,
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    pix_payload TEXT NOT NULL DEFAULT '',
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (from_user_id <> to_user_id)
+);
+
+CREATE INDEX idx_settlements_household_date ON settlements(household_id, date);
@@ -0,0 +1,6 @@
+-- 000023_create_tags_and_saved_searches.down.sql
+DROP TRIGGER IF EXISTS update_saved_searches_timestamp ON saved_searches;
+DROP TABLE IF EXISTS saved_searches;
+DROP TABLE IF EXISTS transaction_tags;
+DROP TRIGGER IF EXISTS update_tags_timestamp ON tags;
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,46 @@
+-- 000023_create_tags_and_saved_searches.up.sql
+-- Etiquetas livres que cruzam categorias ("viagem-2026", "reembolsável")
+CREATE TABLE IF NOT EXISTS tags (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- O nome não diferencia maiúsculas de minúsculas
+CREATE UNIQUE INDEX idx_tags_user_name ON tags(user_id, LOWER(name));
+
+CREATE TRIGGER update_tags_timestamp
+    BEFORE UPDATE ON tags
+    FOR EACH ROW
+    EXECUTE FUNCTION update_timestamp();
+
+CREATE TABLE IF NOT EXISTS transaction_tags (
+    transaction_id UUID NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (transaction_id, tag_id)
+);
+
+CREATE INDEX idx_transaction_tags_tag ON transaction_tags(tag_id);
+
+-- Pesquisas salvas: filter guarda os critérios em JSON (contas, categorias,
+-- etiquetas, período, texto e valores). Com budget, a pesquisa entra no
+-- relatório de orçamento como um limite mensal na moeda currency.
+CREATE TABLE IF NOT EXISTS saved_searches (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    filter JSONB NOT NULL DEFAULT '{}',
+    budget NUMERIC(19,4) CHECK (budget > 0),
+    currency CHAR(3) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_saved_searches_user_name ON saved_searches(user_id, LOWER(name));
+
+CREATE TRIGGER update_saved_searches_timestamp
+    BEFORE UPDATE ON saved_searches
+    FOR EACH ROW
+    EXECUTE FUNCTION update_timestamp();
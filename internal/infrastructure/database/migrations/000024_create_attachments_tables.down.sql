@@ -0,0 +1,3 @@
+-- 000024_create_attachments_tables.down.sql
+DROP TABLE IF EXISTS attachments;
+DROP TABLE IF EXISTS attachment_files;
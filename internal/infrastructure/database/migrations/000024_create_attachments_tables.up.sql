@@ -0,0 +1,34 @@
+-- 000024_create_attachments_tables.up.sql
+-- Conteúdo dos anexos, guardado uma única vez por usuário (sha256). Sem
+-- nenhum anexo apontando para ele, o arquivo é apagado do armazenamento pela
+-- limpeza periódica; se o usuário for removido, user_id fica nulo e os
+-- arquivos também são recolhidos.
+CREATE TABLE IF NOT EXISTS attachment_files (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    sha256 CHAR(64) NOT NULL,
+    size BIGINT NOT NULL CHECK (size > 0),
+    content_type VARCHAR(100) NOT NULL,
+    storage_key VARCHAR(255) NOT NULL,
+    thumbnail_key VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_attachment_files_user_sha256 ON attachment_files(user_id, sha256);
+
+-- Cada anexo pertence a um lançamento ou a uma conta e some junto com ele
+CREATE TABLE IF NOT EXISTS attachments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    file_id UUID NOT NULL REFERENCES attachment_files(id) ON DELETE RESTRICT,
+    transaction_id UUID REFERENCES transactions(id) ON DELETE CASCADE,
+    account_id UUID REFERENCES accounts(id) ON DELETE CASCADE,
+    filename VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((transaction_id IS NULL) <> (account_id IS NULL))
+);
+
+CREATE INDEX idx_attachments_user ON attachments(user_id);
+CREATE INDEX idx_attachments_file ON attachments(file_id);
+CREATE UNIQUE INDEX idx_attachments_transaction_file ON attachments(transaction_id, file_id) WHERE transaction_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_attachments_account_file ON attachments(account_id, file_id) WHERE account_id IS NOT NULL;
@@ -0,0 +1,4 @@
+-- 000025_create_categorization_rules.down.sql
+DROP TABLE IF EXISTS rule_suggestions;
+DROP TRIGGER IF EXISTS update_categorization_rules_timestamp ON categorization_rules;
+DROP TABLE IF EXISTS categorization_rules;
@@ -0,0 +1,37 @@
+-- 000025_create_categorization_rules.up.sql
+-- Regras de categorização: conditions é a lista de condições (todas precisam
+-- bater) e actions o que muda no lançamento. As regras ativas são testadas
+-- pela ordem de position e vale a primeira que bater.
+CREATE TABLE IF NOT EXISTS categorization_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    conditions JSONB NOT NULL DEFAULT '[]',
+    actions JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_categorization_rules_user_position ON categorization_rules(user_id, position);
+
+CREATE TRIGGER update_categorization_rules_timestamp
+    BEFORE UPDATE ON categorization_rules
+    FOR EACH ROW
+    EXECUTE FUNCTION update_timestamp();
+
+-- Recategorizações manuais agrupadas pela palavra-chave da descrição, que
+-- viram sugestões de regra a partir de algumas ocorrências
+CREATE TABLE IF NOT EXISTS rule_suggestions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    keyword VARCHAR(50) NOT NULL,
+    category_id UUID NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    occurrences INTEGER NOT NULL DEFAULT 1,
+    dismissed BOOLEAN NOT NULL DEFAULT FALSE,
+    last_seen_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_rule_suggestions_user_keyword_category ON rule_suggestions(user_id, keyword, category_id);
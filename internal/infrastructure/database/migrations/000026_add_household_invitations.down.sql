@@ -0,0 +1,8 @@
+-- 000026_add_household_invitations.down.sql
+DELETE FROM household_members WHERE status = 'PENDING';
+
+DROP INDEX IF EXISTS idx_household_members_user_pending;
+
+ALTER TABLE household_members
+    DROP COLUMN IF EXISTS invited_by,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,8 @@
+-- 000026_add_household_invitations.up.sql
+-- Quem é adicionado a uma casa entra como convite pendente e só vira membro
+-- ao aceitar. Os membros existentes continuam ativos.
+ALTER TABLE household_members
+    ADD COLUMN IF NOT EXISTS status VARCHAR(10) NOT NULL DEFAULT 'ACTIVE' CHECK (status IN ('PENDING', 'ACTIVE')),
+    ADD COLUMN IF NOT EXISTS invited_by UUID REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_household_members_user_pending ON household_members(user_id) WHERE status = 'PENDING';
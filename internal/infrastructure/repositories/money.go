@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/repositories"
+	"finanvilla/pkg/money"
+)
+
+// Colunas NUMERIC chegam sem moeda; as funções abaixo aplicam a moeda da
+// própria linha logo após a leitura.
+
+func applyAccountCurrency(account *entities.Account) error {
+	var err error
+	if account.OpeningBalance, err = account.OpeningBalance.WithCurrency(account.Currency); err != nil {
+		return err
+	}
+	if account.CurrentBalance, err = account.CurrentBalance.WithCurrency(account.Currency); err != nil {
+		return err
+	}
+	if account.CreditLimit != nil {
+		limit, err := account.CreditLimit.WithCurrency(account.Currency)
+		if err != nil {
+			return err
+		}
+		account.CreditLimit = &limit
+	}
+	return nil
+}
+
+func applyTransactionCurrency(transaction *entities.Transaction) error {
+	for i := range transaction.Postings {
+		amount, err := transaction.Postings[i].Amount.WithCurrency(transaction.Currency)
+		if err != nil {
+			return err
+		}
+		transaction.Postings[i].Amount = amount
+	}
+	return nil
+}
+
+func applyRegisterCurrency(entry *entities.RegisterEntry) error {
+	var err error
+	if entry.Amount, err = entry.Amount.WithCurrency(entry.Currency); err != nil {
+		return err
+	}
+	entry.RunningBalance, err = entry.RunningBalance.WithCurrency(entry.Currency)
+	return err
+}
+
+func applyBudgetLineCurrency(line *entities.BudgetLine, currency string) error {
+	for _, m := range []*money.Money{&line.Budgeted, &line.CarriedOver, &line.Actual, &line.Remaining} {
+		withCurrency, err := m.WithCurrency(currency)
+		if err != nil {
+			return err
+		}
+		*m = withCurrency
+	}
+	return nil
+}
+
+func applyBudgetTotalsCurrency(totals *entities.BudgetMonthTotals, currency string) error {
+	for _, m := range []*money.Money{&totals.Income, &totals.Assigned, &totals.ToBeAssigned} {
+		withCurrency, err := m.WithCurrency(currency)
+		if err != nil {
+			return err
+		}
+		*m = withCurrency
+	}
+	return nil
+}
+
+func applyRecurringCurrency(recurring *entities.RecurringTransaction) error {
+	for i := range recurring.Postings {
+		amount, err := recurring.Postings[i].Amount.WithCurrency(recurring.Currency)
+		if err != nil {
+			return err
+		}
+		recurring.Postings[i].Amount = amount
+	}
+	return nil
+}
+
+func applyInstallmentPurchaseCurrency(purchase *entities.InstallmentPurchase) error {
+	var err error
+	if purchase.TotalAmount, err = purchase.TotalAmount.WithCurrency(purchase.Currency); err != nil {
+		return err
+	}
+	for i := range purchase.Transactions {
+		if err := applyTransactionCurrency(&purchase.Transactions[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyCardStatementCurrency(totals *repositories.CardStatementTotals, currency string) error {
+	for _, m := range []*money.Money{&totals.Charges, &totals.Credits} {
+		withCurrency, err := m.WithCurrency(currency)
+		if err != nil {
+			return err
+		}
+		*m = withCurrency
+	}
+	return nil
+}
+
+func applySharedExpenseCurrency(expense *entities.SharedExpense) error {
+	var err error
+	if expense.Amount, err = expense.Amount.WithCurrency(expense.Currency); err != nil {
+		return err
+	}
+	for i := range expense.Shares {
+		if expense.Shares[i].Amount, err = expense.Shares[i].Amount.WithCurrency(expense.Currency); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applySettlementCurrency(settlement *entities.Settlement) error {
+	var err error
+	settlement.Amount, err = settlement.Amount.WithCurrency(settlement.Currency)
+	return err
+}
+
+func applySavedSearchCurrency(search *entities.SavedSearch) error {
+	if search.Budget == nil {
+		return nil
+	}
+	budget, err := search.Budget.WithCurrency(search.Currency)
+	if err != nil {
+		return err
+	}
+	search.Budget = &budget
+	return nil
+}
@@ -0,0 +1,198 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/repositories"
+	appErrors "finanvilla/pkg/errors"
+	"finanvilla/pkg/money"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// accountBalanceSQL calcula o saldo atual dentro da própria consulta da conta,
+// evitando carregar lançamentos em memória.
+const accountBalanceSQL = `accounts.opening_balance +
+	COALESCE((SELECT SUM(p.amount) FROM postings p WHERE p.account_id = accounts.id), 0)`
+
+type postgresAccountRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresAccountRepository(db *gorm.DB) *postgresAccountRepository {
+	return &postgresAccountRepository{db: db}
+}
+
+func (r *postgresAccountRepository) withBalance(ctx context.Context) *gorm.DB {
+	return conn(ctx, r.db).
+		Model(&entities.Account{}).
+		Select("accounts.*, " + accountBalanceSQL + " AS current_balance")
+}
+
+func (r *postgresAccountRepository) Create(ctx context.Context, account *entities.Account) error {
+	return translateAccountError(conn(ctx, r.db).Create(account).Error)
+}
+
+func (r *postgresAccountRepository) Update(ctx context.Context, account *entities.Account) error {
+	result := conn(ctx, r.db).Model(&entities.Account{}).
+		Where("id = ? AND user_id = ?", account.ID, account.UserID).
+		Updates(map[string]interface{}{
+			"name":            account.Name,
+			"type":            account.Type,
+			"institution":     account.Institution,
+			"opening_balance": account.OpeningBalance,
+			"archived":        account.Archived,
+			"display_order":   account.DisplayOrder,
+			"closing_day":     account.ClosingDay,
+			"due_day":         account.DueDay,
+			"credit_limit":    account.CreditLimit,
+		})
+	if result.Error != nil {
+		return translateAccountError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrAccountNotFound
+	}
+	return nil
+}
+
+func (r *postgresAccountRepository) Delete(ctx context.Context, userID, id string) error {
+	result := conn(ctx, r.db).
+		Where("id = ? AND user_id = ?", id, userID).
+		Delete(&entities.Account{})
+	if result.Error != nil {
+		return translateAccountError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrAccountNotFound
+	}
+	return nil
+}
+
+func (r *postgresAccountRepository) GetByID(ctx context.Context, userID, id string) (*entities.Account, error) {
+	var account entities.Account
+	err := r.withBalance(ctx).
+		Where("accounts.id = ? AND accounts.user_id = ?", id, userID).
+		Take(&account).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, appErrors.ErrAccountNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := applyAccountCurrency(&account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (r *postgresAccountRepository) List(ctx context.Context, userID string, filter repositories.AccountFilter) ([]entities.Account, error) {
+	var accounts []entities.Account
+
+	query := r.withBalance(ctx).Where("accounts.user_id = ?", userID)
+	if !filter.IncludeArchived {
+		query = query.Where("accounts.archived = FALSE")
+	}
+	if filter.Type != "" {
+		query = query.Where("accounts.type = ?", filter.Type)
+	}
+
+	err := query.
+		Order("accounts.archived, accounts.display_order, accounts.name").
+		Find(&accounts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range accounts {
+		if err := applyAccountCurrency(&accounts[i]); err != nil {
+			return nil, err
+		}
+	}
+	return accounts, nil
+}
+
+const balanceAsOfSQL = `
+SELECT a.currency, a.opening_balance + COALESCE((
+	SELECT SUM(p.amount)
+	FROM postings p
+	JOIN transactions t ON t.id = p.transaction_id
+	WHERE p.account_id = a.id AND t.date <= CAST(@date AS DATE)
+), 0) AS balance
+FROM accounts a
+WHERE a.id = @account AND a.user_id = @user`
+
+func (r *postgresAccountRepository) BalanceAsOf(ctx context.Context, userID, accountID string, date time.Time) (money.Money, error) {
+	var rows []struct {
+		Currency string
+		Balance  money.Money
+	}
+
+	err := conn(ctx, r.db).Raw(balanceAsOfSQL, map[string]interface{}{
+		"user":    userID,
+		"account": accountID,
+		"date":    date,
+	}).Scan(&rows).Error
+	if err != nil {
+		return money.Money{}, err
+	}
+	if len(rows) == 0 {
+		return money.Money{}, appErrors.ErrAccountNotFound
+	}
+	return rows[0].Balance.WithCurrency(rows[0].Currency)
+}
+
+const freezeStatementDatesSQL = `
+UPDATE postings p
+SET statement_date = card_statement_date(t.date, @closing_day)
+FROM transactions t
+WHERE t.id = p.transaction_id AND p.account_id = @account AND p.statement_date IS NULL`
+
+func (r *postgresAccountRepository) FreezeStatementDates(ctx context.Context, accountID string, closingDay int) error {
+	return conn(ctx, r.db).Exec(freezeStatementDatesSQL, map[string]interface{}{
+		"account":     accountID,
+		"closing_day": closingDay,
+	}).Error
+}
+
+func (r *postgresAccountRepository) NextDisplayOrder(ctx context.Context, userID string) (int, error) {
+	var next int
+	err := conn(ctx, r.db).Model(&entities.Account{}).
+		Where("user_id = ?", userID).
+		Select("COALESCE(MAX(display_order) + 1, 0)").
+		Scan(&next).Error
+	return next, err
+}
+
+func (r *postgresAccountRepository) UpdateDisplayOrder(ctx context.Context, userID string, accountIDs []string) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for i, id := range accountIDs {
+			result := tx.Model(&entities.Account{}).
+				Where("id = ? AND user_id = ?", id, userID).
+				Update("display_order", i)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return appErrors.ErrAccountNotFound
+			}
+		}
+		return nil
+	})
+}
+
+func translateAccountError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case uniqueViolation:
+			return appErrors.ErrAccountNameTaken
+		case foreignKeyViolation:
+			return appErrors.ErrAccountInUse
+		}
+	}
+	return err
+}
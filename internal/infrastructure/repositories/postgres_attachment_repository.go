@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/repositories"
+	appErrors "finanvilla/pkg/errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// attachmentColumns junta aos anexos os dados do arquivo
+const attachmentColumns = "attachments.*, f.content_type, f.size, f.sha256, f.storage_key, f.thumbnail_key, " +
+	"f.thumbnail_key IS NOT NULL AS has_thumbnail"
+
+type postgresAttachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresAttachmentRepository(db *gorm.DB) *postgresAttachmentRepository {
+	return &postgresAttachmentRepository{db: db}
+}
+
+func (r *postgresAttachmentRepository) FindFile(ctx context.Context, userID, sha256 string) (*entities.AttachmentFile, error) {
+	var file entities.AttachmentFile
+	err := conn(ctx, r.db).Where("user_id = ? AND sha256 = ?", userID, sha256).Take(&file).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+func (r *postgresAttachmentRepository) CreateFile(ctx context.Context, file *entities.AttachmentFile) error {
+	result := conn(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(file)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+	return conn(ctx, r.db).Where("user_id = ? AND sha256 = ?", file.UserID, file.SHA256).Take(file).Error
+}
+
+func (r *postgresAttachmentRepository) Create(ctx context.Context, attachment *entities.Attachment) error {
+	if err := conn(ctx, r.db).Create(attachment).Error; err != nil {
+		return translateAttachmentError(err)
+	}
+	return nil
+}
+
+func (r *postgresAttachmentRepository) GetByID(ctx context.Context, userID, id string) (*entities.Attachment, error) {
+	var attachment entities.Attachment
+	err := r.withFile(ctx).
+		Where("attachments.id = ? AND attachments.user_id = ?", id, userID).
+		Take(&attachment).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, appErrors.ErrAttachmentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (r *postgresAttachmentRepository) List(ctx context.Context, userID string, filter repositories.AttachmentFilter) ([]entities.Attachment, error) {
+	query := r.withFile(ctx).Where("attachments.user_id = ?", userID)
+	if filter.TransactionID != "" {
+		query = query.Where("attachments.transaction_id = ?", filter.TransactionID)
+	}
+	if filter.AccountID != "" {
+		query = query.Where("attachments.account_id = ?", filter.AccountID)
+	}
+
+	var attachments []entities.Attachment
+	err := query.Order("attachments.created_at, attachments.id").Find(&attachments).Error
+	return attachments, err
+}
+
+func (r *postgresAttachmentRepository) Delete(ctx context.Context, userID, id string) error {
+	result := conn(ctx, r.db).
+		Where("id = ? AND user_id = ?", id, userID).
+		Delete(&entities.Attachment{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrAttachmentNotFound
+	}
+	return nil
+}
+
+func (r *postgresAttachmentRepository) DeleteUnusedFiles(ctx context.Context, before time.Time, fileIDs ...string) ([]entities.AttachmentFile, error) {
+	query := conn(ctx, r.db).
+		Clauses(clause.Returning{}).
+		Where("created_at < ?", before).
+		Where("NOT EXISTS (SELECT 1 FROM attachments a WHERE a.file_id = attachment_files.id)")
+	if len(fileIDs) > 0 {
+		query = query.Where("id IN ?", fileIDs)
+	}
+
+	var files []entities.AttachmentFile
+	if err := query.Delete(&files).Error; err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+func (r *postgresAttachmentRepository) withFile(ctx context.Context) *gorm.DB {
+	return conn(ctx, r.db).
+		Model(&entities.Attachment{}).
+		Select(attachmentColumns).
+		Joins("JOIN attachment_files f ON f.id = attachments.file_id")
+}
+
+func translateAttachmentError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case uniqueViolation:
+			return appErrors.ErrAttachmentExists
+		case foreignKeyViolation:
+			return appErrors.ErrAttachmentNotFound
+		}
+	}
+	return err
+}
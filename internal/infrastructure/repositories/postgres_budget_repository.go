@@ -0,0 +1,295 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"finanvilla/internal/domain/entities"
+	appErrors "finanvilla/pkg/errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type postgresBudgetRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresBudgetRepository(db *gorm.DB) *postgresBudgetRepository {
+	return &postgresBudgetRepository{db: db}
+}
+
+func (r *postgresBudgetRepository) Upsert(ctx context.Context, budget *entities.Budget) error {
+	return translateBudgetError(conn(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "category_id"}, {Name: "month"}},
+			DoUpdates: clause.AssignmentColumns([]string{"amount", "currency", "rollover", "updated_at"}),
+		}).
+		Create(budget).Error)
+}
+
+func (r *postgresBudgetRepository) Delete(ctx context.Context, userID, categoryID string, month time.Time) error {
+	result := conn(ctx, r.db).
+		Where("user_id = ? AND category_id = ? AND month = ?", userID, categoryID, month).
+		Delete(&entities.Budget{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrBudgetNotFound
+	}
+	return nil
+}
+
+func (r *postgresBudgetRepository) Get(ctx context.Context, userID, categoryID string, month time.Time) (*entities.Budget, error) {
+	var budget entities.Budget
+	err := conn(ctx, r.db).
+		Where("user_id = ? AND category_id = ? AND month = ?", userID, categoryID, month).
+		Take(&budget).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, appErrors.ErrBudgetNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if budget.Amount, err = budget.Amount.WithCurrency(budget.Currency); err != nil {
+		return nil, err
+	}
+	return &budget, nil
+}
+
+func (r *postgresBudgetRepository) List(ctx context.Context, userID string, month time.Time) ([]entities.Budget, error) {
+	var budgets []entities.Budget
+	err := conn(ctx, r.db).
+		Where("user_id = ? AND month = ?", userID, month).
+		Find(&budgets).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range budgets {
+		if budgets[i].Amount, err = budgets[i].Amount.WithCurrency(budgets[i].Currency); err != nil {
+			return nil, err
+		}
+	}
+	return budgets, nil
+}
+
+func (r *postgresBudgetRepository) LatestMonth(ctx context.Context, userID, currency string) (*time.Time, error) {
+	var latest sql.NullTime
+	err := conn(ctx, r.db).Model(&entities.Budget{}).
+		Select("MAX(month)").
+		Where("user_id = ? AND currency = ?", userID, currency).
+		Row().Scan(&latest)
+	if err != nil || !latest.Valid {
+		return nil, err
+	}
+	return &latest.Time, nil
+}
+
+const copyBudgetsSQL = `
+INSERT INTO budgets (user_id, category_id, month, amount, currency, rollover)
+SELECT b.user_id, b.category_id, CAST(@to AS DATE), b.amount, b.currency, b.rollover
+FROM budgets b
+JOIN categories c ON c.id = b.category_id
+WHERE b.user_id = @user AND b.month = @from AND c.archived = FALSE
+ON CONFLICT (category_id, month) DO `
+
+func (r *postgresBudgetRepository) CopyMonth(ctx context.Context, userID string, from, to time.Time, overwrite bool) (int64, error) {
+	query := copyBudgetsSQL + "NOTHING"
+	if overwrite {
+		query = copyBudgetsSQL + "UPDATE SET amount = EXCLUDED.amount, currency = EXCLUDED.currency, rollover = EXCLUDED.rollover"
+	}
+
+	result := conn(ctx, r.db).Exec(query, map[string]interface{}{
+		"user": userID,
+		"from": from,
+		"to":   to,
+	})
+	return result.RowsAffected, translateBudgetError(result.Error)
+}
+
+func (r *postgresBudgetRepository) MergeCategory(ctx context.Context, userID, sourceID, targetID string) error {
+	params := map[string]interface{}{"user": userID, "source": sourceID, "target": targetID}
+
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// Meses em que as duas categorias têm orçamento são somados no destino
+		err := tx.Exec(`
+			UPDATE budgets t SET amount = t.amount + s.amount, rollover = t.rollover OR s.rollover
+			FROM budgets s
+			WHERE s.user_id = @user AND s.category_id = @source
+				AND t.user_id = @user AND t.category_id = @target AND t.month = s.month`, params).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Exec(`
+			DELETE FROM budgets s USING budgets t
+			WHERE s.user_id = @user AND s.category_id = @source
+				AND t.category_id = @target AND t.month = s.month`, params).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Exec(`UPDATE budgets SET category_id = @target WHERE user_id = @user AND category_id = @source`, params).Error
+	})
+}
+
+// budgetReportSQL monta a grade categoria x mês desde o primeiro orçamento do
+// usuário. Cada gasto conta para a categoria orçada mais próxima (ela mesma ou
+// um ancestral) naquele mês; sem orçamento na cadeia, fica na própria
+// categoria. O saldo acumula com uma janela por categoria que recomeça depois
+// de cada mês sem rollover.
+const budgetReportSQL = `
+WITH RECURSIVE ancestors AS (
+	SELECT id AS category_id, id AS ancestor_id, parent_id, 0 AS depth
+	FROM categories
+	WHERE user_id = @user
+	UNION ALL
+	SELECT a.category_id, c.id, c.parent_id, a.depth + 1
+	FROM ancestors a
+	JOIN categories c ON c.id = a.parent_id
+), months AS (
+	SELECT CAST(generate_series(
+		LEAST(COALESCE(
+			(SELECT MIN(month) FROM budgets WHERE user_id = @user AND currency = @currency),
+			CAST(@month AS DATE)
+		), CAST(@month AS DATE)),
+		CAST(@month AS DATE),
+		INTERVAL '1 month'
+	) AS DATE) AS month
+), spending AS (
+	SELECT p.category_id, CAST(date_trunc('month', t.date) AS DATE) AS month, SUM(p.amount) AS amount
+	FROM postings p
+	JOIN transactions t ON t.id = p.transaction_id
+	JOIN categories c ON c.id = p.category_id
+	WHERE t.user_id = @user AND t.currency = @currency AND c.type = 'EXPENSE'
+		AND t.date >= (SELECT MIN(month) FROM months)
+		AND t.date < CAST(@month AS DATE) + INTERVAL '1 month'
+	GROUP BY 1, 2
+), attributed AS (
+	SELECT s.month, s.amount, COALESCE((
+		SELECT a.ancestor_id
+		FROM ancestors a
+		JOIN budgets b ON b.category_id = a.ancestor_id AND b.month = s.month AND b.currency = @currency
+		WHERE a.category_id = s.category_id
+		ORDER BY a.depth
+		LIMIT 1
+	), s.category_id) AS category_id
+	FROM spending s
+), actuals AS (
+	SELECT category_id, month, SUM(amount) AS amount
+	FROM attributed
+	GROUP BY category_id, month
+), grid AS (
+	SELECT c.id AS category_id, m.month,
+		COALESCE(b.amount, 0) AS budgeted,
+		COALESCE(act.amount, 0) AS actual,
+		b.id IS NOT NULL AS has_budget,
+		CAST(@envelope AS BOOLEAN) OR COALESCE(b.rollover, FALSE) AS rollover
+	FROM categories c
+	CROSS JOIN months m
+	LEFT JOIN budgets b ON b.category_id = c.id AND b.month = m.month AND b.currency = @currency
+	LEFT JOIN actuals act ON act.category_id = c.id AND act.month = m.month
+	WHERE c.user_id = @user AND c.type = 'EXPENSE'
+), periods AS (
+	SELECT g.*, COUNT(*) FILTER (WHERE NOT g.rollover) OVER (
+		PARTITION BY g.category_id ORDER BY g.month
+		ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING
+	) AS period
+	FROM grid g
+), running AS (
+	SELECT p.*, SUM(p.budgeted - p.actual) OVER (
+		PARTITION BY p.category_id, p.period ORDER BY p.month
+		ROWS UNBOUNDED PRECEDING
+	) AS remaining
+	FROM periods p
+)
+SELECT r.category_id, c.parent_id, c.name, c.archived, r.has_budget, r.rollover,
+	r.budgeted, r.remaining - r.budgeted + r.actual AS carried_over, r.actual, r.remaining
+FROM running r
+JOIN categories c ON c.id = r.category_id
+WHERE r.month = CAST(@month AS DATE) AND (r.has_budget OR r.actual <> 0 OR r.remaining <> 0)
+ORDER BY LOWER(c.name), c.id`
+
+func (r *postgresBudgetRepository) Report(ctx context.Context, userID, currency string, month time.Time, envelope bool) ([]entities.BudgetLine, error) {
+	var lines []entities.BudgetLine
+	err := conn(ctx, r.db).Raw(budgetReportSQL, map[string]interface{}{
+		"user":     userID,
+		"currency": currency,
+		"month":    month,
+		"envelope": envelope,
+	}).Scan(&lines).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range lines {
+		if err := applyBudgetLineCurrency(&lines[i], currency); err != nil {
+			return nil, err
+		}
+	}
+	return lines, nil
+}
+
+// budgetTotalsSQL considera receita as pernas em categorias INCOME (com sinal
+// invertido) e soma os saldos iniciais das contas ao valor a distribuir.
+const budgetTotalsSQL = `
+WITH months AS (
+	SELECT CAST(generate_series(CAST(@from AS DATE), CAST(@to AS DATE), INTERVAL '1 month') AS DATE) AS month
+), income AS (
+	SELECT CAST(date_trunc('month', t.date) AS DATE) AS month, -SUM(p.amount) AS amount
+	FROM postings p
+	JOIN transactions t ON t.id = p.transaction_id
+	JOIN categories c ON c.id = p.category_id
+	WHERE t.user_id = @user AND t.currency = @currency AND c.type = 'INCOME'
+		AND t.date < CAST(@to AS DATE) + INTERVAL '1 month'
+	GROUP BY 1
+), assigned AS (
+	SELECT month, SUM(amount) AS amount
+	FROM budgets
+	WHERE user_id = @user AND currency = @currency AND month <= CAST(@to AS DATE)
+	GROUP BY month
+), opening AS (
+	SELECT COALESCE(SUM(opening_balance), 0) AS amount
+	FROM accounts
+	WHERE user_id = @user AND currency = @currency
+)
+SELECT m.month,
+	COALESCE((SELECT i.amount FROM income i WHERE i.month = m.month), 0) AS income,
+	COALESCE((SELECT a.amount FROM assigned a WHERE a.month = m.month), 0) AS assigned,
+	(SELECT amount FROM opening)
+		+ COALESCE((SELECT SUM(i.amount) FROM income i WHERE i.month <= m.month), 0)
+		- COALESCE((SELECT SUM(a.amount) FROM assigned a WHERE a.month <= m.month), 0) AS to_be_assigned
+FROM months m
+ORDER BY m.month`
+
+func (r *postgresBudgetRepository) MonthTotals(ctx context.Context, userID, currency string, from, to time.Time) ([]entities.BudgetMonthTotals, error) {
+	var totals []entities.BudgetMonthTotals
+	err := conn(ctx, r.db).Raw(budgetTotalsSQL, map[string]interface{}{
+		"user":     userID,
+		"currency": currency,
+		"from":     from,
+		"to":       to,
+	}).Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range totals {
+		if err := applyBudgetTotalsCurrency(&totals[i], currency); err != nil {
+			return nil, err
+		}
+	}
+	return totals, nil
+}
+
+func translateBudgetError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+		return appErrors.ErrCategoryNotFound
+	}
+	return err
+}
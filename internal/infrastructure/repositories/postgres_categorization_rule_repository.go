@@ -0,0 +1,189 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"finanvilla/internal/domain/entities"
+	appErrors "finanvilla/pkg/errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type postgresCategorizationRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresCategorizationRuleRepository(db *gorm.DB) *postgresCategorizationRuleRepository {
+	return &postgresCategorizationRuleRepository{db: db}
+}
+
+func (r *postgresCategorizationRuleRepository) Create(ctx context.Context, rule *entities.CategorizationRule) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(rule).Error; err != nil {
+			return err
+		}
+		// O gorm omite o false na criação e o banco grava o default
+		if !rule.Active {
+			return tx.Model(rule).Update("active", false).Error
+		}
+		return nil
+	})
+}
+
+func (r *postgresCategorizationRuleRepository) Update(ctx context.Context, rule *entities.CategorizationRule) error {
+	result := conn(ctx, r.db).Model(&entities.CategorizationRule{}).
+		Where("id = ? AND user_id = ?", rule.ID, rule.UserID).
+		Updates(map[string]interface{}{
+			"name":       rule.Name,
+			"active":     rule.Active,
+			"conditions": rule.Conditions,
+			"actions":    rule.Actions,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrRuleNotFound
+	}
+	return nil
+}
+
+func (r *postgresCategorizationRuleRepository) Delete(ctx context.Context, userID, id string) error {
+	result := conn(ctx, r.db).
+		Where("id = ? AND user_id = ?", id, userID).
+		Delete(&entities.CategorizationRule{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrRuleNotFound
+	}
+	return nil
+}
+
+func (r *postgresCategorizationRuleRepository) GetByID(ctx context.Context, userID, id string) (*entities.CategorizationRule, error) {
+	var rule entities.CategorizationRule
+	err := conn(ctx, r.db).Where("id = ? AND user_id = ?", id, userID).Take(&rule).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, appErrors.ErrRuleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *postgresCategorizationRuleRepository) List(ctx context.Context, userID string, activeOnly bool) ([]entities.CategorizationRule, error) {
+	query := conn(ctx, r.db).Where("user_id = ?", userID)
+	if activeOnly {
+		query = query.Where("active")
+	}
+
+	var rules []entities.CategorizationRule
+	err := query.Order("position, created_at, id").Find(&rules).Error
+	return rules, err
+}
+
+func (r *postgresCategorizationRuleRepository) UpdatePositions(ctx context.Context, userID string, ruleIDs []string) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for i, id := range ruleIDs {
+			result := tx.Model(&entities.CategorizationRule{}).
+				Where("id = ? AND user_id = ?", id, userID).
+				Update("position", i)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return appErrors.ErrRuleNotFound
+			}
+		}
+		return nil
+	})
+}
+
+func (r *postgresCategorizationRuleRepository) NextPosition(ctx context.Context, userID string) (int, error) {
+	var next int
+	err := conn(ctx, r.db).Model(&entities.CategorizationRule{}).
+		Where("user_id = ?", userID).
+		Select("COALESCE(MAX(position) + 1, 0)").
+		Scan(&next).Error
+	return next, err
+}
+
+func (r *postgresCategorizationRuleRepository) RecordRecategorization(ctx context.Context, userID, keyword, categoryID string) error {
+	suggestion := entities.RuleSuggestion{UserID: userID, Keyword: keyword, CategoryID: categoryID, Occurrences: 1}
+	err := conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "keyword"}, {Name: "category_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"occurrences":  gorm.Expr("rule_suggestions.occurrences + 1"),
+			"last_seen_at": gorm.Expr("CURRENT_TIMESTAMP"),
+		}),
+	}).Omit("LastSeenAt").Create(&suggestion).Error
+	return translateRuleSuggestionError(err)
+}
+
+func (r *postgresCategorizationRuleRepository) Suggestions(ctx context.Context, userID string, minOccurrences int) ([]entities.RuleSuggestion, error) {
+	var suggestions []entities.RuleSuggestion
+	err := r.withCategory(ctx).
+		Where("rule_suggestions.user_id = ? AND NOT rule_suggestions.dismissed AND rule_suggestions.occurrences >= ?", userID, minOccurrences).
+		Order("rule_suggestions.occurrences DESC, rule_suggestions.last_seen_at DESC").
+		Find(&suggestions).Error
+	return suggestions, err
+}
+
+func (r *postgresCategorizationRuleRepository) GetSuggestion(ctx context.Context, userID, id string) (*entities.RuleSuggestion, error) {
+	var suggestion entities.RuleSuggestion
+	err := r.withCategory(ctx).
+		Where("rule_suggestions.id = ? AND rule_suggestions.user_id = ?", id, userID).
+		Take(&suggestion).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, appErrors.ErrRuleSuggestionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &suggestion, nil
+}
+
+func (r *postgresCategorizationRuleRepository) DismissSuggestion(ctx context.Context, userID, id string) error {
+	result := conn(ctx, r.db).Model(&entities.RuleSuggestion{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Update("dismissed", true)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrRuleSuggestionNotFound
+	}
+	return nil
+}
+
+func (r *postgresCategorizationRuleRepository) DeleteSuggestion(ctx context.Context, userID, id string) error {
+	result := conn(ctx, r.db).
+		Where("id = ? AND user_id = ?", id, userID).
+		Delete(&entities.RuleSuggestion{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrRuleSuggestionNotFound
+	}
+	return nil
+}
+
+func (r *postgresCategorizationRuleRepository) withCategory(ctx context.Context) *gorm.DB {
+	return conn(ctx, r.db).
+		Model(&entities.RuleSuggestion{}).
+		Select("rule_suggestions.*, c.name AS category_name").
+		Joins("JOIN categories c ON c.id = rule_suggestions.category_id")
+}
+
+func translateRuleSuggestionError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+		return appErrors.ErrCategoryNotFound
+	}
+	return err
+}
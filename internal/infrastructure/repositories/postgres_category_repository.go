@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/repositories"
+	appErrors "finanvilla/pkg/errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+type postgresCategoryRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresCategoryRepository(db *gorm.DB) *postgresCategoryRepository {
+	return &postgresCategoryRepository{db: db}
+}
+
+func (r *postgresCategoryRepository) Create(ctx context.Context, category *entities.Category) error {
+	return translateCategoryError(conn(ctx, r.db).Create(category).Error)
+}
+
+func (r *postgresCategoryRepository) CreateMany(ctx context.Context, categories []entities.Category) error {
+	if len(categories) == 0 {
+		return nil
+	}
+	return translateCategoryError(conn(ctx, r.db).Create(&categories).Error)
+}
+
+func (r *postgresCategoryRepository) Update(ctx context.Context, category *entities.Category) error {
+	result := conn(ctx, r.db).Model(&entities.Category{}).
+		Where("id = ? AND user_id = ?", category.ID, category.UserID).
+		Updates(map[string]interface{}{
+			"parent_id": category.ParentID,
+			"name":      category.Name,
+			"type":      category.Type,
+			"color":     category.Color,
+			"icon":      category.Icon,
+			"archived":  category.Archived,
+		})
+	if result.Error != nil {
+		return translateCategoryError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrCategoryNotFound
+	}
+	return nil
+}
+
+func (r *postgresCategoryRepository) Delete(ctx context.Context, userID, id string) error {
+	result := conn(ctx, r.db).
+		Where("id = ? AND user_id = ?", id, userID).
+		Delete(&entities.Category{})
+	if result.Error != nil {
+		return translateCategoryError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrCategoryNotFound
+	}
+	return nil
+}
+
+func (r *postgresCategoryRepository) GetByID(ctx context.Context, userID, id string) (*entities.Category, error) {
+	var category entities.Category
+	err := conn(ctx, r.db).
+		Where("id = ? AND user_id = ?", id, userID).
+		Take(&category).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, appErrors.ErrCategoryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (r *postgresCategoryRepository) List(ctx context.Context, userID string, filter repositories.CategoryFilter) ([]entities.Category, error) {
+	var categories []entities.Category
+
+	query := conn(ctx, r.db).Where("user_id = ?", userID)
+	if !filter.IncludeArchived {
+		query = query.Where("archived = FALSE")
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+
+	err := query.Order("type DESC, LOWER(name)").Find(&categories).Error
+	return categories, err
+}
+
+func (r *postgresCategoryRepository) Reparent(ctx context.Context, userID, fromParentID string, toParentID *string) error {
+	return translateCategoryError(conn(ctx, r.db).Model(&entities.Category{}).
+		Where("user_id = ? AND parent_id = ?", userID, fromParentID).
+		Update("parent_id", toParentID).Error)
+}
+
+func (r *postgresCategoryRepository) ReassignPostings(ctx context.Context, userID string, fromIDs []string, toID string, from, to *time.Time) (int64, error) {
+	transactions := conn(ctx, r.db).Model(&entities.Transaction{}).Select("id").Where("user_id = ?", userID)
+	if from != nil {
+		transactions = transactions.Where("date >= ?", *from)
+	}
+	if to != nil {
+		transactions = transactions.Where("date <= ?", *to)
+	}
+
+	result := conn(ctx, r.db).Model(&entities.Posting{}).
+		Where("category_id IN ? AND transaction_id IN (?)", fromIDs, transactions).
+		Update("category_id", toID)
+	return result.RowsAffected, result.Error
+}
+
+func (r *postgresCategoryRepository) CountPostings(ctx context.Context, categoryID string) (int64, error) {
+	var count int64
+	err := conn(ctx, r.db).Model(&entities.Posting{}).
+		Where("category_id = ?", categoryID).
+		Count(&count).Error
+	return count, err
+}
+
+func translateCategoryError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case uniqueViolation:
+			return appErrors.ErrCategoryNameTaken
+		case foreignKeyViolation:
+			return appErrors.ErrCategoryInUse
+		}
+	}
+	return err
+}
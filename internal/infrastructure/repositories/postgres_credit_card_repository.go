@@ -0,0 +1,183 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/repositories"
+	appErrors "finanvilla/pkg/errors"
+	"finanvilla/pkg/money"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// cardStatementDateSQL é a fatura de cada perna do cartão: a gravada ou a que
+// sai da data do lançamento
+const cardStatementDateSQL = `COALESCE(p.statement_date, card_statement_date(t.date, a.closing_day))`
+
+type postgresCreditCardRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresCreditCardRepository(db *gorm.DB) *postgresCreditCardRepository {
+	return &postgresCreditCardRepository{db: db}
+}
+
+func (r *postgresCreditCardRepository) CreatePurchase(ctx context.Context, purchase *entities.InstallmentPurchase) error {
+	return conn(ctx, r.db).Omit("Transactions").Create(purchase).Error
+}
+
+func (r *postgresCreditCardRepository) UpdatePurchase(ctx context.Context, purchase *entities.InstallmentPurchase) error {
+	result := conn(ctx, r.db).Model(&entities.InstallmentPurchase{}).
+		Where("id = ? AND user_id = ?", purchase.ID, purchase.UserID).
+		Updates(map[string]interface{}{
+			"description":     purchase.Description,
+			"payee":           purchase.Payee,
+			"notes":           purchase.Notes,
+			"purchase_date":   purchase.PurchaseDate,
+			"total_amount":    purchase.TotalAmount,
+			"installments":    purchase.Installments,
+			"first_statement": purchase.FirstStatement,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrInstallmentPurchaseNotFound
+	}
+	return nil
+}
+
+func (r *postgresCreditCardRepository) DeletePurchase(ctx context.Context, userID, id string) error {
+	result := conn(ctx, r.db).
+		Where("id = ? AND user_id = ?", id, userID).
+		Delete(&entities.InstallmentPurchase{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrInstallmentPurchaseNotFound
+	}
+	return nil
+}
+
+func (r *postgresCreditCardRepository) GetPurchase(ctx context.Context, userID, id string) (*entities.InstallmentPurchase, error) {
+	var purchase entities.InstallmentPurchase
+	err := r.withInstallments(ctx).
+		Where("id = ? AND user_id = ?", id, userID).
+		Take(&purchase).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, appErrors.ErrInstallmentPurchaseNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := applyInstallmentPurchaseCurrency(&purchase); err != nil {
+		return nil, err
+	}
+	return &purchase, nil
+}
+
+func (r *postgresCreditCardRepository) ListPurchases(ctx context.Context, userID, accountID string) ([]entities.InstallmentPurchase, error) {
+	var purchases []entities.InstallmentPurchase
+	query := r.withInstallments(ctx).Where("user_id = ?", userID)
+	if accountID != "" {
+		query = query.Where("account_id = ?", accountID)
+	}
+	if err := query.Order("purchase_date DESC, created_at DESC").Find(&purchases).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range purchases {
+		if err := applyInstallmentPurchaseCurrency(&purchases[i]); err != nil {
+			return nil, err
+		}
+	}
+	return purchases, nil
+}
+
+func (r *postgresCreditCardRepository) withInstallments(ctx context.Context) *gorm.DB {
+	return conn(ctx, r.db).
+		Preload("Transactions", func(db *gorm.DB) *gorm.DB { return db.Order("installment_number") }).
+		Preload("Transactions.Postings", func(db *gorm.DB) *gorm.DB { return db.Order("amount") })
+}
+
+const cardStatementTotalsSQL = `
+SELECT s.closing_date, MAX(s.currency) AS currency, COUNT(*) AS entries,
+	COALESCE(SUM(-s.amount) FILTER (WHERE s.amount < 0), 0) AS charges,
+	COALESCE(SUM(s.amount) FILTER (WHERE s.amount > 0), 0) AS credits
+FROM (
+	SELECT ` + cardStatementDateSQL + ` AS closing_date, a.currency, p.amount
+	FROM postings p
+	JOIN transactions t ON t.id = p.transaction_id
+	JOIN accounts a ON a.id = p.account_id
+	WHERE p.account_id = @account AND a.user_id = @user
+) s
+WHERE s.closing_date IS NOT NULL
+GROUP BY s.closing_date
+ORDER BY s.closing_date DESC`
+
+func (r *postgresCreditCardRepository) StatementTotals(ctx context.Context, userID, accountID string) ([]repositories.CardStatementTotals, error) {
+	var rows []struct {
+		ClosingDate time.Time
+		Currency    string
+		Entries     int
+		Charges     money.Money
+		Credits     money.Money
+	}
+
+	err := conn(ctx, r.db).Raw(cardStatementTotalsSQL, map[string]interface{}{
+		"user":    userID,
+		"account": accountID,
+	}).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	statements := make([]repositories.CardStatementTotals, len(rows))
+	for i, row := range rows {
+		statements[i] = repositories.CardStatementTotals{
+			ClosingDate: row.ClosingDate,
+			Entries:     row.Entries,
+			Charges:     row.Charges,
+			Credits:     row.Credits,
+		}
+		if err := applyCardStatementCurrency(&statements[i], row.Currency); err != nil {
+			return nil, err
+		}
+	}
+	return statements, nil
+}
+
+const cardStatementEntriesSQL = `
+SELECT t.id AS transaction_id, t.date, t.description, t.payee, t.status, t.currency, p.amount,
+	t.installment_number, ip.installments
+FROM postings p
+JOIN transactions t ON t.id = p.transaction_id
+JOIN accounts a ON a.id = p.account_id
+LEFT JOIN installment_purchases ip ON ip.id = t.installment_purchase_id
+WHERE p.account_id = @account AND a.user_id = @user
+	AND ` + cardStatementDateSQL + ` = CAST(@closing AS DATE)
+ORDER BY t.date, t.created_at, t.id`
+
+func (r *postgresCreditCardRepository) StatementEntries(ctx context.Context, userID, accountID string, closingDate time.Time) ([]entities.CardStatementEntry, error) {
+	var entries []entities.CardStatementEntry
+	err := conn(ctx, r.db).Raw(cardStatementEntriesSQL, map[string]interface{}{
+		"user":    userID,
+		"account": accountID,
+		"closing": closingDate,
+	}).Scan(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range entries {
+		amount, err := entries[i].Amount.WithCurrency(entries[i].Currency)
+		if err != nil {
+			return nil, err
+		}
+		entries[i].Amount = amount
+	}
+	return entries, nil
+}
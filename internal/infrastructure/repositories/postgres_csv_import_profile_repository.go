@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"finanvilla/internal/domain/entities"
+	appErrors "finanvilla/pkg/errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+type postgresCSVImportProfileRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresCSVImportProfileRepository(db *gorm.DB) *postgresCSVImportProfileRepository {
+	return &postgresCSVImportProfileRepository{db: db}
+}
+
+func (r *postgresCSVImportProfileRepository) Create(ctx context.Context, profile *entities.CSVImportProfile) error {
+	return translateCSVProfileError(conn(ctx, r.db).Create(profile).Error)
+}
+
+func (r *postgresCSVImportProfileRepository) Update(ctx context.Context, profile *entities.CSVImportProfile) error {
+	result := conn(ctx, r.db).Model(&entities.CSVImportProfile{}).
+		Where("id = ? AND user_id = ?", profile.ID, profile.UserID).
+		Updates(map[string]interface{}{
+			"name":               profile.Name,
+			"institution":        profile.Institution,
+			"delimiter":          profile.Delimiter,
+			"encoding":           profile.Encoding,
+			"has_header":         profile.HasHeader,
+			"skip_rows":          profile.SkipRows,
+			"date_column":        profile.DateColumn,
+			"description_column": profile.DescriptionColumn,
+			"amount_column":      profile.AmountColumn,
+			"debit_column":       profile.DebitColumn,
+			"credit_column":      profile.CreditColumn,
+			"payee_column":       profile.PayeeColumn,
+			"memo_column":        profile.MemoColumn,
+			"external_id_column": profile.ExternalIDColumn,
+			"date_format":        profile.DateFormat,
+			"number_format":      profile.NumberFormat,
+			"invert_sign":        profile.InvertSign,
+		})
+	if result.Error != nil {
+		return translateCSVProfileError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrCSVProfileNotFound
+	}
+	return nil
+}
+
+func (r *postgresCSVImportProfileRepository) Delete(ctx context.Context, userID, id string) error {
+	result := conn(ctx, r.db).
+		Where("id = ? AND user_id = ?", id, userID).
+		Delete(&entities.CSVImportProfile{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrCSVProfileNotFound
+	}
+	return nil
+}
+
+func (r *postgresCSVImportProfileRepository) GetByID(ctx context.Context, userID, id string) (*entities.CSVImportProfile, error) {
+	var profile entities.CSVImportProfile
+	err := conn(ctx, r.db).
+		Where("id = ? AND user_id = ?", id, userID).
+		Take(&profile).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, appErrors.ErrCSVProfileNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+func (r *postgresCSVImportProfileRepository) List(ctx context.Context, userID string) ([]entities.CSVImportProfile, error) {
+	var profiles []entities.CSVImportProfile
+	err := conn(ctx, r.db).
+		Where("user_id = ?", userID).
+		Order("institution, name").
+		Find(&profiles).Error
+	return profiles, err
+}
+
+func translateCSVProfileError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return appErrors.ErrCSVProfileNameTaken
+	}
+	return err
+}
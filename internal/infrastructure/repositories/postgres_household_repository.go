@@ -0,0 +1,174 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	appErrors "finanvilla/pkg/errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// memberOfSQL restringe as casas às que têm o usuário como membro ativo
+const memberOfSQL = "EXISTS (SELECT 1 FROM household_members m WHERE m.household_id = households.id AND m.user_id = ? AND m.status = 'ACTIVE')"
+
+// invitedToSQL restringe as casas às que têm convite pendente para o usuário
+const invitedToSQL = "EXISTS (SELECT 1 FROM household_members m WHERE m.household_id = households.id AND m.user_id = ? AND m.status = 'PENDING')"
+
+type postgresHouseholdRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresHouseholdRepository(db *gorm.DB) *postgresHouseholdRepository {
+	return &postgresHouseholdRepository{db: db}
+}
+
+func (r *postgresHouseholdRepository) Create(ctx context.Context, household *entities.Household) error {
+	return conn(ctx, r.db).Create(household).Error
+}
+
+func (r *postgresHouseholdRepository) Update(ctx context.Context, household *entities.Household) error {
+	result := conn(ctx, r.db).Model(&entities.Household{}).
+		Where("id = ?", household.ID).
+		Update("name", household.Name)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrHouseholdNotFound
+	}
+	return nil
+}
+
+func (r *postgresHouseholdRepository) Delete(ctx context.Context, id string) error {
+	result := conn(ctx, r.db).Where("id = ?", id).Delete(&entities.Household{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrHouseholdNotFound
+	}
+	return nil
+}
+
+func (r *postgresHouseholdRepository) GetByID(ctx context.Context, userID, id string) (*entities.Household, error) {
+	var household entities.Household
+	err := r.withMembers(ctx).
+		Where("households.id = ?", id).
+		Where(memberOfSQL, userID).
+		Take(&household).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, appErrors.ErrHouseholdNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &household, nil
+}
+
+func (r *postgresHouseholdRepository) List(ctx context.Context, userID string) ([]entities.Household, error) {
+	var households []entities.Household
+	err := r.withMembers(ctx).
+		Where(memberOfSQL, userID).
+		Order("households.name, households.created_at").
+		Find(&households).Error
+	return households, err
+}
+
+func (r *postgresHouseholdRepository) AddMember(ctx context.Context, member *entities.HouseholdMember) error {
+	return translateHouseholdError(conn(ctx, r.db).Create(member).Error)
+}
+
+func (r *postgresHouseholdRepository) UpdateMember(ctx context.Context, member *entities.HouseholdMember) error {
+	result := conn(ctx, r.db).Model(&entities.HouseholdMember{}).
+		Where("household_id = ? AND user_id = ? AND status = ?", member.HouseholdID, member.UserID, enums.ActiveMember).
+		Updates(map[string]interface{}{
+			"pix_key":  member.PixKey,
+			"pix_city": member.PixCity,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrHouseholdNotFound
+	}
+	return nil
+}
+
+func (r *postgresHouseholdRepository) RemoveMember(ctx context.Context, householdID, userID string) error {
+	result := conn(ctx, r.db).
+		Where("household_id = ? AND user_id = ? AND status = ?", householdID, userID, enums.ActiveMember).
+		Delete(&entities.HouseholdMember{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *postgresHouseholdRepository) ListInvitations(ctx context.Context, userID string) ([]entities.Household, error) {
+	var households []entities.Household
+	err := r.withMembers(ctx).
+		Where(invitedToSQL, userID).
+		Order("households.name, households.created_at").
+		Find(&households).Error
+	return households, err
+}
+
+func (r *postgresHouseholdRepository) AcceptInvitation(ctx context.Context, householdID, userID string) error {
+	result := conn(ctx, r.db).Model(&entities.HouseholdMember{}).
+		Where("household_id = ? AND user_id = ? AND status = ?", householdID, userID, enums.PendingMember).
+		Updates(map[string]interface{}{
+			"status":    enums.ActiveMember,
+			"joined_at": gorm.Expr("CURRENT_TIMESTAMP"),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrHouseholdNotFound
+	}
+	return nil
+}
+
+func (r *postgresHouseholdRepository) DeclineInvitation(ctx context.Context, householdID, userID string) error {
+	result := conn(ctx, r.db).
+		Where("household_id = ? AND user_id = ? AND status = ?", householdID, userID, enums.PendingMember).
+		Delete(&entities.HouseholdMember{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrHouseholdNotFound
+	}
+	return nil
+}
+
+// withMembers carrega só os membros ativos: convidados não aparecem nem
+// entram nas divisões até aceitar
+func (r *postgresHouseholdRepository) withMembers(ctx context.Context) *gorm.DB {
+	return conn(ctx, r.db).Preload("Members", func(db *gorm.DB) *gorm.DB {
+		return db.
+			Select("household_members.*, users.name, users.email").
+			Joins("JOIN users ON users.id = household_members.user_id").
+			Where("household_members.status = ?", enums.ActiveMember).
+			Order("household_members.joined_at, users.name")
+	})
+}
+
+func translateHouseholdError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case uniqueViolation:
+			return appErrors.ErrAlreadyMember
+		case foreignKeyViolation:
+			return appErrors.ErrUserNotFound
+		}
+	}
+	return err
+}
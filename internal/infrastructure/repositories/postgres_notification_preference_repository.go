@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"context"
+	"finanvilla/internal/domain/entities"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type postgresNotificationPreferenceRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresNotificationPreferenceRepository(db *gorm.DB) *postgresNotificationPreferenceRepository {
+	return &postgresNotificationPreferenceRepository{db: db}
+}
+
+func (r *postgresNotificationPreferenceRepository) ListByUser(ctx context.Context, userID string) ([]entities.NotificationPreference, error) {
+	var prefs []entities.NotificationPreference
+	err := conn(ctx, r.db).
+		Where("user_id = ?", userID).
+		Order("event, channel").
+		Find(&prefs).Error
+	return prefs, err
+}
+
+func (r *postgresNotificationPreferenceRepository) Upsert(ctx context.Context, prefs []entities.NotificationPreference) error {
+	if len(prefs) == 0 {
+		return nil
+	}
+	return conn(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "event"}, {Name: "channel"}},
+			DoUpdates: clause.AssignmentColumns([]string{"enabled", "updated_at"}),
+		}).
+		Create(&prefs).Error
+}
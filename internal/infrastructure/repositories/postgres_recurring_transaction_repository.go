@@ -0,0 +1,151 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"finanvilla/internal/domain/entities"
+	appErrors "finanvilla/pkg/errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+type postgresRecurringTransactionRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresRecurringTransactionRepository(db *gorm.DB) *postgresRecurringTransactionRepository {
+	return &postgresRecurringTransactionRepository{db: db}
+}
+
+func (r *postgresRecurringTransactionRepository) Create(ctx context.Context, recurring *entities.RecurringTransaction) error {
+	return translateRecurringError(conn(ctx, r.db).Create(recurring).Error)
+}
+
+func (r *postgresRecurringTransactionRepository) Update(ctx context.Context, recurring *entities.RecurringTransaction) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.RecurringTransaction{}).
+			Where("id = ? AND user_id = ?", recurring.ID, recurring.UserID).
+			Updates(map[string]interface{}{
+				"description": recurring.Description,
+				"payee":       recurring.Payee,
+				"notes":       recurring.Notes,
+				"currency":    recurring.Currency,
+				"rule":        recurring.Rule,
+				"start_date":  recurring.StartDate,
+				"next_date":   recurring.NextDate,
+				"active":      recurring.Active,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return appErrors.ErrRecurringNotFound
+		}
+
+		if err := tx.Where("recurring_id = ?", recurring.ID).Delete(&entities.RecurringPosting{}).Error; err != nil {
+			return err
+		}
+
+		for i := range recurring.Postings {
+			recurring.Postings[i].ID = ""
+			recurring.Postings[i].RecurringID = recurring.ID
+		}
+		return translateRecurringError(tx.Create(&recurring.Postings).Error)
+	})
+}
+
+func (r *postgresRecurringTransactionRepository) Delete(ctx context.Context, userID, id string) error {
+	result := conn(ctx, r.db).
+		Where("id = ? AND user_id = ?", id, userID).
+		Delete(&entities.RecurringTransaction{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrRecurringNotFound
+	}
+	return nil
+}
+
+func (r *postgresRecurringTransactionRepository) GetByID(ctx context.Context, userID, id string) (*entities.RecurringTransaction, error) {
+	var recurring entities.RecurringTransaction
+	err := conn(ctx, r.db).
+		Preload("Postings", func(db *gorm.DB) *gorm.DB { return db.Order("amount") }).
+		Where("id = ? AND user_id = ?", id, userID).
+		Take(&recurring).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, appErrors.ErrRecurringNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := applyRecurringCurrency(&recurring); err != nil {
+		return nil, err
+	}
+	return &recurring, nil
+}
+
+func (r *postgresRecurringTransactionRepository) List(ctx context.Context, userID string) ([]entities.RecurringTransaction, error) {
+	var recurring []entities.RecurringTransaction
+	err := conn(ctx, r.db).
+		Preload("Postings", func(db *gorm.DB) *gorm.DB { return db.Order("amount") }).
+		Where("user_id = ?", userID).
+		Order("active DESC, next_date NULLS LAST, LOWER(description)").
+		Find(&recurring).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range recurring {
+		if err := applyRecurringCurrency(&recurring[i]); err != nil {
+			return nil, err
+		}
+	}
+	return recurring, nil
+}
+
+func (r *postgresRecurringTransactionRepository) ListDue(ctx context.Context, asOf time.Time, limit int) ([]entities.RecurringTransaction, error) {
+	var recurring []entities.RecurringTransaction
+	err := conn(ctx, r.db).
+		Preload("Postings").
+		Where("active AND next_date <= ?", asOf).
+		Order("next_date, id").
+		Limit(limit).
+		Find(&recurring).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range recurring {
+		if err := applyRecurringCurrency(&recurring[i]); err != nil {
+			return nil, err
+		}
+	}
+	return recurring, nil
+}
+
+func (r *postgresRecurringTransactionRepository) SetNextDate(ctx context.Context, id string, next *time.Time) error {
+	return conn(ctx, r.db).Model(&entities.RecurringTransaction{}).
+		Where("id = ?", id).
+		Update("next_date", next).Error
+}
+
+func (r *postgresRecurringTransactionRepository) ReassignCategory(ctx context.Context, userID, fromID, toID string) error {
+	recurring := conn(ctx, r.db).Model(&entities.RecurringTransaction{}).Select("id").Where("user_id = ?", userID)
+	return conn(ctx, r.db).Model(&entities.RecurringPosting{}).
+		Where("category_id = ? AND recurring_id IN (?)", fromID, recurring).
+		Update("category_id", toID).Error
+}
+
+// translateRecurringError trata contas ou categorias removidas entre a
+// validação e a gravação
+func translateRecurringError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+		return fmt.Errorf("%w: account or category no longer exists", appErrors.ErrInvalidInput)
+	}
+	return err
+}
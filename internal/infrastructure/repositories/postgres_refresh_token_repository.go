@@ -21,7 +21,7 @@ func NewPostgresRefreshTokenRepository(db *gorm.DB) *PostgresRefreshTokenReposit
 }
 
 func (r *PostgresRefreshTokenRepository) Create(ctx context.Context, token *entities.RefreshToken) error {
-	result := r.db.WithContext(ctx).Create(token)
+	result := conn(ctx, r.db).Create(token)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -30,7 +30,7 @@ func (r *PostgresRefreshTokenRepository) Create(ctx context.Context, token *enti
 
 func (r *PostgresRefreshTokenRepository) GetByToken(ctx context.Context, token string) (*entities.RefreshToken, error) {
 	var refreshToken entities.RefreshToken
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Where("token = ? AND NOT revoked AND expires_at > ?", token, time.Now()).
 		First(&refreshToken)
 
@@ -43,7 +43,7 @@ func (r *PostgresRefreshTokenRepository) GetByToken(ctx context.Context, token s
 
 func (r *PostgresRefreshTokenRepository) RevokeByUserID(ctx context.Context, userID uuid.UUID) error {
 	now := time.Now()
-	result := r.db.WithContext(ctx).Model(&entities.RefreshToken{}).
+	result := conn(ctx, r.db).Model(&entities.RefreshToken{}).
 		Where("user_id = ? AND NOT revoked", userID).
 		Updates(map[string]interface{}{
 			"revoked":    true,
@@ -59,7 +59,7 @@ func (r *PostgresRefreshTokenRepository) RevokeByUserID(ctx context.Context, use
 }
 
 func (r *PostgresRefreshTokenRepository) DeleteExpired(ctx context.Context) error {
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Where("expires_at < ? OR (revoked = true AND revoked_at < ?)",
 			time.Now(),
 			time.Now().Add(-30*24*time.Hour)).
@@ -74,7 +74,7 @@ func (r *PostgresRefreshTokenRepository) DeleteExpired(ctx context.Context) erro
 
 func (r *PostgresRefreshTokenRepository) IsTokenRevoked(ctx context.Context, token string) (bool, error) {
 	var refreshToken entities.RefreshToken
-	result := r.db.WithContext(ctx).Where("token = ?", token).First(&refreshToken)
+	result := conn(ctx, r.db).Where("token = ?", token).First(&refreshToken)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return false, nil
@@ -85,7 +85,7 @@ func (r *PostgresRefreshTokenRepository) IsTokenRevoked(ctx context.Context, tok
 }
 
 func (r *PostgresRefreshTokenRepository) RevokeToken(ctx context.Context, token string) error {
-	result := r.db.WithContext(ctx).Model(&entities.RefreshToken{}).
+	result := conn(ctx, r.db).Model(&entities.RefreshToken{}).
 		Where("token = ?", token).
 		Update("revoked", true)
 
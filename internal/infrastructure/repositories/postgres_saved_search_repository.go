@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"finanvilla/internal/domain/entities"
+	appErrors "finanvilla/pkg/errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+type postgresSavedSearchRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresSavedSearchRepository(db *gorm.DB) *postgresSavedSearchRepository {
+	return &postgresSavedSearchRepository{db: db}
+}
+
+func (r *postgresSavedSearchRepository) Create(ctx context.Context, search *entities.SavedSearch) error {
+	return translateSavedSearchError(conn(ctx, r.db).Create(search).Error)
+}
+
+func (r *postgresSavedSearchRepository) Update(ctx context.Context, search *entities.SavedSearch) error {
+	result := conn(ctx, r.db).Model(&entities.SavedSearch{}).
+		Where("id = ? AND user_id = ?", search.ID, search.UserID).
+		Updates(map[string]interface{}{
+			"name":   search.Name,
+			"filter": search.Filter,
+			"budget": search.Budget,
+		})
+	if result.Error != nil {
+		return translateSavedSearchError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrSavedSearchNotFound
+	}
+	return nil
+}
+
+func (r *postgresSavedSearchRepository) Delete(ctx context.Context, userID, id string) error {
+	result := conn(ctx, r.db).Where("id = ? AND user_id = ?", id, userID).Delete(&entities.SavedSearch{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrSavedSearchNotFound
+	}
+	return nil
+}
+
+func (r *postgresSavedSearchRepository) GetByID(ctx context.Context, userID, id string) (*entities.SavedSearch, error) {
+	var search entities.SavedSearch
+	err := conn(ctx, r.db).Where("id = ? AND user_id = ?", id, userID).Take(&search).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, appErrors.ErrSavedSearchNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := applySavedSearchCurrency(&search); err != nil {
+		return nil, err
+	}
+	return &search, nil
+}
+
+func (r *postgresSavedSearchRepository) List(ctx context.Context, userID string) ([]entities.SavedSearch, error) {
+	return r.find(conn(ctx, r.db).Where("user_id = ?", userID))
+}
+
+func (r *postgresSavedSearchRepository) ListBudgeted(ctx context.Context, userID, currency string) ([]entities.SavedSearch, error) {
+	return r.find(conn(ctx, r.db).Where("user_id = ? AND currency = ? AND budget IS NOT NULL", userID, currency))
+}
+
+func (r *postgresSavedSearchRepository) find(query *gorm.DB) ([]entities.SavedSearch, error) {
+	var searches []entities.SavedSearch
+	if err := query.Order("LOWER(name)").Find(&searches).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range searches {
+		if err := applySavedSearchCurrency(&searches[i]); err != nil {
+			return nil, err
+		}
+	}
+	return searches, nil
+}
+
+func translateSavedSearchError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return appErrors.ErrSavedSearchNameTaken
+	}
+	return err
+}
@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"context"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/repositories"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type postgresSecurityEventRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresSecurityEventRepository(db *gorm.DB) *postgresSecurityEventRepository {
+	return &postgresSecurityEventRepository{db: db}
+}
+
+// Create grava o evento num savepoint quando há transação aberta no
+// contexto: uma falha aqui desfaz só o evento, e a transação de quem chamou
+// (troca de e-mail, permissões) continua utilizável
+func (r *postgresSecurityEventRepository) Create(ctx context.Context, event *entities.SecurityEvent) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(event).Error
+	})
+}
+
+func (r *postgresSecurityEventRepository) List(ctx context.Context, filter repositories.SecurityEventFilter, page, limit int) ([]entities.SecurityEvent, int, error) {
+	var events []entities.SecurityEvent
+	var total int64
+
+	query := conn(ctx, r.db).Model(&entities.SecurityEvent{})
+	if filter.UserID != "" {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.Email != "" {
+		query = query.Where("email = ?", filter.Email)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.Outcome != "" {
+		query = query.Where("outcome = ?", filter.Outcome)
+	}
+	if filter.IP != "" {
+		query = query.Where("ip = ?", filter.IP)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Order("created_at DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&events).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return events, int(total), nil
+}
+
+func (r *postgresSecurityEventRepository) DeleteOlderThan(ctx context.Context, before time.Time) error {
+	return conn(ctx, r.db).
+		Where("created_at < ?", before).
+		Delete(&entities.SecurityEvent{}).Error
+}
@@ -0,0 +1,214 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/repositories"
+	appErrors "finanvilla/pkg/errors"
+	"finanvilla/pkg/money"
+	"finanvilla/pkg/settleup"
+
+	"gorm.io/gorm"
+)
+
+type postgresSharedExpenseRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresSharedExpenseRepository(db *gorm.DB) *postgresSharedExpenseRepository {
+	return &postgresSharedExpenseRepository{db: db}
+}
+
+func (r *postgresSharedExpenseRepository) Create(ctx context.Context, expense *entities.SharedExpense) error {
+	return translateHouseholdError(conn(ctx, r.db).Create(expense).Error)
+}
+
+func (r *postgresSharedExpenseRepository) Update(ctx context.Context, expense *entities.SharedExpense) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.SharedExpense{}).
+			Where("id = ? AND household_id = ?", expense.ID, expense.HouseholdID).
+			Updates(map[string]interface{}{
+				"paid_by":     expense.PaidBy,
+				"description": expense.Description,
+				"notes":       expense.Notes,
+				"date":        expense.Date,
+				"amount":      expense.Amount,
+				"split_mode":  expense.SplitMode,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return appErrors.ErrSharedExpenseNotFound
+		}
+
+		if err := tx.Where("expense_id = ?", expense.ID).Delete(&entities.SharedExpenseShare{}).Error; err != nil {
+			return err
+		}
+
+		for i := range expense.Shares {
+			expense.Shares[i].ExpenseID = expense.ID
+		}
+		return translateHouseholdError(tx.Create(&expense.Shares).Error)
+	})
+}
+
+func (r *postgresSharedExpenseRepository) Delete(ctx context.Context, householdID, id string) error {
+	result := conn(ctx, r.db).
+		Where("id = ? AND household_id = ?", id, householdID).
+		Delete(&entities.SharedExpense{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrSharedExpenseNotFound
+	}
+	return nil
+}
+
+func (r *postgresSharedExpenseRepository) GetByID(ctx context.Context, householdID, id string) (*entities.SharedExpense, error) {
+	var expense entities.SharedExpense
+	err := conn(ctx, r.db).
+		Preload("Shares", func(db *gorm.DB) *gorm.DB { return db.Order("amount DESC, user_id") }).
+		Where("id = ? AND household_id = ?", id, householdID).
+		Take(&expense).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, appErrors.ErrSharedExpenseNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := applySharedExpenseCurrency(&expense); err != nil {
+		return nil, err
+	}
+	return &expense, nil
+}
+
+func (r *postgresSharedExpenseRepository) List(ctx context.Context, householdID string, filter repositories.SharedExpenseFilter) ([]entities.SharedExpense, error) {
+	var expenses []entities.SharedExpense
+	query := conn(ctx, r.db).Where("household_id = ?", householdID)
+	if filter.From != nil {
+		query = query.Where("date >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("date <= ?", *filter.To)
+	}
+	if filter.UserID != "" {
+		query = query.Where(
+			"(paid_by = ? OR EXISTS (SELECT 1 FROM shared_expense_shares s WHERE s.expense_id = shared_expenses.id AND s.user_id = ?))",
+			filter.UserID, filter.UserID,
+		)
+	}
+
+	err := query.
+		Preload("Shares", func(db *gorm.DB) *gorm.DB { return db.Order("amount DESC, user_id") }).
+		Order("date DESC, created_at DESC").
+		Find(&expenses).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range expenses {
+		if err := applySharedExpenseCurrency(&expenses[i]); err != nil {
+			return nil, err
+		}
+	}
+	return expenses, nil
+}
+
+func (r *postgresSharedExpenseRepository) CreateSettlement(ctx context.Context, settlement *entities.Settlement) error {
+	return translateHouseholdError(conn(ctx, r.db).Create(settlement).Error)
+}
+
+func (r *postgresSharedExpenseRepository) DeleteSettlement(ctx context.Context, householdID, id string) error {
+	result := conn(ctx, r.db).
+		Where("id = ? AND household_id = ?", id, householdID).
+		Delete(&entities.Settlement{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrSettlementNotFound
+	}
+	return nil
+}
+
+func (r *postgresSharedExpenseRepository) GetSettlement(ctx context.Context, householdID, id string) (*entities.Settlement, error) {
+	var settlement entities.Settlement
+	err := conn(ctx, r.db).
+		Where("id = ? AND household_id = ?", id, householdID).
+		Take(&settlement).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, appErrors.ErrSettlementNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := applySettlementCurrency(&settlement); err != nil {
+		return nil, err
+	}
+	return &settlement, nil
+}
+
+func (r *postgresSharedExpenseRepository) ListSettlements(ctx context.Context, householdID string) ([]entities.Settlement, error) {
+	var settlements []entities.Settlement
+	err := conn(ctx, r.db).
+		Where("household_id = ?", householdID).
+		Order("date DESC, created_at DESC").
+		Find(&settlements).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range settlements {
+		if err := applySettlementCurrency(&settlements[i]); err != nil {
+			return nil, err
+		}
+	}
+	return settlements, nil
+}
+
+// householdDebtsSQL: cada parte de uma despesa é devida a quem pagou, e cada
+// acerto cria a dívida inversa, de quem recebeu para quem pagou
+const householdDebtsSQL = `
+SELECT d.from_user, d.to_user, SUM(d.amount) AS amount, MAX(h.currency) AS currency
+FROM (
+	SELECT s.user_id AS from_user, e.paid_by AS to_user, s.amount
+	FROM shared_expense_shares s
+	JOIN shared_expenses e ON e.id = s.expense_id
+	WHERE e.household_id = @household AND s.user_id <> e.paid_by AND s.amount > 0
+	UNION ALL
+	SELECT to_user_id, from_user_id, amount
+	FROM settlements
+	WHERE household_id = @household
+) d
+JOIN households h ON h.id = @household
+GROUP BY d.from_user, d.to_user
+ORDER BY d.from_user, d.to_user`
+
+func (r *postgresSharedExpenseRepository) Debts(ctx context.Context, householdID string) ([]settleup.Debt, error) {
+	var rows []struct {
+		FromUser string
+		ToUser   string
+		Amount   money.Money
+		Currency string
+	}
+
+	err := conn(ctx, r.db).Raw(householdDebtsSQL, map[string]interface{}{
+		"household": householdID,
+	}).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	debts := make([]settleup.Debt, len(rows))
+	for i, row := range rows {
+		amount, err := row.Amount.WithCurrency(row.Currency)
+		if err != nil {
+			return nil, err
+		}
+		debts[i] = settleup.Debt{From: row.FromUser, To: row.ToUser, Amount: amount}
+	}
+	return debts, nil
+}
@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"finanvilla/internal/domain/entities"
+	appErrors "finanvilla/pkg/errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type postgresTagRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresTagRepository(db *gorm.DB) *postgresTagRepository {
+	return &postgresTagRepository{db: db}
+}
+
+func (r *postgresTagRepository) Resolve(ctx context.Context, userID string, names []string) ([]entities.Tag, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	tags := make([]entities.Tag, len(names))
+	lowered := make([]string, len(names))
+	for i, name := range names {
+		tags[i] = entities.Tag{UserID: userID, Name: name}
+		lowered[i] = strings.ToLower(name)
+	}
+	// Quem já existe fica com o nome como foi criado
+	if err := conn(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(&tags).Error; err != nil {
+		return nil, err
+	}
+
+	var resolved []entities.Tag
+	err := conn(ctx, r.db).
+		Where("user_id = ? AND LOWER(name) IN ?", userID, lowered).
+		Order("LOWER(name)").
+		Find(&resolved).Error
+	return resolved, err
+}
+
+func (r *postgresTagRepository) GetByID(ctx context.Context, userID, id string) (*entities.Tag, error) {
+	var tag entities.Tag
+	err := conn(ctx, r.db).Where("id = ? AND user_id = ?", id, userID).Take(&tag).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, appErrors.ErrTagNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (r *postgresTagRepository) List(ctx context.Context, userID string) ([]entities.Tag, error) {
+	var tags []entities.Tag
+	err := conn(ctx, r.db).
+		Select("tags.*, (SELECT COUNT(*) FROM transaction_tags tt WHERE tt.tag_id = tags.id) AS transactions").
+		Where("user_id = ?", userID).
+		Order("LOWER(name)").
+		Find(&tags).Error
+	return tags, err
+}
+
+func (r *postgresTagRepository) Rename(ctx context.Context, tag *entities.Tag) error {
+	result := conn(ctx, r.db).Model(&entities.Tag{}).
+		Where("id = ? AND user_id = ?", tag.ID, tag.UserID).
+		Update("name", tag.Name)
+	if result.Error != nil {
+		return translateTagError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrTagNotFound
+	}
+	return nil
+}
+
+// mergeSavedSearchTagsSQL troca sourceID por targetID no filtro das pesquisas
+// salvas, sem repetir targetID
+const mergeSavedSearchTagsSQL = `
+UPDATE saved_searches
+SET filter = jsonb_set(filter, '{tagIds}',
+	(filter->'tagIds') - CAST(@source AS TEXT)
+		|| CASE WHEN jsonb_exists(filter->'tagIds', @target) THEN '[]'::jsonb ELSE jsonb_build_array(CAST(@target AS TEXT)) END)
+WHERE user_id = @user AND jsonb_exists(filter->'tagIds', @source)`
+
+func (r *postgresTagRepository) Merge(ctx context.Context, userID, sourceID, targetID string) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`
+			INSERT INTO transaction_tags (transaction_id, tag_id)
+			SELECT transaction_id, ? FROM transaction_tags WHERE tag_id = ?
+			ON CONFLICT DO NOTHING`, targetID, sourceID).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Exec(mergeSavedSearchTagsSQL, map[string]interface{}{
+			"user":   userID,
+			"source": sourceID,
+			"target": targetID,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		result := tx.Where("id = ? AND user_id = ?", sourceID, userID).Delete(&entities.Tag{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return appErrors.ErrTagNotFound
+		}
+		return nil
+	})
+}
+
+// Delete tira a etiqueta dos lançamentos. Pesquisas salvas que a exigiam
+// deixam de encontrar lançamentos, em vez de passarem a encontrar todos.
+func (r *postgresTagRepository) Delete(ctx context.Context, userID, id string) error {
+	result := conn(ctx, r.db).Where("id = ? AND user_id = ?", id, userID).Delete(&entities.Tag{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrTagNotFound
+	}
+	return nil
+}
+
+func translateTagError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return appErrors.ErrTagNameTaken
+	}
+	return err
+}
@@ -0,0 +1,409 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/internal/domain/repositories"
+	appErrors "finanvilla/pkg/errors"
+	"finanvilla/pkg/money"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type postgresTransactionRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresTransactionRepository(db *gorm.DB) *postgresTransactionRepository {
+	return &postgresTransactionRepository{db: db}
+}
+
+func (r *postgresTransactionRepository) Create(ctx context.Context, transaction *entities.Transaction) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Tags").Create(transaction).Error; err != nil {
+			return translateTransactionError(err)
+		}
+		return replaceTags(tx, transaction.ID, transaction.Tags)
+	})
+}
+
+func (r *postgresTransactionRepository) CreateOccurrence(ctx context.Context, transaction *entities.Transaction) (bool, error) {
+	created := false
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Omit("Postings", "Tags").
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "recurring_id"}, {Name: "occurrence_date"}},
+				DoNothing: true,
+			}).
+			Create(transaction)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		for i := range transaction.Postings {
+			transaction.Postings[i].TransactionID = transaction.ID
+		}
+		created = true
+		return tx.Create(&transaction.Postings).Error
+	})
+	return created, err
+}
+
+func (r *postgresTransactionRepository) ExistingExternalIDs(ctx context.Context, accountID string, externalIDs []string) (map[string]bool, error) {
+	existing := make(map[string]bool)
+	if len(externalIDs) == 0 {
+		return existing, nil
+	}
+
+	var found []string
+	err := conn(ctx, r.db).Model(&entities.Posting{}).
+		Where("account_id = ? AND external_id IN ?", accountID, externalIDs).
+		Pluck("external_id", &found).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range found {
+		existing[id] = true
+	}
+	return existing, nil
+}
+
+func (r *postgresTransactionRepository) Update(ctx context.Context, transaction *entities.Transaction) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.Transaction{}).
+			Where("id = ? AND user_id = ?", transaction.ID, transaction.UserID).
+			Updates(map[string]interface{}{
+				"date":        transaction.Date,
+				"description": transaction.Description,
+				"payee":       transaction.Payee,
+				"notes":       transaction.Notes,
+				"status":      transaction.Status,
+				"currency":    transaction.Currency,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return appErrors.ErrTransactionNotFound
+		}
+
+		if err := tx.Where("transaction_id = ?", transaction.ID).Delete(&entities.Posting{}).Error; err != nil {
+			return err
+		}
+
+		for i := range transaction.Postings {
+			transaction.Postings[i].ID = ""
+			transaction.Postings[i].TransactionID = transaction.ID
+		}
+		if err := tx.Create(&transaction.Postings).Error; err != nil {
+			return translateTransactionError(err)
+		}
+		return replaceTags(tx, transaction.ID, transaction.Tags)
+	})
+}
+
+func (r *postgresTransactionRepository) UpdateStatus(ctx context.Context, userID, id string, status enums.TransactionStatus) error {
+	result := conn(ctx, r.db).Model(&entities.Transaction{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Update("status", status)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrTransactionNotFound
+	}
+	return nil
+}
+
+func (r *postgresTransactionRepository) Delete(ctx context.Context, userID, id string) error {
+	result := conn(ctx, r.db).
+		Where("id = ? AND user_id = ?", id, userID).
+		Delete(&entities.Transaction{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrTransactionNotFound
+	}
+	return nil
+}
+
+func (r *postgresTransactionRepository) GetByID(ctx context.Context, userID, id string) (*entities.Transaction, error) {
+	var transaction entities.Transaction
+	err := withPostingsAndTags(conn(ctx, r.db)).
+		Where("id = ? AND user_id = ?", id, userID).
+		Take(&transaction).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, appErrors.ErrTransactionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := applyTransactionCurrency(&transaction); err != nil {
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+func (r *postgresTransactionRepository) List(ctx context.Context, userID string, filter repositories.TransactionFilter, page, limit int) ([]entities.Transaction, int, error) {
+	var transactions []entities.Transaction
+	var total int64
+
+	query := filterTransactions(conn(ctx, r.db).Model(&entities.Transaction{}), userID, filter)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := withPostingsAndTags(query).
+		Order("transactions.date DESC, transactions.created_at DESC, transactions.id DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&transactions).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range transactions {
+		if err := applyTransactionCurrency(&transactions[i]); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return transactions, int(total), nil
+}
+
+// transactionSummarySQL soma as pernas de categoria dos lançamentos filtrados.
+// A perna de categoria tem o sinal oposto ao das contas: positiva numa
+// despesa, negativa numa receita.
+const transactionSummarySQL = `
+SELECT CAST(date_trunc('month', t.date) AS DATE) AS month, p.category_id,
+	COALESCE(SUM(-p.amount) FILTER (WHERE p.amount < 0), 0) AS inflow,
+	COALESCE(SUM(p.amount) FILTER (WHERE p.amount > 0), 0) AS outflow
+FROM postings p
+JOIN transactions t ON t.id = p.transaction_id
+WHERE p.account_id IS NULL AND t.id IN (?)
+	AND (CAST(? AS BOOLEAN) OR p.category_id IN (?))
+GROUP BY 1, 2
+ORDER BY 1, 2`
+
+func (r *postgresTransactionRepository) Summary(ctx context.Context, userID, currency string, filter repositories.TransactionFilter) ([]repositories.TransactionSummaryRow, int, error) {
+	matching := func() *gorm.DB {
+		return filterTransactions(conn(ctx, r.db).Model(&entities.Transaction{}), userID, filter).
+			Where("transactions.currency = ?", currency)
+	}
+
+	var count int64
+	if err := matching().Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// IN com lista vazia não é SQL válido; o uuid nulo nunca casa
+	categoryIDs := filter.CategoryIDs
+	if len(categoryIDs) == 0 {
+		categoryIDs = []string{"00000000-0000-0000-0000-000000000000"}
+	}
+	var rows []repositories.TransactionSummaryRow
+	err := conn(ctx, r.db).Raw(transactionSummarySQL,
+		matching().Select("transactions.id"),
+		len(filter.CategoryIDs) == 0,
+		categoryIDs,
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range rows {
+		for _, m := range []*money.Money{&rows[i].Inflow, &rows[i].Outflow} {
+			withCurrency, err := m.WithCurrency(currency)
+			if err != nil {
+				return nil, 0, err
+			}
+			*m = withCurrency
+		}
+	}
+	return rows, int(count), nil
+}
+
+func (r *postgresTransactionRepository) ListByAccount(ctx context.Context, userID, accountID string, filter repositories.TransactionFilter, offset, limit int) ([]entities.Transaction, error) {
+	var transactions []entities.Transaction
+	err := filterTransactions(conn(ctx, r.db).Model(&entities.Transaction{}), userID, filter).
+		Preload("Postings", func(db *gorm.DB) *gorm.DB { return db.Order("amount") }).
+		Where("EXISTS (SELECT 1 FROM postings p WHERE p.transaction_id = transactions.id AND p.account_id = ?)", accountID).
+		Order("transactions.date, transactions.created_at, transactions.id").
+		Offset(offset).
+		Limit(limit).
+		Find(&transactions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range transactions {
+		if err := applyTransactionCurrency(&transactions[i]); err != nil {
+			return nil, err
+		}
+	}
+	return transactions, nil
+}
+
+// filterTransactions aplica o filtro à consulta sobre transactions
+func filterTransactions(query *gorm.DB, userID string, filter repositories.TransactionFilter) *gorm.DB {
+	query = query.Where("transactions.user_id = ?", userID)
+
+	if len(filter.AccountIDs) > 0 {
+		query = query.Where("EXISTS (SELECT 1 FROM postings p WHERE p.transaction_id = transactions.id AND p.account_id IN ?)", filter.AccountIDs)
+	}
+	if len(filter.CategoryIDs) > 0 {
+		query = query.Where("EXISTS (SELECT 1 FROM postings p WHERE p.transaction_id = transactions.id AND p.category_id IN ?)", filter.CategoryIDs)
+	}
+	if len(filter.TagIDs) > 0 {
+		query = query.Where(
+			"(SELECT COUNT(DISTINCT tt.tag_id) FROM transaction_tags tt WHERE tt.transaction_id = transactions.id AND tt.tag_id IN ?) = ?",
+			filter.TagIDs, distinctCount(filter.TagIDs),
+		)
+	}
+	if filter.Uncategorized {
+		query = query.Where("EXISTS (SELECT 1 FROM postings p WHERE p.transaction_id = transactions.id AND p.account_id IS NULL AND p.category_id IS NULL)")
+	}
+	if filter.Status != "" {
+		query = query.Where("transactions.status = ?", filter.Status)
+	}
+	if filter.From != nil {
+		query = query.Where("transactions.date >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("transactions.date <= ?", *filter.To)
+	}
+	if filter.MinAmount != nil || filter.MaxAmount != nil {
+		const movedAmount = "(SELECT COALESCE(SUM(p.amount), 0) FROM postings p WHERE p.transaction_id = transactions.id AND p.amount > 0)"
+		if filter.MinAmount != nil {
+			query = query.Where(movedAmount+" >= ?", *filter.MinAmount)
+		}
+		if filter.MaxAmount != nil {
+			query = query.Where(movedAmount+" <= ?", *filter.MaxAmount)
+		}
+	}
+	if text := strings.TrimSpace(filter.Text); text != "" {
+		pattern := "%" + escapeLike(text) + "%"
+		query = query.Where(
+			"(transactions.description ILIKE ? OR transactions.payee ILIKE ? OR transactions.notes ILIKE ? OR "+
+				"EXISTS (SELECT 1 FROM postings p WHERE p.transaction_id = transactions.id AND p.memo ILIKE ?))",
+			pattern, pattern, pattern, pattern,
+		)
+	}
+	return query
+}
+
+func withPostingsAndTags(query *gorm.DB) *gorm.DB {
+	return query.
+		Preload("Postings", func(db *gorm.DB) *gorm.DB { return db.Order("amount") }).
+		Preload("Tags", func(db *gorm.DB) *gorm.DB { return db.Order("LOWER(tags.name)") })
+}
+
+// transactionTag é a ligação entre lançamento e etiqueta
+type transactionTag struct {
+	TransactionID string `gorm:"primaryKey;type:uuid"`
+	TagID         string `gorm:"primaryKey;type:uuid"`
+}
+
+func (transactionTag) TableName() string { return "transaction_tags" }
+
+// replaceTags troca as etiquetas do lançamento pelas de tags
+func replaceTags(tx *gorm.DB, transactionID string, tags []entities.Tag) error {
+	if err := tx.Where("transaction_id = ?", transactionID).Delete(&transactionTag{}).Error; err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+	links := make([]transactionTag, len(tags))
+	for i, tag := range tags {
+		links[i] = transactionTag{TransactionID: transactionID, TagID: tag.ID}
+	}
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&links).Error
+}
+
+func distinctCount(values []string) int {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return len(set)
+}
+
+// registerSQL soma as pernas de cada lançamento na conta e calcula o saldo
+// acumulado com uma função de janela sobre todo o histórico, antes de aplicar
+// o período e a paginação.
+const registerSQL = `
+WITH entries AS (
+	SELECT t.id AS transaction_id, t.date, t.description, t.payee, t.status, t.created_at,
+		SUM(p.amount) AS amount
+	FROM transactions t
+	JOIN postings p ON p.transaction_id = t.id
+	WHERE t.user_id = @user AND p.account_id = @account
+	GROUP BY t.id
+), running AS (
+	SELECT e.*, a.currency,
+		a.opening_balance + SUM(e.amount) OVER (
+			ORDER BY e.date, e.created_at, e.transaction_id
+			ROWS UNBOUNDED PRECEDING
+		) AS running_balance
+	FROM entries e
+	JOIN accounts a ON a.id = @account
+)
+SELECT transaction_id, date, description, payee, status, currency, amount, running_balance, COUNT(*) OVER () AS total
+FROM running
+WHERE (CAST(@from AS DATE) IS NULL OR date >= @from)
+	AND (CAST(@to AS DATE) IS NULL OR date <= @to)
+ORDER BY date DESC, created_at DESC, transaction_id DESC
+LIMIT @limit OFFSET @offset`
+
+func (r *postgresTransactionRepository) Register(ctx context.Context, userID, accountID string, from, to *time.Time, page, limit int) ([]entities.RegisterEntry, int, error) {
+	var rows []struct {
+		entities.RegisterEntry
+		Total int
+	}
+
+	err := conn(ctx, r.db).Raw(registerSQL, map[string]interface{}{
+		"user":    userID,
+		"account": accountID,
+		"from":    from,
+		"to":      to,
+		"limit":   limit,
+		"offset":  (page - 1) * limit,
+	}).Scan(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entries := make([]entities.RegisterEntry, len(rows))
+	total := 0
+	for i, row := range rows {
+		entries[i] = row.RegisterEntry
+		if err := applyRegisterCurrency(&entries[i]); err != nil {
+			return nil, 0, err
+		}
+		total = row.Total
+	}
+	return entries, total, nil
+}
+
+// translateTransactionError trata a única restrição de unicidade das pernas:
+// o identificador externo por conta
+func translateTransactionError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return appErrors.ErrAlreadyImported
+	}
+	return err
+}
+
+// escapeLike impede que % e _ digitados pelo usuário virem curingas
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
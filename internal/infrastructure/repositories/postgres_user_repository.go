@@ -4,10 +4,17 @@ import (
 	"context"
 	"errors"
 	"finanvilla/internal/domain/entities"
+	appErrors "finanvilla/pkg/errors"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
 )
 
+const (
+	uniqueViolation     = "23505"
+	foreignKeyViolation = "23503"
+)
+
 type postgresUserRepository struct {
 	db *gorm.DB
 }
@@ -17,31 +24,42 @@ func NewPostgresUserRepository(db *gorm.DB) *postgresUserRepository {
 }
 
 func (r *postgresUserRepository) Create(ctx context.Context, user *entities.User) error {
-	return r.db.WithContext(ctx).Create(user).Error
+	err := conn(ctx, r.db).Create(user).Error
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		if pgErr.ConstraintName == "idx_users_user_type_tax_id" {
+			return appErrors.ErrTaxIDAlreadyUsed
+		}
+		return appErrors.ErrEmailAlreadyUsed
+	}
+	return err
 }
 
 func (r *postgresUserRepository) Update(ctx context.Context, user *entities.User) error {
-	return r.db.WithContext(ctx).Save(user).Error
+	return conn(ctx, r.db).Save(user).Error
 }
 
 func (r *postgresUserRepository) Delete(ctx context.Context, id string) error {
-	return r.db.WithContext(ctx).Delete(&entities.User{}, id).Error
+	return conn(ctx, r.db).Delete(&entities.User{}, id).Error
 }
 
 func (r *postgresUserRepository) GetByID(ctx context.Context, id string) (*entities.User, error) {
 	var user entities.User
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Preload("Settings").
 		Preload("Permissions").
+		Preload("NotificationPreferences").
 		First(&user, "id = ?", id).Error
 	return &user, err
 }
 
 func (r *postgresUserRepository) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
 	var user entities.User
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Preload("Settings").
 		Preload("Permissions").
+		Preload("NotificationPreferences").
 		Where("email = ?", email).
 		First(&user).Error
 	if err != nil {
@@ -50,17 +68,51 @@ func (r *postgresUserRepository) GetByEmail(ctx context.Context, email string) (
 	return &user, nil
 }
 
+func (r *postgresUserRepository) UpdateEmail(ctx context.Context, id, email string) error {
+	err := conn(ctx, r.db).Model(&entities.User{}).
+		Where("id = ?", id).
+		Update("email", email).Error
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return appErrors.ErrEmailAlreadyUsed
+	}
+	return err
+}
+
+func (r *postgresUserRepository) UpdateAvatar(ctx context.Context, id, avatarKey string) error {
+	return conn(ctx, r.db).Model(&entities.User{}).
+		Where("id = ?", id).
+		Update("avatar_key", avatarKey).Error
+}
+
+func (r *postgresUserRepository) UpdateProfile(ctx context.Context, user *entities.User) error {
+	err := conn(ctx, r.db).Model(&entities.User{}).
+		Where("id = ?", user.ID).
+		Updates(map[string]interface{}{
+			"tax_id":     user.TaxID,
+			"phone":      user.Phone,
+			"birth_date": user.BirthDate,
+		}).Error
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return appErrors.ErrTaxIDAlreadyUsed
+	}
+	return err
+}
+
 func (r *postgresUserRepository) List(ctx context.Context, page, limit int) ([]entities.User, int, error) {
 	var users []entities.User
 	var total int64
 
 	offset := (page - 1) * limit
 
-	if err := r.db.WithContext(ctx).Model(&entities.User{}).Count(&total).Error; err != nil {
+	if err := conn(ctx, r.db).Model(&entities.User{}).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Preload("Settings").
 		Preload("Permissions").
 		Offset(offset).
@@ -75,7 +127,7 @@ func (r *postgresUserRepository) List(ctx context.Context, page, limit int) ([]e
 }
 
 func (r *postgresUserRepository) UpdateSettings(ctx context.Context, settings *entities.UserSettings) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Transaction(func(tx *gorm.DB) error {
 			var existingSettings entities.UserSettings
 			err := tx.Where("user_id = ?", settings.UserID).First(&existingSettings).Error
@@ -91,8 +143,17 @@ func (r *postgresUserRepository) UpdateSettings(ctx context.Context, settings *e
 		})
 }
 
+func (r *postgresUserRepository) UpdateSettingsFields(ctx context.Context, userID string, fields map[string]interface{}) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	return conn(ctx, r.db).Model(&entities.UserSettings{}).
+		Where("user_id = ?", userID).
+		Updates(fields).Error
+}
+
 func (r *postgresUserRepository) AddPermissions(ctx context.Context, userID string, permissions []string) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Transaction(func(tx *gorm.DB) error {
 			var user entities.User
 			if err := tx.First(&user, "id = ?", userID).Error; err != nil {
@@ -109,7 +170,7 @@ func (r *postgresUserRepository) AddPermissions(ctx context.Context, userID stri
 }
 
 func (r *postgresUserRepository) RemovePermissions(ctx context.Context, userID string, permissions []string) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Transaction(func(tx *gorm.DB) error {
 			var user entities.User
 			if err := tx.First(&user, "id = ?", userID).Error; err != nil {
@@ -0,0 +1,51 @@
+package repositories
+
+import (
+	"context"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type postgresUserTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresUserTokenRepository(db *gorm.DB) *postgresUserTokenRepository {
+	return &postgresUserTokenRepository{db: db}
+}
+
+func (r *postgresUserTokenRepository) Create(ctx context.Context, token *entities.UserToken) error {
+	return conn(ctx, r.db).Create(token).Error
+}
+
+func (r *postgresUserTokenRepository) GetActiveByHash(ctx context.Context, purpose enums.TokenPurpose, tokenHash string) (*entities.UserToken, error) {
+	var token entities.UserToken
+	err := conn(ctx, r.db).
+		Where("purpose = ? AND token_hash = ? AND used_at IS NULL AND expires_at > ?", purpose, tokenHash, time.Now()).
+		First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *postgresUserTokenRepository) MarkUsed(ctx context.Context, id string) error {
+	return conn(ctx, r.db).Model(&entities.UserToken{}).
+		Where("id = ?", id).
+		Update("used_at", time.Now()).Error
+}
+
+func (r *postgresUserTokenRepository) InvalidateByUser(ctx context.Context, userID string, purpose enums.TokenPurpose) error {
+	return conn(ctx, r.db).Model(&entities.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", time.Now()).Error
+}
+
+func (r *postgresUserTokenRepository) DeleteExpired(ctx context.Context) error {
+	return conn(ctx, r.db).
+		Where("expires_at < ?", time.Now().Add(-30*24*time.Hour)).
+		Delete(&entities.UserToken{}).Error
+}
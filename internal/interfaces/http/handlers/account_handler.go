@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"errors"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/internal/domain/services"
+	appErrors "finanvilla/pkg/errors"
+	"finanvilla/pkg/validator"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AccountHandler struct {
+	accountService *services.AccountService
+}
+
+func NewAccountHandler(accountService *services.AccountService) *AccountHandler {
+	return &AccountHandler{accountService: accountService}
+}
+
+func (h *AccountHandler) List(c *gin.Context) {
+	accountType := enums.AccountType(strings.ToUpper(c.Query("type")))
+	includeArchived := c.Query("includeArchived") == "true"
+
+	accounts, err := h.accountService.List(c.Request.Context(), c.GetString("userID"), accountType, includeArchived)
+	if err != nil {
+		respondAccountError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": accounts})
+}
+
+func (h *AccountHandler) Get(c *gin.Context) {
+	account, err := h.accountService.GetByID(c.Request.Context(), c.GetString("userID"), c.Param("id"))
+	if err != nil {
+		respondAccountError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, account)
+}
+
+func (h *AccountHandler) Create(c *gin.Context) {
+	var req dtos.CreateAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	account, err := h.accountService.Create(c.Request.Context(), c.GetString("userID"), &req)
+	if err != nil {
+		respondAccountError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, account)
+}
+
+func (h *AccountHandler) Update(c *gin.Context) {
+	var req dtos.UpdateAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	account, err := h.accountService.Update(c.Request.Context(), c.GetString("userID"), c.Param("id"), &req)
+	if err != nil {
+		respondAccountError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, account)
+}
+
+func (h *AccountHandler) Delete(c *gin.Context) {
+	if err := h.accountService.Delete(c.Request.Context(), c.GetString("userID"), c.Param("id")); err != nil {
+		respondAccountError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *AccountHandler) Reorder(c *gin.Context) {
+	var req dtos.ReorderAccountsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	accounts, err := h.accountService.Reorder(c.Request.Context(), c.GetString("userID"), req.AccountIDs)
+	if err != nil {
+		respondAccountError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": accounts})
+}
+
+func respondAccountError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, appErrors.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrAccountNotFound), errors.Is(err, appErrors.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrAccountNameTaken), errors.Is(err, appErrors.ErrAccountInUse):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"errors"
+	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/services"
+	appErrors "finanvilla/pkg/errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AttachmentHandler struct {
+	attachmentService *services.AttachmentService
+}
+
+func NewAttachmentHandler(attachmentService *services.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{attachmentService: attachmentService}
+}
+
+// UploadToTransaction recebe o arquivo no campo "file" de um multipart/form-data
+func (h *AttachmentHandler) UploadToTransaction(c *gin.Context) {
+	filename, data, ok := readAttachmentFile(c)
+	if !ok {
+		return
+	}
+
+	attachment, err := h.attachmentService.AttachToTransaction(c.Request.Context(), c.GetString("userID"), c.Param("id"), filename, data)
+	if err != nil {
+		respondAttachmentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, attachment)
+}
+
+// UploadToAccount recebe o arquivo no campo "file" de um multipart/form-data
+func (h *AttachmentHandler) UploadToAccount(c *gin.Context) {
+	filename, data, ok := readAttachmentFile(c)
+	if !ok {
+		return
+	}
+
+	attachment, err := h.attachmentService.AttachToAccount(c.Request.Context(), c.GetString("userID"), c.Param("id"), filename, data)
+	if err != nil {
+		respondAttachmentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, attachment)
+}
+
+func (h *AttachmentHandler) ListByTransaction(c *gin.Context) {
+	attachments, err := h.attachmentService.ListByTransaction(c.Request.Context(), c.GetString("userID"), c.Param("id"))
+	if err != nil {
+		respondAttachmentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": attachments})
+}
+
+func (h *AttachmentHandler) ListByAccount(c *gin.Context) {
+	attachments, err := h.attachmentService.ListByAccount(c.Request.Context(), c.GetString("userID"), c.Param("id"))
+	if err != nil {
+		respondAttachmentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": attachments})
+}
+
+func (h *AttachmentHandler) Get(c *gin.Context) {
+	attachment, err := h.attachmentService.GetByID(c.Request.Context(), c.GetString("userID"), c.Param("id"))
+	if err != nil {
+		respondAttachmentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, attachment)
+}
+
+// Download envia o arquivo original; com inline=true o navegador pode exibi-lo
+func (h *AttachmentHandler) Download(c *gin.Context) {
+	h.serve(c, false)
+}
+
+// Thumbnail envia a miniatura JPEG, só disponível para imagens
+func (h *AttachmentHandler) Thumbnail(c *gin.Context) {
+	h.serve(c, true)
+}
+
+func (h *AttachmentHandler) Delete(c *gin.Context) {
+	if err := h.attachmentService.Delete(c.Request.Context(), c.GetString("userID"), c.Param("id")); err != nil {
+		respondAttachmentError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *AttachmentHandler) serve(c *gin.Context, thumbnail bool) {
+	body, attachment, err := h.attachmentService.Open(c.Request.Context(), c.GetString("userID"), c.Param("id"), thumbnail)
+	if err != nil {
+		respondAttachmentError(c, err)
+		return
+	}
+	defer body.Close()
+
+	contentType, size := attachment.ContentType, strconv.FormatInt(attachment.Size, 10)
+	disposition := "attachment"
+	if thumbnail {
+		contentType, size, disposition = "image/jpeg", "", "inline"
+	} else if c.Query("inline") == "true" {
+		disposition = "inline"
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", attachmentDisposition(disposition, attachment))
+	if size != "" {
+		c.Header("Content-Length", size)
+	}
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Status(http.StatusOK)
+
+	// Com a resposta já iniciada, um erro só pode ser registrado
+	if _, err := io.Copy(c.Writer, body); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+func attachmentDisposition(disposition string, attachment *entities.Attachment) string {
+	if value := mime.FormatMediaType(disposition, map[string]string{"filename": attachment.Filename}); value != "" {
+		return value
+	}
+	return fmt.Sprintf(`%s; filename="attachment-%s"`, disposition, attachment.ID)
+}
+
+// readAttachmentFile lê o campo "file" respondendo ele mesmo aos erros
+func readAttachmentFile(c *gin.Context) (string, []byte, bool) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, services.MaxAttachmentSize+1<<20)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return "", nil, false
+	}
+	if fileHeader.Size > services.MaxAttachmentSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": appErrors.ErrFileTooLarge.Error()})
+		return "", nil, false
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return "", nil, false
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, services.MaxAttachmentSize+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return "", nil, false
+	}
+	return fileHeader.Filename, data, true
+}
+
+func respondAttachmentError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, appErrors.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrFileTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrUnsupportedMedia):
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "attachment must be a JPEG, PNG, GIF or WebP image or a PDF"})
+	case errors.Is(err, appErrors.ErrAttachmentNotFound), errors.Is(err, appErrors.ErrTransactionNotFound),
+		errors.Is(err, appErrors.ErrAccountNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrAttachmentExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"errors"
+	"finanvilla/internal/domain/services"
+	appErrors "finanvilla/pkg/errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AvatarHandler struct {
+	avatarService *services.AvatarService
+}
+
+func NewAvatarHandler(avatarService *services.AvatarService) *AvatarHandler {
+	return &AvatarHandler{avatarService: avatarService}
+}
+
+// Upload recebe a imagem no campo "avatar" de um multipart/form-data
+func (h *AvatarHandler) Upload(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, services.MaxAvatarSize+1<<20)
+
+	fileHeader, err := c.FormFile("avatar")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "avatar file is required"})
+		return
+	}
+	if fileHeader.Size > services.MaxAvatarSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": appErrors.ErrFileTooLarge.Error()})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, services.MaxAvatarSize+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.avatarService.Upload(c.Request.Context(), c.GetString("userID"), data)
+	if err != nil {
+		switch {
+		case errors.Is(err, appErrors.ErrFileTooLarge):
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+		case errors.Is(err, appErrors.ErrUnsupportedMedia):
+			c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "avatar must be a JPEG, PNG, GIF or WebP image"})
+		case errors.Is(err, appErrors.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *AvatarHandler) Delete(c *gin.Context) {
+	if err := h.avatarService.Delete(c.Request.Context(), c.GetString("userID")); err != nil {
+		if errors.Is(err, appErrors.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
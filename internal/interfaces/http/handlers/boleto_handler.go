@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"errors"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/services"
+	appErrors "finanvilla/pkg/errors"
+	"finanvilla/pkg/validator"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BoletoHandler struct {
+	boletoService *services.BoletoService
+}
+
+func NewBoletoHandler(boletoService *services.BoletoService) *BoletoHandler {
+	return &BoletoHandler{boletoService: boletoService}
+}
+
+// Parse valida a linha digitável ou o código de barras e devolve os dados do boleto
+func (h *BoletoHandler) Parse(c *gin.Context) {
+	var req dtos.BoletoParseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	parsed, err := h.boletoService.Parse(c.Request.Context(), c.GetString("userID"), req.Line)
+	if err != nil {
+		respondBoletoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, parsed)
+}
+
+// Schedule agenda o pagamento do boleto como lançamento pendente
+func (h *BoletoHandler) Schedule(c *gin.Context) {
+	var req dtos.BoletoScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	transaction, err := h.boletoService.Schedule(c.Request.Context(), c.GetString("userID"), &req)
+	if err != nil {
+		respondBoletoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, transaction)
+}
+
+func respondBoletoError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, appErrors.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrAccountNotFound), errors.Is(err, appErrors.ErrCategoryNotFound),
+		errors.Is(err, appErrors.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrBoletoScheduled):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
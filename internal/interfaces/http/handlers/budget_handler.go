@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"errors"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/services"
+	appErrors "finanvilla/pkg/errors"
+	"finanvilla/pkg/validator"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BudgetHandler struct {
+	budgetService *services.BudgetService
+}
+
+func NewBudgetHandler(budgetService *services.BudgetService) *BudgetHandler {
+	return &BudgetHandler{budgetService: budgetService}
+}
+
+// Report devolve orçado x realizado x saldo por categoria do mês (YYYY-MM)
+func (h *BudgetHandler) Report(c *gin.Context) {
+	month, err := parseMonthParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.budgetService.Report(c.Request.Context(), c.GetString("userID"), month)
+	if err != nil {
+		respondBudgetError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func (h *BudgetHandler) Set(c *gin.Context) {
+	month, err := parseMonthParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req dtos.SetBudgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	budget, err := h.budgetService.Set(c.Request.Context(), c.GetString("userID"), c.Param("categoryId"), month, &req)
+	if err != nil {
+		respondBudgetError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, budget)
+}
+
+func (h *BudgetHandler) Delete(c *gin.Context) {
+	month, err := parseMonthParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.budgetService.Delete(c.Request.Context(), c.GetString("userID"), c.Param("categoryId"), month); err != nil {
+		respondBudgetError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Copy repete no mês da rota os orçamentos de outro mês (por padrão, o anterior)
+func (h *BudgetHandler) Copy(c *gin.Context) {
+	month, err := parseMonthParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req dtos.CopyBudgetRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var from *time.Time
+	if req.From != "" {
+		t, _ := time.Parse("2006-01", req.From)
+		from = &t
+	}
+
+	copied, err := h.budgetService.CopyMonth(c.Request.Context(), c.GetString("userID"), from, month, req.Overwrite)
+	if err != nil {
+		respondBudgetError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"copied": copied})
+}
+
+func parseMonthParam(c *gin.Context) (time.Time, error) {
+	month, err := time.Parse("2006-01", c.Param("month"))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("month must be in YYYY-MM format")
+	}
+	return month, nil
+}
+
+func respondBudgetError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, appErrors.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrBudgetNotFound), errors.Is(err, appErrors.ErrCategoryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrBudgetOverAssigned):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"errors"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/services"
+	appErrors "finanvilla/pkg/errors"
+	"finanvilla/pkg/validator"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CategorizationRuleHandler struct {
+	ruleService *services.CategorizationRuleService
+}
+
+func NewCategorizationRuleHandler(ruleService *services.CategorizationRuleService) *CategorizationRuleHandler {
+	return &CategorizationRuleHandler{ruleService: ruleService}
+}
+
+// List traz as regras na ordem em que são testadas
+func (h *CategorizationRuleHandler) List(c *gin.Context) {
+	rules, err := h.ruleService.List(c.Request.Context(), c.GetString("userID"))
+	if err != nil {
+		respondRuleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": rules})
+}
+
+func (h *CategorizationRuleHandler) Get(c *gin.Context) {
+	rule, err := h.ruleService.GetByID(c.Request.Context(), c.GetString("userID"), c.Param("id"))
+	if err != nil {
+		respondRuleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+func (h *CategorizationRuleHandler) Create(c *gin.Context) {
+	var req dtos.CategorizationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, err := h.ruleService.Create(c.Request.Context(), c.GetString("userID"), &req)
+	if err != nil {
+		respondRuleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+func (h *CategorizationRuleHandler) Update(c *gin.Context) {
+	var req dtos.CategorizationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, err := h.ruleService.Update(c.Request.Context(), c.GetString("userID"), c.Param("id"), &req)
+	if err != nil {
+		respondRuleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+func (h *CategorizationRuleHandler) Delete(c *gin.Context) {
+	if err := h.ruleService.Delete(c.Request.Context(), c.GetString("userID"), c.Param("id")); err != nil {
+		respondRuleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *CategorizationRuleHandler) Reorder(c *gin.Context) {
+	var req dtos.ReorderRulesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rules, err := h.ruleService.Reorder(c.Request.Context(), c.GetString("userID"), req.RuleIDs)
+	if err != nil {
+		respondRuleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": rules})
+}
+
+// Preview recebe uma regra no mesmo formato do cadastro e mostra em quais
+// lançamentos do histórico ela bate, sem gravar nada
+func (h *CategorizationRuleHandler) Preview(c *gin.Context) {
+	var req dtos.CategorizationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	preview, err := h.ruleService.Preview(c.Request.Context(), c.GetString("userID"), &req)
+	if err != nil {
+		respondRuleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
+func (h *CategorizationRuleHandler) Apply(c *gin.Context) {
+	var req dtos.ApplyRulesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.ruleService.Apply(c.Request.Context(), c.GetString("userID"), &req)
+	if err != nil {
+		respondRuleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func (h *CategorizationRuleHandler) Suggestions(c *gin.Context) {
+	suggestions, err := h.ruleService.Suggestions(c.Request.Context(), c.GetString("userID"))
+	if err != nil {
+		respondRuleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": suggestions})
+}
+
+// AcceptSuggestion cria a regra sugerida e devolve a regra criada
+func (h *CategorizationRuleHandler) AcceptSuggestion(c *gin.Context) {
+	rule, err := h.ruleService.AcceptSuggestion(c.Request.Context(), c.GetString("userID"), c.Param("id"))
+	if err != nil {
+		respondRuleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+func (h *CategorizationRuleHandler) DismissSuggestion(c *gin.Context) {
+	if err := h.ruleService.DismissSuggestion(c.Request.Context(), c.GetString("userID"), c.Param("id")); err != nil {
+		respondRuleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func respondRuleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, appErrors.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrRuleNotFound),
+		errors.Is(err, appErrors.ErrRuleSuggestionNotFound),
+		errors.Is(err, appErrors.ErrCategoryNotFound),
+		errors.Is(err, appErrors.ErrAccountNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"errors"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/internal/domain/repositories"
+	"finanvilla/internal/domain/services"
+	appErrors "finanvilla/pkg/errors"
+	"finanvilla/pkg/validator"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CategoryHandler struct {
+	categoryService *services.CategoryService
+}
+
+func NewCategoryHandler(categoryService *services.CategoryService) *CategoryHandler {
+	return &CategoryHandler{categoryService: categoryService}
+}
+
+// List devolve a árvore de categorias; flat=true devolve a lista plana
+func (h *CategoryHandler) List(c *gin.Context) {
+	filter := repositories.CategoryFilter{
+		Type:            enums.CategoryType(strings.ToUpper(c.Query("type"))),
+		IncludeArchived: c.Query("includeArchived") == "true",
+	}
+
+	categories, err := h.categoryService.List(c.Request.Context(), c.GetString("userID"), filter, c.Query("flat") == "true")
+	if err != nil {
+		respondCategoryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": categories})
+}
+
+func (h *CategoryHandler) Get(c *gin.Context) {
+	category, err := h.categoryService.GetByID(c.Request.Context(), c.GetString("userID"), c.Param("id"))
+	if err != nil {
+		respondCategoryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, category)
+}
+
+func (h *CategoryHandler) Create(c *gin.Context) {
+	var req dtos.CreateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	category, err := h.categoryService.Create(c.Request.Context(), c.GetString("userID"), &req)
+	if err != nil {
+		respondCategoryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, category)
+}
+
+func (h *CategoryHandler) Update(c *gin.Context) {
+	var req dtos.UpdateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	category, err := h.categoryService.Update(c.Request.Context(), c.GetString("userID"), c.Param("id"), &req)
+	if err != nil {
+		respondCategoryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, category)
+}
+
+func (h *CategoryHandler) Delete(c *gin.Context) {
+	if err := h.categoryService.Delete(c.Request.Context(), c.GetString("userID"), c.Param("id")); err != nil {
+		respondCategoryError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *CategoryHandler) Merge(c *gin.Context) {
+	var req dtos.MergeCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	target, err := h.categoryService.Merge(c.Request.Context(), c.GetString("userID"), c.Param("id"), req.TargetID)
+	if err != nil {
+		respondCategoryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, target)
+}
+
+func (h *CategoryHandler) MoveTransactions(c *gin.Context) {
+	var req dtos.MoveCategoryTransactionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var from, to *time.Time
+	if req.From != "" {
+		t, _ := time.Parse("2006-01-02", req.From)
+		from = &t
+	}
+	if req.To != "" {
+		t, _ := time.Parse("2006-01-02", req.To)
+		to = &t
+	}
+
+	moved, err := h.categoryService.MoveTransactions(c.Request.Context(), c.GetString("userID"), c.Param("id"), req.TargetID, from, to)
+	if err != nil {
+		respondCategoryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"moved": moved})
+}
+
+func respondCategoryError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, appErrors.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrCategoryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrCategoryNameTaken), errors.Is(err, appErrors.ErrCategoryInUse):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"errors"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/services"
+	appErrors "finanvilla/pkg/errors"
+	"finanvilla/pkg/validator"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CreditCardHandler struct {
+	creditCardService *services.CreditCardService
+}
+
+func NewCreditCardHandler(creditCardService *services.CreditCardService) *CreditCardHandler {
+	return &CreditCardHandler{creditCardService: creditCardService}
+}
+
+// Statements devolve limite disponível e faturas do cartão
+func (h *CreditCardHandler) Statements(c *gin.Context) {
+	statements, err := h.creditCardService.Statements(c.Request.Context(), c.GetString("userID"), c.Param("id"))
+	if err != nil {
+		respondCreditCardError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statements)
+}
+
+// Statement devolve a fatura que fecha em :closingDate (YYYY-MM-DD)
+func (h *CreditCardHandler) Statement(c *gin.Context) {
+	closingDate, err := time.Parse("2006-01-02", c.Param("closingDate"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid closingDate: use YYYY-MM-DD"})
+		return
+	}
+
+	statement, err := h.creditCardService.Statement(c.Request.Context(), c.GetString("userID"), c.Param("id"), closingDate)
+	if err != nil {
+		respondCreditCardError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statement)
+}
+
+// PayStatement lança a transferência que paga a fatura
+func (h *CreditCardHandler) PayStatement(c *gin.Context) {
+	closingDate, err := time.Parse("2006-01-02", c.Param("closingDate"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid closingDate: use YYYY-MM-DD"})
+		return
+	}
+
+	var req dtos.PayCardStatementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	transaction, err := h.creditCardService.PayStatement(c.Request.Context(), c.GetString("userID"), c.Param("id"), closingDate, &req)
+	if err != nil {
+		respondCreditCardError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, transaction)
+}
+
+// ListPurchases lista as compras parceladas, opcionalmente de um cartão (accountId)
+func (h *CreditCardHandler) ListPurchases(c *gin.Context) {
+	purchases, err := h.creditCardService.ListPurchases(c.Request.Context(), c.GetString("userID"), c.Query("accountId"))
+	if err != nil {
+		respondCreditCardError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": purchases})
+}
+
+func (h *CreditCardHandler) GetPurchase(c *gin.Context) {
+	purchase, err := h.creditCardService.GetPurchase(c.Request.Context(), c.GetString("userID"), c.Param("id"))
+	if err != nil {
+		respondCreditCardError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, purchase)
+}
+
+func (h *CreditCardHandler) CreatePurchase(c *gin.Context) {
+	var req dtos.InstallmentPurchaseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	purchase, err := h.creditCardService.CreatePurchase(c.Request.Context(), c.GetString("userID"), &req)
+	if err != nil {
+		respondCreditCardError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, purchase)
+}
+
+func (h *CreditCardHandler) UpdatePurchase(c *gin.Context) {
+	var req dtos.InstallmentPurchaseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	purchase, err := h.creditCardService.UpdatePurchase(c.Request.Context(), c.GetString("userID"), c.Param("id"), &req)
+	if err != nil {
+		respondCreditCardError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, purchase)
+}
+
+func (h *CreditCardHandler) DeletePurchase(c *gin.Context) {
+	if err := h.creditCardService.DeletePurchase(c.Request.Context(), c.GetString("userID"), c.Param("id")); err != nil {
+		respondCreditCardError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func respondCreditCardError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, appErrors.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrInstallmentPurchaseNotFound), errors.Is(err, appErrors.ErrAccountNotFound),
+		errors.Is(err, appErrors.ErrCategoryNotFound), errors.Is(err, appErrors.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrTransactionLocked):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"errors"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/services"
+	appErrors "finanvilla/pkg/errors"
+	"finanvilla/pkg/validator"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CSVImportProfileHandler struct {
+	profileService *services.CSVImportProfileService
+}
+
+func NewCSVImportProfileHandler(profileService *services.CSVImportProfileService) *CSVImportProfileHandler {
+	return &CSVImportProfileHandler{profileService: profileService}
+}
+
+func (h *CSVImportProfileHandler) List(c *gin.Context) {
+	profiles, err := h.profileService.List(c.Request.Context(), c.GetString("userID"))
+	if err != nil {
+		respondCSVProfileError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": profiles})
+}
+
+func (h *CSVImportProfileHandler) Get(c *gin.Context) {
+	profile, err := h.profileService.GetByID(c.Request.Context(), c.GetString("userID"), c.Param("id"))
+	if err != nil {
+		respondCSVProfileError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+func (h *CSVImportProfileHandler) Create(c *gin.Context) {
+	var req dtos.CSVImportProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	profile, err := h.profileService.Create(c.Request.Context(), c.GetString("userID"), &req)
+	if err != nil {
+		respondCSVProfileError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, profile)
+}
+
+func (h *CSVImportProfileHandler) Update(c *gin.Context) {
+	var req dtos.CSVImportProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	profile, err := h.profileService.Update(c.Request.Context(), c.GetString("userID"), c.Param("id"), &req)
+	if err != nil {
+		respondCSVProfileError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+func (h *CSVImportProfileHandler) Delete(c *gin.Context) {
+	if err := h.profileService.Delete(c.Request.Context(), c.GetString("userID"), c.Param("id")); err != nil {
+		respondCSVProfileError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func respondCSVProfileError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, appErrors.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrCSVProfileNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrCSVProfileNameTaken):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"errors"
+	"finanvilla/internal/domain/services"
+	appErrors "finanvilla/pkg/errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DataExportHandler struct {
+	exportService *services.DataExportService
+}
+
+func NewDataExportHandler(exportService *services.DataExportService) *DataExportHandler {
+	return &DataExportHandler{exportService: exportService}
+}
+
+// Export baixa um ZIP com todos os dados do usuário, anexos incluídos
+func (h *DataExportHandler) Export(c *gin.Context) {
+	export, err := h.exportService.Export(c.Request.Context(), c.GetString("userID"))
+	if err != nil {
+		if errors.Is(err, appErrors.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	filename := fmt.Sprintf("finanvilla-export-%s.zip", time.Now().Format("2006-01-02"))
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Status(http.StatusOK)
+
+	// Com a resposta já iniciada, um erro só pode ser registrado
+	if err := export.Write(c.Request.Context(), c.Writer); err != nil {
+		_ = c.Error(err)
+	}
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"errors"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/services"
+	appErrors "finanvilla/pkg/errors"
+	"finanvilla/pkg/validator"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EmailChangeHandler struct {
+	emailChangeService *services.EmailChangeService
+}
+
+func NewEmailChangeHandler(emailChangeService *services.EmailChangeService) *EmailChangeHandler {
+	return &EmailChangeHandler{emailChangeService: emailChangeService}
+}
+
+func (h *EmailChangeHandler) RequestChange(c *gin.Context) {
+	var req dtos.ChangeEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := h.emailChangeService.RequestChange(c.Request.Context(), c.GetString("userID"), req.NewEmail, req.CurrentPassword)
+	if err != nil {
+		respondEmailChangeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "Confirmation sent to the new email address"})
+}
+
+func (h *EmailChangeHandler) Confirm(c *gin.Context) {
+	var req dtos.EmailTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.emailChangeService.ConfirmChange(c.Request.Context(), req.Token)
+	if err != nil {
+		respondEmailChangeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *EmailChangeHandler) Revert(c *gin.Context) {
+	var req dtos.EmailTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.emailChangeService.RevertChange(c.Request.Context(), req.Token)
+	if err != nil {
+		respondEmailChangeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+func respondEmailChangeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, appErrors.ErrInvalidPassword):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrEmailAlreadyUsed):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrSameEmail), errors.Is(err, appErrors.ErrInvalidToken):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
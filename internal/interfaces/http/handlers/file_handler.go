@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"errors"
+	"finanvilla/pkg/storage"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FileHandler serve os arquivos do armazenamento local através de URLs
+// assinadas. Com o backend S3 as URLs apontam direto para o bucket.
+type FileHandler struct {
+	storage *storage.LocalStorage
+}
+
+func NewFileHandler(storage *storage.LocalStorage) *FileHandler {
+	return &FileHandler{storage: storage}
+}
+
+func (h *FileHandler) Serve(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+
+	if err := h.storage.Verify(key, c.Query("expires"), c.Query("signature")); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	body, contentType, err := h.storage.Get(c.Request.Context(), key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "file not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer body.Close()
+
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Status(http.StatusOK)
+	c.Header("Content-Type", contentType)
+	_, _ = io.Copy(c.Writer, body)
+}
@@ -0,0 +1,329 @@
+package handlers
+
+import (
+	"errors"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/repositories"
+	"finanvilla/internal/domain/services"
+	appErrors "finanvilla/pkg/errors"
+	"finanvilla/pkg/validator"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HouseholdHandler struct {
+	householdService *services.HouseholdService
+}
+
+func NewHouseholdHandler(householdService *services.HouseholdService) *HouseholdHandler {
+	return &HouseholdHandler{householdService: householdService}
+}
+
+func (h *HouseholdHandler) List(c *gin.Context) {
+	households, err := h.householdService.List(c.Request.Context(), c.GetString("userID"))
+	if err != nil {
+		respondHouseholdError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": households})
+}
+
+func (h *HouseholdHandler) GetByID(c *gin.Context) {
+	household, err := h.householdService.GetByID(c.Request.Context(), c.GetString("userID"), c.Param("id"))
+	if err != nil {
+		respondHouseholdError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, household)
+}
+
+func (h *HouseholdHandler) Create(c *gin.Context) {
+	var req dtos.CreateHouseholdRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	household, err := h.householdService.Create(c.Request.Context(), c.GetString("userID"), &req)
+	if err != nil {
+		respondHouseholdError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, household)
+}
+
+func (h *HouseholdHandler) Update(c *gin.Context) {
+	var req dtos.UpdateHouseholdRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	household, err := h.householdService.Update(c.Request.Context(), c.GetString("userID"), c.Param("id"), &req)
+	if err != nil {
+		respondHouseholdError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, household)
+}
+
+func (h *HouseholdHandler) Delete(c *gin.Context) {
+	if err := h.householdService.Delete(c.Request.Context(), c.GetString("userID"), c.Param("id")); err != nil {
+		respondHouseholdError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// InviteMember convida um usuário pelo e-mail. A resposta é a mesma exista
+// ou não o cadastro; o convidado vê o convite em ListInvitations.
+func (h *HouseholdHandler) InviteMember(c *gin.Context) {
+	var req dtos.AddHouseholdMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.householdService.InviteMember(c.Request.Context(), c.GetString("userID"), c.Param("id"), &req); err != nil {
+		respondHouseholdError(c, err)
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+func (h *HouseholdHandler) ListInvitations(c *gin.Context) {
+	households, err := h.householdService.ListInvitations(c.Request.Context(), c.GetString("userID"))
+	if err != nil {
+		respondHouseholdError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": households})
+}
+
+func (h *HouseholdHandler) AcceptInvitation(c *gin.Context) {
+	household, err := h.householdService.AcceptInvitation(c.Request.Context(), c.GetString("userID"), c.Param("id"))
+	if err != nil {
+		respondHouseholdError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, household)
+}
+
+func (h *HouseholdHandler) DeclineInvitation(c *gin.Context) {
+	if err := h.householdService.DeclineInvitation(c.Request.Context(), c.GetString("userID"), c.Param("id")); err != nil {
+		respondHouseholdError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UpdateMembership grava a chave Pix do usuário na casa
+func (h *HouseholdHandler) UpdateMembership(c *gin.Context) {
+	var req dtos.UpdateHouseholdMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	household, err := h.householdService.UpdateMember(c.Request.Context(), c.GetString("userID"), c.Param("id"), &req)
+	if err != nil {
+		respondHouseholdError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, household)
+}
+
+func (h *HouseholdHandler) RemoveMember(c *gin.Context) {
+	err := h.householdService.RemoveMember(c.Request.Context(), c.GetString("userID"), c.Param("id"), c.Param("userId"))
+	if err != nil {
+		respondHouseholdError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListExpenses aceita from/to (YYYY-MM-DD) e userId, para as despesas de um membro
+func (h *HouseholdHandler) ListExpenses(c *gin.Context) {
+	filter := repositories.SharedExpenseFilter{UserID: c.Query("userId")}
+
+	var err error
+	if filter.From, err = parseDateQuery(c, "from"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if filter.To, err = parseDateQuery(c, "to"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	expenses, err := h.householdService.ListExpenses(c.Request.Context(), c.GetString("userID"), c.Param("id"), filter)
+	if err != nil {
+		respondHouseholdError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": expenses})
+}
+
+func (h *HouseholdHandler) GetExpense(c *gin.Context) {
+	expense, err := h.householdService.GetExpense(c.Request.Context(), c.GetString("userID"), c.Param("id"), c.Param("expenseId"))
+	if err != nil {
+		respondHouseholdError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, expense)
+}
+
+func (h *HouseholdHandler) CreateExpense(c *gin.Context) {
+	var req dtos.SharedExpenseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	expense, err := h.householdService.CreateExpense(c.Request.Context(), c.GetString("userID"), c.Param("id"), &req)
+	if err != nil {
+		respondHouseholdError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, expense)
+}
+
+func (h *HouseholdHandler) UpdateExpense(c *gin.Context) {
+	var req dtos.SharedExpenseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	expense, err := h.householdService.UpdateExpense(c.Request.Context(), c.GetString("userID"), c.Param("id"), c.Param("expenseId"), &req)
+	if err != nil {
+		respondHouseholdError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, expense)
+}
+
+func (h *HouseholdHandler) DeleteExpense(c *gin.Context) {
+	err := h.householdService.DeleteExpense(c.Request.Context(), c.GetString("userID"), c.Param("id"), c.Param("expenseId"))
+	if err != nil {
+		respondHouseholdError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Balances devolve saldos, dívidas par a par e as transferências sugeridas
+func (h *HouseholdHandler) Balances(c *gin.Context) {
+	balances, err := h.householdService.Balances(c.Request.Context(), c.GetString("userID"), c.Param("id"))
+	if err != nil {
+		respondHouseholdError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, balances)
+}
+
+func (h *HouseholdHandler) ListSettlements(c *gin.Context) {
+	settlements, err := h.householdService.ListSettlements(c.Request.Context(), c.GetString("userID"), c.Param("id"))
+	if err != nil {
+		respondHouseholdError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": settlements})
+}
+
+// Settle registra um acerto, com o Pix para o pagamento quando pedido
+func (h *HouseholdHandler) Settle(c *gin.Context) {
+	var req dtos.SettlementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	settlement, err := h.householdService.Settle(c.Request.Context(), c.GetString("userID"), c.Param("id"), &req)
+	if err != nil {
+		respondHouseholdError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, settlement)
+}
+
+func (h *HouseholdHandler) DeleteSettlement(c *gin.Context) {
+	err := h.householdService.DeleteSettlement(c.Request.Context(), c.GetString("userID"), c.Param("id"), c.Param("settlementId"))
+	if err != nil {
+		respondHouseholdError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func respondHouseholdError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, appErrors.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrHouseholdNotFound), errors.Is(err, appErrors.ErrSharedExpenseNotFound),
+		errors.Is(err, appErrors.ErrSettlementNotFound), errors.Is(err, appErrors.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrAlreadyMember), errors.Is(err, appErrors.ErrOutstandingBalance):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"errors"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/services"
+	appErrors "finanvilla/pkg/errors"
+	"finanvilla/pkg/validator"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationPreferenceHandler struct {
+	preferenceService *services.NotificationPreferenceService
+}
+
+func NewNotificationPreferenceHandler(preferenceService *services.NotificationPreferenceService) *NotificationPreferenceHandler {
+	return &NotificationPreferenceHandler{preferenceService: preferenceService}
+}
+
+func (h *NotificationPreferenceHandler) Get(c *gin.Context) {
+	prefs, err := h.preferenceService.GetPreferences(c.Request.Context(), c.GetString("userID"))
+	if err != nil {
+		if errors.Is(err, appErrors.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, prefs)
+}
+
+func (h *NotificationPreferenceHandler) Update(c *gin.Context) {
+	var req dtos.UpdateNotificationPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	prefs, err := h.preferenceService.UpdatePreferences(c.Request.Context(), c.GetString("userID"), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, appErrors.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, appErrors.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, prefs)
+}
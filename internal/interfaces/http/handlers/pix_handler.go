@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"errors"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/services"
+	appErrors "finanvilla/pkg/errors"
+	"finanvilla/pkg/validator"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PixHandler struct {
+	pixService *services.PixService
+}
+
+func NewPixHandler(pixService *services.PixService) *PixHandler {
+	return &PixHandler{pixService: pixService}
+}
+
+func (h *PixHandler) Charge(c *gin.Context) {
+	var req dtos.PixChargeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	charge, err := h.pixService.Charge(c.Request.Context(), c.GetString("userID"), &req)
+	if err != nil {
+		respondPixError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, charge)
+}
+
+// Parse lê um "Pix copia e cola" e devolve o lançamento sugerido
+func (h *PixHandler) Parse(c *gin.Context) {
+	var req dtos.PixParseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	parsed, err := h.pixService.Parse(c.Request.Context(), c.GetString("userID"), &req)
+	if err != nil {
+		respondPixError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, parsed)
+}
+
+func (h *PixHandler) QRCode(c *gin.Context) {
+	var req dtos.PixQRCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	image, err := h.pixService.QRCode(&req)
+	if err != nil {
+		respondPixError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "private, no-store")
+	c.Data(http.StatusOK, "image/png", image)
+}
+
+func respondPixError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, appErrors.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"errors"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/services"
+	appErrors "finanvilla/pkg/errors"
+	"finanvilla/pkg/validator"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RecurringTransactionHandler struct {
+	recurringService *services.RecurringTransactionService
+}
+
+func NewRecurringTransactionHandler(recurringService *services.RecurringTransactionService) *RecurringTransactionHandler {
+	return &RecurringTransactionHandler{recurringService: recurringService}
+}
+
+func (h *RecurringTransactionHandler) List(c *gin.Context) {
+	recurring, err := h.recurringService.List(c.Request.Context(), c.GetString("userID"))
+	if err != nil {
+		respondRecurringError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": recurring})
+}
+
+func (h *RecurringTransactionHandler) Get(c *gin.Context) {
+	recurring, err := h.recurringService.GetByID(c.Request.Context(), c.GetString("userID"), c.Param("id"))
+	if err != nil {
+		respondRecurringError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recurring)
+}
+
+func (h *RecurringTransactionHandler) Create(c *gin.Context) {
+	var req dtos.RecurringTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	recurring, err := h.recurringService.Create(c.Request.Context(), c.GetString("userID"), &req)
+	if err != nil {
+		respondRecurringError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, recurring)
+}
+
+func (h *RecurringTransactionHandler) Update(c *gin.Context) {
+	var req dtos.RecurringTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	recurring, err := h.recurringService.Update(c.Request.Context(), c.GetString("userID"), c.Param("id"), &req)
+	if err != nil {
+		respondRecurringError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recurring)
+}
+
+// SetActive pausa ({"active": false}) ou retoma o modelo
+func (h *RecurringTransactionHandler) SetActive(c *gin.Context) {
+	var req dtos.SetRecurringActiveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	recurring, err := h.recurringService.SetActive(c.Request.Context(), c.GetString("userID"), c.Param("id"), *req.Active)
+	if err != nil {
+		respondRecurringError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recurring)
+}
+
+func (h *RecurringTransactionHandler) Delete(c *gin.Context) {
+	if err := h.recurringService.Delete(c.Request.Context(), c.GetString("userID"), c.Param("id")); err != nil {
+		respondRecurringError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Upcoming lista as próximas ocorrências (count, padrão 12) ainda não lançadas
+func (h *RecurringTransactionHandler) Upcoming(c *gin.Context) {
+	count, _ := strconv.Atoi(c.DefaultQuery("count", "12"))
+
+	dates, err := h.recurringService.Upcoming(c.Request.Context(), c.GetString("userID"), c.Param("id"), count)
+	if err != nil {
+		respondRecurringError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": formatDates(dates)})
+}
+
+// Preview calcula as datas de uma regra antes de salvar o modelo
+func (h *RecurringTransactionHandler) Preview(c *gin.Context) {
+	var req dtos.RecurrencePreviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	dates, err := h.recurringService.Preview(&req)
+	if err != nil {
+		respondRecurringError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": formatDates(dates)})
+}
+
+func formatDates(dates []time.Time) []string {
+	out := make([]string, len(dates))
+	for i, d := range dates {
+		out[i] = d.Format("2006-01-02")
+	}
+	return out
+}
+
+func respondRecurringError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, appErrors.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrRecurringNotFound),
+		errors.Is(err, appErrors.ErrAccountNotFound),
+		errors.Is(err, appErrors.ErrCategoryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"errors"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/services"
+	appErrors "finanvilla/pkg/errors"
+	"finanvilla/pkg/validator"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SavedSearchHandler struct {
+	savedSearchService *services.SavedSearchService
+}
+
+func NewSavedSearchHandler(savedSearchService *services.SavedSearchService) *SavedSearchHandler {
+	return &SavedSearchHandler{savedSearchService: savedSearchService}
+}
+
+func (h *SavedSearchHandler) List(c *gin.Context) {
+	searches, err := h.savedSearchService.List(c.Request.Context(), c.GetString("userID"))
+	if err != nil {
+		respondSavedSearchError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": searches})
+}
+
+func (h *SavedSearchHandler) Get(c *gin.Context) {
+	search, err := h.savedSearchService.GetByID(c.Request.Context(), c.GetString("userID"), c.Param("id"))
+	if err != nil {
+		respondSavedSearchError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, search)
+}
+
+func (h *SavedSearchHandler) Create(c *gin.Context) {
+	var req dtos.SavedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	search, err := h.savedSearchService.Create(c.Request.Context(), c.GetString("userID"), &req)
+	if err != nil {
+		respondSavedSearchError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, search)
+}
+
+func (h *SavedSearchHandler) Update(c *gin.Context) {
+	var req dtos.SavedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	search, err := h.savedSearchService.Update(c.Request.Context(), c.GetString("userID"), c.Param("id"), &req)
+	if err != nil {
+		respondSavedSearchError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, search)
+}
+
+func (h *SavedSearchHandler) Delete(c *gin.Context) {
+	if err := h.savedSearchService.Delete(c.Request.Context(), c.GetString("userID"), c.Param("id")); err != nil {
+		respondSavedSearchError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Transactions lista, paginados, os lançamentos que passam na pesquisa
+func (h *SavedSearchHandler) Transactions(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	transactions, total, err := h.savedSearchService.Transactions(c.Request.Context(), c.GetString("userID"), c.Param("id"), page, limit)
+	if err != nil {
+		respondSavedSearchError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  transactions,
+		"total": total,
+		"page":  page,
+		"limit": limit,
+	})
+}
+
+// Report aceita from e to (YYYY-MM-DD) para restringir o período da pesquisa
+func (h *SavedSearchHandler) Report(c *gin.Context) {
+	from, err := parseDateQuery(c, "from")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	to, err := parseDateQuery(c, "to")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.savedSearchService.Report(c.Request.Context(), c.GetString("userID"), c.Param("id"), from, to)
+	if err != nil {
+		respondSavedSearchError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func respondSavedSearchError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, appErrors.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrSavedSearchNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrSavedSearchNameTaken):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
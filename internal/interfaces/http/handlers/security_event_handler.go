@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"finanvilla/internal/domain/enums"
+	"finanvilla/internal/domain/repositories"
+	"finanvilla/internal/domain/services"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SecurityEventHandler struct {
+	securityEventService *services.SecurityEventService
+}
+
+func NewSecurityEventHandler(securityEventService *services.SecurityEventService) *SecurityEventHandler {
+	return &SecurityEventHandler{securityEventService: securityEventService}
+}
+
+// ListMine retorna o histórico de segurança do usuário autenticado
+func (h *SecurityEventHandler) ListMine(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	events, total, err := h.securityEventService.ListForUser(c.Request.Context(), c.GetString("userID"), page, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  events,
+		"total": total,
+		"page":  page,
+		"limit": limit,
+	})
+}
+
+// Search permite a administradores consultar eventos de todos os usuários
+func (h *SecurityEventHandler) Search(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	filter := repositories.SecurityEventFilter{
+		UserID:  c.Query("userId"),
+		Email:   c.Query("email"),
+		Type:    enums.SecurityEventType(strings.ToUpper(c.Query("type"))),
+		Outcome: enums.SecurityEventOutcome(strings.ToUpper(c.Query("outcome"))),
+		IP:      c.Query("ip"),
+	}
+
+	var err error
+	if filter.From, err = parseTimeQuery(c, "from", false); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if filter.To, err = parseTimeQuery(c, "to", true); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	events, total, err := h.securityEventService.Search(c.Request.Context(), filter, page, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  events,
+		"total": total,
+		"page":  page,
+		"limit": limit,
+	})
+}
+
+// parseTimeQuery aceita RFC3339 ou YYYY-MM-DD. O limite final é exclusivo, então
+// uma data sem horário usada como "to" avança para o dia seguinte e cobre o dia inteiro.
+func parseTimeQuery(c *gin.Context, key string, endOfDay bool) (*time.Time, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: use RFC3339 or YYYY-MM-DD", key)
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"errors"
+	"finanvilla/internal/domain/services"
+	appErrors "finanvilla/pkg/errors"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+)
+
+var unsafeFilenameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+type StatementExportHandler struct {
+	exportService *services.StatementExportService
+}
+
+func NewStatementExportHandler(exportService *services.StatementExportService) *StatementExportHandler {
+	return &StatementExportHandler{exportService: exportService}
+}
+
+// ExportQIF baixa todo o histórico da conta em QIF. dateOrder (MDY ou DMY)
+// escolhe a ordem das datas; o padrão segue o formato do usuário. searchId
+// exporta só os lançamentos de uma pesquisa salva.
+func (h *StatementExportHandler) ExportQIF(c *gin.Context) {
+	export, err := h.exportService.ExportQIF(c.Request.Context(), c.GetString("userID"), c.Param("id"), c.Query("dateOrder"), c.Query("searchId"))
+	if err != nil {
+		switch {
+		case errors.Is(err, appErrors.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, appErrors.ErrAccountNotFound), errors.Is(err, appErrors.ErrSavedSearchNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	name := export.Account.Name
+	if export.Search != nil {
+		name += "-" + export.Search.Name
+	}
+	filename := unsafeFilenameChars.ReplaceAllString(name, "_")
+	if filename == "" || filename == "_" {
+		filename = "account"
+	}
+	c.Header("Content-Type", "application/qif; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.qif"`, filename))
+	c.Status(http.StatusOK)
+
+	// Com a resposta já iniciada, um erro só pode ser registrado
+	if err := export.Write(c.Request.Context(), c.Writer); err != nil {
+		_ = c.Error(err)
+	}
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"errors"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/services"
+	appErrors "finanvilla/pkg/errors"
+	"finanvilla/pkg/validator"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Planilhas e históricos exportados de outros programas passam facilmente do
+// limite dos outros importadores
+const maxStatementCSVSize = 50 << 20 // 50 MB
+
+type StatementImportHandler struct {
+	importService *services.StatementImportService
+}
+
+func NewStatementImportHandler(importService *services.StatementImportService) *StatementImportHandler {
+	return &StatementImportHandler{importService: importService}
+}
+
+// ImportOFX importa um extrato OFX na conta da rota. Com dryRun=true devolve
+// só a prévia, sem gravar nada.
+func (h *StatementImportHandler) ImportOFX(c *gin.Context) {
+	var opts dtos.StatementImportOptions
+	if err := c.ShouldBindQuery(&opts); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&opts); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	body, err := statementFile(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer body.Close()
+
+	report, err := h.importService.ImportOFX(c.Request.Context(), c.GetString("userID"), c.Param("id"), io.LimitReader(body, maxImportFileSize), opts)
+	if err != nil {
+		respondStatementImportError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// ImportCSV importa um CSV na conta da rota usando o perfil profileId ou o
+// mapeamento informado na query string
+func (h *StatementImportHandler) ImportCSV(c *gin.Context) {
+	var opts dtos.CSVImportOptions
+	if err := c.ShouldBindQuery(&opts); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&opts); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	body, err := statementFile(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer body.Close()
+
+	report, err := h.importService.ImportCSV(c.Request.Context(), c.GetString("userID"), c.Param("id"), io.LimitReader(body, maxStatementCSVSize), opts)
+	if err != nil {
+		respondStatementImportError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// ImportQIF importa as movimentações de uma conta do arquivo QIF. Com vários
+// blocos !Account, account escolhe qual.
+func (h *StatementImportHandler) ImportQIF(c *gin.Context) {
+	var opts dtos.QIFImportOptions
+	if err := c.ShouldBindQuery(&opts); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&opts); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	body, err := statementFile(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer body.Close()
+
+	report, err := h.importService.ImportQIF(c.Request.Context(), c.GetString("userID"), c.Param("id"), io.LimitReader(body, maxStatementCSVSize), opts)
+	if err != nil {
+		respondStatementImportError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// ImportCNAB importa um arquivo de retorno bancário CNAB 240 ou 400
+func (h *StatementImportHandler) ImportCNAB(c *gin.Context) {
+	var opts dtos.CNABImportOptions
+	if err := c.ShouldBindQuery(&opts); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&opts); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	body, err := statementFile(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer body.Close()
+
+	report, err := h.importService.ImportCNAB(c.Request.Context(), c.GetString("userID"), c.Param("id"), io.LimitReader(body, maxStatementCSVSize), opts)
+	if err != nil {
+		respondStatementImportError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// InspectCSV detecta codificação e delimitador e sugere um mapeamento a partir
+// das primeiras linhas do arquivo
+func (h *StatementImportHandler) InspectCSV(c *gin.Context) {
+	var mapping dtos.CSVMapping
+	if err := c.ShouldBindQuery(&mapping); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&mapping); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	body, err := statementFile(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer body.Close()
+
+	inspection, err := h.importService.InspectCSV(io.LimitReader(body, maxStatementCSVSize), mapping)
+	if err != nil {
+		respondStatementImportError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, inspection)
+}
+
+// statementFile aceita o arquivo como campo "file" de um multipart/form-data
+// ou diretamente no corpo
+func statementFile(c *gin.Context) (io.ReadCloser, error) {
+	if !strings.HasPrefix(c.ContentType(), "multipart/") {
+		return c.Request.Body, nil
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return nil, errors.New("file is required")
+	}
+	return fileHeader.Open()
+}
+
+func respondStatementImportError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, appErrors.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrAccountNotFound), errors.Is(err, appErrors.ErrCSVProfileNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrAlreadyImported):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
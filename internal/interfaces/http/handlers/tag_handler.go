@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"errors"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/services"
+	appErrors "finanvilla/pkg/errors"
+	"finanvilla/pkg/validator"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TagHandler struct {
+	tagService *services.TagService
+}
+
+func NewTagHandler(tagService *services.TagService) *TagHandler {
+	return &TagHandler{tagService: tagService}
+}
+
+// List devolve as etiquetas com quantos lançamentos usam cada uma
+func (h *TagHandler) List(c *gin.Context) {
+	tags, err := h.tagService.List(c.Request.Context(), c.GetString("userID"))
+	if err != nil {
+		respondTagError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": tags})
+}
+
+func (h *TagHandler) Rename(c *gin.Context) {
+	var req dtos.RenameTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tag, err := h.tagService.Rename(c.Request.Context(), c.GetString("userID"), c.Param("id"), &req)
+	if err != nil {
+		respondTagError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tag)
+}
+
+// Merge junta a etiqueta da URL na etiqueta de destino e devolve o destino
+func (h *TagHandler) Merge(c *gin.Context) {
+	var req dtos.MergeTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tag, err := h.tagService.Merge(c.Request.Context(), c.GetString("userID"), c.Param("id"), &req)
+	if err != nil {
+		respondTagError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tag)
+}
+
+func (h *TagHandler) Delete(c *gin.Context) {
+	if err := h.tagService.Delete(c.Request.Context(), c.GetString("userID"), c.Param("id")); err != nil {
+		respondTagError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func respondTagError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, appErrors.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrTagNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrTagNameTaken):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,263 @@
+package handlers
+
+import (
+	"errors"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/enums"
+	"finanvilla/internal/domain/repositories"
+	"finanvilla/internal/domain/services"
+	appErrors "finanvilla/pkg/errors"
+	"finanvilla/pkg/money"
+	"finanvilla/pkg/validator"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TransactionHandler struct {
+	transactionService *services.TransactionService
+}
+
+func NewTransactionHandler(transactionService *services.TransactionService) *TransactionHandler {
+	return &TransactionHandler{transactionService: transactionService}
+}
+
+// List aceita os filtros accountId, categoryId e tagId (repetíveis), status,
+// from e to (YYYY-MM-DD), minAmount e maxAmount e q (texto livre)
+func (h *TransactionHandler) List(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	filter := repositories.TransactionFilter{
+		AccountIDs:  c.QueryArray("accountId"),
+		CategoryIDs: c.QueryArray("categoryId"),
+		TagIDs:      c.QueryArray("tagId"),
+		Status:      enums.TransactionStatus(strings.ToUpper(c.Query("status"))),
+		Text:        c.Query("q"),
+	}
+
+	var err error
+	if filter.From, err = parseDateQuery(c, "from"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if filter.To, err = parseDateQuery(c, "to"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if filter.MinAmount, err = parseAmountQuery(c, "minAmount"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if filter.MaxAmount, err = parseAmountQuery(c, "maxAmount"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	transactions, total, err := h.transactionService.List(c.Request.Context(), c.GetString("userID"), filter, page, limit)
+	if err != nil {
+		respondTransactionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  transactions,
+		"total": total,
+		"page":  page,
+		"limit": limit,
+	})
+}
+
+func (h *TransactionHandler) Get(c *gin.Context) {
+	transaction, err := h.transactionService.GetByID(c.Request.Context(), c.GetString("userID"), c.Param("id"))
+	if err != nil {
+		respondTransactionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, transaction)
+}
+
+func (h *TransactionHandler) Create(c *gin.Context) {
+	var req dtos.TransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	transaction, err := h.transactionService.Create(c.Request.Context(), c.GetString("userID"), &req)
+	if err != nil {
+		respondTransactionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, transaction)
+}
+
+func (h *TransactionHandler) Update(c *gin.Context) {
+	var req dtos.TransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	transaction, err := h.transactionService.Update(c.Request.Context(), c.GetString("userID"), c.Param("id"), &req)
+	if err != nil {
+		respondTransactionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, transaction)
+}
+
+// Split divide o lançamento entre as categorias informadas
+func (h *TransactionHandler) Split(c *gin.Context) {
+	var req dtos.SplitTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	transaction, err := h.transactionService.Split(c.Request.Context(), c.GetString("userID"), c.Param("id"), req.Splits)
+	if err != nil {
+		respondTransactionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, transaction)
+}
+
+// Unsplit junta as linhas numa só categoria (categoryId na query, ou a da maior linha)
+func (h *TransactionHandler) Unsplit(c *gin.Context) {
+	var categoryID *string
+	if value := c.Query("categoryId"); value != "" {
+		categoryID = &value
+	}
+
+	transaction, err := h.transactionService.Unsplit(c.Request.Context(), c.GetString("userID"), c.Param("id"), categoryID)
+	if err != nil {
+		respondTransactionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, transaction)
+}
+
+func (h *TransactionHandler) UpdateStatus(c *gin.Context) {
+	var req dtos.UpdateTransactionStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	transaction, err := h.transactionService.UpdateStatus(c.Request.Context(), c.GetString("userID"), c.Param("id"), req.Status)
+	if err != nil {
+		respondTransactionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, transaction)
+}
+
+func (h *TransactionHandler) Delete(c *gin.Context) {
+	if err := h.transactionService.Delete(c.Request.Context(), c.GetString("userID"), c.Param("id")); err != nil {
+		respondTransactionError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Register lista os lançamentos de uma conta com saldo acumulado
+func (h *TransactionHandler) Register(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	from, err := parseDateQuery(c, "from")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	to, err := parseDateQuery(c, "to")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entries, total, err := h.transactionService.Register(c.Request.Context(), c.GetString("userID"), c.Param("id"), from, to, page, limit)
+	if err != nil {
+		respondTransactionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  entries,
+		"total": total,
+		"page":  page,
+		"limit": limit,
+	})
+}
+
+func respondTransactionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, appErrors.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrTransactionNotFound), errors.Is(err, appErrors.ErrAccountNotFound),
+		errors.Is(err, appErrors.ErrCategoryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, appErrors.ErrTransactionLocked), errors.Is(err, appErrors.ErrInstallmentManaged):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+func parseDateQuery(c *gin.Context, key string) (*time.Time, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: use YYYY-MM-DD", key)
+	}
+	return &t, nil
+}
+
+func parseAmountQuery(c *gin.Context, key string) (*money.Money, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	amount, err := money.Parse(value, "")
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", key, err)
+	}
+	return &amount, nil
+}
@@ -1,24 +1,32 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
-	"regexp"
 	"strconv"
+	"strings"
 
+	"finanvilla/internal/application/dtos"
 	"finanvilla/internal/domain/entities"
+	"finanvilla/internal/domain/enums"
 	"finanvilla/internal/domain/services"
+	appErrors "finanvilla/pkg/errors"
+	"finanvilla/pkg/locale"
+	"finanvilla/pkg/validator"
 
 	"github.com/gin-gonic/gin"
 )
 
 type UserHandler struct {
-	userService *services.UserService
+	userService   *services.UserService
+	avatarService *services.AvatarService
 }
 
-func NewUserHandler(userService *services.UserService) *UserHandler {
+func NewUserHandler(userService *services.UserService, avatarService *services.AvatarService) *UserHandler {
 	return &UserHandler{
-		userService: userService,
+		userService:   userService,
+		avatarService: avatarService,
 	}
 }
 
@@ -30,7 +38,14 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 	}
 
 	if err := h.userService.CreateUser(c.Request.Context(), &user); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		switch {
+		case errors.Is(err, appErrors.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, appErrors.ErrTaxIDAlreadyUsed), errors.Is(err, appErrors.ErrEmailAlreadyUsed):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
@@ -45,6 +60,61 @@ func (h *UserHandler) GetUserByID(c *gin.Context) {
 		return
 	}
 
+	if err := h.avatarService.ResolveURLs(c.Request.Context(), user); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *UserHandler) GetMe(c *gin.Context) {
+	user, err := h.userService.GetByID(c.Request.Context(), c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	if err := h.avatarService.ResolveURLs(c.Request.Context(), user); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *UserHandler) UpdateProfile(c *gin.Context) {
+	var req dtos.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.userService.UpdateProfile(c.Request.Context(), c.GetString("userID"), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, appErrors.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, appErrors.ErrTaxIDAlreadyUsed):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, appErrors.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	if err := h.avatarService.ResolveURLs(c.Request.Context(), user); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
 	c.JSON(http.StatusOK, user)
 }
 
@@ -58,6 +128,13 @@ func (h *UserHandler) ListUsers(c *gin.Context) {
 		return
 	}
 
+	for i := range users {
+		if err := h.avatarService.ResolveURLs(c.Request.Context(), &users[i]); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"data":  users,
 		"total": total,
@@ -80,7 +157,6 @@ func (h *UserHandler) UpdateSettings(c *gin.Context) {
 		return
 	}
 
-	// Validação básica dos campos
 	if err := validateSettings(&settings); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -101,27 +177,52 @@ func (h *UserHandler) UpdateSettings(c *gin.Context) {
 	})
 }
 
+// validateSettings valida e normaliza as preferências usando as tabelas
+// BCP 47 (idioma), ISO 4217 (moeda) e IANA (fuso horário).
 func validateSettings(settings *entities.UserSettings) error {
 	if settings.Theme != "dark" && settings.Theme != "light" {
 		return fmt.Errorf("theme must be 'dark' or 'light'")
 	}
 
-	// Validação básica do formato da linguagem (xx-XX)
-	if !regexp.MustCompile(`^[a-z]{2}-[A-Z]{2}$`).MatchString(settings.Language) {
-		return fmt.Errorf("invalid language format")
+	language, err := locale.ParseLanguage(settings.Language)
+	if err != nil {
+		return fmt.Errorf("invalid language: %w", err)
 	}
+	settings.Language = language
 
-	// Lista de moedas suportadas
-	validCurrencies := []string{"BRL", "USD", "EUR"}
-	currencyValid := false
-	for _, c := range validCurrencies {
-		if c == settings.Currency {
-			currencyValid = true
-			break
+	currency, err := locale.ParseCurrency(settings.Currency)
+	if err != nil {
+		return fmt.Errorf("unsupported currency: %w", err)
+	}
+	settings.Currency = currency
+
+	// Os campos abaixo são opcionais: vazio mantém o valor atual
+	if settings.Timezone != "" {
+		if err := locale.ValidateTimezone(settings.Timezone); err != nil {
+			return err
 		}
 	}
-	if !currencyValid {
-		return fmt.Errorf("unsupported currency")
+	if settings.DateFormat != "" {
+		if err := locale.ValidateDateFormat(settings.DateFormat); err != nil {
+			return err
+		}
+	}
+	if settings.NumberFormat != "" {
+		if err := locale.ValidateNumberFormat(settings.NumberFormat); err != nil {
+			return err
+		}
+	}
+	if settings.FirstDayOfWeek != "" {
+		if err := locale.ValidateWeekday(settings.FirstDayOfWeek); err != nil {
+			return err
+		}
+		settings.FirstDayOfWeek = strings.ToUpper(settings.FirstDayOfWeek)
+	}
+	if settings.BudgetMode != "" {
+		settings.BudgetMode = enums.BudgetMode(strings.ToUpper(string(settings.BudgetMode)))
+		if !settings.BudgetMode.IsValid() {
+			return fmt.Errorf("budget mode must be STANDARD or ENVELOPE")
+		}
 	}
 
 	return nil
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"finanvilla/internal/application/dtos"
+	"finanvilla/internal/domain/services"
+	appErrors "finanvilla/pkg/errors"
+	"finanvilla/pkg/validator"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxImportFileSize = 5 << 20 // 5 MB
+
+type UserImportHandler struct {
+	importService *services.UserImportService
+}
+
+func NewUserImportHandler(importService *services.UserImportService) *UserImportHandler {
+	return &UserImportHandler{importService: importService}
+}
+
+// ImportUsers aceita o CSV como campo "file" de um multipart/form-data ou
+// diretamente no corpo (text/csv). As opções vêm da query string.
+func (h *UserImportHandler) ImportUsers(c *gin.Context) {
+	var opts dtos.UserImportOptions
+	if err := c.ShouldBindQuery(&opts); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validator.Validate(&opts); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var body io.Reader
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+			return
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		defer file.Close()
+		body = file
+	} else {
+		body = c.Request.Body
+	}
+
+	// Lê um byte além do limite: arquivo maior é recusado em vez de truncado,
+	// o que poderia importar a última linha pela metade
+	data, err := io.ReadAll(io.LimitReader(body, maxImportFileSize+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(data) > maxImportFileSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": appErrors.ErrFileTooLarge.Error()})
+		return
+	}
+
+	report, err := h.importService.Import(c.Request.Context(), bytes.NewReader(data), opts)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	status := http.StatusOK
+	if !report.DryRun && report.Failed > 0 && report.Mode == dtos.ImportAtomic {
+		status = http.StatusUnprocessableEntity
+	}
+
+	c.JSON(status, report)
+}
@@ -40,7 +40,7 @@ func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
 			return
 		}
 
-		c.Set("userID", claims["user_id"])
+		c.Set("userID", claims["userId"])
 		c.Next()
 	}
 }
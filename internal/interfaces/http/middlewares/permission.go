@@ -0,0 +1,35 @@
+package middlewares
+
+import (
+	"finanvilla/internal/domain/enums"
+	"finanvilla/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequirePermission deve ser usado após AuthMiddleware, que define o userID no contexto
+func RequirePermission(userService *services.UserService, permission enums.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetString("userID")
+		if userID == "" {
+			c.JSON(401, gin.H{"error": "unauthorized"})
+			c.Abort()
+			return
+		}
+
+		user, err := userService.GetByID(c.Request.Context(), userID)
+		if err != nil {
+			c.JSON(401, gin.H{"error": "unauthorized"})
+			c.Abort()
+			return
+		}
+
+		if !userService.HasPermission(user, permission) {
+			c.JSON(403, gin.H{"error": "forbidden"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,19 @@
+package middlewares
+
+import (
+	"finanvilla/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestMeta disponibiliza IP e user agent no context.Context da requisição
+func RequestMeta() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := services.ContextWithRequestMeta(c.Request.Context(), services.RequestMeta{
+			IP:        c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+		})
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -2,6 +2,8 @@
 package routes
 
 import (
+	"finanvilla/internal/domain/enums"
+	"finanvilla/internal/domain/services"
 	"finanvilla/internal/interfaces/http/handlers"
 	"finanvilla/internal/interfaces/http/middlewares"
 
@@ -10,10 +12,36 @@ import (
 )
 
 type RouterConfig struct {
-	UserHandler   *handlers.UserHandler
-	HealthHandler *handlers.HealthHandler
-	AuthHandler   *handlers.AuthHandler
-	JWTSecret     string
+	UserHandler        *handlers.UserHandler
+	HealthHandler      *handlers.HealthHandler
+	AuthHandler        *handlers.AuthHandler
+	UserImportHandler  *handlers.UserImportHandler
+	InvitationHandler  *handlers.InvitationHandler
+	EmailChangeHandler *handlers.EmailChangeHandler
+	AvatarHandler      *handlers.AvatarHandler
+	FileHandler        *handlers.FileHandler // nil quando o armazenamento não é local
+
+	NotificationPreferenceHandler *handlers.NotificationPreferenceHandler
+	SecurityEventHandler          *handlers.SecurityEventHandler
+	AccountHandler                *handlers.AccountHandler
+	TransactionHandler            *handlers.TransactionHandler
+	CategoryHandler               *handlers.CategoryHandler
+	BudgetHandler                 *handlers.BudgetHandler
+	RecurringTransactionHandler   *handlers.RecurringTransactionHandler
+	StatementImportHandler        *handlers.StatementImportHandler
+	CSVImportProfileHandler       *handlers.CSVImportProfileHandler
+	StatementExportHandler        *handlers.StatementExportHandler
+	PixHandler                    *handlers.PixHandler
+	BoletoHandler                 *handlers.BoletoHandler
+	CreditCardHandler             *handlers.CreditCardHandler
+	HouseholdHandler              *handlers.HouseholdHandler
+	TagHandler                    *handlers.TagHandler
+	SavedSearchHandler            *handlers.SavedSearchHandler
+	AttachmentHandler             *handlers.AttachmentHandler
+	CategorizationRuleHandler     *handlers.CategorizationRuleHandler
+	DataExportHandler             *handlers.DataExportHandler
+	UserService                   *services.UserService
+	JWTSecret                     string
 }
 
 func SetupRouter(config RouterConfig) *gin.Engine {
@@ -29,16 +57,24 @@ func SetupRouter(config RouterConfig) *gin.Engine {
 
 	router.Use(gin.Recovery())
 	router.Use(gin.Logger())
+	router.Use(middlewares.RequestMeta())
 
 	api := router.Group("/api/v1")
 	{
 		api.GET("/health", config.HealthHandler.Check)
 
+		if config.FileHandler != nil {
+			api.GET("/files/*key", config.FileHandler.Serve)
+		}
+
 		auth := api.Group("/auth")
 		{
 			auth.POST("/register", config.AuthHandler.Register)
 			auth.POST("/login", config.AuthHandler.Login)
 			auth.POST("/refresh", config.AuthHandler.RefreshToken)
+			auth.POST("/invitations/accept", config.InvitationHandler.Accept)
+			auth.POST("/email/confirm", config.EmailChangeHandler.Confirm)
+			auth.POST("/email/revert", config.EmailChangeHandler.Revert)
 
 			auth.POST("/logout", middlewares.AuthMiddleware(config.JWTSecret), config.AuthHandler.Logout)
 		}
@@ -46,14 +82,199 @@ func SetupRouter(config RouterConfig) *gin.Engine {
 		protected := api.Group("")
 		protected.Use(middlewares.AuthMiddleware(config.JWTSecret))
 		{
+			me := protected.Group("/me")
+			{
+				me.GET("", config.UserHandler.GetMe)
+				me.PUT("/profile", config.UserHandler.UpdateProfile)
+				me.POST("/email", config.EmailChangeHandler.RequestChange)
+				me.PUT("/avatar", config.AvatarHandler.Upload)
+				me.DELETE("/avatar", config.AvatarHandler.Delete)
+				me.GET("/notification-preferences", config.NotificationPreferenceHandler.Get)
+				me.PUT("/notification-preferences", config.NotificationPreferenceHandler.Update)
+				me.GET("/security-events", config.SecurityEventHandler.ListMine)
+				me.GET("/export", config.DataExportHandler.Export)
+			}
+
+			protected.GET("/security-events",
+				middlewares.RequirePermission(config.UserService, enums.ViewAllUsers),
+				config.SecurityEventHandler.Search,
+			)
+
 			users := protected.Group("/users")
 			{
 				users.POST("/", config.UserHandler.CreateUser)
 				users.GET("/:id", config.UserHandler.GetUserByID)
 				users.GET("/", config.UserHandler.ListUsers)
 				users.PUT("/:id/settings", config.UserHandler.UpdateSettings)
+				users.POST("/import",
+					middlewares.RequirePermission(config.UserService, enums.CreateUser),
+					config.UserImportHandler.ImportUsers,
+				)
+			}
+
+			accounts := protected.Group("/accounts")
+			{
+				accounts.GET("", config.AccountHandler.List)
+				accounts.POST("", config.AccountHandler.Create)
+				accounts.PUT("/order", config.AccountHandler.Reorder)
+				accounts.GET("/:id", config.AccountHandler.Get)
+				accounts.PUT("/:id", config.AccountHandler.Update)
+				accounts.DELETE("/:id", config.AccountHandler.Delete)
+				accounts.GET("/:id/transactions", config.TransactionHandler.Register)
+				accounts.POST("/:id/import/ofx", config.StatementImportHandler.ImportOFX)
+				accounts.POST("/:id/import/csv", config.StatementImportHandler.ImportCSV)
+				accounts.POST("/:id/import/qif", config.StatementImportHandler.ImportQIF)
+				accounts.POST("/:id/import/cnab", config.StatementImportHandler.ImportCNAB)
+				accounts.GET("/:id/export/qif", config.StatementExportHandler.ExportQIF)
+				accounts.GET("/:id/statements", config.CreditCardHandler.Statements)
+				accounts.GET("/:id/statements/:closingDate", config.CreditCardHandler.Statement)
+				accounts.POST("/:id/statements/:closingDate/pay", config.CreditCardHandler.PayStatement)
+				accounts.GET("/:id/attachments", config.AttachmentHandler.ListByAccount)
+				accounts.POST("/:id/attachments", config.AttachmentHandler.UploadToAccount)
+			}
+
+			transactions := protected.Group("/transactions")
+			{
+				transactions.GET("", config.TransactionHandler.List)
+				transactions.POST("", config.TransactionHandler.Create)
+				transactions.GET("/:id", config.TransactionHandler.Get)
+				transactions.PUT("/:id", config.TransactionHandler.Update)
+				transactions.PATCH("/:id/status", config.TransactionHandler.UpdateStatus)
+				transactions.PUT("/:id/splits", config.TransactionHandler.Split)
+				transactions.DELETE("/:id/splits", config.TransactionHandler.Unsplit)
+				transactions.GET("/:id/attachments", config.AttachmentHandler.ListByTransaction)
+				transactions.POST("/:id/attachments", config.AttachmentHandler.UploadToTransaction)
+				transactions.DELETE("/:id", config.TransactionHandler.Delete)
+			}
+
+			categories := protected.Group("/categories")
+			{
+				categories.GET("", config.CategoryHandler.List)
+				categories.POST("", config.CategoryHandler.Create)
+				categories.GET("/:id", config.CategoryHandler.Get)
+				categories.PUT("/:id", config.CategoryHandler.Update)
+				categories.DELETE("/:id", config.CategoryHandler.Delete)
+				categories.POST("/:id/merge", config.CategoryHandler.Merge)
+				categories.POST("/:id/move-transactions", config.CategoryHandler.MoveTransactions)
+			}
+
+			budgets := protected.Group("/budgets")
+			{
+				budgets.GET("/:month", config.BudgetHandler.Report)
+				budgets.POST("/:month/copy", config.BudgetHandler.Copy)
+				budgets.PUT("/:month/categories/:categoryId", config.BudgetHandler.Set)
+				budgets.DELETE("/:month/categories/:categoryId", config.BudgetHandler.Delete)
+			}
+
+			recurring := protected.Group("/recurring-transactions")
+			{
+				recurring.GET("", config.RecurringTransactionHandler.List)
+				recurring.POST("", config.RecurringTransactionHandler.Create)
+				recurring.POST("/preview", config.RecurringTransactionHandler.Preview)
+				recurring.GET("/:id", config.RecurringTransactionHandler.Get)
+				recurring.PUT("/:id", config.RecurringTransactionHandler.Update)
+				recurring.PATCH("/:id/active", config.RecurringTransactionHandler.SetActive)
+				recurring.DELETE("/:id", config.RecurringTransactionHandler.Delete)
+				recurring.GET("/:id/upcoming", config.RecurringTransactionHandler.Upcoming)
+			}
+
+			csvProfiles := protected.Group("/csv-profiles")
+			{
+				csvProfiles.GET("", config.CSVImportProfileHandler.List)
+				csvProfiles.POST("", config.CSVImportProfileHandler.Create)
+				csvProfiles.POST("/inspect", config.StatementImportHandler.InspectCSV)
+				csvProfiles.GET("/:id", config.CSVImportProfileHandler.Get)
+				csvProfiles.PUT("/:id", config.CSVImportProfileHandler.Update)
+				csvProfiles.DELETE("/:id", config.CSVImportProfileHandler.Delete)
+			}
+
+			pix := protected.Group("/pix")
+			{
+				pix.POST("/charges", config.PixHandler.Charge)
+				pix.POST("/parse", config.PixHandler.Parse)
+				pix.POST("/qrcode", config.PixHandler.QRCode)
+			}
+
+			boletos := protected.Group("/boletos")
+			{
+				boletos.POST("/parse", config.BoletoHandler.Parse)
+				boletos.POST("/schedule", config.BoletoHandler.Schedule)
+			}
+
+			installments := protected.Group("/installment-purchases")
+			{
+				installments.GET("", config.CreditCardHandler.ListPurchases)
+				installments.POST("", config.CreditCardHandler.CreatePurchase)
+				installments.GET("/:id", config.CreditCardHandler.GetPurchase)
+				installments.PUT("/:id", config.CreditCardHandler.UpdatePurchase)
+				installments.DELETE("/:id", config.CreditCardHandler.DeletePurchase)
+			}
+
+			households := protected.Group("/households")
+			{
+				households.GET("", config.HouseholdHandler.List)
+				households.POST("", config.HouseholdHandler.Create)
+				households.GET("/invitations", config.HouseholdHandler.ListInvitations)
+				households.GET("/:id", config.HouseholdHandler.GetByID)
+				households.PUT("/:id", config.HouseholdHandler.Update)
+				households.DELETE("/:id", config.HouseholdHandler.Delete)
+				households.POST("/:id/members", config.HouseholdHandler.InviteMember)
+				households.POST("/:id/invitation", config.HouseholdHandler.AcceptInvitation)
+				households.DELETE("/:id/invitation", config.HouseholdHandler.DeclineInvitation)
+				households.PUT("/:id/members/me", config.HouseholdHandler.UpdateMembership)
+				households.DELETE("/:id/members/:userId", config.HouseholdHandler.RemoveMember)
+				households.GET("/:id/expenses", config.HouseholdHandler.ListExpenses)
+				households.POST("/:id/expenses", config.HouseholdHandler.CreateExpense)
+				households.GET("/:id/expenses/:expenseId", config.HouseholdHandler.GetExpense)
+				households.PUT("/:id/expenses/:expenseId", config.HouseholdHandler.UpdateExpense)
+				households.DELETE("/:id/expenses/:expenseId", config.HouseholdHandler.DeleteExpense)
+				households.GET("/:id/balances", config.HouseholdHandler.Balances)
+				households.GET("/:id/settlements", config.HouseholdHandler.ListSettlements)
+				households.POST("/:id/settlements", config.HouseholdHandler.Settle)
+				households.DELETE("/:id/settlements/:settlementId", config.HouseholdHandler.DeleteSettlement)
+			}
+
+			tags := protected.Group("/tags")
+			{
+				tags.GET("", config.TagHandler.List)
+				tags.PUT("/:id", config.TagHandler.Rename)
+				tags.POST("/:id/merge", config.TagHandler.Merge)
+				tags.DELETE("/:id", config.TagHandler.Delete)
+			}
+
+			attachments := protected.Group("/attachments")
+			{
+				attachments.GET("/:id", config.AttachmentHandler.Get)
+				attachments.GET("/:id/content", config.AttachmentHandler.Download)
+				attachments.GET("/:id/thumbnail", config.AttachmentHandler.Thumbnail)
+				attachments.DELETE("/:id", config.AttachmentHandler.Delete)
+			}
+
+			savedSearches := protected.Group("/saved-searches")
+			{
+				savedSearches.GET("", config.SavedSearchHandler.List)
+				savedSearches.POST("", config.SavedSearchHandler.Create)
+				savedSearches.GET("/:id", config.SavedSearchHandler.Get)
+				savedSearches.PUT("/:id", config.SavedSearchHandler.Update)
+				savedSearches.DELETE("/:id", config.SavedSearchHandler.Delete)
+				savedSearches.GET("/:id/transactions", config.SavedSearchHandler.Transactions)
+				savedSearches.GET("/:id/report", config.SavedSearchHandler.Report)
 			}
 
+			rules := protected.Group("/categorization-rules")
+			{
+				rules.GET("", config.CategorizationRuleHandler.List)
+				rules.POST("", config.CategorizationRuleHandler.Create)
+				rules.PUT("/order", config.CategorizationRuleHandler.Reorder)
+				rules.POST("/preview", config.CategorizationRuleHandler.Preview)
+				rules.POST("/apply", config.CategorizationRuleHandler.Apply)
+				rules.GET("/suggestions", config.CategorizationRuleHandler.Suggestions)
+				rules.POST("/suggestions/:id/accept", config.CategorizationRuleHandler.AcceptSuggestion)
+				rules.DELETE("/suggestions/:id", config.CategorizationRuleHandler.DismissSuggestion)
+				rules.GET("/:id", config.CategorizationRuleHandler.Get)
+				rules.PUT("/:id", config.CategorizationRuleHandler.Update)
+				rules.DELETE("/:id", config.CategorizationRuleHandler.Delete)
+			}
 		}
 	}
 
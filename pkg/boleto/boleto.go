@@ -0,0 +1,331 @@
+// Package boleto valida e converte boletos: o bancário (código de barras de
+// 44 dígitos e linha digitável de 47) e o de arrecadação de concessionárias e
+// tributos (44 dígitos no código de barras, 48 na linha digitável). Os
+// dígitos verificadores de cada campo e o geral são sempre conferidos.
+package boleto
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"finanvilla/pkg/money"
+)
+
+var (
+	ErrInvalidBoleto = errors.New("invalid boleto")
+	ErrCheckDigit    = errors.New("boleto check digit mismatch")
+)
+
+type Kind string
+
+const (
+	// Bank é o boleto de cobrança bancária (FEBRABAN)
+	Bank Kind = "BANK"
+	// Utility é a guia de arrecadação: contas de consumo, tributos, carnês
+	Utility Kind = "UTILITY"
+)
+
+const (
+	barcodeLength     = 44
+	bankLineLength    = 47
+	utilityLineLength = 48
+)
+
+// Boleto são os dados extraídos do código de barras
+type Boleto struct {
+	Kind    Kind
+	Barcode string
+	// TypedLine é a linha digitável, só com dígitos
+	TypedLine string
+	// BankCode e BankName só existem no boleto bancário
+	BankCode string
+	BankName string
+	// DueDate é zero quando o boleto não tem vencimento (fator 0000) e nas
+	// guias de arrecadação, que não o trazem em posição fixa
+	DueDate time.Time
+	// Amount zero indica valor a ser informado pelo pagador
+	Amount money.Money
+	// Segment e CompanyID só existem nas guias de arrecadação
+	Segment     string
+	SegmentName string
+	CompanyID   string
+}
+
+var banks = map[string]string{
+	"001": "Banco do Brasil",
+	"033": "Santander",
+	"041": "Banrisul",
+	"070": "BRB",
+	"077": "Banco Inter",
+	"104": "Caixa Econômica Federal",
+	"208": "BTG Pactual",
+	"212": "Banco Original",
+	"237": "Bradesco",
+	"260": "Nubank",
+	"336": "C6 Bank",
+	"341": "Itaú Unibanco",
+	"389": "Mercantil do Brasil",
+	"422": "Safra",
+	"745": "Citibank",
+	"748": "Sicredi",
+	"756": "Sicoob",
+}
+
+var segments = map[string]string{
+	"1": "Prefeituras",
+	"2": "Saneamento",
+	"3": "Energia elétrica e gás",
+	"4": "Telecomunicações",
+	"5": "Órgãos governamentais",
+	"6": "Carnês e assemelhados",
+	"7": "Multas de trânsito",
+	"9": "Uso exclusivo do banco",
+}
+
+// Parse aceita o código de barras ou a linha digitável, com ou sem pontuação.
+// reference é a data usada para decidir o ciclo do fator de vencimento, em
+// geral hoje.
+func Parse(s string, reference time.Time) (*Boleto, error) {
+	digits := strings.Map(func(r rune) rune {
+		switch {
+		case r >= '0' && r <= '9':
+			return r
+		case r == ' ' || r == '.' || r == '-' || r == '\t' || r == '\n' || r == '\r':
+			return -1
+		}
+		return 'x'
+	}, s)
+	if strings.Contains(digits, "x") {
+		return nil, fmt.Errorf("%w: only digits are allowed", ErrInvalidBoleto)
+	}
+
+	var barcode string
+	var err error
+	switch len(digits) {
+	case barcodeLength:
+		barcode = digits
+	case bankLineLength:
+		barcode, err = bankLineToBarcode(digits)
+	case utilityLineLength:
+		barcode, err = utilityLineToBarcode(digits)
+	default:
+		return nil, fmt.Errorf("%w: expected 44, 47 or 48 digits, got %d", ErrInvalidBoleto, len(digits))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if barcode[0] == '8' {
+		return parseUtility(barcode)
+	}
+	return parseBank(barcode, reference)
+}
+
+// FormatTypedLine pontua a linha digitável como impressa no boleto
+func FormatTypedLine(line string) string {
+	switch len(line) {
+	case bankLineLength:
+		return line[0:5] + "." + line[5:10] + " " + line[10:15] + "." + line[15:21] + " " +
+			line[21:26] + "." + line[26:32] + " " + line[32:33] + " " + line[33:47]
+	case utilityLineLength:
+		return line[0:11] + "-" + line[11:12] + " " + line[12:23] + "-" + line[23:24] + " " +
+			line[24:35] + "-" + line[35:36] + " " + line[36:47] + "-" + line[47:48]
+	}
+	return line
+}
+
+// Boleto bancário. Código de barras: banco (3), moeda (1), DV geral (1),
+// fator de vencimento (4), valor (10) e campo livre (25). Linha digitável:
+// três campos com o campo livre, cada um com DV módulo 10, o DV geral e, por
+// fim, fator e valor.
+
+func parseBank(barcode string, reference time.Time) (*Boleto, error) {
+	if dv := bankBarcodeDV(barcode); barcode[4] != dv {
+		return nil, fmt.Errorf("%w: barcode check digit is %c, expected %c", ErrCheckDigit, barcode[4], dv)
+	}
+	if barcode[3] != '9' {
+		return nil, fmt.Errorf("%w: currency code %c is not BRL", ErrInvalidBoleto, barcode[3])
+	}
+
+	cents, _ := strconv.ParseInt(barcode[9:19], 10, 64)
+	factor, _ := strconv.Atoi(barcode[5:9])
+	b := &Boleto{
+		Kind:      Bank,
+		Barcode:   barcode,
+		TypedLine: bankTypedLine(barcode),
+		BankCode:  barcode[0:3],
+		BankName:  banks[barcode[0:3]],
+		DueDate:   DueDate(factor, reference),
+		Amount:    money.MustNew(cents, "BRL"),
+	}
+	return b, nil
+}
+
+func bankLineToBarcode(line string) (string, error) {
+	fields := []struct{ body, dv string }{
+		{line[0:9], line[9:10]},
+		{line[10:20], line[20:21]},
+		{line[21:31], line[31:32]},
+	}
+	for i, f := range fields {
+		if dv := mod10(f.body); string(dv) != f.dv {
+			return "", fmt.Errorf("%w: field %d check digit is %s, expected %c", ErrCheckDigit, i+1, f.dv, dv)
+		}
+	}
+	return line[0:4] + line[32:33] + line[33:47] + line[4:9] + line[10:20] + line[21:31], nil
+}
+
+func bankTypedLine(barcode string) string {
+	free := barcode[19:44]
+	field1 := barcode[0:4] + free[0:5]
+	field2 := free[5:15]
+	field3 := free[15:25]
+	return field1 + string(mod10(field1)) +
+		field2 + string(mod10(field2)) +
+		field3 + string(mod10(field3)) +
+		barcode[4:5] + barcode[5:19]
+}
+
+// bankBarcodeDV é o módulo 11 com pesos de 2 a 9 sobre os 43 dígitos sem o
+// próprio DV; restos que dariam 0, 10 ou 11 viram 1
+func bankBarcodeDV(barcode string) byte {
+	dv := 11 - mod11Sum(barcode[0:4]+barcode[5:])%11
+	if dv == 0 || dv == 10 || dv == 11 {
+		return '1'
+	}
+	return byte('0' + dv)
+}
+
+var (
+	factorBase = time.Date(1997, 10, 7, 0, 0, 0, 0, time.UTC)
+	// O fator chegou a 9999 em 21/02/2025 e voltou a 1000 no dia seguinte;
+	// cada ciclo cobre 9000 dias
+	factorCycle = 9000
+)
+
+// DueDate converte o fator de vencimento em data. Como o fator reinicia a
+// cada 9000 dias, avança ciclos até que a data não fique mais de 3000 dias
+// antes da referência. Fator 0 é boleto sem vencimento.
+func DueDate(factor int, reference time.Time) time.Time {
+	if factor < 1000 {
+		return time.Time{}
+	}
+	if reference.IsZero() {
+		reference = time.Now()
+	}
+	ref := time.Date(reference.Year(), reference.Month(), reference.Day(), 0, 0, 0, 0, time.UTC)
+
+	date := factorBase.AddDate(0, 0, factor)
+	for date.Before(ref.AddDate(0, 0, -3000)) {
+		date = date.AddDate(0, 0, factorCycle)
+	}
+	return date
+}
+
+// Guia de arrecadação. Código de barras: produto "8" (1), segmento (1),
+// identificador do valor (1), DV geral (1), valor (11), empresa ou órgão (4,
+// ou 8 com o CNPJ no segmento 6) e campo livre. A linha digitável são quatro
+// blocos de 11 dígitos, cada um com seu DV. O identificador 6 ou 7 pede
+// módulo 10; 8 ou 9, módulo 11. Com 7 ou 9 o campo é uma referência e não o
+// valor em reais.
+
+func parseUtility(barcode string) (*Boleto, error) {
+	dvFunc, err := utilityDVFunc(barcode[2])
+	if err != nil {
+		return nil, err
+	}
+	if dv := dvFunc(barcode[0:3] + barcode[4:]); barcode[3] != dv {
+		return nil, fmt.Errorf("%w: barcode check digit is %c, expected %c", ErrCheckDigit, barcode[3], dv)
+	}
+
+	b := &Boleto{
+		Kind:        Utility,
+		Barcode:     barcode,
+		Segment:     barcode[1:2],
+		SegmentName: segments[barcode[1:2]],
+		CompanyID:   barcode[15:19],
+		Amount:      money.MustNew(0, "BRL"),
+	}
+	if b.Segment == "6" {
+		b.CompanyID = barcode[15:23]
+	}
+	if barcode[2] == '6' || barcode[2] == '8' {
+		cents, _ := strconv.ParseInt(barcode[4:15], 10, 64)
+		b.Amount = money.MustNew(cents, "BRL")
+	}
+
+	for i := 0; i < 4; i++ {
+		block := barcode[i*11 : i*11+11]
+		b.TypedLine += block + string(dvFunc(block))
+	}
+	return b, nil
+}
+
+func utilityLineToBarcode(line string) (string, error) {
+	if line[0] != '8' {
+		return "", fmt.Errorf("%w: a 48-digit line must start with 8", ErrInvalidBoleto)
+	}
+	dvFunc, err := utilityDVFunc(line[2])
+	if err != nil {
+		return "", err
+	}
+
+	var barcode strings.Builder
+	for i := 0; i < 4; i++ {
+		block, dv := line[i*12:i*12+11], line[i*12+11]
+		if got := dvFunc(block); got != dv {
+			return "", fmt.Errorf("%w: block %d check digit is %c, expected %c", ErrCheckDigit, i+1, dv, got)
+		}
+		barcode.WriteString(block)
+	}
+	return barcode.String(), nil
+}
+
+func utilityDVFunc(identifier byte) (func(string) byte, error) {
+	switch identifier {
+	case '6', '7':
+		return mod10, nil
+	case '8', '9':
+		return utilityMod11, nil
+	}
+	return nil, fmt.Errorf("%w: unknown value identifier %c", ErrInvalidBoleto, identifier)
+}
+
+// mod10 multiplica os dígitos, da direita para a esquerda, por 2 e 1
+// alternados, somando os algarismos de cada produto
+func mod10(s string) byte {
+	sum, weight := 0, 2
+	for i := len(s) - 1; i >= 0; i-- {
+		p := int(s[i]-'0') * weight
+		sum += p/10 + p%10
+		weight = 3 - weight
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// mod11Sum multiplica os dígitos, da direita para a esquerda, pelos pesos 2 a 9
+func mod11Sum(s string) int {
+	sum, weight := 0, 2
+	for i := len(s) - 1; i >= 0; i-- {
+		sum += int(s[i]-'0') * weight
+		if weight++; weight > 9 {
+			weight = 2
+		}
+	}
+	return sum
+}
+
+// utilityMod11 é o módulo 11 da arrecadação: restos 0 e 1 dão DV 0; resto 10, DV 1
+func utilityMod11(s string) byte {
+	switch r := mod11Sum(s) % 11; r {
+	case 0, 1:
+		return '0'
+	case 10:
+		return '1'
+	default:
+		return byte('0' + 11 - r)
+	}
+}
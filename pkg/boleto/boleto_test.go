@@ -0,0 +1,77 @@
+package boleto
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var today = time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+func TestParseBank(t *testing.T) {
+	const line = "00190.50095 40144.816069 06809.350314 3 37370000000100"
+	const barcode = "00193373700000001000500940144816060680935031"
+
+	for _, input := range []string{line, barcode} {
+		b, err := Parse(input, time.Date(2008, 1, 1, 0, 0, 0, 0, time.UTC))
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", input, err)
+		}
+		if b.Kind != Bank || b.Barcode != barcode || FormatTypedLine(b.TypedLine) != line {
+			t.Errorf("conversion = %+v", b)
+		}
+		if b.BankName != "Banco do Brasil" || b.Amount.MinorUnits() != 100 {
+			t.Errorf("bank/amount = %s %s", b.BankName, b.Amount)
+		}
+		if !b.DueDate.Equal(time.Date(2007, 12, 31, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("due date = %s", b.DueDate)
+		}
+	}
+
+	if _, err := Parse("00190.50095 40144.816069 06809.350314 4 37370000000100", today); !errors.Is(err, ErrCheckDigit) {
+		t.Errorf("general DV err = %v", err)
+	}
+	if _, err := Parse("00190.50096 40144.816069 06809.350314 3 37370000000100", today); !errors.Is(err, ErrCheckDigit) {
+		t.Errorf("field DV err = %v", err)
+	}
+	if _, err := Parse("0019050095", today); !errors.Is(err, ErrInvalidBoleto) {
+		t.Errorf("length err = %v", err)
+	}
+}
+
+func TestDueDateRollover(t *testing.T) {
+	for _, tc := range []struct {
+		factor int
+		want   time.Time
+	}{
+		{1000, time.Date(2025, 2, 22, 0, 0, 0, 0, time.UTC)},
+		{9999, time.Date(2025, 2, 21, 0, 0, 0, 0, time.UTC)},
+		{1667, time.Date(2026, 12, 21, 0, 0, 0, 0, time.UTC)},
+	} {
+		if got := DueDate(tc.factor, today); !got.Equal(tc.want) {
+			t.Errorf("DueDate(%d) = %s, want %s", tc.factor, got, tc.want)
+		}
+	}
+	if !DueDate(0, today).IsZero() {
+		t.Error("factor 0 should have no due date")
+	}
+}
+
+func TestParseUtility(t *testing.T) {
+	const line = "83620000000-5 66780048100-0 18097565731-3 00158963608-1"
+
+	b, err := Parse(line, today)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Kind != Utility || b.Barcode != "83620000000667800481001809756573100158963608" || FormatTypedLine(b.TypedLine) != line {
+		t.Errorf("conversion = %+v", b)
+	}
+	if b.Amount.MinorUnits() != 6678 || b.SegmentName != "Energia elétrica e gás" || b.CompanyID != "0048" {
+		t.Errorf("boleto = %+v", b)
+	}
+
+	if _, err := Parse("83620000000-5 66780048100-1 18097565731-3 00158963608-1", today); !errors.Is(err, ErrCheckDigit) {
+		t.Errorf("block DV err = %v", err)
+	}
+}
@@ -0,0 +1,151 @@
+// Package brdoc valida, normaliza e mascara documentos brasileiros (CPF, CNPJ)
+// e números de telefone.
+package brdoc
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidCPF   = errors.New("invalid CPF")
+	ErrInvalidCNPJ  = errors.New("invalid CNPJ")
+	ErrInvalidTaxID = errors.New("invalid CPF or CNPJ")
+	ErrInvalidPhone = errors.New("invalid phone number")
+)
+
+const (
+	cpfLength  = 11
+	cnpjLength = 14
+)
+
+// NormalizeCPF remove a pontuação e valida os dígitos verificadores
+func NormalizeCPF(s string) (string, error) {
+	cpf := onlyDigits(s)
+	if len(cpf) != cpfLength || allEqual(cpf) {
+		return "", ErrInvalidCPF
+	}
+
+	for _, n := range []int{9, 10} {
+		if checkDigit(cpf[:n], cpfWeights(n)) != cpf[n] {
+			return "", ErrInvalidCPF
+		}
+	}
+	return cpf, nil
+}
+
+// NormalizeCNPJ aceita tanto o CNPJ numérico quanto o alfanumérico (vigente a
+// partir de julho de 2026), devolvendo-o sem pontuação e em maiúsculas.
+func NormalizeCNPJ(s string) (string, error) {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(s) {
+		if (r >= '0' && r <= '9') || (r >= 'A' && r <= 'Z') {
+			b.WriteRune(r)
+		}
+	}
+	cnpj := b.String()
+
+	if len(cnpj) != cnpjLength || allEqual(cnpj) {
+		return "", ErrInvalidCNPJ
+	}
+	// Os dois dígitos verificadores continuam sempre numéricos
+	if onlyDigits(cnpj[12:]) != cnpj[12:] {
+		return "", ErrInvalidCNPJ
+	}
+
+	for _, n := range []int{12, 13} {
+		if checkDigit(cnpj[:n], cnpjWeights(n)) != cnpj[n] {
+			return "", ErrInvalidCNPJ
+		}
+	}
+	return cnpj, nil
+}
+
+// NormalizeTaxID detecta se o documento é CPF ou CNPJ pelo tamanho
+func NormalizeTaxID(s string) (string, error) {
+	if cpf, err := NormalizeCPF(s); err == nil {
+		return cpf, nil
+	}
+	if cnpj, err := NormalizeCNPJ(s); err == nil {
+		return cnpj, nil
+	}
+	return "", ErrInvalidTaxID
+}
+
+func IsCPF(taxID string) bool  { return len(taxID) == cpfLength }
+func IsCNPJ(taxID string) bool { return len(taxID) == cnpjLength }
+
+// FormatCPF formata um CPF normalizado como 000.000.000-00
+func FormatCPF(cpf string) string {
+	if len(cpf) != cpfLength {
+		return cpf
+	}
+	return cpf[0:3] + "." + cpf[3:6] + "." + cpf[6:9] + "-" + cpf[9:11]
+}
+
+// FormatCNPJ formata um CNPJ normalizado como 00.000.000/0000-00
+func FormatCNPJ(cnpj string) string {
+	if len(cnpj) != cnpjLength {
+		return cnpj
+	}
+	return cnpj[0:2] + "." + cnpj[2:5] + "." + cnpj[5:8] + "/" + cnpj[8:12] + "-" + cnpj[12:14]
+}
+
+// MaskTaxID oculta parte do documento: o CPF mantém apenas os seis dígitos
+// centrais (***.456.789-**) e o CNPJ mantém apenas a raiz (12.345.678/****-**).
+func MaskTaxID(taxID string) string {
+	switch {
+	case IsCPF(taxID):
+		return "***." + taxID[3:6] + "." + taxID[6:9] + "-**"
+	case IsCNPJ(taxID):
+		return taxID[0:2] + "." + taxID[2:5] + "." + taxID[5:8] + "/****-**"
+	case taxID == "":
+		return ""
+	default:
+		return "***"
+	}
+}
+
+func checkDigit(base string, weights []int) byte {
+	sum := 0
+	for i := 0; i < len(base); i++ {
+		sum += int(base[i]-'0') * weights[i]
+	}
+	rest := sum % 11
+	if rest < 2 {
+		return '0'
+	}
+	return byte('0' + 11 - rest)
+}
+
+// cpfWeights devolve os pesos n+1, n, ..., 2 usados no cálculo do dígito n
+func cpfWeights(n int) []int {
+	weights := make([]int, n)
+	for i := range weights {
+		weights[i] = n + 1 - i
+	}
+	return weights
+}
+
+// cnpjWeights devolve os pesos cíclicos de 2 a 9, da direita para a esquerda
+func cnpjWeights(n int) []int {
+	weights := make([]int, n)
+	for i := range weights {
+		weights[n-1-i] = 2 + i%8
+	}
+	return weights
+}
+
+func onlyDigits(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func allEqual(s string) bool {
+	return strings.Count(s, s[:1]) == len(s)
+}
@@ -0,0 +1,95 @@
+package brdoc
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestNormalizeCPF(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		valid bool
+	}{
+		{"529.982.247-25", "52998224725", true},
+		{"52998224725", "52998224725", true},
+		{"529.982.247-26", "", false},
+		{"111.111.111-11", "", false},
+		{"1234567890", "", false},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeCPF(tt.input)
+		if (err == nil) != tt.valid || got != tt.want {
+			t.Errorf("NormalizeCPF(%q) = %q, %v", tt.input, got, err)
+		}
+	}
+}
+
+func TestNormalizeCNPJ(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		valid bool
+	}{
+		{"11.222.333/0001-81", "11222333000181", true},
+		{"11.222.333/0001-82", "", false},
+		{"00.000.000/0000-00", "", false},
+		// CNPJ alfanumérico (exemplo publicado pela Receita Federal)
+		{"12.ABC.345/01DE-35", "12ABC34501DE35", true},
+		{"12abc34501de35", "12ABC34501DE35", true},
+		{"12.ABC.345/01DE-36", "", false},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeCNPJ(tt.input)
+		if (err == nil) != tt.valid || got != tt.want {
+			t.Errorf("NormalizeCNPJ(%q) = %q, %v", tt.input, got, err)
+		}
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		valid bool
+	}{
+		{"(11) 98765-4321", "+5511987654321", true},
+		{"+55 11 98765-4321", "+5511987654321", true},
+		{"5511987654321", "+5511987654321", true},
+		{"(21) 3456-7890", "+552134567890", true},
+		{"(11) 88765-4321", "", false},
+		{"(01) 98765-4321", "", false},
+		{"+1 415 555 0100", "+14155550100", true},
+		{"123", "", false},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizePhone(tt.input)
+		if (err == nil) != tt.valid || got != tt.want {
+			t.Errorf("NormalizePhone(%q) = %q, %v", tt.input, got, err)
+		}
+	}
+}
+
+func TestMasking(t *testing.T) {
+	cpf := TaxID("52998224725")
+	if got := fmt.Sprint(cpf); got != "***.982.247-**" {
+		t.Errorf("CPF mask = %q", got)
+	}
+	if got := TaxID("11222333000181").String(); got != "11.222.333/****-**" {
+		t.Errorf("CNPJ mask = %q", got)
+	}
+	if got := Phone("+5511987654321").String(); got != "+55 (11) *****-4321" {
+		t.Errorf("phone mask = %q", got)
+	}
+
+	out, _ := json.Marshal(struct {
+		TaxID TaxID `json:"taxId"`
+	}{cpf})
+	if string(out) != `{"taxId":"***.982.247-**"}` {
+		t.Errorf("JSON = %s", out)
+	}
+}
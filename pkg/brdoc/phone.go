@@ -0,0 +1,63 @@
+package brdoc
+
+import "strings"
+
+// NormalizePhone converte o telefone para E.164. Números sem código de país
+// são tratados como brasileiros (DDD + número); números estrangeiros devem
+// começar com "+".
+func NormalizePhone(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	international := strings.HasPrefix(s, "+") || strings.HasPrefix(s, "00")
+	digits := onlyDigits(s)
+	if strings.HasPrefix(s, "00") {
+		digits = digits[2:]
+	}
+
+	switch {
+	case international && strings.HasPrefix(digits, "55"):
+		digits = digits[2:]
+	case international:
+		if len(digits) < 8 || len(digits) > 15 || digits[0] == '0' {
+			return "", ErrInvalidPhone
+		}
+		return "+" + digits, nil
+	case len(digits) == 12 || len(digits) == 13:
+		if !strings.HasPrefix(digits, "55") {
+			return "", ErrInvalidPhone
+		}
+		digits = digits[2:]
+	}
+
+	if !validBrazilianNumber(digits) {
+		return "", ErrInvalidPhone
+	}
+	return "+55" + digits, nil
+}
+
+// MaskPhone mantém o código de país, o DDD e os quatro últimos dígitos
+func MaskPhone(phone string) string {
+	if len(phone) < 8 {
+		return strings.Repeat("*", len(phone))
+	}
+	if strings.HasPrefix(phone, "+55") && len(phone) >= 13 {
+		return "+55 (" + phone[3:5] + ") " + strings.Repeat("*", len(phone)-9) + "-" + phone[len(phone)-4:]
+	}
+	return phone[:3] + strings.Repeat("*", len(phone)-7) + phone[len(phone)-4:]
+}
+
+// validBrazilianNumber valida DDD + número: celulares têm 9 dígitos começando
+// com 9 e fixos têm 8 dígitos começando de 2 a 5.
+func validBrazilianNumber(digits string) bool {
+	if len(digits) != 10 && len(digits) != 11 {
+		return false
+	}
+	if digits[0] == '0' || digits[1] == '0' {
+		return false
+	}
+
+	number := digits[2:]
+	if len(number) == 9 {
+		return number[0] == '9'
+	}
+	return number[0] >= '2' && number[0] <= '5'
+}
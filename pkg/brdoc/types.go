@@ -0,0 +1,32 @@
+package brdoc
+
+import "encoding/json"
+
+// TaxID é um CPF ou CNPJ normalizado. Ao ser serializado em JSON ou impresso
+// (logs, fmt), o valor é mascarado; use Raw para obter o documento completo.
+type TaxID string
+
+func (t TaxID) Raw() string    { return string(t) }
+func (t TaxID) String() string { return MaskTaxID(string(t)) }
+
+func (t TaxID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// Formatted devolve o documento completo com pontuação
+func (t TaxID) Formatted() string {
+	if IsCPF(string(t)) {
+		return FormatCPF(string(t))
+	}
+	return FormatCNPJ(string(t))
+}
+
+// Phone é um telefone normalizado em E.164, mascarado em JSON e logs
+type Phone string
+
+func (p Phone) Raw() string    { return string(p) }
+func (p Phone) String() string { return MaskPhone(string(p)) }
+
+func (p Phone) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
@@ -0,0 +1,133 @@
+// Package calendar conhece os feriados nacionais brasileiros e calcula dias
+// úteis bancários. Além dos feriados nacionais, segue o calendário da ANBIMA:
+// segunda e terça de Carnaval e Corpus Christi também não são dias úteis.
+// Feriados estaduais e municipais não são considerados.
+package calendar
+
+import (
+	"sort"
+	"time"
+)
+
+type Holiday struct {
+	Date time.Time
+	Name string
+}
+
+// Easter devolve o domingo de Páscoa do ano (algoritmo de Meeus/Jones/Butcher)
+func Easter(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// Holidays lista, em ordem, os dias sem expediente bancário do ano
+func Holidays(year int) []Holiday {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	easter := Easter(year)
+
+	holidays := []Holiday{
+		{date(time.January, 1), "Confraternização Universal"},
+		{easter.AddDate(0, 0, -48), "Carnaval"},
+		{easter.AddDate(0, 0, -47), "Carnaval"},
+		{easter.AddDate(0, 0, -2), "Sexta-feira Santa"},
+		{date(time.April, 21), "Tiradentes"},
+		{date(time.May, 1), "Dia do Trabalho"},
+		{easter.AddDate(0, 0, 60), "Corpus Christi"},
+		{date(time.September, 7), "Independência do Brasil"},
+		{date(time.October, 12), "Nossa Senhora Aparecida"},
+		{date(time.November, 2), "Finados"},
+		{date(time.November, 15), "Proclamação da República"},
+		{date(time.December, 25), "Natal"},
+	}
+	// Feriado nacional desde a Lei 14.759/2023
+	if year >= 2024 {
+		holidays = append(holidays, Holiday{date(time.November, 20), "Dia Nacional de Zumbi e da Consciência Negra"})
+	}
+
+	sort.Slice(holidays, func(i, j int) bool { return holidays[i].Date.Before(holidays[j].Date) })
+	return holidays
+}
+
+// IsHoliday considera apenas o dia do calendário de t, ignorando hora e fuso
+func IsHoliday(t time.Time) bool {
+	day := dateOf(t)
+	for _, h := range Holidays(day.Year()) {
+		if h.Date.Equal(day) {
+			return true
+		}
+	}
+	return false
+}
+
+func IsBusinessDay(t time.Time) bool {
+	switch t.Weekday() {
+	case time.Saturday, time.Sunday:
+		return false
+	}
+	return !IsHoliday(t)
+}
+
+// NextBusinessDay devolve t, se for dia útil, ou o próximo dia útil
+func NextBusinessDay(t time.Time) time.Time {
+	day := dateOf(t)
+	for !IsBusinessDay(day) {
+		day = day.AddDate(0, 0, 1)
+	}
+	return day
+}
+
+// PreviousBusinessDay devolve t, se for dia útil, ou o dia útil anterior
+func PreviousBusinessDay(t time.Time) time.Time {
+	day := dateOf(t)
+	for !IsBusinessDay(day) {
+		day = day.AddDate(0, 0, -1)
+	}
+	return day
+}
+
+// NthBusinessDay devolve o n-ésimo dia útil do mês (1 = primeiro); valores
+// negativos contam do fim (-1 = último). Se o mês não tiver tantos dias úteis,
+// devolve o último (ou o primeiro, para n negativo).
+func NthBusinessDay(year int, month time.Month, n int) time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1)
+
+	if n >= 0 {
+		day := NextBusinessDay(first)
+		for i := 1; i < n; i++ {
+			next := NextBusinessDay(day.AddDate(0, 0, 1))
+			if next.After(last) {
+				break
+			}
+			day = next
+		}
+		return day
+	}
+
+	day := PreviousBusinessDay(last)
+	for i := -1; i > n; i-- {
+		prev := PreviousBusinessDay(day.AddDate(0, 0, -1))
+		if prev.Before(first) {
+			break
+		}
+		day = prev
+	}
+	return day
+}
+
+func dateOf(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
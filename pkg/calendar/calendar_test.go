@@ -0,0 +1,71 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestEaster(t *testing.T) {
+	tests := map[int]time.Time{
+		2024: date(2024, time.March, 31),
+		2025: date(2025, time.April, 20),
+		2026: date(2026, time.April, 5),
+		2038: date(2038, time.April, 25),
+	}
+	for year, want := range tests {
+		if got := Easter(year); !got.Equal(want) {
+			t.Errorf("Easter(%d) = %s, want %s", year, got.Format("2006-01-02"), want.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestBusinessDays(t *testing.T) {
+	tests := []struct {
+		day  time.Time
+		want bool
+	}{
+		{date(2026, time.February, 16), false}, // Carnaval
+		{date(2026, time.February, 18), true},  // Quarta-feira de Cinzas
+		{date(2026, time.April, 3), false},     // Sexta-feira Santa
+		{date(2026, time.June, 4), false},      // Corpus Christi
+		{date(2026, time.November, 20), false}, // Consciência Negra
+		{date(2023, time.November, 20), true},  // antes da Lei 14.759/2023
+		{date(2026, time.March, 7), false},     // sábado
+		{time.Date(2026, time.December, 25, 23, 0, 0, 0, time.FixedZone("BRT", -3*3600)), false},
+	}
+	for _, tt := range tests {
+		if got := IsBusinessDay(tt.day); got != tt.want {
+			t.Errorf("IsBusinessDay(%s) = %v", tt.day.Format("2006-01-02"), got)
+		}
+	}
+
+	if got := NextBusinessDay(date(2026, time.February, 14)); !got.Equal(date(2026, time.February, 18)) {
+		t.Errorf("NextBusinessDay after Carnaval = %s", got.Format("2006-01-02"))
+	}
+	if got := PreviousBusinessDay(date(2026, time.April, 5)); !got.Equal(date(2026, time.April, 2)) {
+		t.Errorf("PreviousBusinessDay before Easter = %s", got.Format("2006-01-02"))
+	}
+}
+
+func TestNthBusinessDay(t *testing.T) {
+	tests := []struct {
+		month time.Month
+		n     int
+		want  time.Time
+	}{
+		{time.January, 1, date(2026, time.January, 2)},
+		{time.January, 5, date(2026, time.January, 8)},
+		{time.February, -1, date(2026, time.February, 27)},
+		{time.April, -1, date(2026, time.April, 30)},
+		{time.February, 40, date(2026, time.February, 27)},
+	}
+	for _, tt := range tests {
+		if got := NthBusinessDay(2026, tt.month, tt.n); !got.Equal(tt.want) {
+			t.Errorf("NthBusinessDay(%s, %d) = %s, want %s", tt.month, tt.n, got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+		}
+	}
+}
@@ -0,0 +1,328 @@
+package cnab
+
+import (
+	"fmt"
+	"strings"
+)
+
+// layout240 guarda o que varia entre bancos no CNAB 240. O restante segue o
+// padrão FEBRABAN, adotado por todos para os segmentos T/U (cobrança) e A/J
+// (pagamentos); bancos fora da tabela usam as posições do padrão.
+type layout240 struct {
+	bankName  string
+	ourNumber span
+}
+
+var febraban240 = layout240{ourNumber: span{38, 57}}
+
+var layouts240 = map[string]layout240{
+	"001": {bankName: "BANCO DO BRASIL", ourNumber: span{38, 57}},
+	"033": {bankName: "SANTANDER", ourNumber: span{41, 53}},
+	"104": {bankName: "CAIXA ECONOMICA FEDERAL", ourNumber: span{38, 57}},
+	"237": {bankName: "BRADESCO", ourNumber: span{38, 57}},
+	"341": {bankName: "ITAU", ourNumber: span{38, 57}},
+	"748": {bankName: "SICREDI", ourNumber: span{38, 57}},
+	"756": {bankName: "SICOOB", ourNumber: span{38, 57}},
+}
+
+// Códigos de movimento do retorno de cobrança (segmento T, posições 16-17)
+var collectionOccurrences240 = map[string]occurrence{
+	"02": {Informational, "Entrada confirmada"},
+	"03": {Rejected, "Entrada rejeitada"},
+	"04": {Informational, "Transferência de carteira/entrada"},
+	"05": {Informational, "Transferência de carteira/baixa"},
+	"06": {Settled, "Liquidação"},
+	"07": {Informational, "Confirmação do recebimento da instrução de desconto"},
+	"09": {Informational, "Baixa"},
+	"11": {Informational, "Títulos em carteira (em ser)"},
+	"12": {Informational, "Confirmação do recebimento da instrução de abatimento"},
+	"13": {Informational, "Confirmação do cancelamento do abatimento"},
+	"14": {Informational, "Confirmação da alteração do vencimento"},
+	"17": {Settled, "Liquidação após baixa ou de título não registrado"},
+	"19": {Informational, "Confirmação do recebimento da instrução de protesto"},
+	"20": {Informational, "Confirmação do recebimento da instrução de sustação de protesto"},
+	"23": {Informational, "Remessa a cartório"},
+	"24": {Informational, "Retirada de cartório e manutenção em carteira"},
+	"25": {Informational, "Protestado e baixado"},
+	"26": {Rejected, "Instrução rejeitada"},
+	"27": {Informational, "Confirmação do pedido de alteração de outros dados"},
+	"28": {Charged, "Débito de tarifas/custas"},
+	"30": {Rejected, "Alteração de dados rejeitada"},
+}
+
+// Ocorrências de pagamentos (segmentos A e J, posições 231-240) que não são
+// recusa: "00" efetivou o pagamento; as demais confirmam o agendamento
+var paymentOccurrences240 = map[string]occurrence{
+	"00": {Settled, "Pagamento efetuado"},
+	"BD": {Informational, "Pagamento agendado"},
+	"BE": {Informational, "Pagamento agendado com forma alterada"},
+	"BF": {Informational, "Pagamento agendado com valor alterado"},
+}
+
+const (
+	recordFileHeader  = '0'
+	recordBatchHeader = '1'
+	recordDetail      = '3'
+	recordBatchTrail  = '5'
+	recordFileTrailer = '9'
+)
+
+func parse240(lines []line) (*File, error) {
+	for i := range lines {
+		if err := lines[i].pad(240); err != nil {
+			return nil, err
+		}
+	}
+
+	header, trailer := lines[0], lines[len(lines)-1]
+	if recordType240(header) != recordFileHeader {
+		return nil, fmt.Errorf("%w: line %d: first record must be the file header", ErrInvalidCNAB, header.number)
+	}
+	if recordType240(trailer) != recordFileTrailer {
+		return nil, fmt.Errorf("%w: line %d: last record must be the file trailer", ErrInvalidCNAB, trailer.number)
+	}
+	if header.str(span{143, 143}) != "2" {
+		return nil, fmt.Errorf("%w: not a return file (arquivo de retorno)", ErrInvalidCNAB)
+	}
+
+	bank := header.str(span{1, 3})
+	layout, ok := layouts240[bank]
+	if !ok {
+		layout = febraban240
+	}
+
+	h := fields{line: header}
+	file := &File{
+		Format:          CNAB240,
+		Bank:            bank,
+		BankName:        firstNonEmpty(header.str(span{103, 132}), layout.bankName),
+		Company:         header.str(span{73, 102}),
+		CompanyDocument: header.str(span{19, 32}),
+		Generated:       h.date(span{144, 151}),
+	}
+	file.Sequence, _ = header.int(span{158, 163})
+
+	if err := validateBatches240(lines); err != nil {
+		return nil, err
+	}
+
+	p := parser240{layout: layout, file: file}
+	for _, l := range lines[1 : len(lines)-1] {
+		if recordType240(l) == recordDetail {
+			p.detail(l)
+		}
+	}
+	p.flush()
+	return file, nil
+}
+
+func recordType240(l line) rune {
+	return l.text[7]
+}
+
+// validateBatches240 confere cada lote com seu trailer e o trailer do arquivo
+// com o total de lotes e de registros
+func validateBatches240(lines []line) error {
+	batches, inBatch, count := 0, false, 0
+
+	for _, l := range lines[1 : len(lines)-1] {
+		switch recordType240(l) {
+		case recordBatchHeader:
+			if inBatch {
+				return fmt.Errorf("%w: line %d: batch header before the previous batch trailer", ErrInvalidCNAB, l.number)
+			}
+			inBatch, count = true, 1
+			batches++
+		case recordBatchTrail:
+			if !inBatch {
+				return fmt.Errorf("%w: line %d: batch trailer without a batch header", ErrInvalidCNAB, l.number)
+			}
+			count++
+			declared, err := l.int(span{18, 23})
+			if err != nil {
+				return fmt.Errorf("%w: line %d: %v", ErrInvalidCNAB, l.number, err)
+			}
+			if declared != count {
+				return fmt.Errorf("%w: line %d: batch trailer declares %d records, found %d", ErrInvalidCNAB, l.number, declared, count)
+			}
+			inBatch = false
+		default:
+			if !inBatch {
+				return fmt.Errorf("%w: line %d: record outside a batch", ErrInvalidCNAB, l.number)
+			}
+			count++
+		}
+	}
+	if inBatch {
+		return fmt.Errorf("%w: last batch has no trailer", ErrInvalidCNAB)
+	}
+
+	trailer := lines[len(lines)-1]
+	if declared, err := trailer.int(span{18, 23}); err != nil || declared != batches {
+		return fmt.Errorf("%w: file trailer declares %s batches, found %d", ErrInvalidCNAB, trailer.str(span{18, 23}), batches)
+	}
+	if declared, err := trailer.int(span{24, 29}); err != nil || declared != len(lines) {
+		return fmt.Errorf("%w: file trailer declares %s records, found %d", ErrInvalidCNAB, trailer.str(span{24, 29}), len(lines))
+	}
+	return nil
+}
+
+// parser240 junta os segmentos de um mesmo título: T seguido de U, ou A/J
+// seguidos dos opcionais B e J-52
+type parser240 struct {
+	layout  layout240
+	file    *File
+	pending *Record
+}
+
+func (p *parser240) flush() {
+	if p.pending != nil {
+		p.file.Records = append(p.file.Records, *p.pending)
+		p.pending = nil
+	}
+}
+
+func (p *parser240) detail(l line) {
+	f := &fields{line: l}
+	segment := strings.ToUpper(l.str(span{14, 14}))
+
+	switch segment {
+	case "T":
+		p.flush()
+		p.pending = p.segmentT(f)
+	case "U":
+		if p.pending == nil || p.pending.Kind != Collection {
+			p.file.Records = append(p.file.Records, Record{
+				Line: l.number, Kind: Collection, Status: Unknown,
+				Err: fmt.Errorf("segment U without a preceding segment T"),
+			})
+			return
+		}
+		p.segmentU(f, p.pending)
+	case "A":
+		p.flush()
+		p.pending = p.segmentA(f)
+	case "B":
+		if p.pending != nil && p.pending.Kind == Payment && p.pending.CounterpartyDocument == "" {
+			p.pending.CounterpartyDocument = l.str(span{19, 32})
+		}
+	case "J":
+		if l.str(span{18, 19}) == "52" {
+			// J-52 traz os documentos de pagador e beneficiário do boleto pago
+			if p.pending != nil && p.pending.Kind == Payment {
+				p.pending.CounterpartyDocument = l.str(span{77, 91})
+			}
+			return
+		}
+		p.flush()
+		p.pending = p.segmentJ(f)
+	}
+}
+
+func (p *parser240) segmentT(f *fields) *Record {
+	r := &Record{
+		Line:                 f.number,
+		Kind:                 Collection,
+		Occurrence:           f.str(span{16, 17}),
+		OurNumber:            f.str(p.layout.ourNumber),
+		DocumentNumber:       f.str(span{59, 73}),
+		DueDate:              f.date(span{74, 81}),
+		FaceAmount:           f.amount(span{82, 96}),
+		CompanyReference:     f.str(span{106, 130}),
+		CounterpartyDocument: f.str(span{134, 148}),
+		Counterparty:         f.str(span{149, 188}),
+		Fee:                  f.amount(span{199, 213}),
+		RejectionCodes:       f.codes(span{214, 223}),
+	}
+	r.Status, r.Description = describe(collectionOccurrences240, r.Occurrence)
+	r.Err = f.err
+	return r
+}
+
+func (p *parser240) segmentU(f *fields, r *Record) {
+	r.Interest = f.amount(span{18, 32})
+	r.Discount = f.amount(span{33, 47})
+	r.Rebate = f.amount(span{48, 62})
+	r.PaidAmount = f.amount(span{78, 92})
+	r.NetAmount = f.amount(span{93, 107})
+	r.OccurredAt = f.date(span{138, 145})
+	r.CreditDate = f.date(span{146, 153})
+	if r.Err == nil {
+		r.Err = f.err
+	}
+}
+
+func (p *parser240) segmentA(f *fields) *Record {
+	r := &Record{
+		Line:           f.number,
+		Kind:           Payment,
+		Counterparty:   f.str(span{44, 73}),
+		DocumentNumber: f.str(span{74, 93}),
+		DueDate:        f.date(span{94, 101}),
+		FaceAmount:     f.amount(span{120, 134}),
+		OurNumber:      f.str(span{135, 154}),
+		OccurredAt:     f.date(span{155, 162}),
+		PaidAmount:     f.amount(span{163, 177}),
+	}
+	if r.PaidAmount.IsZero() {
+		r.PaidAmount = r.FaceAmount
+	}
+	r.NetAmount = r.PaidAmount
+	r.CreditDate = r.OccurredAt
+	paymentStatus(f, r)
+	return r
+}
+
+func (p *parser240) segmentJ(f *fields) *Record {
+	r := &Record{
+		Line:           f.number,
+		Kind:           Payment,
+		Barcode:        f.str(span{18, 61}),
+		Counterparty:   f.str(span{62, 91}),
+		DueDate:        f.date(span{92, 99}),
+		FaceAmount:     f.amount(span{100, 114}),
+		Discount:       f.amount(span{115, 129}),
+		Interest:       f.amount(span{130, 144}),
+		OccurredAt:     f.date(span{145, 152}),
+		PaidAmount:     f.amount(span{153, 167}),
+		DocumentNumber: f.str(span{183, 202}),
+		OurNumber:      f.str(span{203, 222}),
+	}
+	r.NetAmount = r.PaidAmount
+	r.CreditDate = r.OccurredAt
+	paymentStatus(f, r)
+	return r
+}
+
+// paymentStatus lê até cinco ocorrências; qualquer código fora da tabela é
+// motivo de recusa
+func paymentStatus(f *fields, r *Record) {
+	raw := f.str(span{231, 240})
+	var codes []string
+	for i := 0; i+2 <= len(raw); i += 2 {
+		if code := strings.TrimSpace(raw[i : i+2]); code != "" {
+			codes = append(codes, code)
+		}
+	}
+
+	r.Status, r.Description = Unknown, "no occurrence informed"
+	if len(codes) > 0 {
+		r.Occurrence = codes[0]
+		if o, ok := paymentOccurrences240[codes[0]]; ok {
+			r.Status, r.Description = o.status, o.description
+		} else {
+			r.Status, r.Description = Rejected, "Pagamento rejeitado"
+			r.RejectionCodes = codes
+		}
+	}
+	r.Err = f.err
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,193 @@
+package cnab
+
+import "fmt"
+
+// layout400 descreve o detalhe (tipo 1) do retorno de cobrança de um banco.
+// Campos que o banco não informa ficam com span vazio.
+type layout400 struct {
+	bankName         string
+	ourNumber        span
+	companyReference span
+	occurrence       span
+	occurredAt       span
+	documentNumber   span
+	dueDate          span
+	faceAmount       span
+	fee              span
+	rebate           span
+	discount         span
+	paidAmount       span
+	interest         span
+	creditDate       span
+	payerName        span
+	rejections       span
+	occurrences      map[string]occurrence
+}
+
+var layouts400 = map[string]layout400{
+	"237": {
+		bankName:         "BRADESCO",
+		ourNumber:        span{71, 82},
+		companyReference: span{38, 62},
+		occurrence:       span{109, 110},
+		occurredAt:       span{111, 116},
+		documentNumber:   span{117, 126},
+		dueDate:          span{147, 152},
+		faceAmount:       span{153, 165},
+		fee:              span{176, 188},
+		rebate:           span{228, 240},
+		discount:         span{241, 253},
+		paidAmount:       span{254, 266},
+		interest:         span{267, 279},
+		creditDate:       span{296, 301},
+		rejections:       span{319, 328},
+		occurrences: map[string]occurrence{
+			"02": {Informational, "Entrada confirmada"},
+			"03": {Rejected, "Entrada rejeitada"},
+			"06": {Settled, "Liquidação normal"},
+			"09": {Informational, "Baixado automaticamente via arquivo"},
+			"10": {Informational, "Baixado conforme instruções da agência"},
+			"11": {Informational, "Em ser"},
+			"12": {Informational, "Abatimento concedido"},
+			"13": {Informational, "Abatimento cancelado"},
+			"14": {Informational, "Vencimento alterado"},
+			"15": {Settled, "Liquidação em cartório"},
+			"16": {Settled, "Título pago em cheque vinculado"},
+			"17": {Settled, "Liquidação após baixa ou título não registrado"},
+			"19": {Informational, "Confirmação do recebimento da instrução de protesto"},
+			"20": {Informational, "Confirmação do recebimento da instrução de sustação de protesto"},
+			"22": {Informational, "Título com pagamento cancelado"},
+			"23": {Informational, "Entrada do título em cartório"},
+			"24": {Rejected, "Entrada rejeitada por CEP irregular"},
+			"27": {Rejected, "Baixa rejeitada"},
+			"28": {Charged, "Débito de tarifas/custas"},
+			"30": {Rejected, "Alteração de outros dados rejeitada"},
+			"32": {Rejected, "Instrução rejeitada"},
+			"33": {Informational, "Confirmação do pedido de alteração de outros dados"},
+		},
+	},
+	"341": {
+		bankName:         "ITAU",
+		ourNumber:        span{63, 70},
+		companyReference: span{38, 62},
+		occurrence:       span{109, 110},
+		occurredAt:       span{111, 116},
+		documentNumber:   span{117, 126},
+		dueDate:          span{147, 152},
+		faceAmount:       span{153, 165},
+		fee:              span{176, 188},
+		rebate:           span{228, 240},
+		discount:         span{241, 253},
+		paidAmount:       span{254, 266},
+		interest:         span{267, 279},
+		creditDate:       span{296, 301},
+		payerName:        span{325, 354},
+		rejections:       span{378, 385},
+		occurrences: map[string]occurrence{
+			"02": {Informational, "Entrada confirmada"},
+			"03": {Rejected, "Entrada rejeitada"},
+			"04": {Informational, "Alteração de dados - nova entrada ou alteração/exclusão de dados acatada"},
+			"05": {Informational, "Alteração de dados - baixa"},
+			"06": {Settled, "Liquidação normal"},
+			"07": {Settled, "Liquidação parcial"},
+			"08": {Settled, "Liquidação em cartório"},
+			"09": {Informational, "Baixa simples"},
+			"10": {Informational, "Baixa por ter sido liquidado"},
+			"11": {Informational, "Em ser"},
+			"12": {Informational, "Abatimento concedido"},
+			"13": {Informational, "Abatimento cancelado"},
+			"14": {Informational, "Vencimento alterado"},
+			"15": {Rejected, "Baixas rejeitadas"},
+			"16": {Rejected, "Instruções rejeitadas"},
+			"17": {Rejected, "Alteração/exclusão de dados rejeitados"},
+			"19": {Informational, "Confirma recebimento de instrução de protesto"},
+			"20": {Informational, "Confirma recebimento de instrução de sustação de protesto"},
+			"32": {Informational, "Baixa por ter sido protestado"},
+		},
+	},
+}
+
+const (
+	record400Header  = '0'
+	record400Detail  = '1'
+	record400Trailer = '9'
+)
+
+func parse400(lines []line) (*File, error) {
+	for i := range lines {
+		if err := lines[i].pad(400); err != nil {
+			return nil, err
+		}
+	}
+
+	header, trailer := lines[0], lines[len(lines)-1]
+	if header.text[0] != record400Header {
+		return nil, fmt.Errorf("%w: line %d: first record must be the file header", ErrInvalidCNAB, header.number)
+	}
+	if trailer.text[0] != record400Trailer {
+		return nil, fmt.Errorf("%w: line %d: last record must be the file trailer", ErrInvalidCNAB, trailer.number)
+	}
+	if header.str(span{2, 2}) != "2" {
+		return nil, fmt.Errorf("%w: not a return file (arquivo de retorno)", ErrInvalidCNAB)
+	}
+
+	bank := header.str(span{77, 79})
+	layout, ok := layouts400[bank]
+	if !ok {
+		return nil, fmt.Errorf("%w: CNAB 400 for bank %s", ErrUnsupportedBank, bank)
+	}
+
+	// O sequencial das posições 395-400 denuncia linhas perdidas ou duplicadas
+	for i, l := range lines {
+		seq, err := l.int(span{395, 400})
+		if err != nil || seq != i+1 {
+			return nil, fmt.Errorf("%w: line %d: sequence number %q, expected %d", ErrInvalidCNAB, l.number, l.str(span{395, 400}), i+1)
+		}
+	}
+
+	h := fields{line: header}
+	file := &File{
+		Format:    CNAB400,
+		Bank:      bank,
+		BankName:  firstNonEmpty(header.str(span{80, 94}), layout.bankName),
+		Company:   header.str(span{47, 76}),
+		Generated: h.date(span{95, 100}),
+	}
+	file.Sequence, _ = header.int(span{109, 113})
+
+	for _, l := range lines[1 : len(lines)-1] {
+		if l.text[0] != record400Detail {
+			continue
+		}
+		file.Records = append(file.Records, layout.detail(l))
+	}
+	return file, nil
+}
+
+func (layout layout400) detail(l line) Record {
+	f := &fields{line: l}
+	r := Record{
+		Line:             l.number,
+		Kind:             Collection,
+		Occurrence:       f.str(layout.occurrence),
+		OurNumber:        f.str(layout.ourNumber),
+		DocumentNumber:   f.str(layout.documentNumber),
+		CompanyReference: f.str(layout.companyReference),
+		Counterparty:     f.str(layout.payerName),
+		DueDate:          f.date(layout.dueDate),
+		OccurredAt:       f.date(layout.occurredAt),
+		CreditDate:       f.date(layout.creditDate),
+		FaceAmount:       f.amount(layout.faceAmount),
+		PaidAmount:       f.amount(layout.paidAmount),
+		Interest:         f.amount(layout.interest),
+		Discount:         f.amount(layout.discount),
+		Rebate:           f.amount(layout.rebate),
+		Fee:              f.amount(layout.fee),
+		RejectionCodes:   f.codes(layout.rejections),
+	}
+	// O CNAB 400 não traz o líquido; a tarifa é debitada à parte (ocorrência 28)
+	r.NetAmount = r.PaidAmount
+	r.Status, r.Description = describe(layout.occurrences, r.Occurrence)
+	r.Err = f.err
+	return r
+}
@@ -0,0 +1,166 @@
+package cnab
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// record monta uma linha de largura fixa a partir de campos posicionais
+type record struct {
+	text []rune
+}
+
+func newRecord(width int) *record {
+	return &record{text: []rune(strings.Repeat(" ", width))}
+}
+
+func (r *record) set(start int, value string) *record {
+	copy(r.text[start-1:], []rune(value))
+	return r
+}
+
+func (r *record) num(start, end int, n int64) *record {
+	return r.set(start, fmt.Sprintf("%0*d", end-start+1, n))
+}
+
+func (r *record) String() string { return string(r.text) }
+
+func file240(details ...*record) string {
+	header := newRecord(240).set(1, "341").set(8, "0").set(19, "12345678000199").
+		set(73, "EMPRESA LTDA").set(143, "2").set(144, "10032026").num(158, 163, 42)
+	batchHeader := newRecord(240).set(1, "341").set(8, "1")
+	lines := []string{header.String(), batchHeader.String()}
+	for _, d := range details {
+		lines = append(lines, d.String())
+	}
+	batchTrailer := newRecord(240).set(1, "341").set(8, "5").num(18, 23, int64(len(details)+2))
+	trailer := newRecord(240).set(1, "341").set(8, "9").num(18, 23, 1).num(24, 29, int64(len(lines)+2))
+	lines = append(lines, batchTrailer.String(), trailer.String())
+	return strings.Join(lines, "\r\n") + "\r\n"
+}
+
+func segment(kind string) *record {
+	return newRecord(240).set(1, "341").set(8, "3").set(14, kind)
+}
+
+func TestParse240Collection(t *testing.T) {
+	data := file240(
+		segment("T").set(16, "06").set(38, "00000000000000012345").set(59, "NF-100").
+			set(74, "05032026").num(82, 96, 150000).set(134, "11144477735").set(149, "JOSE DA SILVA").num(199, 213, 250),
+		segment("U").num(18, 32, 1000).num(78, 92, 151000).num(93, 107, 150750).
+			set(138, "09032026").set(146, "10032026"),
+		segment("T").set(16, "03").set(38, "00000000000000012346").set(214, "0845"),
+		segment("U"),
+	)
+
+	f, err := Parse(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if f.Format != CNAB240 || f.Bank != "341" || f.BankName != "ITAU" || f.Sequence != 42 {
+		t.Fatalf("header = %+v", f)
+	}
+	if len(f.Records) != 2 {
+		t.Fatalf("records = %d, want 2", len(f.Records))
+	}
+
+	settled := f.Records[0]
+	if settled.Err != nil || settled.Status != Settled || settled.Kind != Collection {
+		t.Fatalf("settled = %+v", settled)
+	}
+	if settled.OurNumber != "00000000000000012345" || settled.DocumentNumber != "NF-100" || settled.Counterparty != "JOSE DA SILVA" {
+		t.Errorf("settled identification = %+v", settled)
+	}
+	if settled.PaidAmount.Decimal() != "1510.00" || settled.NetAmount.Decimal() != "1507.50" || settled.Fee.Decimal() != "2.50" {
+		t.Errorf("amounts = %s %s %s", settled.PaidAmount.Decimal(), settled.NetAmount.Decimal(), settled.Fee.Decimal())
+	}
+	if !settled.CreditDate.Equal(time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("credit date = %s", settled.CreditDate)
+	}
+
+	rejected := f.Records[1]
+	if rejected.Status != Rejected || strings.Join(rejected.RejectionCodes, ",") != "08,45" {
+		t.Errorf("rejected = %+v", rejected)
+	}
+}
+
+func TestParse240Payment(t *testing.T) {
+	data := file240(
+		segment("J").set(18, "34191790010104351004791020150008291070026000").set(62, "FORNECEDOR SA").
+			set(92, "15032026").num(100, 114, 20000).set(145, "15032026").num(153, 167, 20000).
+			set(183, "PAG-1").set(231, "00"),
+		segment("J").set(18, "52").set(77, "00000098765432000198"),
+		segment("A").set(44, "PRESTADOR").num(120, 134, 5000).set(155, "16032026").set(231, "AB"),
+	)
+
+	f, err := Parse(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(f.Records) != 2 {
+		t.Fatalf("records = %d, want 2", len(f.Records))
+	}
+	if j := f.Records[0]; j.Status != Settled || j.Kind != Payment || j.PaidAmount.Decimal() != "200.00" || j.CounterpartyDocument == "" {
+		t.Errorf("J = %+v", j)
+	}
+	if a := f.Records[1]; a.Status != Rejected || a.PaidAmount.Decimal() != "50.00" || strings.Join(a.RejectionCodes, ",") != "AB" {
+		t.Errorf("A = %+v", a)
+	}
+}
+
+func TestParse240TrailerMismatch(t *testing.T) {
+	data := file240(segment("T").set(16, "06"), segment("U"))
+	lines := strings.Split(data, "\r\n")
+	// Descarta o segmento U: o trailer do lote passa a contar um registro a mais
+	data = strings.Join(append(lines[:3:3], lines[4:]...), "\r\n")
+
+	if _, err := Parse(strings.NewReader(data)); !errors.Is(err, ErrInvalidCNAB) {
+		t.Fatalf("err = %v, want ErrInvalidCNAB", err)
+	}
+}
+
+func file400(bank string, details ...*record) string {
+	header := newRecord(400).set(1, "0").set(2, "2").set(47, "EMPRESA LTDA").set(77, bank).
+		set(95, "100326").num(395, 400, 1)
+	lines := []string{header.String()}
+	for i, d := range details {
+		lines = append(lines, d.num(395, 400, int64(i+2)).String())
+	}
+	lines = append(lines, newRecord(400).set(1, "9").num(395, 400, int64(len(lines)+1)).String())
+	return strings.Join(lines, "\n")
+}
+
+func TestParse400Itau(t *testing.T) {
+	data := file400("341",
+		newRecord(400).set(1, "1").set(63, "12345678").set(109, "06").set(111, "090326").
+			set(117, "NF-200").num(153, 165, 9990).num(254, 266, 9990).set(296, "100326").set(325, "MARIA SOUZA"),
+		newRecord(400).set(1, "1").set(63, "12345679").set(109, "99"),
+	)
+
+	f, err := Parse(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if f.Format != CNAB400 || f.BankName != "ITAU" || len(f.Records) != 2 {
+		t.Fatalf("file = %+v", f)
+	}
+	r := f.Records[0]
+	if r.Status != Settled || r.OurNumber != "12345678" || r.NetAmount.Decimal() != "99.90" || r.Counterparty != "MARIA SOUZA" {
+		t.Errorf("record = %+v", r)
+	}
+	if f.Records[1].Status != Unknown {
+		t.Errorf("unknown occurrence = %+v", f.Records[1])
+	}
+
+	broken := strings.Replace(data, "000002", "000003", 1)
+	if _, err := Parse(strings.NewReader(broken)); !errors.Is(err, ErrInvalidCNAB) {
+		t.Errorf("sequence err = %v, want ErrInvalidCNAB", err)
+	}
+
+	if _, err := Parse(strings.NewReader(file400("999"))); !errors.Is(err, ErrUnsupportedBank) {
+		t.Errorf("bank err = %v, want ErrUnsupportedBank", err)
+	}
+}
@@ -22,6 +22,30 @@ type Config struct {
 	MarketAPI struct {
 		Key string
 	}
+	SMTP struct {
+		Host     string
+		Port     string
+		User     string
+		Password string
+		From     string
+	}
+	App struct {
+		BaseURL string
+	}
+	Storage struct {
+		Driver        string
+		LocalPath     string
+		PublicURL     string
+		SigningSecret string
+		S3Endpoint    string
+		S3Region      string
+		S3Bucket      string
+		S3AccessKey   string
+		S3SecretKey   string
+	}
+	Security struct {
+		EventRetentionDays int
+	}
 	Environment string
 }
 
@@ -58,6 +82,31 @@ func Load() (*Config, error) {
 	// Market API configs
 	config.MarketAPI.Key = viper.GetString("MARKET_API_KEY")
 
+	// SMTP configs
+	config.SMTP.Host = viper.GetString("SMTP_HOST")
+	config.SMTP.Port = viper.GetString("SMTP_PORT")
+	config.SMTP.User = viper.GetString("SMTP_USER")
+	config.SMTP.Password = viper.GetString("SMTP_PASSWORD")
+	config.SMTP.From = viper.GetString("SMTP_FROM")
+
+	// App configs
+	config.App.BaseURL = viper.GetString("APP_BASE_URL")
+
+	// Storage configs
+	config.Storage.Driver = viper.GetString("STORAGE_DRIVER")
+	config.Storage.LocalPath = viper.GetString("STORAGE_LOCAL_PATH")
+	config.Storage.PublicURL = viper.GetString("STORAGE_PUBLIC_URL")
+	config.Storage.SigningSecret = viper.GetString("STORAGE_SIGNING_SECRET")
+	config.Storage.S3Endpoint = viper.GetString("STORAGE_S3_ENDPOINT")
+	config.Storage.S3Region = viper.GetString("STORAGE_S3_REGION")
+	config.Storage.S3Bucket = viper.GetString("STORAGE_S3_BUCKET")
+	config.Storage.S3AccessKey = viper.GetString("STORAGE_S3_ACCESS_KEY")
+	config.Storage.S3SecretKey = viper.GetString("STORAGE_S3_SECRET_KEY")
+
+	// Security configs
+	viper.SetDefault("SECURITY_EVENTS_RETENTION_DAYS", 180)
+	config.Security.EventRetentionDays = viper.GetInt("SECURITY_EVENTS_RETENTION_DAYS")
+
 	config.Environment = viper.GetString("ENVIRONMENT")
 
 	return config, nil
@@ -0,0 +1,401 @@
+// Package csvimport lê extratos em CSV exportados por bancos ou planilhas. O
+// arquivo é lido em fluxo, registro a registro; só o início (até 64 KB) é
+// examinado para detectar a codificação (UTF-8 ou Windows-1252) e o
+// delimitador, quando não informados.
+package csvimport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"finanvilla/pkg/money"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+var ErrInvalidMapping = errors.New("invalid column mapping")
+
+type Encoding string
+
+const (
+	UTF8        Encoding = "UTF-8"
+	Windows1252 Encoding = "WINDOWS-1252"
+)
+
+func (e Encoding) IsValid() bool {
+	return e == UTF8 || e == Windows1252
+}
+
+// Delimiters são os delimitadores reconhecidos, na ordem de preferência em caso de empate
+var Delimiters = []rune{';', ',', '\t', '|'}
+
+const sniffSize = 64 << 10
+
+var bom = []byte("\xef\xbb\xbf")
+
+// Dialect descreve o arquivo; campos zerados são detectados por NewReader
+type Dialect struct {
+	Encoding  Encoding
+	Delimiter rune
+}
+
+// Record é um registro do arquivo. Err indica um registro malformado; a
+// leitura pode continuar a partir do próximo.
+type Record struct {
+	Line   int
+	Fields []string
+	Err    error
+}
+
+type Reader struct {
+	csv     *csv.Reader
+	dialect Dialect
+}
+
+func NewReader(r io.Reader, d Dialect) (*Reader, error) {
+	br := bufio.NewReaderSize(r, sniffSize)
+	sample, err := br.Peek(sniffSize)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	full := len(sample) == sniffSize
+
+	if bytes.HasPrefix(sample, bom) {
+		if _, err := br.Discard(len(bom)); err != nil {
+			return nil, err
+		}
+		sample = sample[len(bom):]
+		if d.Encoding == "" {
+			d.Encoding = UTF8
+		}
+	}
+	if full {
+		// A última linha da amostra pode estar cortada
+		if i := bytes.LastIndexByte(sample, '\n'); i > 0 {
+			sample = sample[:i]
+		}
+	}
+
+	if d.Encoding == "" {
+		d.Encoding = UTF8
+		if !utf8.Valid(sample) {
+			d.Encoding = Windows1252
+		}
+	}
+
+	var src io.Reader = br
+	if d.Encoding == Windows1252 {
+		src = charmap.Windows1252.NewDecoder().Reader(br)
+		if sample, err = charmap.Windows1252.NewDecoder().Bytes(sample); err != nil {
+			return nil, err
+		}
+	}
+	if d.Delimiter == 0 {
+		d.Delimiter = detectDelimiter(string(sample))
+	}
+
+	c := csv.NewReader(src)
+	c.Comma = d.Delimiter
+	c.FieldsPerRecord = -1
+	c.LazyQuotes = true
+	c.TrimLeadingSpace = true
+	return &Reader{csv: c, dialect: d}, nil
+}
+
+// Dialect devolve a codificação e o delimitador em uso, já detectados
+func (r *Reader) Dialect() Dialect {
+	return r.dialect
+}
+
+// Read devolve o próximo registro não vazio ou io.EOF ao fim do arquivo
+func (r *Reader) Read() (Record, error) {
+	fields, err := r.csv.Read()
+
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return Record{Line: parseErr.StartLine, Err: parseErr.Err}, nil
+	}
+	if err != nil {
+		return Record{}, err
+	}
+	line, _ := r.csv.FieldPos(0)
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+	return Record{Line: line, Fields: fields}, nil
+}
+
+// detectDelimiter escolhe o delimitador que produz a mesma quantidade de
+// campos (mais de um) no maior número de linhas da amostra
+func detectDelimiter(sample string) rune {
+	best, bestLines, bestFields := Delimiters[0], 0, 0
+	for _, delimiter := range Delimiters {
+		c := csv.NewReader(strings.NewReader(sample))
+		c.Comma = delimiter
+		c.FieldsPerRecord = -1
+		c.LazyQuotes = true
+
+		counts := make(map[int]int)
+		for i := 0; i < 50; i++ {
+			fields, err := c.Read()
+			if err == io.EOF {
+				break
+			}
+			if err == nil && len(fields) > 1 {
+				counts[len(fields)]++
+			}
+		}
+
+		for fields, lines := range counts {
+			if lines > bestLines || (lines == bestLines && fields > bestFields) {
+				best, bestLines, bestFields = delimiter, lines, fields
+			}
+		}
+	}
+	return best
+}
+
+// Mapping diz onde está cada informação. As colunas são o nome no cabeçalho
+// (sem diferenciar maiúsculas) ou o número da coluna, a partir de 1. O valor
+// vem de Amount ou das colunas separadas Debit/Credit.
+type Mapping struct {
+	HasHeader   bool
+	SkipRows    int // linhas ignoradas antes do cabeçalho ou dos dados
+	Date        string
+	Description string
+	Amount      string
+	Debit       string
+	Credit      string
+	Payee       string
+	Memo        string
+	ExternalID  string
+	DateLayout  string // layout Go, ex.: 02/01/2006
+	Decimal     rune
+	InvertSign  bool // para extratos de cartão em que compras vêm positivas
+}
+
+// Row é um registro interpretado. Amount não tem moeda: positivo entra na conta.
+type Row struct {
+	Date        time.Time
+	Description string
+	Amount      money.Money
+	Payee       string
+	Memo        string
+	ExternalID  string
+}
+
+// Columns é o mapeamento resolvido para as posições do arquivo
+type Columns struct {
+	mapping                                  Mapping
+	date, description, amount, debit, credit int
+	payee, memo, externalID                  int
+}
+
+// Columns resolve o mapeamento contra o cabeçalho (nil se o arquivo não tiver)
+func (m Mapping) Columns(header []string) (*Columns, error) {
+	switch {
+	case m.Date == "" || m.Description == "":
+		return nil, fmt.Errorf("%w: date and description columns are required", ErrInvalidMapping)
+	case m.Amount == "" && m.Debit == "" && m.Credit == "":
+		return nil, fmt.Errorf("%w: an amount column or debit/credit columns are required", ErrInvalidMapping)
+	case m.Amount != "" && (m.Debit != "" || m.Credit != ""):
+		return nil, fmt.Errorf("%w: use either an amount column or debit/credit columns", ErrInvalidMapping)
+	case m.DateLayout == "":
+		return nil, fmt.Errorf("%w: date layout is required", ErrInvalidMapping)
+	case m.Decimal != ',' && m.Decimal != '.':
+		return nil, fmt.Errorf("%w: decimal separator must be ',' or '.'", ErrInvalidMapping)
+	}
+
+	c := &Columns{mapping: m}
+	var err error
+	for _, col := range []struct {
+		ref string
+		idx *int
+	}{
+		{m.Date, &c.date},
+		{m.Description, &c.description},
+		{m.Amount, &c.amount},
+		{m.Debit, &c.debit},
+		{m.Credit, &c.credit},
+		{m.Payee, &c.payee},
+		{m.Memo, &c.memo},
+		{m.ExternalID, &c.externalID},
+	} {
+		if *col.idx, err = resolveColumn(col.ref, header); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func resolveColumn(ref string, header []string) (int, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return -1, nil
+	}
+	if n, err := strconv.Atoi(ref); err == nil {
+		if n < 1 {
+			return 0, fmt.Errorf("%w: column numbers start at 1", ErrInvalidMapping)
+		}
+		return n - 1, nil
+	}
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(name), ref) {
+			return i, nil
+		}
+	}
+	if header == nil {
+		return 0, fmt.Errorf("%w: column %q can only be found by name in files with a header", ErrInvalidMapping, ref)
+	}
+	return 0, fmt.Errorf("%w: column %q not found in header", ErrInvalidMapping, ref)
+}
+
+// Parse interpreta um registro de dados
+func (c *Columns) Parse(fields []string) (Row, error) {
+	get := func(i int) string {
+		if i < 0 || i >= len(fields) {
+			return ""
+		}
+		return fields[i]
+	}
+
+	var row Row
+	var err error
+	if row.Date, err = parseDate(get(c.date), c.mapping.DateLayout); err != nil {
+		return row, err
+	}
+
+	row.Description = get(c.description)
+	if row.Description == "" {
+		return row, fmt.Errorf("description is empty")
+	}
+	row.Payee = get(c.payee)
+	row.Memo = get(c.memo)
+	row.ExternalID = get(c.externalID)
+
+	if c.amount >= 0 {
+		value := get(c.amount)
+		if value == "" {
+			return row, fmt.Errorf("amount is empty")
+		}
+		if row.Amount, err = parseAmount(value, c.mapping.Decimal); err != nil {
+			return row, err
+		}
+	} else {
+		debitValue, creditValue := get(c.debit), get(c.credit)
+		if debitValue == "" && creditValue == "" {
+			return row, fmt.Errorf("debit and credit are empty")
+		}
+		debit, err := parseAmount(debitValue, c.mapping.Decimal)
+		if err != nil {
+			return row, err
+		}
+		credit, err := parseAmount(creditValue, c.mapping.Decimal)
+		if err != nil {
+			return row, err
+		}
+		// Bancos variam no sinal da coluna de débito; vale a coluna, não o sinal
+		if row.Amount, err = credit.Abs().Sub(debit.Abs()); err != nil {
+			return row, err
+		}
+	}
+
+	if c.mapping.InvertSign {
+		row.Amount = row.Amount.Neg()
+	}
+	return row, nil
+}
+
+// parseDate aceita hora depois da data ("05/01/2026 10:32"), que é descartada
+func parseDate(s, layout string) (time.Time, error) {
+	t, err := time.Parse(layout, s)
+	if err != nil && len(s) > len(layout) {
+		t, err = time.Parse(layout, s[:len(layout)])
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", s)
+	}
+	return t, nil
+}
+
+// parseAmount aceita o indicador D/C no fim usado por vários bancos ("150,00 D").
+// Vazio é zero.
+func parseAmount(s string, decimal rune) (money.Money, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return money.Money{}, nil
+	}
+
+	negative := false
+	if n := len(s); n > 1 && (s[n-2] == ' ' || unicode.IsDigit(rune(s[n-2]))) {
+		switch s[n-1] {
+		case 'D', 'd':
+			negative = true
+			s = strings.TrimSpace(s[:n-1])
+		case 'C', 'c':
+			s = strings.TrimSpace(s[:n-1])
+		}
+	}
+
+	amount, err := money.ParseWithDecimal(s, "", decimal)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("invalid amount %q", s)
+	}
+	if negative {
+		amount = amount.Abs().Neg()
+	}
+	return amount, nil
+}
+
+// Suggest propõe um mapeamento a partir dos nomes do cabeçalho. Formato de
+// data e separador decimal ficam a cargo de quem chama.
+func Suggest(header []string) Mapping {
+	m := Mapping{HasHeader: true}
+	// Nomes comuns de cabeçalho, sem acento e em minúsculas
+	hints := []struct {
+		target *string
+		names  []string
+	}{
+		{&m.Date, []string{"data", "date", "data lancamento", "data do lancamento", "dt lancamento", "data movimento"}},
+		{&m.Description, []string{"descricao", "historico", "description", "lancamento", "memo", "detalhes"}},
+		{&m.Amount, []string{"valor", "amount", "value", "valor (r$)", "quantia"}},
+		{&m.Debit, []string{"debito", "debit", "saida", "saidas", "valor debito"}},
+		{&m.Credit, []string{"credito", "credit", "entrada", "entradas", "valor credito"}},
+		{&m.Payee, []string{"favorecido", "beneficiario", "payee", "estabelecimento"}},
+		{&m.ExternalID, []string{"id", "identificador", "fitid", "transaction id", "id da transacao"}},
+	}
+
+	for _, hint := range hints {
+		for _, name := range header {
+			normalized := normalizeHeader(name)
+			for _, candidate := range hint.names {
+				if normalized == candidate && *hint.target == "" {
+					*hint.target = name
+				}
+			}
+		}
+	}
+	if m.Amount != "" {
+		m.Debit, m.Credit = "", ""
+	}
+	return m
+}
+
+var stripAccents = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+func normalizeHeader(s string) string {
+	s, _, _ = transform.String(stripAccents, strings.ToLower(strings.TrimSpace(s)))
+	return strings.Join(strings.Fields(s), " ")
+}
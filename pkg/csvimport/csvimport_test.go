@@ -0,0 +1,133 @@
+package csvimport
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+func readAll(t *testing.T, r *Reader) []Record {
+	t.Helper()
+	var records []Record
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return records
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+}
+
+func TestNewReaderDetectsDialect(t *testing.T) {
+	input := "Data;Histórico;Valor\n05/01/2026;PADARIA SÃO JOÃO;-1.150,75\n10/01/2026;SALÁRIO;2.500,00\n"
+	latin1, err := charmap.Windows1252.NewEncoder().String(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewReader(strings.NewReader(latin1), Dialect{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := r.Dialect(); d.Encoding != Windows1252 || d.Delimiter != ';' {
+		t.Errorf("dialect = %+v", d)
+	}
+
+	records := readAll(t, r)
+	if len(records) != 3 || records[1].Fields[1] != "PADARIA SÃO JOÃO" || records[2].Line != 3 {
+		t.Errorf("records = %+v", records)
+	}
+
+	utf, err := NewReader(strings.NewReader("\xef\xbb\xbfdate,description,amount\n2026-01-05,\"Coffee, large\",-4.50\n"), Dialect{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := utf.Dialect(); d.Encoding != UTF8 || d.Delimiter != ',' {
+		t.Errorf("dialect = %+v", d)
+	}
+	if records := readAll(t, utf); records[0].Fields[0] != "date" || records[1].Fields[1] != "Coffee, large" {
+		t.Errorf("records = %+v", records)
+	}
+}
+
+func TestColumnsParse(t *testing.T) {
+	header := []string{"Data", "Descrição", "Débito", "Crédito", "Documento"}
+	m := Suggest(header)
+	if m.Date != "Data" || m.Description != "Descrição" || m.Debit != "Débito" || m.Credit != "Crédito" || m.Amount != "" {
+		t.Fatalf("suggested mapping = %+v", m)
+	}
+	m.ExternalID = "5"
+	m.DateLayout = "02/01/2006"
+	m.Decimal = ','
+
+	cols, err := m.Columns(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	row, err := cols.Parse([]string{"05/01/2026 10:32", "PADARIA", "1.150,75", "", "123"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !row.Date.Equal(time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)) || row.Amount.Decimal() != "-1150.75" || row.ExternalID != "123" {
+		t.Errorf("row = %+v (%s)", row, row.Amount.Decimal())
+	}
+
+	row, err = cols.Parse([]string{"10/01/2026", "SALARIO", "", "2.500,00"})
+	if err != nil || row.Amount.Decimal() != "2500" {
+		t.Errorf("credit row = %+v, %v", row, err)
+	}
+
+	for _, fields := range [][]string{
+		{"2026-01-10", "X", "1,00", ""},
+		{"10/01/2026", "", "1,00", ""},
+		{"10/01/2026", "X", "", ""},
+		{"10/01/2026", "X", "abc", ""},
+	} {
+		if _, err := cols.Parse(fields); err == nil {
+			t.Errorf("Parse(%q) accepted", fields)
+		}
+	}
+}
+
+func TestParseAmountMarkers(t *testing.T) {
+	tests := map[string]string{
+		"150,00 D":   "-150",
+		"150,00C":    "150",
+		"-150,00":    "-150",
+		"10,00 USD":  "10",
+		"R$ 1.234,5": "1234.5",
+	}
+	for input, want := range tests {
+		got, err := parseAmount(input, ',')
+		if err != nil || got.Decimal() != want {
+			t.Errorf("parseAmount(%q) = %s, %v; want %s", input, got.Decimal(), err, want)
+		}
+	}
+}
+
+func TestMappingValidation(t *testing.T) {
+	base := Mapping{Date: "1", Description: "2", Amount: "3", DateLayout: "02/01/2006", Decimal: ','}
+	if _, err := base.Columns(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	invalid := []Mapping{
+		{Description: "2", Amount: "3", DateLayout: "02/01/2006", Decimal: ','},
+		{Date: "1", Description: "2", DateLayout: "02/01/2006", Decimal: ','},
+		{Date: "1", Description: "2", Amount: "3", Debit: "4", DateLayout: "02/01/2006", Decimal: ','},
+		{Date: "Data", Description: "2", Amount: "3", DateLayout: "02/01/2006", Decimal: ','},
+		{Date: "0", Description: "2", Amount: "3", DateLayout: "02/01/2006", Decimal: ','},
+	}
+	for _, m := range invalid {
+		if _, err := m.Columns(nil); err == nil {
+			t.Errorf("Columns(%+v) accepted", m)
+		}
+	}
+}
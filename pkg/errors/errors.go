@@ -3,18 +3,58 @@ package errors
 import "errors"
 
 var (
-	ErrNotFound           = errors.New("resource not found")
-	ErrInvalidInput       = errors.New("invalid input")
-	ErrUnauthorized       = errors.New("unauthorized")
-	ErrForbidden          = errors.New("forbidden")
-	ErrInternalServer     = errors.New("internal server error")
-	ErrDuplicateEntry     = errors.New("duplicate entry")
-	ErrInvalidCredentials = errors.New("invalid credentials")
-	ErrUserNotFound       = errors.New("user not found")
-	ErrInvalidPassword    = errors.New("invalid password")
-	ErrEmailAlreadyUsed   = errors.New("email already in use")
-	ErrInvalidUserID      = errors.New("invalid user ID")
-	ErrInvalidPermission  = errors.New("invalid permission")
+	ErrNotFound            = errors.New("resource not found")
+	ErrInvalidInput        = errors.New("invalid input")
+	ErrUnauthorized        = errors.New("unauthorized")
+	ErrForbidden           = errors.New("forbidden")
+	ErrInternalServer      = errors.New("internal server error")
+	ErrDuplicateEntry      = errors.New("duplicate entry")
+	ErrInvalidCredentials  = errors.New("invalid credentials")
+	ErrUserNotFound        = errors.New("user not found")
+	ErrInvalidPassword     = errors.New("invalid password")
+	ErrEmailAlreadyUsed    = errors.New("email already in use")
+	ErrInvalidUserID       = errors.New("invalid user ID")
+	ErrInvalidPermission   = errors.New("invalid permission")
+	ErrInvalidToken        = errors.New("invalid or expired token")
+	ErrSameEmail           = errors.New("new email must be different from the current one")
+	ErrFileTooLarge        = errors.New("file too large")
+	ErrUnsupportedMedia    = errors.New("unsupported media type")
+	ErrTaxIDAlreadyUsed    = errors.New("CPF/CNPJ already in use")
+	ErrAccountNotFound     = errors.New("account not found")
+	ErrAccountNameTaken    = errors.New("an account with this name already exists")
+	ErrAccountInUse        = errors.New("account has transactions; archive it instead")
+	ErrTransactionNotFound = errors.New("transaction not found")
+	ErrTransactionLocked   = errors.New("reconciled transactions cannot be changed")
+	ErrCategoryNotFound    = errors.New("category not found")
+	ErrCategoryNameTaken   = errors.New("a category with this name already exists at this level")
+	ErrCategoryInUse       = errors.New("category has subcategories or transactions; merge or archive it instead")
+	ErrBudgetNotFound      = errors.New("budget not found")
+	ErrBudgetOverAssigned  = errors.New("not enough unassigned income for this budget")
+	ErrRecurringNotFound   = errors.New("recurring transaction not found")
+	ErrAlreadyImported     = errors.New("statement entries were already imported")
+	ErrCSVProfileNotFound  = errors.New("CSV import profile not found")
+	ErrCSVProfileNameTaken = errors.New("a CSV import profile with this name already exists")
+	ErrBoletoScheduled     = errors.New("this boleto is already scheduled in the account")
+
+	ErrInstallmentPurchaseNotFound = errors.New("installment purchase not found")
+	ErrInstallmentManaged          = errors.New("installments are managed by their purchase; edit or delete the purchase instead")
+
+	ErrHouseholdNotFound     = errors.New("household not found")
+	ErrSharedExpenseNotFound = errors.New("shared expense not found")
+	ErrSettlementNotFound    = errors.New("settlement not found")
+	ErrAlreadyMember         = errors.New("user is already a member of this household")
+	ErrOutstandingBalance    = errors.New("member still has an outstanding balance; settle up first")
+
+	ErrTagNotFound          = errors.New("tag not found")
+	ErrTagNameTaken         = errors.New("a tag with this name already exists; merge the tags instead")
+	ErrSavedSearchNotFound  = errors.New("saved search not found")
+	ErrSavedSearchNameTaken = errors.New("a saved search with this name already exists")
+
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	ErrAttachmentExists   = errors.New("this file is already attached")
+
+	ErrRuleNotFound           = errors.New("categorization rule not found")
+	ErrRuleSuggestionNotFound = errors.New("rule suggestion not found")
 )
 
 type AppError struct {
@@ -0,0 +1,90 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// MaxPixels limita o tamanho das imagens decodificadas para evitar
+// "decompression bombs" (arquivos pequenos que expandem para gigabytes)
+const MaxPixels = 40_000_000
+
+var ErrUnsupportedImage = errors.New("unsupported or corrupted image")
+
+// Decode lê JPEG, PNG, GIF ou WebP, validando as dimensões antes de decodificar
+func Decode(data []byte) (image.Image, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > MaxPixels {
+		return nil, ErrUnsupportedImage
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+	return img, nil
+}
+
+// Fit reduz a imagem para caber em maxSize x maxSize, preservando a proporção.
+// Imagens menores não são ampliadas.
+func Fit(img image.Image, maxSize int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= maxSize && h <= maxSize {
+		return flatten(img)
+	}
+
+	if w >= h {
+		h = h * maxSize / w
+		w = maxSize
+	} else {
+		w = w * maxSize / h
+		h = maxSize
+	}
+	return scale(img, b, max(w, 1), max(h, 1))
+}
+
+// Square recorta o centro da imagem em um quadrado e o redimensiona para size x size
+func Square(img image.Image, size int) image.Image {
+	b := img.Bounds()
+	side := min(b.Dx(), b.Dy())
+	x0 := b.Min.X + (b.Dx()-side)/2
+	y0 := b.Min.Y + (b.Dy()-side)/2
+	return scale(img, image.Rect(x0, y0, x0+side, y0+side), size, size)
+}
+
+// EncodeJPEG reescreve a imagem como JPEG, descartando metadados (EXIF, GPS...)
+func EncodeJPEG(img image.Image, quality int) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func scale(img image.Image, src image.Rectangle, w, h int) image.Image {
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	// Fundo branco para imagens com transparência, já que o JPEG não tem canal alfa
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, src, draw.Over, nil)
+	return dst
+}
+
+func flatten(img image.Image) image.Image {
+	b := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), img, b.Min, draw.Over)
+	return dst
+}
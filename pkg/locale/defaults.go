@@ -0,0 +1,95 @@
+package locale
+
+import (
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+type position int
+
+const (
+	symbolPrefixSpaced position = iota // R$ 1.234,56
+	symbolPrefix                       // $1,234.56
+	symbolSuffix                       // 1.234,56 €
+)
+
+// Regras simplificadas a partir do CLDR para os idiomas mais comuns entre os usuários
+var symbolPositions = map[string]position{
+	"pt": symbolPrefixSpaced,
+	"nl": symbolPrefixSpaced,
+	"en": symbolPrefix,
+	"ja": symbolPrefix,
+	"zh": symbolPrefix,
+	"ko": symbolPrefix,
+	"es": symbolSuffix,
+	"de": symbolSuffix,
+	"fr": symbolSuffix,
+	"it": symbolSuffix,
+	"pl": symbolSuffix,
+	"ru": symbolSuffix,
+}
+
+var numberFormats = map[string]string{
+	"en": NumberFormatDotDecimal,
+	"ja": NumberFormatDotDecimal,
+	"zh": NumberFormatDotDecimal,
+	"ko": NumberFormatDotDecimal,
+	"fr": NumberFormatSpaceCommaDecimal,
+	"pl": NumberFormatSpaceCommaDecimal,
+	"ru": NumberFormatSpaceCommaDecimal,
+}
+
+var dateFormats = map[string]string{
+	"en-US": "01/02/2006",
+	"ja":    "2006-01-02",
+	"zh":    "2006-01-02",
+	"ko":    "2006-01-02",
+	"de":    "02.01.2006",
+}
+
+// Regiões em que a semana começa no domingo; nas demais começa na segunda
+var sundayFirstRegions = map[string]bool{
+	"BR": true, "US": true, "CA": true, "MX": true, "JP": true,
+	"IL": true, "PH": true, "ZA": true, "KR": true, "TW": true,
+}
+
+func symbolPosition(tag language.Tag) position {
+	base, _ := tag.Base()
+	if p, ok := symbolPositions[base.String()]; ok {
+		return p
+	}
+	return symbolPrefixSpaced
+}
+
+func defaultNumberFormat(tag language.Tag) string {
+	base, _ := tag.Base()
+	region, _ := tag.Region()
+	if base.String() == "de" && (region.String() == "CH" || region.String() == "LI") {
+		return NumberFormatApostropheDot
+	}
+	if f, ok := numberFormats[base.String()]; ok {
+		return f
+	}
+	return NumberFormatCommaDecimal
+}
+
+func defaultDateLayout(tag language.Tag) string {
+	base, _ := tag.Base()
+	region, _ := tag.Region()
+	if layout, ok := dateFormats[base.String()+"-"+region.String()]; ok {
+		return layout
+	}
+	if layout, ok := dateFormats[base.String()]; ok {
+		return layout
+	}
+	return "02/01/2006"
+}
+
+func defaultFirstDayOfWeek(tag language.Tag) time.Weekday {
+	region, _ := tag.Region()
+	if sundayFirstRegions[region.String()] {
+		return time.Sunday
+	}
+	return time.Monday
+}
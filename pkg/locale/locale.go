@@ -0,0 +1,354 @@
+// Package locale formata valores monetários, números e datas conforme as
+// preferências regionais do usuário (idioma, moeda, fuso, formato numérico).
+package locale
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	_ "time/tzdata" // Garante a base de fusos horários mesmo em imagens sem /usr/share/zoneinfo
+
+	"finanvilla/pkg/money"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+var (
+	ErrInvalidLanguage     = errors.New("invalid language tag")
+	ErrInvalidCurrency     = errors.New("invalid ISO 4217 currency code")
+	ErrInvalidTimezone     = errors.New("invalid timezone")
+	ErrInvalidDateFormat   = errors.New("invalid date format")
+	ErrInvalidNumberFormat = errors.New("invalid number format")
+	ErrInvalidWeekday      = errors.New("invalid first day of week")
+)
+
+// Formatos numéricos suportados, descritos pelo próprio exemplo
+const (
+	NumberFormatCommaDecimal      = "1.234,56"
+	NumberFormatDotDecimal        = "1,234.56"
+	NumberFormatSpaceCommaDecimal = "1 234,56"
+	NumberFormatApostropheDot     = "1'234.56"
+)
+
+var numberSeparators = map[string]struct{ group, decimal string }{
+	NumberFormatCommaDecimal:      {".", ","},
+	NumberFormatDotDecimal:        {",", "."},
+	NumberFormatSpaceCommaDecimal: {" ", ","},
+	NumberFormatApostropheDot:     {"'", "."},
+}
+
+// Formatos de data aceitos em UserSettings.DateFormat e seus layouts Go
+var dateLayouts = map[string]string{
+	"DD/MM/YYYY": "02/01/2006",
+	"MM/DD/YYYY": "01/02/2006",
+	"YYYY-MM-DD": "2006-01-02",
+	"DD.MM.YYYY": "02.01.2006",
+	"DD-MM-YYYY": "02-01-2006",
+}
+
+var weekdays = map[string]time.Weekday{
+	"SUNDAY":    time.Sunday,
+	"MONDAY":    time.Monday,
+	"TUESDAY":   time.Tuesday,
+	"WEDNESDAY": time.Wednesday,
+	"THURSDAY":  time.Thursday,
+	"FRIDAY":    time.Friday,
+	"SATURDAY":  time.Saturday,
+}
+
+type Options struct {
+	Language       string // BCP 47, ex.: pt-BR
+	Currency       string // ISO 4217, ex.: BRL
+	Timezone       string // IANA, ex.: America/Sao_Paulo
+	DateFormat     string // ex.: DD/MM/YYYY
+	NumberFormat   string // ex.: 1.234,56; vazio usa o padrão do idioma
+	FirstDayOfWeek string // ex.: SUNDAY; vazio usa o padrão da região
+}
+
+type Formatter struct {
+	tag        language.Tag
+	currency   currency.Unit
+	location   *time.Location
+	dateLayout string
+	group      string
+	decimal    string
+	firstDay   time.Weekday
+	printer    *message.Printer
+}
+
+// Default é o formatador usado quando o usuário não tem preferências salvas
+var Default = MustNew(Options{Language: "pt-BR", Currency: "BRL", Timezone: "America/Sao_Paulo"})
+
+// New cria um formatador. Campos vazios recebem os padrões do idioma/região.
+func New(opts Options) (*Formatter, error) {
+	if opts.Language == "" {
+		opts.Language = "pt-BR"
+	}
+	if opts.Currency == "" {
+		opts.Currency = "BRL"
+	}
+
+	lang, err := ParseLanguage(opts.Language)
+	if err != nil {
+		return nil, err
+	}
+	tag := language.Make(lang)
+
+	cur, err := currency.ParseISO(strings.ToUpper(opts.Currency))
+	if err != nil {
+		return nil, ErrInvalidCurrency
+	}
+
+	loc := time.UTC
+	if opts.Timezone != "" {
+		if loc, err = time.LoadLocation(opts.Timezone); err != nil {
+			return nil, ErrInvalidTimezone
+		}
+	}
+
+	dateLayout, ok := dateLayouts[opts.DateFormat]
+	if opts.DateFormat == "" {
+		dateLayout, ok = defaultDateLayout(tag), true
+	}
+	if !ok {
+		return nil, ErrInvalidDateFormat
+	}
+
+	numberFormat := opts.NumberFormat
+	if numberFormat == "" {
+		numberFormat = defaultNumberFormat(tag)
+	}
+	sep, ok := numberSeparators[numberFormat]
+	if !ok {
+		return nil, ErrInvalidNumberFormat
+	}
+
+	firstDay := defaultFirstDayOfWeek(tag)
+	if opts.FirstDayOfWeek != "" {
+		if firstDay, ok = weekdays[strings.ToUpper(opts.FirstDayOfWeek)]; !ok {
+			return nil, ErrInvalidWeekday
+		}
+	}
+
+	return &Formatter{
+		tag:        tag,
+		currency:   cur,
+		location:   loc,
+		dateLayout: dateLayout,
+		group:      sep.group,
+		decimal:    sep.decimal,
+		firstDay:   firstDay,
+		printer:    message.NewPrinter(tag),
+	}, nil
+}
+
+func MustNew(opts Options) *Formatter {
+	f, err := New(opts)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// ParseLanguage valida uma tag BCP 47 e devolve sua forma canônica (ex.: "pt_br" -> "pt-BR")
+func ParseLanguage(s string) (string, error) {
+	tag, err := language.Parse(s)
+	if err != nil || tag == language.Und {
+		return "", ErrInvalidLanguage
+	}
+	return tag.String(), nil
+}
+
+// ParseCurrency valida um código ISO 4217 e o devolve em maiúsculas
+func ParseCurrency(s string) (string, error) {
+	cur, err := currency.ParseISO(strings.ToUpper(s))
+	if err != nil {
+		return "", ErrInvalidCurrency
+	}
+	return cur.String(), nil
+}
+
+// CurrencyDigits devolve a quantidade de casas decimais da moeda (BRL: 2, JPY: 0)
+func CurrencyDigits(code string) (int, error) {
+	cur, err := currency.ParseISO(strings.ToUpper(code))
+	if err != nil {
+		return 0, ErrInvalidCurrency
+	}
+	scale, _ := currency.Standard.Rounding(cur)
+	return scale, nil
+}
+
+func ValidateTimezone(s string) error {
+	if _, err := time.LoadLocation(s); err != nil || s == "" {
+		return ErrInvalidTimezone
+	}
+	return nil
+}
+
+func ValidateDateFormat(s string) error {
+	if _, ok := dateLayouts[s]; !ok {
+		return ErrInvalidDateFormat
+	}
+	return nil
+}
+
+func ValidateNumberFormat(s string) error {
+	if _, ok := numberSeparators[s]; !ok {
+		return ErrInvalidNumberFormat
+	}
+	return nil
+}
+
+// DateLayout devolve o layout Go de um formato de data aceito (ex.: DD/MM/YYYY)
+func DateLayout(format string) (string, error) {
+	layout, ok := dateLayouts[format]
+	if !ok {
+		return "", ErrInvalidDateFormat
+	}
+	return layout, nil
+}
+
+// DecimalSeparator devolve o separador decimal de um formato numérico (ex.: ',' em 1.234,56)
+func DecimalSeparator(numberFormat string) (rune, error) {
+	sep, ok := numberSeparators[numberFormat]
+	if !ok {
+		return 0, ErrInvalidNumberFormat
+	}
+	return rune(sep.decimal[0]), nil
+}
+
+func ValidateWeekday(s string) error {
+	if _, ok := weekdays[strings.ToUpper(s)]; !ok {
+		return ErrInvalidWeekday
+	}
+	return nil
+}
+
+func (f *Formatter) Language() string         { return f.tag.String() }
+func (f *Formatter) Currency() string         { return f.currency.String() }
+func (f *Formatter) Location() *time.Location { return f.location }
+func (f *Formatter) FirstDayOfWeek() time.Weekday {
+	return f.firstDay
+}
+
+// FormatAmount formata um valor em unidades mínimas (centavos) da moeda
+// informada, sem passar por ponto flutuante. Ex.: 123456 BRL -> "R$ 1.234,56".
+func (f *Formatter) FormatAmount(minorUnits int64, currencyCode string) string {
+	if currencyCode == "" {
+		currencyCode = f.currency.String()
+	}
+
+	cur, err := currency.ParseISO(strings.ToUpper(currencyCode))
+	if err != nil {
+		return f.FormatMinorUnits(minorUnits, 2) + " " + currencyCode
+	}
+	digits, _ := currency.Standard.Rounding(cur)
+
+	negative := minorUnits < 0
+	number := f.FormatMinorUnits(abs(minorUnits), digits)
+	symbol := f.printer.Sprint(currency.Symbol(cur))
+
+	var out string
+	switch symbolPosition(f.tag) {
+	case symbolPrefix:
+		out = symbol + number
+	case symbolPrefixSpaced:
+		out = symbol + " " + number
+	default:
+		out = number + " " + symbol
+	}
+
+	if negative {
+		return "-" + out
+	}
+	return out
+}
+
+// FormatMoney formata um money.Money na moeda dele (ou na do usuário, se não tiver)
+func (f *Formatter) FormatMoney(m money.Money) string {
+	if m.Currency() == "" {
+		if withCurrency, err := m.Round(f.currency.String(), money.HalfEven); err == nil {
+			m = withCurrency
+		}
+	}
+	return f.FormatAmount(m.MinorUnits(), m.Currency())
+}
+
+// FormatMinorUnits formata um inteiro escalado (value / 10^digits) com os separadores do usuário
+func (f *Formatter) FormatMinorUnits(value int64, digits int) string {
+	negative := value < 0
+	s := fmt.Sprintf("%0*d", digits+1, abs(value))
+
+	intPart, fracPart := s[:len(s)-digits], s[len(s)-digits:]
+	out := f.groupThousands(intPart)
+	if digits > 0 {
+		out += f.decimal + fracPart
+	}
+
+	if negative {
+		return "-" + out
+	}
+	return out
+}
+
+// FormatNumber formata números que não são dinheiro (percentuais, quantidades)
+func (f *Formatter) FormatNumber(value float64, digits int) string {
+	scale := 1.0
+	for i := 0; i < digits; i++ {
+		scale *= 10
+	}
+	rounded := value * scale
+	if rounded < 0 {
+		rounded -= 0.5
+	} else {
+		rounded += 0.5
+	}
+	return f.FormatMinorUnits(int64(rounded), digits)
+}
+
+// FormatDate formata a data no fuso e no formato preferidos do usuário
+func (f *Formatter) FormatDate(t time.Time) string {
+	return t.In(f.location).Format(f.dateLayout)
+}
+
+// FormatDateTime formata data e hora (24h) no fuso do usuário
+func (f *Formatter) FormatDateTime(t time.Time) string {
+	return t.In(f.location).Format(f.dateLayout + " 15:04")
+}
+
+// StartOfWeek devolve a meia-noite do primeiro dia da semana que contém t
+func (f *Formatter) StartOfWeek(t time.Time) time.Time {
+	local := t.In(f.location)
+	offset := (int(local.Weekday()) - int(f.firstDay) + 7) % 7
+	y, m, d := local.AddDate(0, 0, -offset).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, f.location)
+}
+
+func (f *Formatter) groupThousands(digits string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+
+	var b strings.Builder
+	head := len(digits) % 3
+	if head > 0 {
+		b.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if b.Len() > 0 {
+			b.WriteString(f.group)
+		}
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
+
+func abs(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,80 @@
+package locale
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		opts     Options
+		amount   int64
+		currency string
+		want     string
+	}{
+		{Options{Language: "pt-BR"}, 123456, "BRL", "R$ 1.234,56"},
+		{Options{Language: "en-US"}, 123456, "USD", "$1,234.56"},
+		{Options{Language: "pt-BR"}, 123456, "USD", "US$ 1.234,56"},
+		{Options{Language: "de-DE"}, -123456789, "EUR", "-1.234.567,89 €"},
+		{Options{Language: "en-US"}, 1235, "JPY", "¥1,235"},
+		{Options{Language: "pt-BR", NumberFormat: NumberFormatDotDecimal}, 5, "BRL", "R$ 0.05"},
+		{Options{Language: "fr-FR"}, 123456, "EUR", "1 234,56 €"},
+	}
+
+	for _, tt := range tests {
+		f, err := New(tt.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := f.FormatAmount(tt.amount, tt.currency); got != tt.want {
+			t.Errorf("%s %d %s = %q, want %q", tt.opts.Language, tt.amount, tt.currency, got, tt.want)
+		}
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	// 02:30 UTC ainda é o dia anterior em São Paulo
+	instant := time.Date(2026, 3, 10, 2, 30, 0, 0, time.UTC)
+
+	br := MustNew(Options{Language: "pt-BR", Timezone: "America/Sao_Paulo", DateFormat: "DD/MM/YYYY"})
+	if got := br.FormatDateTime(instant); got != "09/03/2026 23:30" {
+		t.Errorf("pt-BR = %q", got)
+	}
+
+	us := MustNew(Options{Language: "en-US", Timezone: "America/New_York"})
+	if got := us.FormatDate(instant); got != "03/09/2026" {
+		t.Errorf("en-US = %q", got)
+	}
+}
+
+func TestStartOfWeek(t *testing.T) {
+	wednesday := time.Date(2026, 3, 11, 15, 0, 0, 0, time.UTC)
+
+	br := MustNew(Options{Language: "pt-BR", Timezone: "UTC"})
+	if got := br.StartOfWeek(wednesday); got.Weekday() != time.Sunday || got.Day() != 8 {
+		t.Errorf("pt-BR week starts %v", got)
+	}
+
+	de := MustNew(Options{Language: "de-DE", Timezone: "UTC"})
+	if got := de.StartOfWeek(wednesday); got.Weekday() != time.Monday || got.Day() != 9 {
+		t.Errorf("de-DE week starts %v", got)
+	}
+}
+
+func TestValidation(t *testing.T) {
+	if _, err := ParseCurrency("XYZ"); err == nil {
+		t.Error("XYZ accepted as currency")
+	}
+	if got, err := ParseCurrency("brl"); err != nil || got != "BRL" {
+		t.Errorf("ParseCurrency(brl) = %q, %v", got, err)
+	}
+	if got, err := ParseLanguage("pt_br"); err != nil || got != "pt-BR" {
+		t.Errorf("ParseLanguage(pt_br) = %q, %v", got, err)
+	}
+	if _, err := ParseLanguage("not a tag"); err == nil {
+		t.Error("invalid language accepted")
+	}
+	if err := ValidateTimezone("America/Nowhere"); err == nil {
+		t.Error("invalid timezone accepted")
+	}
+}
@@ -0,0 +1,79 @@
+package money
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// MarshalJSON codifica o valor como string ("1234.56") para que clientes em
+// JavaScript não o convertam em ponto flutuante. A moeda vai em campo próprio
+// da entidade.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Decimal())
+}
+
+// UnmarshalJSON aceita "1234.56" ou o número literal 1234.56, lido como texto
+// e nunca como float64. O resultado fica sem moeda; quem recebe deve chamar
+// WithCurrency com a moeda da conta.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	text := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+	}
+
+	parsed, err := parseCanonical(text)
+	if err != nil {
+		return fmt.Errorf("%w: %q", err, text)
+	}
+	*m = parsed
+	return nil
+}
+
+// Value grava o valor em colunas NUMERIC
+func (m Money) Value() (driver.Value, error) {
+	return m.Decimal(), nil
+}
+
+// Scan lê colunas NUMERIC. O valor fica sem moeda até WithCurrency, em geral
+// chamado no hook AfterFind da entidade.
+func (m *Money) Scan(src interface{}) error {
+	var text string
+	switch v := src.(type) {
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	case int64:
+		*m = Money{amount: v}
+		return nil
+	case float64:
+		// Alguns drivers devolvem NUMERIC como float64; a representação mais
+		// curta que identifica o número preserva o valor gravado.
+		text = strconv.FormatFloat(v, 'f', -1, 64)
+	case nil:
+		return fmt.Errorf("money: cannot scan NULL, use *money.Money")
+	default:
+		return fmt.Errorf("money: cannot scan %T", src)
+	}
+
+	parsed, err := parseCanonical(text)
+	if err != nil {
+		return fmt.Errorf("%w: %q", err, text)
+	}
+	*m = parsed
+	return nil
+}
+
+// GormDataType informa ao GORM o tipo da coluna
+func (Money) GormDataType() string {
+	return "numeric"
+}
@@ -0,0 +1,395 @@
+// Package money implementa valores monetários exatos, sem float64: um inteiro
+// em unidades mínimas (centavos para BRL) acompanhado do código ISO 4217.
+package money
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+
+	"golang.org/x/text/currency"
+)
+
+var (
+	ErrCurrencyMismatch = errors.New("money: currency mismatch")
+	ErrInvalidCurrency  = errors.New("money: invalid currency")
+	ErrInvalidAmount    = errors.New("money: invalid amount")
+	ErrPrecisionLoss    = errors.New("money: amount has more decimal places than the currency allows")
+	ErrOverflow         = errors.New("money: amount out of range")
+	ErrInvalidRatios    = errors.New("money: invalid allocation ratios")
+)
+
+// RoundingMode define o desempate quando o resultado cai exatamente na metade
+type RoundingMode int
+
+const (
+	// HalfUp arredonda a metade para longe do zero (0,5 -> 1; -0,5 -> -1)
+	HalfUp RoundingMode = iota
+	// HalfEven é o arredondamento bancário: a metade vai para o par mais próximo
+	HalfEven
+)
+
+// maxScale limita as casas decimais aceitas em valores ainda sem moeda
+const maxScale = 8
+
+// Money é imutável; o valor zero é um montante zero sem moeda.
+//
+// Com moeda definida, amount está em unidades mínimas e scale é o número de
+// casas da moeda. Valores lidos do banco ou de JSON chegam sem moeda e mantêm
+// a escala do texto de origem até receberem uma com WithCurrency.
+type Money struct {
+	amount   int64
+	scale    uint8
+	currency string
+}
+
+// New cria um valor a partir de unidades mínimas da moeda (New(123456, "BRL") = R$ 1.234,56)
+func New(minorUnits int64, code string) (Money, error) {
+	code, digits, err := lookup(code)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: minorUnits, scale: uint8(digits), currency: code}, nil
+}
+
+// MustNew é como New, mas entra em pânico com moeda inválida
+func MustNew(minorUnits int64, code string) Money {
+	m, err := New(minorUnits, code)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Zero devolve o montante zero na moeda informada
+func Zero(code string) (Money, error) {
+	return New(0, code)
+}
+
+// Digits devolve a quantidade de casas decimais da moeda (BRL: 2, JPY: 0)
+func Digits(code string) (int, error) {
+	_, digits, err := lookup(code)
+	return digits, err
+}
+
+func lookup(code string) (string, int, error) {
+	unit, err := currency.ParseISO(strings.ToUpper(strings.TrimSpace(code)))
+	if err != nil {
+		return "", 0, ErrInvalidCurrency
+	}
+	digits, _ := currency.Standard.Rounding(unit)
+	return unit.String(), digits, nil
+}
+
+func (m Money) Currency() string { return m.currency }
+
+// MinorUnits devolve o valor em unidades mínimas da moeda. Para valores sem
+// moeda, o inteiro está na escala do texto de origem.
+func (m Money) MinorUnits() int64 { return m.amount }
+
+func (m Money) IsZero() bool { return m.amount == 0 }
+
+func (m Money) Sign() int {
+	switch {
+	case m.amount > 0:
+		return 1
+	case m.amount < 0:
+		return -1
+	}
+	return 0
+}
+
+func (m Money) Neg() Money {
+	m.amount = -m.amount
+	return m
+}
+
+func (m Money) Abs() Money {
+	if m.amount < 0 {
+		m.amount = -m.amount
+	}
+	return m
+}
+
+// WithCurrency associa a moeda a um valor lido do banco ou de JSON. Falha com
+// ErrPrecisionLoss se o valor tiver mais casas do que a moeda permite.
+func (m Money) WithCurrency(code string) (Money, error) {
+	code, digits, err := lookup(code)
+	if err != nil {
+		return Money{}, err
+	}
+	if m.currency != "" && m.currency != code {
+		return Money{}, ErrCurrencyMismatch
+	}
+
+	amount, err := rescale(m.amount, int(m.scale), digits)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: amount, scale: uint8(digits), currency: code}, nil
+}
+
+// Round é como WithCurrency, mas arredonda as casas excedentes em vez de falhar
+func (m Money) Round(code string, mode RoundingMode) (Money, error) {
+	code, digits, err := lookup(code)
+	if err != nil {
+		return Money{}, err
+	}
+	if m.currency != "" && m.currency != code {
+		return Money{}, ErrCurrencyMismatch
+	}
+
+	if digits >= int(m.scale) {
+		return m.WithCurrency(code)
+	}
+	r := new(big.Rat).SetFrac(big.NewInt(m.amount), pow10(int(m.scale)-digits))
+	amount, err := roundRat(r, mode)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: amount, scale: uint8(digits), currency: code}, nil
+}
+
+// Add soma dois valores da mesma moeda
+func (m Money) Add(other Money) (Money, error) {
+	a, b, scale, err := align(m, other)
+	if err != nil {
+		return Money{}, err
+	}
+	if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+		return Money{}, ErrOverflow
+	}
+	return Money{amount: a + b, scale: scale, currency: m.currency}, nil
+}
+
+// Sub subtrai dois valores da mesma moeda
+func (m Money) Sub(other Money) (Money, error) {
+	if other.amount == math.MinInt64 {
+		return Money{}, ErrOverflow
+	}
+	return m.Add(other.Neg())
+}
+
+// Cmp compara dois valores da mesma moeda: -1, 0 ou 1
+func (m Money) Cmp(other Money) (int, error) {
+	a, b, _, err := align(m, other)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case a < b:
+		return -1, nil
+	case a > b:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Equal informa se os valores têm a mesma moeda e o mesmo montante
+func (m Money) Equal(other Money) bool {
+	cmp, err := m.Cmp(other)
+	return err == nil && cmp == 0
+}
+
+// Sum soma uma lista de valores da mesma moeda
+func Sum(values ...Money) (Money, error) {
+	var total Money
+	for i, v := range values {
+		if i == 0 {
+			total = v
+			continue
+		}
+		var err error
+		if total, err = total.Add(v); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+// Mul multiplica por um inteiro (quantidade de parcelas, por exemplo)
+func (m Money) Mul(n int64) (Money, error) {
+	product := new(big.Int).Mul(big.NewInt(m.amount), big.NewInt(n))
+	if !product.IsInt64() {
+		return Money{}, ErrOverflow
+	}
+	m.amount = product.Int64()
+	return m, nil
+}
+
+// MulRat multiplica por uma fração exata (juros, câmbio, percentuais) e
+// arredonda para a escala do valor
+func (m Money) MulRat(factor *big.Rat, mode RoundingMode) (Money, error) {
+	r := new(big.Rat).Mul(new(big.Rat).SetInt64(m.amount), factor)
+	amount, err := roundRat(r, mode)
+	if err != nil {
+		return Money{}, err
+	}
+	m.amount = amount
+	return m, nil
+}
+
+// Percent aplica um percentual decimal ("12.5" para 12,5%)
+func (m Money) Percent(percent string, mode RoundingMode) (Money, error) {
+	factor, ok := new(big.Rat).SetString(percent)
+	if !ok {
+		return Money{}, ErrInvalidAmount
+	}
+	return m.MulRat(factor.Quo(factor, big.NewRat(100, 1)), mode)
+}
+
+// Div divide por um inteiro e arredonda. Para dividir sem perder centavos use Split.
+func (m Money) Div(n int64, mode RoundingMode) (Money, error) {
+	if n == 0 {
+		return Money{}, ErrInvalidAmount
+	}
+	return m.MulRat(big.NewRat(1, n), mode)
+}
+
+// Allocate divide o valor proporcionalmente aos pesos sem perder unidades
+// mínimas: cada parte recebe o quociente truncado e as sobras vão, uma a uma,
+// para as partes com maior resto. Em caso de empate, a primeira parte ganha.
+func (m Money) Allocate(ratios ...int64) ([]Money, error) {
+	if len(ratios) == 0 {
+		return nil, ErrInvalidRatios
+	}
+
+	total := new(big.Int)
+	for _, ratio := range ratios {
+		if ratio < 0 {
+			return nil, ErrInvalidRatios
+		}
+		total.Add(total, big.NewInt(ratio))
+	}
+	if total.Sign() == 0 {
+		return nil, ErrInvalidRatios
+	}
+
+	amount := big.NewInt(m.amount)
+	amount.Abs(amount)
+
+	parts := make([]Money, len(ratios))
+	remainders := make([]*big.Int, len(ratios))
+	allocated := new(big.Int)
+	for i, ratio := range ratios {
+		share, rem := new(big.Int).QuoRem(new(big.Int).Mul(amount, big.NewInt(ratio)), total, new(big.Int))
+		parts[i] = Money{amount: share.Int64(), scale: m.scale, currency: m.currency}
+		remainders[i] = rem
+		allocated.Add(allocated, share)
+	}
+
+	for left := new(big.Int).Sub(amount, allocated).Int64(); left > 0; left-- {
+		best := -1
+		for i, rem := range remainders {
+			if rem.Sign() > 0 && (best < 0 || rem.Cmp(remainders[best]) > 0) {
+				best = i
+			}
+		}
+		parts[best].amount++
+		remainders[best].SetInt64(0)
+	}
+
+	if m.amount < 0 {
+		for i := range parts {
+			parts[i].amount = -parts[i].amount
+		}
+	}
+	return parts, nil
+}
+
+// Split divide o valor em n partes iguais (R$ 100,00 / 3 = 33,34 + 33,33 + 33,33)
+func (m Money) Split(n int) ([]Money, error) {
+	if n < 1 {
+		return nil, ErrInvalidRatios
+	}
+	ratios := make([]int64, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return m.Allocate(ratios...)
+}
+
+// align coloca os dois valores na mesma escala; valores com moedas diferentes
+// (ou um com moeda e outro sem) não se misturam.
+func align(a, b Money) (int64, int64, uint8, error) {
+	if a.currency != b.currency {
+		return 0, 0, 0, ErrCurrencyMismatch
+	}
+	if a.scale == b.scale {
+		return a.amount, b.amount, a.scale, nil
+	}
+
+	scale := a.scale
+	if b.scale > scale {
+		scale = b.scale
+	}
+	x, err := rescale(a.amount, int(a.scale), int(scale))
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	y, err := rescale(b.amount, int(b.scale), int(scale))
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return x, y, scale, nil
+}
+
+// rescale muda a escala de um inteiro sem arredondar
+func rescale(amount int64, from, to int) (int64, error) {
+	if from == to {
+		return amount, nil
+	}
+
+	v := big.NewInt(amount)
+	if to > from {
+		v.Mul(v, pow10(to-from))
+	} else {
+		var rem big.Int
+		v.QuoRem(v, pow10(from-to), &rem)
+		if rem.Sign() != 0 {
+			return 0, ErrPrecisionLoss
+		}
+	}
+
+	if !v.IsInt64() {
+		return 0, ErrOverflow
+	}
+	return v.Int64(), nil
+}
+
+// roundRat arredonda uma fração para o inteiro mais próximo conforme o modo
+func roundRat(r *big.Rat, mode RoundingMode) (int64, error) {
+	q, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+
+	if rem.Sign() != 0 {
+		// Compara 2*|resto| com o denominador para saber de que lado da metade está
+		twice := new(big.Int).Abs(rem)
+		twice.Lsh(twice, 1)
+
+		away := false
+		switch twice.Cmp(r.Denom()) {
+		case 1:
+			away = true
+		case 0:
+			away = mode == HalfUp || q.Bit(0) == 1
+		}
+
+		if away {
+			if r.Sign() < 0 {
+				q.Sub(q, big.NewInt(1))
+			} else {
+				q.Add(q, big.NewInt(1))
+			}
+		}
+	}
+
+	if !q.IsInt64() {
+		return 0, ErrOverflow
+	}
+	return q.Int64(), nil
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
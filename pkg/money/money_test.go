@@ -0,0 +1,227 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		code  string
+		want  string
+		err   error
+	}{
+		{"1.234,56", "BRL", "1234.56 BRL", nil},
+		{"1,234.56", "USD", "1234.56 USD", nil},
+		{"1234.56", "BRL", "1234.56 BRL", nil},
+		{"1234,5", "BRL", "1234.50 BRL", nil},
+		{"R$ 1.234,56", "BRL", "1234.56 BRL", nil},
+		{"R$ -1.234,56", "BRL", "-1234.56 BRL", nil},
+		{"-R$ 12,00", "BRL", "-12.00 BRL", nil},
+		{"(12,00)", "BRL", "-12.00 BRL", nil},
+		{"12,00-", "BRL", "-12.00 BRL", nil},
+		{"1.234.567", "BRL", "1234567.00 BRL", nil},
+		{"1.234", "BRL", "1234.00 BRL", nil},
+		{"1 234,56", "EUR", "1234.56 EUR", nil},
+		{"1.234", "KWD", "1.234 KWD", nil},
+		{"¥1,500", "JPY", "1500 JPY", nil},
+		{"0,001", "BRL", "", ErrPrecisionLoss},
+		{"1.5", "JPY", "", ErrPrecisionLoss},
+		{"1.23.4", "BRL", "", ErrInvalidAmount},
+		{"abc", "BRL", "", ErrInvalidAmount},
+		{"12", "XYZ1", "", ErrInvalidCurrency},
+		{"0.125", "", "0.125", nil},
+		{"10.125", "", "10.125", nil},
+		{"1.234.567", "", "1234567", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input, tt.code)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("expected %v, got %v (%s)", tt.err, err, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWithDecimal(t *testing.T) {
+	// "1.234" com ponto decimal tem três casas: não cabe em BRL
+	if _, err := ParseWithDecimal("1.234", "BRL", '.'); !errors.Is(err, ErrPrecisionLoss) {
+		t.Fatalf("expected precision loss, got %v", err)
+	}
+
+	got, err := ParseWithDecimal("1.234,5", "BRL", ',')
+	if err != nil || got.MinorUnits() != 123450 {
+		t.Fatalf("unexpected result %s, %v", got, err)
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	a := MustNew(1050, "BRL")
+	b := MustNew(250, "BRL")
+
+	sum, err := a.Add(b)
+	if err != nil || sum.MinorUnits() != 1300 {
+		t.Fatalf("unexpected sum %s, %v", sum, err)
+	}
+
+	diff, err := b.Sub(a)
+	if err != nil || diff.MinorUnits() != -800 {
+		t.Fatalf("unexpected difference %s, %v", diff, err)
+	}
+
+	if _, err := a.Add(MustNew(100, "USD")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Fatalf("expected currency mismatch, got %v", err)
+	}
+
+	// Valores vindos do banco ainda sem moeda não se misturam com valores com moeda
+	var scanned Money
+	if err := scanned.Scan("10.5000"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Add(scanned); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Fatalf("expected currency mismatch, got %v", err)
+	}
+	withCurrency, err := scanned.WithCurrency("BRL")
+	if err != nil || !withCurrency.Equal(a) {
+		t.Fatalf("expected %s, got %s (%v)", a, withCurrency, err)
+	}
+}
+
+func TestRounding(t *testing.T) {
+	tests := []struct {
+		amount int64
+		mode   RoundingMode
+		want   int64
+	}{
+		{25, HalfUp, 3},
+		{25, HalfEven, 2},
+		{35, HalfEven, 4},
+		{-25, HalfUp, -3},
+		{-25, HalfEven, -2},
+		{26, HalfEven, 3},
+		{24, HalfUp, 2},
+	}
+
+	for _, tt := range tests {
+		got, err := MustNew(tt.amount, "BRL").Div(10, tt.mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.MinorUnits() != tt.want {
+			t.Errorf("%d / 10 (mode %d) = %d, want %d", tt.amount, tt.mode, got.MinorUnits(), tt.want)
+		}
+	}
+
+	interest, err := MustNew(100000, "BRL").MulRat(big.NewRat(1, 3), HalfEven)
+	if err != nil || interest.MinorUnits() != 33333 {
+		t.Fatalf("unexpected result %s, %v", interest, err)
+	}
+
+	rounded, err := mustParse(t, "10.125").Round("BRL", HalfEven)
+	if err != nil || rounded.MinorUnits() != 1012 {
+		t.Fatalf("unexpected result %s, %v", rounded, err)
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	parts, err := MustNew(10000, "BRL").Split(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertParts(t, parts, 3334, 3333, 3333)
+
+	parts, err = MustNew(-10000, "BRL").Split(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertParts(t, parts, -3334, -3333, -3333)
+
+	// 70/30 de R$ 0,05: 3,5 e 1,5 centavos; o maior resto empata e vai para o primeiro
+	parts, err = MustNew(5, "BRL").Allocate(70, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertParts(t, parts, 4, 1)
+
+	parts, err = MustNew(100, "BRL").Allocate(1, 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertParts(t, parts, 33, 0, 67)
+
+	if _, err := MustNew(100, "BRL").Allocate(0, 0); !errors.Is(err, ErrInvalidRatios) {
+		t.Fatalf("expected invalid ratios, got %v", err)
+	}
+}
+
+func TestEncoding(t *testing.T) {
+	data, err := json.Marshal(struct {
+		Amount Money `json:"amount"`
+	}{MustNew(-123456, "BRL")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"amount":"-1234.56"}` {
+		t.Fatalf("unexpected JSON %s", data)
+	}
+
+	for _, input := range []string{`"1234.56"`, `1234.56`} {
+		var m Money
+		if err := json.Unmarshal([]byte(input), &m); err != nil {
+			t.Fatal(err)
+		}
+		brl, err := m.WithCurrency("BRL")
+		if err != nil || brl.MinorUnits() != 123456 {
+			t.Fatalf("unexpected value %s, %v", brl, err)
+		}
+	}
+
+	var m Money
+	if err := json.Unmarshal([]byte(`"1,234.56"`), &m); err == nil {
+		t.Fatal("expected error for non-canonical JSON amount")
+	}
+
+	value, err := MustNew(5, "JPY").Value()
+	if err != nil || value != "5" {
+		t.Fatalf("unexpected value %v, %v", value, err)
+	}
+	value, _ = MustNew(-5, "BRL").Value()
+	if value != "-0.05" {
+		t.Fatalf("unexpected value %v", value)
+	}
+}
+
+func mustParse(t *testing.T, s string) Money {
+	t.Helper()
+	m, err := Parse(s, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func assertParts(t *testing.T, parts []Money, want ...int64) {
+	t.Helper()
+	if len(parts) != len(want) {
+		t.Fatalf("got %d parts, want %d", len(parts), len(want))
+	}
+	for i, p := range parts {
+		if p.MinorUnits() != want[i] {
+			t.Fatalf("part %d = %d, want %d (all: %v)", i, p.MinorUnits(), want[i], parts)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package money
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Parse interpreta valores digitados em qualquer convenção usual: "1.234,56",
+// "1,234.56", "1234.56", "R$ -1.234,56", "(12,00)" e "12,00-". Com um único
+// separador seguido de exatamente três dígitos ("1.234"), ele é tratado como
+// separador de milhar, exceto em moedas de três casas ou quando a parte inteira
+// é zero ("0,125"). Com code vazio, o valor fica sem moeda, mantém as casas
+// informadas e um separador único é sempre decimal.
+func Parse(s, code string) (Money, error) {
+	return parse(s, code, 0)
+}
+
+// ParseWithDecimal é como Parse, mas usa o separador decimal informado
+// (',' ou '.'), sem heurística; o outro separador é tratado como de milhar.
+func ParseWithDecimal(s, code string, decimal rune) (Money, error) {
+	if decimal != ',' && decimal != '.' {
+		return Money{}, ErrInvalidAmount
+	}
+	return parse(s, code, decimal)
+}
+
+func parse(s, code string, decimal rune) (Money, error) {
+	digits := -1
+	if code != "" {
+		var err error
+		if code, digits, err = lookup(code); err != nil {
+			return Money{}, err
+		}
+	}
+
+	body, negative, ok := stripDecorations(s)
+	if !ok {
+		return Money{}, ErrInvalidAmount
+	}
+
+	if decimal == 0 {
+		decimal = guessDecimal(body, digits)
+	}
+
+	intPart, fracPart, ok := splitNumber(body, decimal)
+	if !ok {
+		return Money{}, ErrInvalidAmount
+	}
+
+	m, err := fromParts(intPart, fracPart, negative)
+	if err != nil {
+		return Money{}, err
+	}
+	if code == "" {
+		return m, nil
+	}
+	return m.WithCurrency(code)
+}
+
+// stripDecorations remove símbolo ou código da moeda, espaços e o sinal,
+// devolvendo apenas dígitos e separadores.
+func stripDecorations(s string) (string, bool, bool) {
+	s = strings.TrimSpace(s)
+	negative := false
+
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative = true
+		s = s[1 : len(s)-1]
+	}
+
+	isDecoration := func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.Is(unicode.Sc, r) || unicode.IsSpace(r)
+	}
+
+	s = strings.TrimFunc(s, isDecoration)
+	for _, sign := range []string{"-", "+"} {
+		if strings.HasPrefix(s, sign) {
+			negative = negative != (sign == "-")
+			s = strings.TrimFunc(s[1:], isDecoration)
+			break
+		}
+	}
+	if strings.HasSuffix(s, "-") {
+		negative = !negative
+		s = strings.TrimFunc(s[:len(s)-1], isDecoration)
+	}
+
+	// Espaços e apóstrofos só podem aparecer como separadores de milhar
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9', r == '.', r == ',':
+			b.WriteRune(r)
+		case unicode.IsSpace(r), r == '\'':
+		default:
+			return "", false, false
+		}
+	}
+
+	body := b.String()
+	return body, negative, strings.IndexFunc(body, unicode.IsDigit) >= 0
+}
+
+// guessDecimal escolhe o separador decimal; digits < 0 indica valor sem moeda
+func guessDecimal(body string, digits int) rune {
+	lastDot := strings.LastIndexByte(body, '.')
+	lastComma := strings.LastIndexByte(body, ',')
+
+	switch {
+	case lastDot >= 0 && lastComma >= 0:
+		if lastDot > lastComma {
+			return '.'
+		}
+		return ','
+	case lastDot < 0 && lastComma < 0:
+		return '.'
+	}
+
+	sep, last := byte('.'), lastDot
+	if lastComma >= 0 {
+		sep, last = ',', lastComma
+	}
+	if strings.Count(body, string(sep)) > 1 {
+		return otherSeparator(rune(sep))
+	}
+	if len(body)-last-1 == 3 && digits >= 0 && digits != 3 && strings.Trim(body[:last], "0") != "" {
+		return otherSeparator(rune(sep))
+	}
+	return rune(sep)
+}
+
+func otherSeparator(sep rune) rune {
+	if sep == '.' {
+		return ','
+	}
+	return '.'
+}
+
+// splitNumber separa parte inteira e fração, validando os grupos de milhar
+func splitNumber(body string, decimal rune) (string, string, bool) {
+	intPart, fracPart := body, ""
+	if i := strings.LastIndex(body, string(decimal)); i >= 0 {
+		intPart, fracPart = body[:i], body[i+1:]
+		if strings.ContainsAny(fracPart, ".,") {
+			return "", "", false
+		}
+	}
+	if strings.ContainsRune(intPart, decimal) {
+		return "", "", false
+	}
+
+	if group := string(otherSeparator(decimal)); strings.Contains(intPart, group) {
+		groups := strings.Split(intPart, group)
+		if len(groups[0]) == 0 || len(groups[0]) > 3 {
+			return "", "", false
+		}
+		for _, g := range groups[1:] {
+			if len(g) != 3 {
+				return "", "", false
+			}
+		}
+		intPart = strings.Join(groups, "")
+	}
+
+	if intPart == "" {
+		intPart = "0"
+	}
+	return intPart, fracPart, true
+}
+
+// parseCanonical lê o formato usado em JSON e no banco: "-1234.56"
+func parseCanonical(s string) (Money, error) {
+	negative := false
+	if strings.HasPrefix(s, "-") {
+		negative = true
+		s = s[1:]
+	} else if strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+
+	intPart, fracPart, found := strings.Cut(s, ".")
+	if intPart == "" || (found && fracPart == "") {
+		return Money{}, ErrInvalidAmount
+	}
+	for _, part := range []string{intPart, fracPart} {
+		if strings.IndexFunc(part, func(r rune) bool { return r < '0' || r > '9' }) >= 0 {
+			return Money{}, ErrInvalidAmount
+		}
+	}
+	return fromParts(intPart, fracPart, negative)
+}
+
+func fromParts(intPart, fracPart string, negative bool) (Money, error) {
+	// Zeros à direita não mudam o valor e não devem contar como precisão extra
+	fracPart = strings.TrimRight(fracPart, "0")
+	if len(fracPart) > maxScale {
+		return Money{}, ErrPrecisionLoss
+	}
+
+	amount, err := strconv.ParseInt(intPart+fracPart, 10, 64)
+	if err != nil {
+		return Money{}, ErrOverflow
+	}
+	if negative {
+		amount = -amount
+	}
+	return Money{amount: amount, scale: uint8(len(fracPart))}, nil
+}
+
+// Decimal formata o valor em notação canônica, com as casas da moeda: "-1234.56"
+func (m Money) Decimal() string {
+	digits := strconv.FormatUint(uint64(abs(m.amount)), 10)
+	scale := int(m.scale)
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+
+	var b strings.Builder
+	if m.amount < 0 {
+		b.WriteByte('-')
+	}
+	b.WriteString(digits[:len(digits)-scale])
+	if scale > 0 {
+		b.WriteByte('.')
+		b.WriteString(digits[len(digits)-scale:])
+	}
+	return b.String()
+}
+
+// String devolve "1234.56 BRL"; para exibição ao usuário use pkg/locale
+func (m Money) String() string {
+	if m.currency == "" {
+		return m.Decimal()
+	}
+	return m.Decimal() + " " + m.currency
+}
+
+func abs(v int64) uint64 {
+	if v < 0 {
+		return uint64(-(v + 1)) + 1
+	}
+	return uint64(v)
+}
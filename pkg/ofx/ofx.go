@@ -0,0 +1,308 @@
+// Package ofx lê extratos OFX 1.x (SGML) e 2.x (XML). Os dois formatos passam
+// pelo mesmo analisador tolerante: no SGML os elementos com valor não têm tag
+// de fechamento, e no XML ela é simplesmente consumida.
+//
+// Bancos brasileiros costumam exportar OFX 1.x em Latin-1/Windows-1252, muitas
+// vezes declarando outra codificação no cabeçalho; conteúdo que não é UTF-8
+// válido é sempre lido como Windows-1252.
+package ofx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"finanvilla/pkg/money"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+var ErrInvalidOFX = errors.New("invalid OFX file")
+
+// Statement é o extrato de uma conta corrente/poupança (STMTRS) ou de um
+// cartão de crédito (CCSTMTRS)
+type Statement struct {
+	BankID           string
+	BranchID         string
+	AccountID        string
+	AccountType      string // CHECKING, SAVINGS, CREDITLINE...; CREDITCARD para CCSTMTRS
+	Currency         string
+	Start            time.Time
+	End              time.Time
+	Transactions     []Transaction
+	LedgerBalance    *Balance
+	AvailableBalance *Balance
+}
+
+type Transaction struct {
+	FITID    string
+	Type     string // TRNTYPE: CREDIT, DEBIT, PAYMENT, XFER...
+	Posted   time.Time
+	Amount   money.Money
+	Name     string
+	Memo     string
+	CheckNum string
+	RefNum   string
+}
+
+type Balance struct {
+	Amount money.Money
+	AsOf   time.Time
+}
+
+// Parse lê todos os extratos do arquivo
+func Parse(r io.Reader) ([]Statement, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	start := bytes.Index(bytes.ToUpper(data), []byte("<OFX>"))
+	if start < 0 {
+		return nil, fmt.Errorf("%w: missing <OFX> element", ErrInvalidOFX)
+	}
+
+	body := data[start:]
+	if !utf8.Valid(body) || declaresLatin1(data[:start]) {
+		if body, err = charmap.Windows1252.NewDecoder().Bytes(body); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidOFX, err)
+		}
+	}
+
+	root := parseTree(string(body))
+
+	var statements []Statement
+	for _, rs := range root.findAll("STMTRS", "CCSTMTRS") {
+		statement, err := parseStatement(rs)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, statement)
+	}
+	if len(statements) == 0 {
+		return nil, fmt.Errorf("%w: no bank or credit card statement found", ErrInvalidOFX)
+	}
+	return statements, nil
+}
+
+// declaresLatin1 examina o cabeçalho SGML (CHARSET:1252) ou a declaração XML
+func declaresLatin1(header []byte) bool {
+	h := strings.ToUpper(string(header))
+	for _, marker := range []string{"CHARSET:1252", "CHARSET:ISO-8859-1", "CHARSET:8859-1", "WINDOWS-1252", "ISO-8859-1"} {
+		if strings.Contains(h, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseStatement(rs *node) (Statement, error) {
+	statement := Statement{
+		Currency: strings.ToUpper(rs.value("CURDEF")),
+	}
+
+	if from := rs.child("BANKACCTFROM"); from != nil {
+		statement.BankID = from.value("BANKID")
+		statement.BranchID = from.value("BRANCHID")
+		statement.AccountID = from.value("ACCTID")
+		statement.AccountType = strings.ToUpper(from.value("ACCTTYPE"))
+	} else if from := rs.child("CCACCTFROM"); from != nil {
+		statement.AccountID = from.value("ACCTID")
+		statement.AccountType = "CREDITCARD"
+	}
+
+	var err error
+	if list := rs.child("BANKTRANLIST"); list != nil {
+		if statement.Start, err = parseOptionalDate(list.value("DTSTART")); err != nil {
+			return statement, err
+		}
+		if statement.End, err = parseOptionalDate(list.value("DTEND")); err != nil {
+			return statement, err
+		}
+
+		for i, trn := range list.children {
+			if trn.name != "STMTTRN" {
+				continue
+			}
+			transaction, err := parseTransaction(trn, statement.Currency)
+			if err != nil {
+				return statement, fmt.Errorf("%w: transaction %d: %v", ErrInvalidOFX, i+1, err)
+			}
+			statement.Transactions = append(statement.Transactions, transaction)
+		}
+	}
+
+	if statement.LedgerBalance, err = parseBalance(rs.child("LEDGERBAL"), statement.Currency); err != nil {
+		return statement, err
+	}
+	if statement.AvailableBalance, err = parseBalance(rs.child("AVAILBAL"), statement.Currency); err != nil {
+		return statement, err
+	}
+	return statement, nil
+}
+
+func parseTransaction(trn *node, currency string) (Transaction, error) {
+	posted, err := parseDate(trn.value("DTPOSTED"))
+	if err != nil {
+		return Transaction{}, err
+	}
+	amount, err := parseAmount(trn.value("TRNAMT"), currency)
+	if err != nil {
+		return Transaction{}, err
+	}
+
+	return Transaction{
+		FITID:    trn.value("FITID"),
+		Type:     strings.ToUpper(trn.value("TRNTYPE")),
+		Posted:   posted,
+		Amount:   amount,
+		Name:     trn.value("NAME"),
+		Memo:     trn.value("MEMO"),
+		CheckNum: trn.value("CHECKNUM"),
+		RefNum:   trn.value("REFNUM"),
+	}, nil
+}
+
+func parseBalance(n *node, currency string) (*Balance, error) {
+	if n == nil || n.value("BALAMT") == "" {
+		return nil, nil
+	}
+
+	amount, err := parseAmount(n.value("BALAMT"), currency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidOFX, n.name, err)
+	}
+	asOf, err := parseOptionalDate(n.value("DTASOF"))
+	if err != nil {
+		return nil, err
+	}
+	return &Balance{Amount: amount, AsOf: asOf}, nil
+}
+
+// parseAmount aceita ponto ou vírgula como separador decimal, como prevê a
+// especificação; o OFX não usa separador de milhar
+func parseAmount(s, currency string) (money.Money, error) {
+	if s == "" {
+		return money.Money{}, fmt.Errorf("missing amount")
+	}
+	decimal := '.'
+	if strings.Contains(s, ",") && !strings.Contains(s, ".") {
+		decimal = ','
+	}
+	return money.ParseWithDecimal(s, currency, decimal)
+}
+
+// parseDate lê o formato AAAAMMDD[HHMMSS[.XXX]][[gmt:tz]] e devolve só a data,
+// como informada pelo banco (sem conversão de fuso)
+func parseDate(s string) (time.Time, error) {
+	if len(s) < 8 {
+		return time.Time{}, fmt.Errorf("%w: invalid date %q", ErrInvalidOFX, s)
+	}
+	t, err := time.Parse("20060102", s[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: invalid date %q", ErrInvalidOFX, s)
+	}
+	return t, nil
+}
+
+func parseOptionalDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return parseDate(s)
+}
+
+type node struct {
+	name     string
+	text     string
+	children []*node
+}
+
+func (n *node) child(name string) *node {
+	for _, c := range n.children {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func (n *node) value(name string) string {
+	if c := n.child(name); c != nil {
+		return c.text
+	}
+	return ""
+}
+
+func (n *node) findAll(names ...string) []*node {
+	var found []*node
+	for _, c := range n.children {
+		for _, name := range names {
+			if c.name == name {
+				found = append(found, c)
+			}
+		}
+		found = append(found, c.findAll(names...)...)
+	}
+	return found
+}
+
+// parseTree monta a árvore de elementos. Um elemento com texto é uma folha e
+// se fecha implicitamente na próxima tag, o que cobre o SGML sem quebrar o XML.
+func parseTree(s string) *node {
+	root := &node{}
+	stack := []*node{root}
+	top := func() *node { return stack[len(stack)-1] }
+	closeLeaf := func(name string) {
+		if t := top(); len(stack) > 1 && t.text != "" && len(t.children) == 0 && t.name != name {
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	for len(s) > 0 {
+		lt := strings.IndexByte(s, '<')
+		if lt < 0 {
+			break
+		}
+		if text := strings.TrimSpace(s[:lt]); text != "" && len(stack) > 1 {
+			top().text = html.UnescapeString(text)
+		}
+
+		gt := strings.IndexByte(s[lt:], '>')
+		if gt < 0 {
+			break
+		}
+		tag := strings.TrimSpace(s[lt+1 : lt+gt])
+		s = s[lt+gt+1:]
+
+		switch {
+		case tag == "" || strings.HasPrefix(tag, "?") || strings.HasPrefix(tag, "!"):
+			continue
+		case strings.HasPrefix(tag, "/"):
+			name := strings.ToUpper(strings.TrimSpace(tag[1:]))
+			closeLeaf(name)
+			for i := len(stack) - 1; i > 0; i-- {
+				if stack[i].name == name {
+					stack = stack[:i]
+					break
+				}
+			}
+		default:
+			// Atributos não são usados pelo OFX; só o nome importa
+			name, _, _ := strings.Cut(tag, " ")
+			closeLeaf("")
+			n := &node{name: strings.ToUpper(strings.TrimSuffix(name, "/"))}
+			top().children = append(top().children, n)
+			if !strings.HasSuffix(tag, "/") {
+				stack = append(stack, n)
+			}
+		}
+	}
+	return root
+}
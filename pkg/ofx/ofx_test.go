@@ -0,0 +1,151 @@
+package ofx
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+const sgmlStatement = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+SECURITY:NONE
+ENCODING:USASCII
+CHARSET:1252
+COMPRESSION:NONE
+OLDFILEUID:NONE
+NEWFILEUID:NONE
+
+<OFX>
+<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0<SEVERITY>INFO</STATUS><DTSERVER>20260131120000[-3:BRT]<LANGUAGE>POR</SONRS></SIGNONMSGSRSV1>
+<BANKMSGSRSV1><STMTTRNRS><TRNUID>1<STATUS><CODE>0<SEVERITY>INFO</STATUS>
+<STMTRS>
+<CURDEF>BRL
+<BANKACCTFROM><BANKID>0341<BRANCHID>1234<ACCTID>56789-0<ACCTTYPE>CHECKING</BANKACCTFROM>
+<BANKTRANLIST>
+<DTSTART>20260101
+<DTEND>20260131
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20260105000000[-3:BRT]
+<TRNAMT>-150,75
+<FITID>202601050001
+<MEMO>PADARIA SÃO JOÃO
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20260110
+<TRNAMT>2500.00
+<FITID>202601100001
+<NAME>SALARIO &amp; BONUS
+</STMTTRN>
+</BANKTRANLIST>
+<LEDGERBAL><BALAMT>2349.25<DTASOF>20260131</LEDGERBAL>
+</STMTRS>
+</STMTTRNRS></BANKMSGSRSV1>
+</OFX>
+`
+
+const xmlStatement = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+  <CREDITCARDMSGSRSV1>
+    <CCSTMTTRNRS>
+      <TRNUID>1</TRNUID>
+      <CCSTMTRS>
+        <CURDEF>BRL</CURDEF>
+        <CCACCTFROM><ACCTID>4111********1111</ACCTID></CCACCTFROM>
+        <BANKTRANLIST>
+          <DTSTART>20260201</DTSTART>
+          <DTEND>20260228</DTEND>
+          <STMTTRN>
+            <TRNTYPE>DEBIT</TRNTYPE>
+            <DTPOSTED>20260203</DTPOSTED>
+            <TRNAMT>-89.90</TRNAMT>
+            <FITID>abc-1</FITID>
+            <NAME>Livraria Ação</NAME>
+          </STMTTRN>
+        </BANKTRANLIST>
+        <LEDGERBAL><BALAMT>-89.90</BALAMT><DTASOF>20260228</DTASOF></LEDGERBAL>
+      </CCSTMTRS>
+    </CCSTMTTRNRS>
+  </CREDITCARDMSGSRSV1>
+</OFX>
+`
+
+func TestParseSGMLLatin1(t *testing.T) {
+	latin1, err := charmap.Windows1252.NewEncoder().String(sgmlStatement)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statements, err := Parse(strings.NewReader(latin1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statements) != 1 {
+		t.Fatalf("got %d statements", len(statements))
+	}
+
+	s := statements[0]
+	if s.Currency != "BRL" || s.BankID != "0341" || s.AccountID != "56789-0" || s.AccountType != "CHECKING" {
+		t.Errorf("unexpected account data: %+v", s)
+	}
+	if !s.Start.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) || !s.End.Equal(time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("period = %v..%v", s.Start, s.End)
+	}
+	if len(s.Transactions) != 2 {
+		t.Fatalf("got %d transactions", len(s.Transactions))
+	}
+
+	first := s.Transactions[0]
+	if first.FITID != "202601050001" || first.Type != "DEBIT" || first.Amount.String() != "-150.75 BRL" {
+		t.Errorf("first transaction = %+v (%s)", first, first.Amount)
+	}
+	if first.Memo != "PADARIA SÃO JOÃO" {
+		t.Errorf("memo = %q", first.Memo)
+	}
+	if !first.Posted.Equal(time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("posted = %v", first.Posted)
+	}
+	if name := s.Transactions[1].Name; name != "SALARIO & BONUS" {
+		t.Errorf("name = %q", name)
+	}
+
+	if s.LedgerBalance == nil || s.LedgerBalance.Amount.Decimal() != "2349.25" {
+		t.Errorf("ledger balance = %+v", s.LedgerBalance)
+	}
+}
+
+func TestParseXML(t *testing.T) {
+	statements, err := Parse(strings.NewReader(xmlStatement))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := statements[0]
+	if s.AccountType != "CREDITCARD" || s.AccountID != "4111********1111" {
+		t.Errorf("unexpected account data: %+v", s)
+	}
+	if len(s.Transactions) != 1 || s.Transactions[0].Name != "Livraria Ação" || s.Transactions[0].Amount.Decimal() != "-89.90" {
+		t.Errorf("transactions = %+v", s.Transactions)
+	}
+	if s.LedgerBalance == nil || !s.LedgerBalance.AsOf.Equal(time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("ledger balance = %+v", s.LedgerBalance)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, input := range []string{
+		"not an ofx file",
+		"<OFX><SIGNONMSGSRSV1></SIGNONMSGSRSV1></OFX>",
+		"<OFX><STMTRS><CURDEF>BRL<BANKTRANLIST><STMTTRN><DTPOSTED>2026<TRNAMT>1.00</STMTTRN></BANKTRANLIST></STMTRS></OFX>",
+	} {
+		if _, err := Parse(strings.NewReader(input)); !errors.Is(err, ErrInvalidOFX) {
+			t.Errorf("Parse(%q) error = %v", input, err)
+		}
+	}
+}
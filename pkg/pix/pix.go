@@ -0,0 +1,346 @@
+// Package pix monta e lê o BR Code do Pix: o payload EMV MPM (QR Code
+// "merchant presented") que o pagador escaneia ou cola no aplicativo do banco
+// ("Pix copia e cola"). O BR Code estático traz a chave e, opcionalmente, o
+// valor; o dinâmico traz a URL da cobrança criada no PSP.
+package pix
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"finanvilla/pkg/brdoc"
+	"finanvilla/pkg/money"
+
+	"github.com/google/uuid"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	ErrInvalidPayload = errors.New("invalid Pix BR Code")
+	ErrInvalidKey     = errors.New("invalid Pix key")
+)
+
+// Identificadores do BR Code (Manual de Padrões para Iniciação do Pix)
+const (
+	idFormatIndicator   = "00"
+	idInitiationMethod  = "01"
+	idMerchantAccount   = "26"
+	idCategoryCode      = "52"
+	idCurrency          = "53"
+	idAmount            = "54"
+	idCountryCode       = "58"
+	idMerchantName      = "59"
+	idMerchantCity      = "60"
+	idPostalCode        = "61"
+	idAdditionalData    = "62"
+	idCRC               = "63"
+	idGUI               = "00"
+	idKey               = "01"
+	idInfo              = "02"
+	idURL               = "25"
+	idTxID              = "05"
+	gui                 = "br.gov.bcb.pix"
+	currencyBRL         = "986"
+	initiationSingleUse = "12"
+	// noTxID é o txid dos BR Codes sem identificador e dos dinâmicos, cujo
+	// identificador está na cobrança do PSP
+	noTxID = "***"
+
+	maxNameLength = 25
+	maxCityLength = 15
+	maxTxIDLength = 25
+)
+
+type KeyType string
+
+const (
+	CPFKey    KeyType = "CPF"
+	CNPJKey   KeyType = "CNPJ"
+	EmailKey  KeyType = "EMAIL"
+	PhoneKey  KeyType = "PHONE"
+	RandomKey KeyType = "EVP"
+)
+
+// NormalizeKey identifica o tipo da chave e a devolve no formato do DICT:
+// CPF/CNPJ só com dígitos, telefone em E.164, e-mail e chave aleatória em
+// minúsculas
+func NormalizeKey(key string) (string, KeyType, error) {
+	key = strings.TrimSpace(key)
+	switch {
+	case key == "":
+		return "", "", ErrInvalidKey
+	case strings.Contains(key, "@"):
+		email := strings.ToLower(key)
+		local, domain, _ := strings.Cut(email, "@")
+		if len(email) > 77 || local == "" || !strings.Contains(domain, ".") || strings.ContainsAny(domain, "@ ") {
+			return "", "", ErrInvalidKey
+		}
+		return email, EmailKey, nil
+	case strings.HasPrefix(key, "+"):
+		phone, err := brdoc.NormalizePhone(key)
+		if err != nil || !strings.HasPrefix(phone, "+55") {
+			return "", "", ErrInvalidKey
+		}
+		return phone, PhoneKey, nil
+	}
+
+	if id, err := uuid.Parse(key); err == nil && len(key) == 36 {
+		return id.String(), RandomKey, nil
+	}
+	if strings.Trim(key, "0123456789.-/") != "" {
+		return "", "", ErrInvalidKey
+	}
+	if cpf, err := brdoc.NormalizeCPF(key); err == nil {
+		return cpf, CPFKey, nil
+	}
+	if cnpj, err := brdoc.NormalizeCNPJ(key); err == nil {
+		return cnpj, CNPJKey, nil
+	}
+	return "", "", ErrInvalidKey
+}
+
+// Payload são os dados de uma cobrança Pix
+type Payload struct {
+	// Key é a chave do recebedor (BR Code estático)
+	Key string
+	// URL é o endereço da cobrança no PSP, sem "https://" (BR Code dinâmico)
+	URL string
+	// Description é a mensagem ao pagador, só no estático
+	Description  string
+	MerchantName string
+	MerchantCity string
+	PostalCode   string
+	// Amount zero deixa o valor para o pagador informar
+	Amount money.Money
+	// TxID identifica a cobrança na conciliação; só letras e dígitos
+	TxID string
+	// SingleUse pede ao aplicativo que não aceite pagar o código duas vezes
+	SingleUse bool
+}
+
+func (p Payload) Dynamic() bool { return p.URL != "" }
+
+// Encode monta o BR Code com o CRC16 no fim. Nome e cidade perdem os acentos
+// e são cortados nos limites do padrão (25 e 15 caracteres).
+func Encode(p Payload) (string, error) {
+	account := field(idGUI, gui)
+	switch {
+	case p.URL != "" && p.Key != "":
+		return "", fmt.Errorf("%w: use either a key or a URL", ErrInvalidPayload)
+	case p.URL != "":
+		url := strings.TrimPrefix(strings.TrimSpace(p.URL), "https://")
+		if url == "" || strings.Contains(url, "://") {
+			return "", fmt.Errorf("%w: invalid URL", ErrInvalidPayload)
+		}
+		account += field(idURL, url)
+	case p.Key != "":
+		key, _, err := NormalizeKey(p.Key)
+		if err != nil {
+			return "", err
+		}
+		account += field(idKey, key)
+		if desc := ascii(p.Description); desc != "" {
+			account += field(idInfo, desc)
+		}
+	default:
+		return "", fmt.Errorf("%w: a key or a URL is required", ErrInvalidPayload)
+	}
+	if len(account) > 99 {
+		return "", fmt.Errorf("%w: key and description are too long", ErrInvalidPayload)
+	}
+
+	name, city := truncate(ascii(p.MerchantName), maxNameLength), truncate(ascii(p.MerchantCity), maxCityLength)
+	if name == "" || city == "" {
+		return "", fmt.Errorf("%w: merchant name and city are required", ErrInvalidPayload)
+	}
+
+	txID := noTxID
+	if !p.Dynamic() && p.TxID != "" {
+		if len(p.TxID) > maxTxIDLength || strings.IndexFunc(p.TxID, notAlphanumeric) >= 0 {
+			return "", fmt.Errorf("%w: txid must have up to %d letters and digits", ErrInvalidPayload, maxTxIDLength)
+		}
+		txID = p.TxID
+	}
+
+	var b strings.Builder
+	b.WriteString(field(idFormatIndicator, "01"))
+	if p.SingleUse {
+		b.WriteString(field(idInitiationMethod, initiationSingleUse))
+	}
+	b.WriteString(field(idMerchantAccount, account))
+	b.WriteString(field(idCategoryCode, "0000"))
+	b.WriteString(field(idCurrency, currencyBRL))
+	if !p.Amount.IsZero() {
+		amount, err := p.Amount.WithCurrency("BRL")
+		if err != nil || amount.Sign() < 0 {
+			return "", fmt.Errorf("%w: amount must be a positive value in BRL", ErrInvalidPayload)
+		}
+		b.WriteString(field(idAmount, amount.Decimal()))
+	}
+	b.WriteString(field(idCountryCode, "BR"))
+	b.WriteString(field(idMerchantName, name))
+	b.WriteString(field(idMerchantCity, city))
+	if postal := strings.Map(digitsOnly, p.PostalCode); postal != "" {
+		b.WriteString(field(idPostalCode, postal))
+	}
+	b.WriteString(field(idAdditionalData, field(idTxID, txID)))
+
+	b.WriteString(idCRC + "04")
+	return b.String() + fmt.Sprintf("%04X", CRC16([]byte(b.String()))), nil
+}
+
+// Parse lê um BR Code colado pelo usuário, conferindo o CRC
+func Parse(s string) (*Payload, error) {
+	s = strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\r' || r == '\t' {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(s))
+
+	if len(s) < 8 || s[len(s)-8:len(s)-4] != idCRC+"04" {
+		return nil, fmt.Errorf("%w: missing CRC", ErrInvalidPayload)
+	}
+	crc, err := strconv.ParseUint(s[len(s)-4:], 16, 16)
+	if err != nil || uint16(crc) != CRC16([]byte(s[:len(s)-4])) {
+		return nil, fmt.Errorf("%w: CRC mismatch", ErrInvalidPayload)
+	}
+
+	fields, err := parseFields(s[:len(s)-8])
+	if err != nil {
+		return nil, err
+	}
+	if fields[idFormatIndicator] != "01" {
+		return nil, fmt.Errorf("%w: unsupported payload format", ErrInvalidPayload)
+	}
+	if currency, ok := fields[idCurrency]; ok && currency != currencyBRL {
+		return nil, fmt.Errorf("%w: currency %s is not BRL", ErrInvalidPayload, currency)
+	}
+
+	p := &Payload{
+		MerchantName: fields[idMerchantName],
+		MerchantCity: fields[idMerchantCity],
+		PostalCode:   fields[idPostalCode],
+		SingleUse:    fields[idInitiationMethod] == initiationSingleUse,
+	}
+
+	// O Pix pode vir em qualquer um dos templates 26 a 51, ao lado de outros arranjos
+	found := false
+	for id := 26; id <= 51 && !found; id++ {
+		value, ok := fields[strconv.Itoa(id)]
+		if !ok {
+			continue
+		}
+		account, err := parseFields(value)
+		if err != nil || !strings.EqualFold(account[idGUI], gui) {
+			continue
+		}
+		found = true
+		p.Key, p.URL, p.Description = account[idKey], account[idURL], account[idInfo]
+	}
+	if !found || (p.Key == "" && p.URL == "") {
+		return nil, fmt.Errorf("%w: no Pix merchant account information", ErrInvalidPayload)
+	}
+
+	if value, ok := fields[idAmount]; ok {
+		if p.Amount, err = money.ParseWithDecimal(value, "BRL", '.'); err != nil {
+			return nil, fmt.Errorf("%w: invalid amount %q", ErrInvalidPayload, value)
+		}
+	} else {
+		p.Amount = money.MustNew(0, "BRL")
+	}
+
+	if value, ok := fields[idAdditionalData]; ok {
+		additional, err := parseFields(value)
+		if err != nil {
+			return nil, err
+		}
+		if txID := additional[idTxID]; txID != noTxID {
+			p.TxID = txID
+		}
+	}
+	return p, nil
+}
+
+// CRC16 é o CRC-16/CCITT-FALSE (polinômio 0x1021, valor inicial 0xFFFF)
+func CRC16(data []byte) uint16 {
+	crc := uint16(0xFFFF)
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// field monta um TLV: id, tamanho com dois dígitos e valor
+func field(id, value string) string {
+	return fmt.Sprintf("%s%02d%s", id, utf8.RuneCountInString(value), value)
+}
+
+// parseFields lê uma sequência de TLVs. O tamanho conta caracteres, não
+// bytes, para aceitar nomes acentuados gerados por outros aplicativos.
+func parseFields(s string) (map[string]string, error) {
+	fields := make(map[string]string)
+	rs := []rune(s)
+	for i := 0; i < len(rs); {
+		if i+4 > len(rs) {
+			return nil, fmt.Errorf("%w: truncated field at position %d", ErrInvalidPayload, i)
+		}
+		id := string(rs[i : i+2])
+		n, err := strconv.Atoi(string(rs[i+2 : i+4]))
+		if err != nil || i+4+n > len(rs) {
+			return nil, fmt.Errorf("%w: invalid length for field %s", ErrInvalidPayload, id)
+		}
+		fields[id] = string(rs[i+4 : i+4+n])
+		i += 4 + n
+	}
+	return fields, nil
+}
+
+// ascii remove acentos e troca o que não for ASCII imprimível por espaço,
+// como pedem os aplicativos mais antigos
+func ascii(s string) string {
+	// A cadeia guarda estado, então não pode ser compartilhada entre requisições
+	stripMarks := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(stripMarks, s)
+	if err != nil {
+		folded = s
+	}
+	folded = strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7E {
+			return ' '
+		}
+		return r
+	}, folded)
+	return strings.Join(strings.Fields(folded), " ")
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return strings.TrimSpace(s[:n])
+	}
+	return s
+}
+
+func notAlphanumeric(r rune) bool {
+	return !(r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+}
+
+func digitsOnly(r rune) rune {
+	if r >= '0' && r <= '9' {
+		return r
+	}
+	return -1
+}
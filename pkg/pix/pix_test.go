@@ -0,0 +1,104 @@
+package pix
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"finanvilla/pkg/money"
+)
+
+// Exemplo do Manual de Padrões para Iniciação do Pix
+const manualExample = "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D"
+
+func TestEncodeStatic(t *testing.T) {
+	got, err := Encode(Payload{
+		Key:          "123E4567-E12B-12D1-A456-426655440000",
+		MerchantName: "Fulano de Tal",
+		MerchantCity: "BRASÍLIA",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != manualExample {
+		t.Errorf("payload = %s\nwant      %s", got, manualExample)
+	}
+}
+
+func TestParseManualExample(t *testing.T) {
+	p, err := Parse(manualExample)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.MerchantName != "Fulano de Tal" || p.MerchantCity != "BRASILIA" || p.Dynamic() {
+		t.Errorf("parsed = %+v", p)
+	}
+
+	broken := strings.Replace(manualExample, "Fulano", "Fulana", 1)
+	if _, err := Parse(broken); !errors.Is(err, ErrInvalidPayload) {
+		t.Errorf("err = %v, want ErrInvalidPayload", err)
+	}
+}
+
+func TestRoundTripWithAmount(t *testing.T) {
+	in := Payload{
+		Key:          "+55 (11) 98765-4321",
+		Description:  "Conta de luz de março",
+		MerchantName: "Associação dos Moradores do Condomínio",
+		MerchantCity: "São José dos Campos",
+		Amount:       money.MustNew(12345, "BRL"),
+		TxID:         "LUZ202603",
+		SingleUse:    true,
+	}
+	s, err := Encode(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := Parse(" " + s + "\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Key != "+5511987654321" || p.Description != "Conta de luz de marco" || p.TxID != "LUZ202603" || !p.SingleUse {
+		t.Errorf("parsed = %+v", p)
+	}
+	if p.MerchantName != "Associacao dos Moradores" || p.MerchantCity != "Sao Jose dos Ca" {
+		t.Errorf("name/city = %q %q", p.MerchantName, p.MerchantCity)
+	}
+	if p.Amount.MinorUnits() != 12345 {
+		t.Errorf("amount = %s", p.Amount)
+	}
+
+	dynamic, err := Encode(Payload{URL: "https://pix.example.com/qr/v2/9d36b84f", MerchantName: "Loja", MerchantCity: "Recife", TxID: "ignored"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, err := Parse(dynamic); err != nil || p.URL != "pix.example.com/qr/v2/9d36b84f" || p.TxID != "" {
+		t.Errorf("dynamic = %+v, %v", p, err)
+	}
+}
+
+func TestNormalizeKey(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		key  string
+		kind KeyType
+	}{
+		{"529.982.247-25", "52998224725", CPFKey},
+		{"11.222.333/0001-81", "11222333000181", CNPJKey},
+		{"Fulano@Example.com", "fulano@example.com", EmailKey},
+		{"+5561912345678", "+5561912345678", PhoneKey},
+		{"123E4567-E12B-12D1-A456-426655440000", "123e4567-e12b-12d1-a456-426655440000", RandomKey},
+	} {
+		key, kind, err := NormalizeKey(tc.in)
+		if err != nil || key != tc.key || kind != tc.kind {
+			t.Errorf("NormalizeKey(%q) = %q, %s, %v", tc.in, key, kind, err)
+		}
+	}
+
+	for _, in := range []string{"", "12345678900", "+14155550100", "fulano@"} {
+		if _, _, err := NormalizeKey(in); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("NormalizeKey(%q) err = %v", in, err)
+		}
+	}
+}
@@ -0,0 +1,565 @@
+// Package qif lê e grava arquivos QIF (Quicken Interchange Format), o formato
+// de troca de Quicken, MS Money e GnuCash. Cada registro é uma sequência de
+// linhas "código + valor" terminada por "^"; linhas "!" abrem seções.
+//
+// O formato não declara a ordem das datas nem o separador decimal. Os dois
+// são deduzidos do arquivo inteiro: uma data com dia acima de 12 define a
+// ordem, e um valor com os dois separadores (ou um único seguido de um ou dois
+// dígitos) define o decimal. Conteúdo que não é UTF-8 válido é lido como
+// Windows-1252.
+package qif
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"finanvilla/pkg/money"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+var ErrInvalidQIF = errors.New("invalid QIF file")
+
+// SectionType é o tipo declarado em "!Type:" para as movimentações de uma conta
+type SectionType string
+
+const (
+	Bank           SectionType = "Bank"
+	CreditCard     SectionType = "CCard"
+	Cash           SectionType = "Cash"
+	OtherAsset     SectionType = "Oth A"
+	OtherLiability SectionType = "Oth L"
+	Investment     SectionType = "Invst"
+)
+
+var sectionTypes = []SectionType{Bank, CreditCard, Cash, OtherAsset, OtherLiability, Investment}
+
+// DateOrder é a ordem de dia e mês nas datas do arquivo
+type DateOrder string
+
+const (
+	MonthFirst DateOrder = "MDY"
+	DayFirst   DateOrder = "DMY"
+)
+
+// ClearedStatus é o campo C: em branco, conferido (* ou c) ou conciliado (X ou R)
+type ClearedStatus string
+
+const (
+	Uncleared  ClearedStatus = ""
+	Cleared    ClearedStatus = "*"
+	Reconciled ClearedStatus = "X"
+)
+
+type Options struct {
+	// DateOrder desempata arquivos em que nenhuma data tem dia acima de 12;
+	// vazio usa MonthFirst, o padrão do Quicken
+	DateOrder DateOrder
+	// Decimal força o separador decimal dos valores; 0 detecta pelo arquivo
+	Decimal rune
+}
+
+type File struct {
+	Accounts   []Account
+	Categories []Category
+}
+
+// Account reúne as movimentações que seguem um bloco !Account. Arquivos sem
+// !Account produzem uma conta sem nome por seção.
+type Account struct {
+	Name         string
+	Type         SectionType
+	Description  string
+	Transactions []Transaction
+}
+
+// Category vem de uma seção !Type:Cat; Name é o caminho completo ("Casa:Luz")
+type Category struct {
+	Name        string
+	Description string
+	Income      bool
+}
+
+// Transaction é um registro de uma seção de conta. Em seções de investimento,
+// Action (campo N) diz o que aconteceu e Amount é o total da operação.
+type Transaction struct {
+	Line     int
+	Date     time.Time
+	Amount   money.Money
+	Payee    string
+	Memo     string
+	Number   string
+	Cleared  ClearedStatus
+	Category string
+	// Transfer é o nome da conta de L[Conta]; exclui Category
+	Transfer string
+	Class    string
+	Splits   []Split
+
+	Action         string
+	Security       string
+	Price          money.Money
+	Quantity       money.Money
+	Commission     money.Money
+	TransferAmount money.Money
+
+	// Err indica que o registro não pôde ser lido; os outros campos podem
+	// estar incompletos
+	Err error
+}
+
+// Split é uma divisão do valor (linhas S, E e $), no mesmo sinal de Amount
+type Split struct {
+	Category string
+	Transfer string
+	Class    string
+	Memo     string
+	Amount   money.Money
+}
+
+type field struct {
+	code  byte
+	value string
+}
+
+type record struct {
+	line   int
+	fields []field
+}
+
+type block struct {
+	header  string
+	records []record
+}
+
+// Parse lê o arquivo inteiro. Registros com data ou valor ilegível não
+// interrompem a leitura: ficam com Err preenchido.
+func Parse(r io.Reader, opts Options) (*File, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if !utf8.Valid(data) {
+		if data, err = charmap.Windows1252.NewDecoder().Bytes(data); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidQIF, err)
+		}
+	}
+
+	blocks, err := splitBlocks(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{decimal: opts.Decimal}
+	if p.decimal == 0 {
+		p.decimal = detectDecimal(blocks)
+	}
+	if p.order, err = detectDateOrder(blocks, opts.DateOrder); err != nil {
+		return nil, err
+	}
+	return p.build(blocks), nil
+}
+
+// splitBlocks separa as seções e os registros sem interpretar os valores
+func splitBlocks(text string) ([]block, error) {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+
+	var blocks []block
+	var current *record
+	flush := func() {
+		if current != nil && len(current.fields) > 0 {
+			b := &blocks[len(blocks)-1]
+			b.records = append(b.records, *current)
+		}
+		current = nil
+	}
+
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, " \t")
+		switch {
+		case line == "":
+			continue
+		case line[0] == '!':
+			flush()
+			header := strings.ToLower(strings.TrimSpace(line[1:]))
+			header = strings.Replace(header, "type: ", "type:", 1)
+			blocks = append(blocks, block{header: header})
+		case len(blocks) == 0:
+			return nil, fmt.Errorf("%w: line %d: data before the first !Type header", ErrInvalidQIF, i+1)
+		case line[0] == '^':
+			flush()
+		default:
+			if current == nil {
+				current = &record{line: i + 1}
+			}
+			current.fields = append(current.fields, field{code: line[0], value: strings.TrimSpace(line[1:])})
+		}
+	}
+	flush()
+
+	if len(blocks) == 0 {
+		return nil, fmt.Errorf("%w: no !Type header found", ErrInvalidQIF)
+	}
+	return blocks, nil
+}
+
+func sectionType(header string) (SectionType, bool) {
+	name, ok := strings.CutPrefix(header, "type:")
+	if !ok {
+		return "", false
+	}
+	for _, t := range sectionTypes {
+		if strings.EqualFold(name, string(t)) {
+			return t, true
+		}
+	}
+	return "", false
+}
+
+// transactionFields percorre os campos das seções de conta com os códigos pedidos
+func transactionFields(blocks []block, codes string, fn func(value string) bool) {
+	for _, b := range blocks {
+		if _, ok := sectionType(b.header); !ok {
+			continue
+		}
+		for _, rec := range b.records {
+			for _, f := range rec.fields {
+				if strings.IndexByte(codes, f.code) >= 0 && !fn(f.value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// detectDecimal usa o primeiro valor que não deixa dúvida; sem nenhum, ponto
+func detectDecimal(blocks []block) rune {
+	decimal := '.'
+	transactionFields(blocks, "TU$IQO", func(value string) bool {
+		dot, comma := strings.LastIndexByte(value, '.'), strings.LastIndexByte(value, ',')
+		switch {
+		case dot >= 0 && comma >= 0:
+			if comma > dot {
+				decimal = ','
+			}
+			return false
+		case dot < 0 && comma < 0:
+			return true
+		}
+
+		sep, at := byte('.'), dot
+		if comma >= 0 {
+			sep, at = ',', comma
+		}
+		if strings.Count(value, string(sep)) > 1 {
+			// Repetido, só pode ser separador de milhar
+			decimal = ','
+			if sep == ',' {
+				decimal = '.'
+			}
+			return false
+		}
+		if digits := len(strings.TrimRight(value[at+1:], " -")); digits == 1 || digits == 2 {
+			decimal = rune(sep)
+			return false
+		}
+		return true
+	})
+	return decimal
+}
+
+func detectDateOrder(blocks []block, fallback DateOrder) (DateOrder, error) {
+	var dayFirst, monthFirst bool
+	transactionFields(blocks, "D", func(value string) bool {
+		parts, _, yearFirst, ok := splitDate(value)
+		if ok && !yearFirst {
+			dayFirst = dayFirst || parts[0] > 12
+			monthFirst = monthFirst || parts[1] > 12
+		}
+		return !(dayFirst && monthFirst)
+	})
+
+	switch {
+	case dayFirst && monthFirst:
+		return "", fmt.Errorf("%w: dates mix day-first and month-first formats", ErrInvalidQIF)
+	case dayFirst:
+		return DayFirst, nil
+	case monthFirst:
+		return MonthFirst, nil
+	case fallback == DayFirst:
+		return DayFirst, nil
+	}
+	return MonthFirst, nil
+}
+
+// splitDate aceita "12/31/2023", "31.12.2023", "2023-12-31" e as formas do
+// Quicken com ano de dois dígitos e espaços (" 1/ 5'24")
+func splitDate(s string) (parts [3]int, apostrophe, yearFirst, ok bool) {
+	s = strings.ReplaceAll(s, " ", "")
+	apostrophe = strings.Contains(s, "'")
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == '/' || r == '-' || r == '.' || r == '\''
+	})
+	if len(fields) != 3 {
+		return parts, false, false, false
+	}
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 {
+			return parts, false, false, false
+		}
+		parts[i] = n
+	}
+	if len(fields[0]) == 4 {
+		return parts, apostrophe, true, true
+	}
+	if len(fields[2]) <= 2 {
+		// Com apóstrofo o Quicken indica o século 2000; com barra, outros
+		// programas também abreviam anos recentes
+		switch {
+		case apostrophe || parts[2] < 70:
+			parts[2] += 2000
+		default:
+			parts[2] += 1900
+		}
+	}
+	return parts, apostrophe, false, true
+}
+
+type parser struct {
+	order   DateOrder
+	decimal rune
+}
+
+func (p *parser) date(s string) (time.Time, error) {
+	parts, _, yearFirst, ok := splitDate(s)
+	if !ok {
+		return time.Time{}, fmt.Errorf("invalid date %q", s)
+	}
+
+	year, month, day := parts[2], parts[0], parts[1]
+	switch {
+	case yearFirst:
+		year, month, day = parts[0], parts[1], parts[2]
+	case p.order == DayFirst:
+		month, day = parts[1], parts[0]
+	}
+
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if date.Year() != year || int(date.Month()) != month || date.Day() != day {
+		return time.Time{}, fmt.Errorf("invalid date %q", s)
+	}
+	return date, nil
+}
+
+func (p *parser) amount(s string) (money.Money, error) {
+	amount, err := money.ParseWithDecimal(s, "", p.decimal)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("invalid amount %q", s)
+	}
+	return amount, nil
+}
+
+func (p *parser) build(blocks []block) *File {
+	file := &File{}
+	current := -1
+
+	for _, b := range blocks {
+		switch b.header {
+		case "account":
+			for _, rec := range b.records {
+				current = file.account(parseAccount(rec))
+			}
+			continue
+		case "type:cat":
+			for _, rec := range b.records {
+				if c, ok := parseCategoryRecord(rec); ok {
+					file.Categories = append(file.Categories, c)
+				}
+			}
+			continue
+		}
+
+		section, ok := sectionType(b.header)
+		if !ok {
+			// Classes, memorizadas, cotações e títulos não interessam
+			continue
+		}
+		if current < 0 || (len(file.Accounts[current].Transactions) > 0 && file.Accounts[current].Type != section) {
+			file.Accounts = append(file.Accounts, Account{})
+			current = len(file.Accounts) - 1
+		}
+		account := &file.Accounts[current]
+		account.Type = section
+		for _, rec := range b.records {
+			account.Transactions = append(account.Transactions, p.transaction(rec, section == Investment))
+		}
+	}
+	return file
+}
+
+// account devolve o índice da conta com o nome dado, criando-a se preciso. Um
+// nome repetido (lista de contas do AutoSwitch seguida das movimentações)
+// aponta para a mesma conta.
+func (f *File) account(a Account) int {
+	for i := range f.Accounts {
+		if a.Name != "" && strings.EqualFold(f.Accounts[i].Name, a.Name) {
+			return i
+		}
+	}
+	f.Accounts = append(f.Accounts, a)
+	return len(f.Accounts) - 1
+}
+
+func parseAccount(rec record) Account {
+	var a Account
+	for _, f := range rec.fields {
+		switch f.code {
+		case 'N':
+			a.Name = f.value
+		case 'T':
+			a.Type = SectionType(f.value)
+			if t, ok := sectionType("type:" + strings.ToLower(f.value)); ok {
+				a.Type = t
+			}
+		case 'D':
+			a.Description = f.value
+		}
+	}
+	return a
+}
+
+func parseCategoryRecord(rec record) (Category, bool) {
+	var c Category
+	for _, f := range rec.fields {
+		switch f.code {
+		case 'N':
+			c.Name = f.value
+		case 'D':
+			c.Description = f.value
+		case 'I':
+			c.Income = true
+		case 'E':
+			c.Income = false
+		}
+	}
+	return c, c.Name != ""
+}
+
+func (p *parser) transaction(rec record, investment bool) Transaction {
+	t := Transaction{Line: rec.line}
+	fail := func(err error) {
+		if t.Err == nil {
+			t.Err = err
+		}
+	}
+	amount := func(s string) money.Money {
+		m, err := p.amount(s)
+		if err != nil {
+			fail(err)
+		}
+		return m
+	}
+
+	var hasDate, hasAmount bool
+	for _, f := range rec.fields {
+		v := f.value
+		split := len(t.Splits) - 1
+		switch f.code {
+		case 'D':
+			var err error
+			if t.Date, err = p.date(v); err != nil {
+				fail(err)
+			}
+			hasDate = true
+		case 'T', 'U':
+			// U repete T com mais casas em versões novas do Quicken
+			if !hasAmount {
+				t.Amount = amount(v)
+				hasAmount = true
+			}
+		case 'P':
+			t.Payee = v
+		case 'M':
+			t.Memo = v
+		case 'N':
+			if investment {
+				t.Action = v
+			} else {
+				t.Number = v
+			}
+		case 'C':
+			t.Cleared = parseCleared(v)
+		case 'L':
+			t.Category, t.Transfer, t.Class = parseCategory(v)
+		case 'S':
+			var s Split
+			s.Category, s.Transfer, s.Class = parseCategory(v)
+			t.Splits = append(t.Splits, s)
+		case 'E':
+			if split >= 0 {
+				t.Splits[split].Memo = v
+			}
+		case '$':
+			switch {
+			case investment:
+				t.TransferAmount = amount(v)
+			case split >= 0:
+				t.Splits[split].Amount = amount(v)
+			}
+		case 'Y':
+			t.Security = v
+		case 'I':
+			t.Price = amount(v)
+		case 'Q':
+			t.Quantity = amount(v)
+		case 'O':
+			t.Commission = amount(v)
+		}
+	}
+
+	if !hasDate {
+		fail(errors.New("missing date"))
+	}
+	if !hasAmount && !investment {
+		fail(errors.New("missing amount"))
+	}
+	return t
+}
+
+func parseCleared(v string) ClearedStatus {
+	switch strings.ToUpper(v) {
+	case "*", "C":
+		return Cleared
+	case "X", "R":
+		return Reconciled
+	}
+	return Uncleared
+}
+
+// parseCategory separa "Casa:Luz/Classe" ou "[Poupança]/Classe". O Quicken
+// grava "--Split--" em L quando a divisão está nas linhas S.
+func parseCategory(v string) (category, transfer, class string) {
+	if strings.HasPrefix(v, "[") {
+		if end := strings.IndexByte(v, ']'); end > 0 {
+			transfer = strings.TrimSpace(v[1:end])
+			if rest := v[end+1:]; strings.HasPrefix(rest, "/") {
+				class = strings.TrimSpace(rest[1:])
+			}
+			return "", transfer, class
+		}
+	}
+	if v == "--Split--" {
+		return "", "", ""
+	}
+	category, class, _ = strings.Cut(v, "/")
+	return strings.TrimSpace(category), "", strings.TrimSpace(class)
+}
@@ -0,0 +1,187 @@
+package qif
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+const bankFile = `!Option:AutoSwitch
+!Account
+NCorrente
+TBank
+^
+NPoupança
+TBank
+^
+!Clear:AutoSwitch
+!Type:Cat
+NAlimentação
+DComida
+E
+^
+NSalário
+I
+^
+!Account
+NCorrente
+TBank
+^
+!Type:Bank
+D05/01/2026
+T-1.234,50
+CX
+N1001
+PSupermercado Pão
+MCompra do mês
+SAlimentação:Mercado
+EMercado
+$-1.000,00
+SCasa/Reforma
+$-234,50
+^
+D25/01/2026
+T5.000,00
+PEmpresa
+LSalário
+^
+D31/01/2026
+T-500,00
+L[Poupança]
+^
+D32/01/2026
+T-10,00
+^
+`
+
+func TestParseBank(t *testing.T) {
+	file, err := Parse(strings.NewReader(bankFile), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(file.Categories) != 2 || !file.Categories[1].Income || file.Categories[0].Description != "Comida" {
+		t.Fatalf("categories = %+v", file.Categories)
+	}
+	if len(file.Accounts) != 2 {
+		t.Fatalf("accounts = %d, want 2", len(file.Accounts))
+	}
+
+	account := file.Accounts[0]
+	if account.Name != "Corrente" || account.Type != Bank || len(account.Transactions) != 4 {
+		t.Fatalf("account = %+v", account)
+	}
+
+	first := account.Transactions[0]
+	if first.Err != nil {
+		t.Fatal(first.Err)
+	}
+	if !first.Date.Equal(time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("date = %v, want 2026-01-05 (day-first detected)", first.Date)
+	}
+	if first.Amount.Decimal() != "-1234.5" || first.Cleared != Reconciled || first.Number != "1001" {
+		t.Errorf("first = %+v", first)
+	}
+	if len(first.Splits) != 2 {
+		t.Fatalf("splits = %+v", first.Splits)
+	}
+	if s := first.Splits[0]; s.Category != "Alimentação:Mercado" || s.Memo != "Mercado" || s.Amount.Decimal() != "-1000" {
+		t.Errorf("split 0 = %+v", s)
+	}
+	if s := first.Splits[1]; s.Category != "Casa" || s.Class != "Reforma" {
+		t.Errorf("split 1 = %+v", s)
+	}
+
+	if tr := account.Transactions[2]; tr.Transfer != "Poupança" || tr.Category != "" {
+		t.Errorf("transfer = %+v", tr)
+	}
+	if bad := account.Transactions[3]; bad.Err == nil || bad.Line != 44 {
+		t.Errorf("invalid date: line %d, err %v", bad.Line, bad.Err)
+	}
+}
+
+func TestParseInvestmentLatin1(t *testing.T) {
+	content := "!Type:Invst\r\nD1/15'26\r\nNBuy\r\nYAÇÃO3\r\nI35.125\r\nQ100\r\nT3,512.50\r\nO10\r\n^\r\n" +
+		"D2/1'26\r\nNDiv\r\nYAÇÃO3\r\nT120.00\r\nLRendimentos\r\n^\r\n"
+	latin1, err := charmap.Windows1252.NewEncoder().String(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := Parse(strings.NewReader(latin1), Options{DateOrder: DayFirst})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(file.Accounts) != 1 || file.Accounts[0].Type != Investment || file.Accounts[0].Name != "" {
+		t.Fatalf("accounts = %+v", file.Accounts)
+	}
+
+	buy := file.Accounts[0].Transactions[0]
+	if buy.Err != nil {
+		t.Fatal(buy.Err)
+	}
+	if buy.Action != "Buy" || buy.Security != "AÇÃO3" || buy.Quantity.Decimal() != "100" {
+		t.Errorf("buy = %+v", buy)
+	}
+	if buy.Amount.Decimal() != "3512.5" || buy.Price.Decimal() != "35.125" || buy.Commission.Decimal() != "10" {
+		t.Errorf("buy amounts = %s %s %s", buy.Amount.Decimal(), buy.Price.Decimal(), buy.Commission.Decimal())
+	}
+	if !buy.Date.Equal(time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("date = %v, want 2026-01-15 (month-first detected)", buy.Date)
+	}
+	if div := file.Accounts[0].Transactions[1]; div.Action != "Div" || div.Category != "Rendimentos" {
+		t.Errorf("div = %+v", div)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	if _, err := Parse(strings.NewReader("D01/01/2026\nT10\n^\n"), Options{}); !errors.Is(err, ErrInvalidQIF) {
+		t.Errorf("data before header: err = %v", err)
+	}
+	if _, err := Parse(strings.NewReader("!Type:Bank\nD13/01/2026\nT1\n^\nD01/13/2026\nT1\n^\n"), Options{}); !errors.Is(err, ErrInvalidQIF) {
+		t.Errorf("mixed date orders: err = %v", err)
+	}
+}
+
+func TestWriterRoundTrip(t *testing.T) {
+	file, err := Parse(strings.NewReader(bankFile), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	account := file.Accounts[0]
+	account.Transactions = account.Transactions[:3]
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf, MonthFirst)
+	w.WriteCategories(file.Categories)
+	w.WriteAccount(account)
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "D01/05/2026\nT-1234.5\nCX\nN1001\n") {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+
+	again, err := Parse(&buf, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again.Categories) != 2 || len(again.Accounts) != 1 {
+		t.Fatalf("round trip = %+v", again)
+	}
+	got := again.Accounts[0]
+	if got.Name != "Corrente" || len(got.Transactions) != 3 {
+		t.Fatalf("account = %+v", got)
+	}
+	for i, tr := range got.Transactions {
+		want := account.Transactions[i]
+		if tr.Err != nil || !tr.Date.Equal(want.Date) || tr.Amount.Decimal() != want.Amount.Decimal() ||
+			tr.Category != want.Category || tr.Transfer != want.Transfer || len(tr.Splits) != len(want.Splits) {
+			t.Errorf("transaction %d = %+v, want %+v", i, tr, want)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package qif
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"finanvilla/pkg/money"
+)
+
+// Writer grava QIF em fluxo, com ano de quatro dígitos e ponto decimal
+type Writer struct {
+	w       *bufio.Writer
+	layout  string
+	section SectionType
+	err     error
+}
+
+func NewWriter(w io.Writer, order DateOrder) *Writer {
+	layout := "01/02/2006"
+	if order == DayFirst {
+		layout = "02/01/2006"
+	}
+	return &Writer{w: bufio.NewWriter(w), layout: layout}
+}
+
+func (w *Writer) WriteCategories(categories []Category) error {
+	w.line("!Type:Cat")
+	for _, c := range categories {
+		w.field('N', c.Name)
+		w.field('D', c.Description)
+		if c.Income {
+			w.line("I")
+		} else {
+			w.line("E")
+		}
+		w.line("^")
+	}
+	return w.err
+}
+
+// WriteAccount abre a seção da conta e grava as movimentações que ela já
+// traz; outras podem seguir com WriteTransaction
+func (w *Writer) WriteAccount(a Account) error {
+	if a.Type == "" {
+		a.Type = Bank
+	}
+	w.line("!Account")
+	w.field('N', a.Name)
+	w.field('T', string(a.Type))
+	w.field('D', a.Description)
+	w.line("^")
+	w.line("!Type:" + string(a.Type))
+	w.section = a.Type
+
+	for _, t := range a.Transactions {
+		w.WriteTransaction(t)
+	}
+	return w.err
+}
+
+func (w *Writer) WriteTransaction(t Transaction) error {
+	w.field('D', t.Date.Format(w.layout))
+	if w.section == Investment {
+		w.field('N', t.Action)
+		w.field('Y', t.Security)
+		w.amount('I', t.Price)
+		w.amount('Q', t.Quantity)
+	}
+	w.field('T', t.Amount.Decimal())
+	w.field('C', string(t.Cleared))
+	if w.section != Investment {
+		w.field('N', t.Number)
+	}
+	w.field('P', t.Payee)
+	w.field('M', t.Memo)
+	if w.section == Investment {
+		w.amount('O', t.Commission)
+	}
+	w.field('L', categoryField(t.Category, t.Transfer, t.Class))
+	if w.section == Investment {
+		w.amount('$', t.TransferAmount)
+	}
+
+	for _, s := range t.Splits {
+		w.line("S" + categoryField(s.Category, s.Transfer, s.Class))
+		w.field('E', s.Memo)
+		w.field('$', s.Amount.Decimal())
+	}
+	w.line("^")
+	return w.err
+}
+
+func (w *Writer) Flush() error {
+	if w.err == nil {
+		w.err = w.w.Flush()
+	}
+	return w.err
+}
+
+func categoryField(category, transfer, class string) string {
+	v := category
+	if transfer != "" {
+		v = "[" + transfer + "]"
+	}
+	if class != "" {
+		v += "/" + class
+	}
+	return v
+}
+
+func (w *Writer) amount(code byte, m money.Money) {
+	if !m.IsZero() {
+		w.field(code, m.Decimal())
+	}
+}
+
+// field omite valores vazios; quebras de linha viram espaço para não abrir
+// um campo novo
+func (w *Writer) field(code byte, value string) {
+	if value == "" {
+		return
+	}
+	value = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(value)
+	w.line(string(code) + value)
+}
+
+func (w *Writer) line(s string) {
+	if w.err != nil {
+		return
+	}
+	if _, err := w.w.WriteString(s + "\n"); err != nil {
+		w.err = err
+	}
+}
@@ -0,0 +1,171 @@
+package qrcode
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestReedSolomon(t *testing.T) {
+	// Exemplo "HELLO WORLD" 1-M do tutorial da norma
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+
+	if got := reedSolomonRemainder(data, reedSolomonDivisor(len(want))); !bytes.Equal(got, want) {
+		t.Errorf("ecc = %v, want %v", got, want)
+	}
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		data    string
+		level   Level
+		version int
+	}{
+		{"hello", Medium, 1},
+		{strings.Repeat("Pix copia e cola ", 12), Medium, 10},
+		{strings.Repeat("0123456789", 30), Medium, 13},
+	} {
+		c, err := Encode([]byte(tc.data), tc.level)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.Version != tc.version {
+			t.Errorf("len %d: version = %d, want %d", len(tc.data), c.Version, tc.version)
+		}
+
+		level, mask := readFormat(t, c)
+		if level != tc.level {
+			t.Fatalf("format level = %d, want %d", level, tc.level)
+		}
+		if got := readData(c, mask); got != tc.data {
+			t.Errorf("decoded %q, want %q", got, tc.data)
+		}
+	}
+
+	if _, err := Encode(make([]byte, 3000), High); err != ErrTooLong {
+		t.Errorf("err = %v, want ErrTooLong", err)
+	}
+}
+
+func TestPNG(t *testing.T) {
+	c, err := Encode([]byte("hello"), Medium)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := c.PNG(300)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 21 módulos + 8 de margem, 10 pixels cada
+	if b := img.Bounds(); b.Dx() != 290 || b.Dy() != 290 {
+		t.Errorf("bounds = %v", b)
+	}
+}
+
+// readFormat confere que as duas cópias do campo de formato são iguais
+func readFormat(t *testing.T, c *Code) (Level, int) {
+	var first, second int
+	for i := 0; i <= 5; i++ {
+		first |= bit(c.Dark(8, i)) << i
+	}
+	first |= bit(c.Dark(8, 7))<<6 | bit(c.Dark(8, 8))<<7 | bit(c.Dark(7, 8))<<8
+	for i := 9; i < 15; i++ {
+		first |= bit(c.Dark(14-i, 8)) << i
+	}
+	for i := 0; i < 8; i++ {
+		second |= bit(c.Dark(c.Size-1-i, 8)) << i
+	}
+	for i := 8; i < 15; i++ {
+		second |= bit(c.Dark(8, c.Size-15+i)) << i
+	}
+	if first != second {
+		t.Fatalf("format copies differ: %015b %015b", first, second)
+	}
+
+	bits := (first ^ 0x5412) >> 10
+	for level, code := range formatBits {
+		if code == bits>>3 {
+			return Level(level), bits & 7
+		}
+	}
+	t.Fatalf("unknown level in format %015b", first)
+	return 0, 0
+}
+
+// readData faz o caminho inverso de Encode: remove a máscara, lê os bytes em
+// zigue-zague, desfaz a intercalação e interpreta o segmento em modo byte
+func readData(c *Code, mask int) string {
+	c.applyMask(mask)
+	defer c.applyMask(mask)
+
+	var raw []byte
+	var cur byte
+	n := 0
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < c.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x, y := right-j, vert
+				if (right+1)&2 == 0 {
+					y = c.Size - 1 - vert
+				}
+				if c.isFunction[y][x] {
+					continue
+				}
+				cur = cur<<1 | byte(bit(c.modules[y][x]))
+				if n++; n%8 == 0 {
+					raw = append(raw, cur)
+					cur = 0
+				}
+			}
+		}
+	}
+
+	numBlocks := numErrorCorrectionBlocks[c.Level][c.Version]
+	eccLen := eccCodewordsPerBlock[c.Level][c.Version]
+	total := numRawDataModules(c.Version) / 8
+	numShort := numBlocks - total%numBlocks
+	shortData := total/numBlocks - eccLen
+
+	blocks := make([][]byte, numBlocks)
+	k := 0
+	for i := 0; i < shortData+1; i++ {
+		for j := range blocks {
+			if i < shortData || j >= numShort {
+				blocks[j] = append(blocks[j], raw[k])
+				k++
+			}
+		}
+	}
+	var data []byte
+	for _, b := range blocks {
+		data = append(data, b...)
+	}
+
+	count := int(data[0]&0x0F)<<4 | int(data[1]>>4)
+	start := 1
+	if c.Version >= 10 {
+		count = count<<8 | int(data[1]&0x0F)<<4 | int(data[2]>>4)
+		start = 2
+	}
+	out := make([]byte, count)
+	for i := range out {
+		out[i] = data[start+i]<<4 | data[start+i+1]>>4
+	}
+	return string(out)
+}
+
+func bit(dark bool) int {
+	if dark {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,323 @@
+// Package recurrence interpreta regras de repetição no estilo RRULE (RFC 5545)
+// e calcula as datas das ocorrências, com ajuste para dias úteis bancários.
+//
+// Além de FREQ, INTERVAL, BYMONTHDAY, BYMONTH, COUNT e UNTIL, aceita duas
+// extensões:
+//   - X-BUSINESSDAY=N: n-ésimo dia útil do mês (-1 = último dia útil);
+//   - X-ADJUST=FOLLOWING|PRECEDING|MODIFIED_FOLLOWING: move ocorrências que
+//     caem em fim de semana ou feriado para o dia útil seguinte ou anterior.
+//
+// Exemplos: "FREQ=MONTHLY;BYMONTHDAY=5;X-ADJUST=FOLLOWING" (dia 5 ou o próximo
+// dia útil), "FREQ=MONTHLY;X-BUSINESSDAY=-1" (último dia útil),
+// "FREQ=WEEKLY;INTERVAL=2" (a cada duas semanas), "FREQ=YEARLY;BYMONTH=12;BYMONTHDAY=20".
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"finanvilla/pkg/calendar"
+)
+
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+type Adjustment string
+
+const (
+	NoAdjustment      Adjustment = ""
+	Following         Adjustment = "FOLLOWING"
+	Preceding         Adjustment = "PRECEDING"
+	ModifiedFollowing Adjustment = "MODIFIED_FOLLOWING" // seguinte, a menos que mude o mês
+)
+
+// maxIterations limita o laço de períodos para regras que nunca produzem datas no intervalo
+const maxIterations = 100000
+
+// Rule é uma regra já validada. Campos zerados assumem os valores da data de
+// início: BYMONTHDAY usa o dia dela e BYMONTH, o mês.
+type Rule struct {
+	Freq        Frequency
+	Interval    int
+	MonthDay    int // 1..31 ou -1..-31 (a partir do fim); dias inexistentes caem no último dia do mês
+	Month       int // 1..12, só para YEARLY
+	BusinessDay int // n-ésimo dia útil do mês; exclusivo com MonthDay
+	Adjust      Adjustment
+	Count       int
+	Until       time.Time
+}
+
+// Parse lê uma regra como "FREQ=MONTHLY;BYMONTHDAY=5"; o prefixo "RRULE:" é opcional
+func Parse(s string) (Rule, error) {
+	var r Rule
+	s = strings.TrimPrefix(strings.TrimSpace(strings.ToUpper(s)), "RRULE:")
+	if s == "" {
+		return r, fmt.Errorf("%w: empty rule", ErrInvalidRule)
+	}
+
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return r, fmt.Errorf("%w: malformed part %q", ErrInvalidRule, part)
+		}
+		if seen[key] {
+			return r, fmt.Errorf("%w: %s appears twice", ErrInvalidRule, key)
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "FREQ":
+			r.Freq = Frequency(value)
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(value)
+		case "BYMONTHDAY":
+			r.MonthDay, err = strconv.Atoi(value)
+		case "BYMONTH":
+			r.Month, err = strconv.Atoi(value)
+		case "X-BUSINESSDAY":
+			r.BusinessDay, err = strconv.Atoi(value)
+		case "X-ADJUST":
+			r.Adjust = Adjustment(value)
+		case "COUNT":
+			r.Count, err = strconv.Atoi(value)
+		case "UNTIL":
+			// Só a data importa; a parte de hora (20261231T235959Z) é descartada
+			date, _, _ := strings.Cut(value, "T")
+			r.Until, err = time.Parse("20060102", date)
+		default:
+			return r, fmt.Errorf("%w: unsupported part %s", ErrInvalidRule, key)
+		}
+		if err != nil {
+			return r, fmt.Errorf("%w: invalid %s", ErrInvalidRule, key)
+		}
+	}
+
+	if r.Interval == 0 && !seen["INTERVAL"] {
+		r.Interval = 1
+	}
+	return r, r.Validate()
+}
+
+func (r Rule) Validate() error {
+	switch r.Freq {
+	case Daily, Weekly, Monthly, Yearly:
+	case "":
+		return fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	default:
+		return fmt.Errorf("%w: unsupported FREQ %s", ErrInvalidRule, r.Freq)
+	}
+
+	monthBased := r.Freq == Monthly || r.Freq == Yearly
+	switch {
+	case r.Interval < 1 || r.Interval > 1000:
+		return fmt.Errorf("%w: INTERVAL must be between 1 and 1000", ErrInvalidRule)
+	case r.MonthDay < -31 || r.MonthDay > 31:
+		return fmt.Errorf("%w: BYMONTHDAY must be between -31 and 31", ErrInvalidRule)
+	case r.MonthDay != 0 && !monthBased:
+		return fmt.Errorf("%w: BYMONTHDAY requires FREQ=MONTHLY or YEARLY", ErrInvalidRule)
+	case r.Month < 0 || r.Month > 12:
+		return fmt.Errorf("%w: BYMONTH must be between 1 and 12", ErrInvalidRule)
+	case r.Month != 0 && r.Freq != Yearly:
+		return fmt.Errorf("%w: BYMONTH requires FREQ=YEARLY", ErrInvalidRule)
+	case r.BusinessDay < -23 || r.BusinessDay > 23:
+		return fmt.Errorf("%w: X-BUSINESSDAY must be between -23 and 23", ErrInvalidRule)
+	case r.BusinessDay != 0 && !monthBased:
+		return fmt.Errorf("%w: X-BUSINESSDAY requires FREQ=MONTHLY or YEARLY", ErrInvalidRule)
+	case r.BusinessDay != 0 && (r.MonthDay != 0 || r.Adjust != NoAdjustment):
+		return fmt.Errorf("%w: X-BUSINESSDAY cannot be combined with BYMONTHDAY or X-ADJUST", ErrInvalidRule)
+	case r.Count < 0:
+		return fmt.Errorf("%w: COUNT must be positive", ErrInvalidRule)
+	case r.Count > 0 && !r.Until.IsZero():
+		return fmt.Errorf("%w: COUNT and UNTIL are mutually exclusive", ErrInvalidRule)
+	}
+
+	switch r.Adjust {
+	case NoAdjustment, Following, Preceding, ModifiedFollowing:
+	default:
+		return fmt.Errorf("%w: unsupported X-ADJUST %s", ErrInvalidRule, r.Adjust)
+	}
+	return nil
+}
+
+// String devolve a regra na forma canônica, com as partes sempre na mesma ordem
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Month != 0 {
+		parts = append(parts, "BYMONTH="+strconv.Itoa(r.Month))
+	}
+	if r.MonthDay != 0 {
+		parts = append(parts, "BYMONTHDAY="+strconv.Itoa(r.MonthDay))
+	}
+	if r.BusinessDay != 0 {
+		parts = append(parts, "X-BUSINESSDAY="+strconv.Itoa(r.BusinessDay))
+	}
+	if r.Adjust != NoAdjustment {
+		parts = append(parts, "X-ADJUST="+string(r.Adjust))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Between devolve as ocorrências da série iniciada em start com data entre
+// from e to, inclusive. Ocorrências anteriores a start não existem nem contam
+// para COUNT.
+func (r Rule) Between(start, from, to time.Time) []time.Time {
+	from, to = dateOf(from), dateOf(to)
+
+	var dates []time.Time
+	r.each(start, to, func(date time.Time) bool {
+		if !date.Before(from) {
+			dates = append(dates, date)
+		}
+		return true
+	})
+	return dates
+}
+
+// Next devolve a primeira ocorrência em after ou depois; false quando a série acabou
+func (r Rule) Next(start, after time.Time) (time.Time, bool) {
+	after = dateOf(after)
+
+	var next time.Time
+	found := false
+	r.each(start, time.Time{}, func(date time.Time) bool {
+		if date.Before(after) {
+			return true
+		}
+		next, found = date, true
+		return false
+	})
+	return next, found
+}
+
+// Upcoming devolve até n ocorrências a partir de after
+func (r Rule) Upcoming(start, after time.Time, n int) []time.Time {
+	if n <= 0 {
+		return nil
+	}
+	after = dateOf(after)
+
+	var dates []time.Time
+	r.each(start, time.Time{}, func(date time.Time) bool {
+		if date.Before(after) {
+			return true
+		}
+		dates = append(dates, date)
+		return len(dates) < n
+	})
+	return dates
+}
+
+// each percorre as ocorrências em ordem até fn devolver false, a série acabar
+// ou, com limit definido, passar de limit. Os ajustes preservam a ordem das
+// datas, então a primeira ocorrência além do limite encerra o laço.
+func (r Rule) each(start, limit time.Time, fn func(time.Time) bool) {
+	start = dateOf(start)
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	var last time.Time
+	produced := 0
+	for n := 0; n < maxIterations; n++ {
+		nominal := r.nominal(start, n*interval)
+		date := r.adjust(nominal)
+		if date.Before(start) || date.Equal(last) {
+			continue
+		}
+		if !r.Until.IsZero() && date.After(r.Until) {
+			return
+		}
+		if !limit.IsZero() && date.After(limit) {
+			return
+		}
+
+		last = date
+		produced++
+		if !fn(date) {
+			return
+		}
+		if r.Count > 0 && produced >= r.Count {
+			return
+		}
+	}
+}
+
+// nominal calcula a data do período deslocado em offset unidades de FREQ, antes do ajuste
+func (r Rule) nominal(start time.Time, offset int) time.Time {
+	switch r.Freq {
+	case Daily:
+		return start.AddDate(0, 0, offset)
+	case Weekly:
+		return start.AddDate(0, 0, 7*offset)
+	case Yearly:
+		month := start.Month()
+		if r.Month != 0 {
+			month = time.Month(r.Month)
+		}
+		return r.dayInMonth(start, start.Year()+offset, month)
+	default:
+		first := time.Date(start.Year(), start.Month()+time.Month(offset), 1, 0, 0, 0, 0, time.UTC)
+		return r.dayInMonth(start, first.Year(), first.Month())
+	}
+}
+
+func (r Rule) dayInMonth(start time.Time, year int, month time.Month) time.Time {
+	if r.BusinessDay != 0 {
+		return calendar.NthBusinessDay(year, month, r.BusinessDay)
+	}
+
+	days := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	day := start.Day()
+	if r.MonthDay != 0 {
+		day = r.MonthDay
+	}
+	if day < 0 {
+		day = days + day + 1
+	}
+	day = max(1, min(day, days))
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func (r Rule) adjust(date time.Time) time.Time {
+	switch r.Adjust {
+	case Following:
+		return calendar.NextBusinessDay(date)
+	case Preceding:
+		return calendar.PreviousBusinessDay(date)
+	case ModifiedFollowing:
+		next := calendar.NextBusinessDay(date)
+		if next.Month() != date.Month() {
+			return calendar.PreviousBusinessDay(date)
+		}
+		return next
+	}
+	return date
+}
+
+func dateOf(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,141 @@
+package recurrence
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func formatDates(dates []time.Time) string {
+	out := make([]string, len(dates))
+	for i, d := range dates {
+		out[i] = d.Format("2006-01-02")
+	}
+	return strings.Join(out, " ")
+}
+
+func TestParse(t *testing.T) {
+	r, err := Parse("rrule:freq=monthly;bymonthday=5;x-adjust=following;until=20261231T235959Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := r.String(); got != "FREQ=MONTHLY;BYMONTHDAY=5;X-ADJUST=FOLLOWING;UNTIL=20261231" {
+		t.Errorf("String() = %q", got)
+	}
+
+	invalid := []string{
+		"",
+		"FREQ=HOURLY",
+		"FREQ=MONTHLY;INTERVAL=0",
+		"FREQ=WEEKLY;BYMONTHDAY=5",
+		"FREQ=MONTHLY;BYMONTH=3",
+		"FREQ=MONTHLY;X-BUSINESSDAY=5;BYMONTHDAY=5",
+		"FREQ=MONTHLY;COUNT=3;UNTIL=20261231",
+		"FREQ=MONTHLY;BYDAY=MO",
+		"FREQ=MONTHLY;FREQ=YEARLY",
+	}
+	for _, s := range invalid {
+		if _, err := Parse(s); !errors.Is(err, ErrInvalidRule) {
+			t.Errorf("Parse(%q) should fail, got %v", s, err)
+		}
+	}
+}
+
+func TestOccurrences(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  string
+		start time.Time
+		from  time.Time
+		to    time.Time
+		want  string
+	}{
+		{
+			"monthly on day 5 moved past weekends and holidays",
+			"FREQ=MONTHLY;BYMONTHDAY=5;X-ADJUST=FOLLOWING",
+			date(2026, time.January, 1), date(2026, time.January, 1), date(2026, time.April, 30),
+			"2026-01-05 2026-02-05 2026-03-05 2026-04-06",
+		},
+		{
+			"day 31 falls on the last day of short months",
+			"FREQ=MONTHLY;BYMONTHDAY=31",
+			date(2026, time.January, 31), date(2026, time.January, 1), date(2026, time.April, 30),
+			"2026-01-31 2026-02-28 2026-03-31 2026-04-30",
+		},
+		{
+			"last business day",
+			"FREQ=MONTHLY;X-BUSINESSDAY=-1",
+			date(2026, time.January, 1), date(2026, time.January, 1), date(2026, time.May, 31),
+			"2026-01-30 2026-02-27 2026-03-31 2026-04-30 2026-05-29",
+		},
+		{
+			"fifth business day",
+			"FREQ=MONTHLY;X-BUSINESSDAY=5",
+			date(2026, time.January, 1), date(2026, time.January, 1), date(2026, time.February, 28),
+			"2026-01-08 2026-02-06",
+		},
+		{
+			"every two weeks",
+			"FREQ=WEEKLY;INTERVAL=2",
+			date(2026, time.March, 6), date(2026, time.March, 10), date(2026, time.April, 30),
+			"2026-03-20 2026-04-03 2026-04-17",
+		},
+		{
+			"yearly moved back from Christmas",
+			"FREQ=YEARLY;BYMONTH=12;BYMONTHDAY=25;X-ADJUST=PRECEDING",
+			date(2025, time.January, 1), date(2025, time.January, 1), date(2027, time.December, 31),
+			"2025-12-24 2026-12-24 2027-12-24",
+		},
+		{
+			"modified following stays in the month",
+			"FREQ=MONTHLY;BYMONTHDAY=-1;X-ADJUST=MODIFIED_FOLLOWING",
+			date(2026, time.January, 1), date(2026, time.January, 1), date(2026, time.February, 28),
+			"2026-01-30 2026-02-27",
+		},
+		{
+			"count starts at the first occurrence after start",
+			"FREQ=MONTHLY;BYMONTHDAY=10;COUNT=2",
+			date(2026, time.January, 15), date(2026, time.January, 1), date(2026, time.December, 31),
+			"2026-02-10 2026-03-10",
+		},
+		{
+			"until is inclusive",
+			"FREQ=MONTHLY;UNTIL=20260315",
+			date(2026, time.January, 15), date(2026, time.January, 1), date(2026, time.December, 31),
+			"2026-01-15 2026-02-15 2026-03-15",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := formatDates(r.Between(tt.start, tt.from, tt.to)); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextAndUpcoming(t *testing.T) {
+	r, _ := Parse("FREQ=MONTHLY;BYMONTHDAY=5;COUNT=3")
+	start := date(2026, time.January, 5)
+
+	next, ok := r.Next(start, date(2026, time.January, 6))
+	if !ok || !next.Equal(date(2026, time.February, 5)) {
+		t.Errorf("Next = %s, %v", next.Format("2006-01-02"), ok)
+	}
+	if _, ok := r.Next(start, date(2026, time.March, 6)); ok {
+		t.Error("series should have ended after COUNT occurrences")
+	}
+	if got := formatDates(r.Upcoming(start, date(2026, time.February, 1), 5)); got != "2026-02-05 2026-03-05" {
+		t.Errorf("Upcoming = %s", got)
+	}
+}
@@ -0,0 +1,178 @@
+// Package settleup calcula quem deve a quem num grupo que divide despesas:
+// saldos por membro, dívidas compensadas par a par e uma sugestão de
+// transferências que zera todos os saldos.
+package settleup
+
+import (
+	"sort"
+
+	"finanvilla/pkg/money"
+)
+
+// Debt indica que From deve Amount a To
+type Debt struct {
+	From   string
+	To     string
+	Amount money.Money
+}
+
+// Balances devolve o saldo de cada membro: positivo é a receber, negativo a
+// pagar. A soma dos saldos é sempre zero.
+func Balances(debts []Debt) (map[string]money.Money, error) {
+	balances := make(map[string]money.Money)
+	for _, d := range debts {
+		if err := add(balances, d.To, d.Amount); err != nil {
+			return nil, err
+		}
+		if err := add(balances, d.From, d.Amount.Neg()); err != nil {
+			return nil, err
+		}
+	}
+	return balances, nil
+}
+
+// Pairwise compensa as dívidas de cada par de membros e devolve no máximo uma
+// por par, ordenadas por devedor e credor
+func Pairwise(debts []Debt) ([]Debt, error) {
+	type pair struct{ a, b string }
+	// Chave com a < b; valor positivo significa que a deve a b
+	net := make(map[pair]money.Money)
+	for _, d := range debts {
+		if d.From == d.To {
+			continue
+		}
+		key, amount := pair{d.From, d.To}, d.Amount
+		if d.To < d.From {
+			key, amount = pair{d.To, d.From}, d.Amount.Neg()
+		}
+		current, ok := net[key]
+		if !ok {
+			net[key] = amount
+			continue
+		}
+		sum, err := current.Add(amount)
+		if err != nil {
+			return nil, err
+		}
+		net[key] = sum
+	}
+
+	var pairwise []Debt
+	for key, amount := range net {
+		switch amount.Sign() {
+		case 1:
+			pairwise = append(pairwise, Debt{From: key.a, To: key.b, Amount: amount})
+		case -1:
+			pairwise = append(pairwise, Debt{From: key.b, To: key.a, Amount: amount.Neg()})
+		}
+	}
+	sortDebts(pairwise)
+	return pairwise, nil
+}
+
+// Simplify sugere transferências que zeram os saldos. Primeiro casa devedores
+// e credores com o mesmo valor; depois o maior devedor paga ao maior credor
+// até um dos dois zerar. O resultado nunca passa de n-1 transferências para n
+// membros com saldo e, na prática, costuma ser o mínimo.
+func Simplify(balances map[string]money.Money) ([]Debt, error) {
+	var creditors, debtors []*entry
+	for id, amount := range balances {
+		switch amount.Sign() {
+		case 1:
+			creditors = append(creditors, &entry{id, amount})
+		case -1:
+			debtors = append(debtors, &entry{id, amount.Neg()})
+		}
+	}
+	// A ordem do mapa é aleatória; ordenar deixa a sugestão estável
+	sortEntries(creditors)
+	sortEntries(debtors)
+
+	var transfers []Debt
+	for _, d := range debtors {
+		for _, c := range creditors {
+			if !c.amount.IsZero() && d.amount.Equal(c.amount) {
+				transfers = append(transfers, Debt{From: d.id, To: c.id, Amount: d.amount})
+				d.settle(d.amount)
+				c.settle(c.amount)
+				break
+			}
+		}
+	}
+
+	for {
+		d, c := largest(debtors), largest(creditors)
+		if d == nil || c == nil {
+			break
+		}
+		amount := d.amount
+		cmp, err := c.amount.Cmp(d.amount)
+		if err != nil {
+			return nil, err
+		}
+		if cmp < 0 {
+			amount = c.amount
+		}
+		transfers = append(transfers, Debt{From: d.id, To: c.id, Amount: amount})
+		d.settle(amount)
+		c.settle(amount)
+	}
+
+	sortDebts(transfers)
+	return transfers, nil
+}
+
+type entry struct {
+	id     string
+	amount money.Money
+}
+
+// settle abate amount, que nunca passa do saldo da entrada
+func (e *entry) settle(amount money.Money) {
+	if rest, err := e.amount.Sub(amount); err == nil {
+		e.amount = rest
+	}
+}
+
+// largest devolve a entrada de maior saldo ainda não zerado
+func largest(entries []*entry) *entry {
+	var best *entry
+	for _, e := range entries {
+		if e.amount.IsZero() {
+			continue
+		}
+		if best == nil {
+			best = e
+		} else if cmp, err := e.amount.Cmp(best.amount); err == nil && cmp > 0 {
+			best = e
+		}
+	}
+	return best
+}
+
+func sortEntries(entries []*entry) {
+	sort.Slice(entries, func(i, j int) bool { return entries[i].id < entries[j].id })
+}
+
+func sortDebts(debts []Debt) {
+	sort.Slice(debts, func(i, j int) bool {
+		if debts[i].From != debts[j].From {
+			return debts[i].From < debts[j].From
+		}
+		return debts[i].To < debts[j].To
+	})
+}
+
+func add(balances map[string]money.Money, id string, amount money.Money) error {
+	current, ok := balances[id]
+	if !ok {
+		balances[id] = amount
+		return nil
+	}
+	sum, err := current.Add(amount)
+	if err != nil {
+		return err
+	}
+	balances[id] = sum
+	return nil
+}
@@ -0,0 +1,104 @@
+package settleup
+
+import (
+	"testing"
+
+	"finanvilla/pkg/money"
+)
+
+func brl(cents int64) money.Money {
+	return money.MustNew(cents, "BRL")
+}
+
+func TestBalancesAndPairwise(t *testing.T) {
+	debts := []Debt{
+		{From: "bia", To: "ana", Amount: brl(5000)},
+		{From: "ana", To: "bia", Amount: brl(2000)},
+		{From: "caio", To: "ana", Amount: brl(3000)},
+		// Acerto de caio com ana: ana passa a dever o que recebeu
+		{From: "ana", To: "caio", Amount: brl(3000)},
+	}
+
+	balances, err := Balances(debts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]int64{"ana": 3000, "bia": -3000, "caio": 0}
+	for id, cents := range want {
+		if got := balances[id].MinorUnits(); got != cents {
+			t.Errorf("balance %s = %d, want %d", id, got, cents)
+		}
+	}
+
+	pairwise, err := Pairwise(debts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pairwise) != 1 || pairwise[0].From != "bia" || pairwise[0].To != "ana" || pairwise[0].Amount.MinorUnits() != 3000 {
+		t.Errorf("pairwise = %+v", pairwise)
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	t.Run("chain", func(t *testing.T) {
+		// ana deve 10 a bia, que deve 10 a caio: basta ana pagar caio
+		transfers, err := Simplify(map[string]money.Money{"ana": brl(-1000), "bia": brl(0), "caio": brl(1000)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(transfers) != 1 || transfers[0].From != "ana" || transfers[0].To != "caio" || transfers[0].Amount.MinorUnits() != 1000 {
+			t.Errorf("transfers = %+v", transfers)
+		}
+	})
+
+	t.Run("exact matches first", func(t *testing.T) {
+		balances := map[string]money.Money{
+			"a": brl(-7000), "b": brl(-3000), "c": brl(3000), "d": brl(7000),
+		}
+		transfers, err := Simplify(balances)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(transfers) != 2 {
+			t.Fatalf("transfers = %+v", transfers)
+		}
+		if transfers[0].From != "a" || transfers[0].To != "d" || transfers[1].From != "b" || transfers[1].To != "c" {
+			t.Errorf("transfers = %+v", transfers)
+		}
+	})
+
+	t.Run("zeroes every balance", func(t *testing.T) {
+		balances := map[string]money.Money{
+			"a": brl(-4550), "b": brl(-1225), "c": brl(-25), "d": brl(3300), "e": brl(2500),
+		}
+		transfers, err := Simplify(balances)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(transfers) > 4 {
+			t.Errorf("%d transfers for 5 members", len(transfers))
+		}
+		var debts []Debt
+		for id, amount := range balances {
+			// Saldo inicial como dívida com um membro fictício
+			if amount.Sign() < 0 {
+				debts = append(debts, Debt{From: id, To: "_", Amount: amount.Neg()})
+			} else {
+				debts = append(debts, Debt{From: "_", To: id, Amount: amount})
+			}
+		}
+		// Cada pagamento quita a dívida no sentido inverso
+		for _, transfer := range transfers {
+			debts = append(debts, Debt{From: transfer.To, To: transfer.From, Amount: transfer.Amount})
+		}
+		final, err := Balances(debts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for id, amount := range final {
+			if id != "_" && !amount.IsZero() {
+				t.Errorf("%s still has %s", id, amount.Decimal())
+			}
+		}
+	})
+}
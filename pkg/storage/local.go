@@ -0,0 +1,180 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const contentTypeSuffix = ".content-type"
+
+// LocalStorage grava os arquivos no disco. Como não há um servidor de
+// arquivos dedicado, as URLs assinadas apontam para a própria API, que valida
+// a assinatura com Verify antes de servir o conteúdo.
+type LocalStorage struct {
+	root      string
+	publicURL string
+	secret    []byte
+}
+
+func NewLocalStorage(root, publicURL, secret string) (*LocalStorage, error) {
+	if root == "" {
+		return nil, errors.New("local storage path is required")
+	}
+	if secret == "" {
+		return nil, errors.New("local storage signing secret is required")
+	}
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, err
+	}
+
+	return &LocalStorage{
+		root:      root,
+		publicURL: strings.TrimRight(publicURL, "/"),
+		secret:    []byte(secret),
+	}, nil
+}
+
+func (s *LocalStorage) Put(ctx context.Context, key string, r io.Reader, _ int64, contentType string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+		return err
+	}
+
+	// Grava em um arquivo temporário e renomeia para que leitores nunca vejam arquivos pela metade
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, readerWithContext(ctx, r)); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(p+contentTypeSuffix, []byte(contentType), 0o640); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), p)
+}
+
+func (s *LocalStorage) Get(_ context.Context, key string) (io.ReadCloser, string, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return nil, "", err
+	}
+
+	f, err := os.Open(p)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, "", ErrNotFound
+		}
+		return nil, "", err
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if b, err := os.ReadFile(p + contentTypeSuffix); err == nil {
+		contentType = string(b)
+	}
+
+	return f, contentType, nil
+}
+
+func (s *LocalStorage) Delete(_ context.Context, key string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := os.Remove(p + contentTypeSuffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *LocalStorage) SignedURL(_ context.Context, key string, ttl time.Duration) (string, error) {
+	if _, err := s.path(key); err != nil {
+		return "", err
+	}
+
+	expires := strconv.FormatInt(time.Now().Add(ttl).Unix(), 10)
+
+	q := url.Values{}
+	q.Set("expires", expires)
+	q.Set("signature", s.sign(key, expires))
+
+	return fmt.Sprintf("%s/%s?%s", s.publicURL, escapeKey(key), q.Encode()), nil
+}
+
+// Verify valida a assinatura e a expiração de uma URL gerada por SignedURL
+func (s *LocalStorage) Verify(key, expires, signature string) error {
+	exp, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > exp {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.sign(key, expires))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (s *LocalStorage) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// path converte a chave em um caminho dentro de root, rejeitando tentativas de path traversal
+func (s *LocalStorage) path(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if key == "" || clean == "/" || clean[1:] != key || strings.HasSuffix(key, contentTypeSuffix) {
+		return "", fmt.Errorf("invalid storage key %q", key)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+func escapeKey(key string) string {
+	parts := strings.Split(key, "/")
+	for i, p := range parts {
+		parts[i] = url.PathEscape(p)
+	}
+	return strings.Join(parts, "/")
+}
+
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func readerWithContext(ctx context.Context, r io.Reader) io.Reader {
+	return &ctxReader{ctx: ctx, r: r}
+}
+
+func (r *ctxReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+var (
+	ErrNotFound         = errors.New("object not found")
+	ErrInvalidSignature = errors.New("invalid or expired signature")
+)
+
+// Storage abstrai o armazenamento de arquivos (avatares, anexos). As chaves
+// usam "/" como separador, independentemente do backend.
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, string, error)
+	Delete(ctx context.Context, key string) error
+	// SignedURL devolve uma URL de leitura que expira após ttl
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
+type Config struct {
+	Driver string // "local" ou "s3"
+
+	// Backend local: os arquivos ficam em LocalPath e são servidos pela API em
+	// PublicURL (ex.: http://localhost:8080/api/v1/files), com URLs assinadas com SigningSecret
+	LocalPath     string
+	PublicURL     string
+	SigningSecret string
+
+	// Backend compatível com S3 (AWS, MinIO, R2...)
+	S3 S3Config
+}
+
+func New(cfg Config) (Storage, error) {
+	switch cfg.Driver {
+	case "", "local":
+		s, err := NewLocalStorage(cfg.LocalPath, cfg.PublicURL, cfg.SigningSecret)
+		if err != nil {
+			return nil, err
+		}
+		return s, nil
+	case "s3":
+		s, err := NewS3Storage(cfg.S3)
+		if err != nil {
+			return nil, err
+		}
+		return s, nil
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.Driver)
+	}
+}
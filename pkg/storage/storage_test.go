@@ -0,0 +1,199 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3 é um substituto mínimo de um servidor MinIO/S3 para os testes
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string]fakeObject
+	signer  *S3Storage
+}
+
+type fakeObject struct {
+	body        []byte
+	contentType string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.URL.Query().Get("X-Amz-Signature") != "" {
+		if !f.validPresigned(r) {
+			http.Error(w, "SignatureDoesNotMatch", http.StatusForbidden)
+			return
+		}
+	} else if !strings.HasPrefix(r.Header.Get("Authorization"), sigAlgorithm+" Credential=minio/") {
+		http.Error(w, "AccessDenied", http.StatusForbidden)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = fakeObject{body: body, contentType: r.Header.Get("Content-Type")}
+	case http.MethodGet:
+		obj, ok := f.objects[r.URL.Path]
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", obj.contentType)
+		w.Write(obj.body)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (f *fakeS3) validPresigned(r *http.Request) bool {
+	q := r.URL.Query()
+	date, err := time.Parse(amzDateFormat, q.Get("X-Amz-Date"))
+	if err != nil {
+		return false
+	}
+	expires, _ := time.ParseDuration(q.Get("X-Amz-Expires") + "s")
+	if time.Now().After(date.Add(expires)) {
+		return false
+	}
+
+	signature := q.Get("X-Amz-Signature")
+	q.Del("X-Amz-Signature")
+	canonical := strings.Join([]string{
+		r.Method,
+		r.URL.EscapedPath(),
+		canonicalQuery(q),
+		"host:" + r.Host + "\n",
+		"host",
+		unsignedPayload,
+	}, "\n")
+	return f.signer.signature(date, canonical) == signature
+}
+
+func newFakeS3(t *testing.T) (*S3Storage, *httptest.Server) {
+	fake := &fakeS3{objects: make(map[string]fakeObject)}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	s, err := NewS3Storage(S3Config{
+		Endpoint:  server.URL,
+		Bucket:    "finanvilla",
+		AccessKey: "minio",
+		SecretKey: "minio-secret",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake.signer = s
+	return s, server
+}
+
+func TestS3StorageRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newFakeS3(t)
+
+	if err := s.Put(ctx, "avatars/u1/64.jpg", strings.NewReader("jpeg-bytes"), 10, "image/jpeg"); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+
+	body, contentType, err := s.Get(ctx, "avatars/u1/64.jpg")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	data, _ := io.ReadAll(body)
+	body.Close()
+	if string(data) != "jpeg-bytes" || contentType != "image/jpeg" {
+		t.Fatalf("got %q (%s)", data, contentType)
+	}
+
+	if err := s.Delete(ctx, "avatars/u1/64.jpg"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, _, err := s.Get(ctx, "avatars/u1/64.jpg"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestS3StorageSignedURL(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newFakeS3(t)
+
+	if err := s.Put(ctx, "docs/a.pdf", strings.NewReader("%PDF"), 4, "application/pdf"); err != nil {
+		t.Fatal(err)
+	}
+
+	signed, err := s.SignedURL(ctx, "docs/a.pdf", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := http.Get(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("signed URL rejected: %s", resp.Status)
+	}
+
+	tampered := strings.Replace(signed, "docs%2Fa.pdf", "docs%2Fb.pdf", 1)
+	tampered = strings.Replace(tampered, "/docs/a.pdf", "/docs/b.pdf", 1)
+	resp, err = http.Get(tampered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("tampered URL accepted: %s", resp.Status)
+	}
+}
+
+func TestLocalStorage(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewLocalStorage(t.TempDir(), "http://localhost:8080/api/v1/files", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Put(ctx, "avatars/u1/128.jpg", strings.NewReader("img"), 3, "image/jpeg"); err != nil {
+		t.Fatal(err)
+	}
+
+	body, contentType, err := s.Get(ctx, "avatars/u1/128.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body.Close()
+	if contentType != "image/jpeg" {
+		t.Fatalf("unexpected content type %q", contentType)
+	}
+
+	signed, err := s.SignedURL(ctx, "avatars/u1/128.jpg", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, _ := url.Parse(signed)
+	if err := s.Verify("avatars/u1/128.jpg", u.Query().Get("expires"), u.Query().Get("signature")); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if err := s.Verify("avatars/u2/128.jpg", u.Query().Get("expires"), u.Query().Get("signature")); err == nil {
+		t.Fatal("signature accepted for another key")
+	}
+
+	for _, key := range []string{"../etc/passwd", "a/../../b", "/abs", ""} {
+		if err := s.Put(ctx, key, strings.NewReader("x"), 1, "text/plain"); err == nil {
+			t.Errorf("key %q should be rejected", key)
+		}
+	}
+}
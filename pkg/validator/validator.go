@@ -1,7 +1,7 @@
 package validator
 
 import (
-	"regexp"
+	"finanvilla/pkg/brdoc"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -13,6 +13,8 @@ func Init() {
 
 	// Registro de validações customizadas
 	_ = validate.RegisterValidation("cpf", validateCPF)
+	_ = validate.RegisterValidation("cnpj", validateCNPJ)
+	_ = validate.RegisterValidation("taxid", validateTaxID)
 	_ = validate.RegisterValidation("phone", validatePhone)
 }
 
@@ -21,13 +23,22 @@ func Validate(i interface{}) error {
 }
 
 func validateCPF(fl validator.FieldLevel) bool {
-	cpf := fl.Field().String()
-	regex := regexp.MustCompile(`^\d{3}\.\d{3}\.\d{3}-\d{2}$`)
-	return regex.MatchString(cpf)
+	_, err := brdoc.NormalizeCPF(fl.Field().String())
+	return err == nil
+}
+
+func validateCNPJ(fl validator.FieldLevel) bool {
+	_, err := brdoc.NormalizeCNPJ(fl.Field().String())
+	return err == nil
+}
+
+// validateTaxID aceita CPF ou CNPJ
+func validateTaxID(fl validator.FieldLevel) bool {
+	_, err := brdoc.NormalizeTaxID(fl.Field().String())
+	return err == nil
 }
 
 func validatePhone(fl validator.FieldLevel) bool {
-	phone := fl.Field().String()
-	regex := regexp.MustCompile(`^\+?[1-9]\d{1,14}$`)
-	return regex.MatchString(phone)
+	_, err := brdoc.NormalizePhone(fl.Field().String())
+	return err == nil
 }